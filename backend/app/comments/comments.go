@@ -0,0 +1,112 @@
+package comments
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+)
+
+const (
+	// SubjectFundraise defines fundraise as commented subject.
+	SubjectFundraise string = "FUNDRAISE"
+	// SubjectEvent defines event as commented subject.
+	SubjectEvent string = "EVENT"
+)
+
+const (
+	// ReactionLike defines like reaction.
+	ReactionLike string = "LIKE"
+	// ReactionHeart defines heart reaction.
+	ReactionHeart string = "HEART"
+	// ReactionSupport defines support reaction.
+	ReactionSupport string = "SUPPORT"
+	// ReactionFire defines fire reaction.
+	ReactionFire string = "FIRE"
+)
+
+// MaxBodyLength defines maximum length of the comment body in symbols.
+const MaxBodyLength = 2000
+
+// Subject describes commented entity.
+type Subject struct {
+	Type string
+	ID   uuid.UUID
+}
+
+// Comment describes comment entity.
+type Comment struct {
+	ID        uuid.UUID
+	ParentID  uuid.UUID // INFO: uuid.Nil for root comments.
+	Subject   Subject
+	AuthorID  uuid.UUID
+	Body      string
+	Pinned    bool
+	Hidden    bool
+	HiddenBy  uuid.UUID
+	Deleted   bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// INFO: Derived values, populated on listing.
+	IsDonor   bool
+	Replies   int
+	Reactions map[string]int
+}
+
+// IsReply returns true if comment is a reply to another comment.
+func (c *Comment) IsReply() bool {
+	return c.ParentID != uuid.Nil
+}
+
+// CreateParams defines needed params to create a new comment.
+type CreateParams struct {
+	Subject  Subject
+	ParentID uuid.UUID
+	AuthorID uuid.UUID
+	Body     string
+}
+
+// Cursor defines position in the comments list ordered by creation time.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// Encode returns cursor as opaque url-safe string.
+func (c Cursor) Encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID.String()))
+}
+
+// DecodeCursor parses cursor from its string representation.
+func DecodeCursor(value string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return Cursor{}, errs.New("invalid cursor")
+	}
+
+	createdAt, id, found := strings.Cut(string(raw), "|")
+	if !found {
+		return Cursor{}, errs.New("invalid cursor")
+	}
+
+	var cursor Cursor
+	if cursor.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+		return Cursor{}, errs.New("invalid cursor")
+	}
+
+	if cursor.ID, err = uuid.Parse(id); err != nil {
+		return Cursor{}, errs.New("invalid cursor")
+	}
+
+	return cursor, nil
+}
+
+// Page holds comments list chunk with cursor to the next one.
+type Page struct {
+	Comments   []Comment
+	Pinned     []Comment
+	NextCursor string
+}
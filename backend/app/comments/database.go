@@ -0,0 +1,45 @@
+package comments
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+)
+
+var (
+	// ErrNoComment indicates that comment does not exist.
+	ErrNoComment = errs.New("comment does not exist")
+)
+
+// DB exposes access to comments db.
+//
+// architecture: DB
+type DB interface {
+	// Create inserts comment into the database.
+	Create(ctx context.Context, comment Comment) error
+	// Get comment from the database.
+	Get(ctx context.Context, id uuid.UUID) (Comment, error)
+	// List returns comments of the subject ordered from the newest to the oldest.
+	List(ctx context.Context, params ListParams) ([]Comment, error)
+	// Update updates comment in database by id.
+	Update(ctx context.Context, comment Comment) error
+	// AddReaction adds user reaction to the comment.
+	AddReaction(ctx context.Context, commentID, userID uuid.UUID, reaction string) error
+	// RemoveReaction removes user reaction from the comment.
+	RemoveReaction(ctx context.Context, commentID, userID uuid.UUID, reaction string) error
+}
+
+// ListParams defines params for list method.
+type ListParams struct {
+	Subject Subject
+	// ParentID lists replies of the comment, root comments are listed when nil.
+	ParentID *uuid.UUID
+	// Pinned filters comments by pinned state when set.
+	Pinned        *bool
+	IncludeHidden bool
+	// DonorFundraiseID defines fundraise confirmed donations to which mark author as donor.
+	DonorFundraiseID uuid.UUID
+	Cursor           *Cursor
+	Limit            int
+}
@@ -0,0 +1,363 @@
+package comments
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+
+	"one-help/app/events"
+	"one-help/app/fundraises"
+	"one-help/app/users"
+	"one-help/internal/logger"
+)
+
+var (
+	// Error wraps errors from comments service that indicates about internal errors.
+	Error = errs.Class("comments service")
+	// ParamsError wraps errors from comments service that indicates about invalid or malformed parameters' data.
+	ParamsError = errs.Class("comments service: params")
+	// ErrForbidden indicates that caller is not allowed to perform the action.
+	ErrForbidden = errs.New("action is not permitted")
+)
+
+// Service handles comments related logic.
+//
+// architecture: Service
+type Service struct {
+	logger logger.Logger
+
+	comments   DB
+	fundraises fundraises.DB
+	events     events.DB
+	users      users.DB
+}
+
+// NewService is a constructor for comments service.
+func NewService(logger logger.Logger, comments DB, fundraises fundraises.DB, events events.DB, users users.DB) *Service {
+	return &Service{
+		logger:     logger,
+		comments:   comments,
+		fundraises: fundraises,
+		events:     events,
+		users:      users,
+	}
+}
+
+// Create creates new comment on the subject.
+func (service *Service) Create(ctx context.Context, params CreateParams) (*Comment, error) {
+	params.Body = strings.TrimSpace(params.Body)
+	if err := validateBody(params.Body); err != nil {
+		return nil, err
+	}
+
+	if _, _, err := service.resolveSubject(ctx, params.Subject); err != nil {
+		return nil, err
+	}
+
+	if params.ParentID != uuid.Nil {
+		parent, err := service.comments.Get(ctx, params.ParentID)
+		if err != nil {
+			if errors.Is(err, ErrNoComment) {
+				return nil, ParamsError.New("parent comment does not exist")
+			}
+
+			return nil, Error.Wrap(err)
+		}
+
+		switch {
+		case parent.Subject != params.Subject:
+			return nil, ParamsError.New("parent comment belongs to another subject")
+		case parent.Deleted || parent.Hidden:
+			return nil, ParamsError.New("parent comment is not available")
+		}
+	}
+
+	now := time.Now().UTC()
+	comment := &Comment{
+		ID:        uuid.New(),
+		ParentID:  params.ParentID,
+		Subject:   params.Subject,
+		AuthorID:  params.AuthorID,
+		Body:      params.Body,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := service.comments.Create(ctx, *comment); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return comment, nil
+}
+
+// Get returns comment by id.
+func (service *Service) Get(ctx context.Context, id uuid.UUID) (*Comment, error) {
+	comment, err := service.comments.Get(ctx, id)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return &comment, nil
+}
+
+// List returns cursor paginated comments of the subject.
+// Pinned comments are returned separately on the first page of root comments.
+func (service *Service) List(ctx context.Context, callerID uuid.UUID, subject Subject, parentID *uuid.UUID, cursor string, limit int) (page Page, err error) {
+	if limit <= 0 {
+		return page, ParamsError.New("limit must be positive")
+	}
+
+	fundraiseID, organizerID, err := service.resolveSubject(ctx, subject)
+	if err != nil {
+		return page, err
+	}
+
+	includeHidden, err := service.canModerate(ctx, callerID, organizerID)
+	if err != nil {
+		return page, err
+	}
+
+	params := ListParams{
+		Subject:          subject,
+		ParentID:         parentID,
+		IncludeHidden:    includeHidden,
+		DonorFundraiseID: fundraiseID,
+		Limit:            limit + 1, // INFO: one extra item detects next page existence.
+	}
+
+	if cursor != "" {
+		decoded, err := DecodeCursor(cursor)
+		if err != nil {
+			return page, ParamsError.Wrap(err)
+		}
+		params.Cursor = &decoded
+	}
+
+	if parentID == nil {
+		pinned, notPinned := true, false
+		params.Pinned = &notPinned
+
+		if params.Cursor == nil {
+			pinnedParams := params
+			pinnedParams.Pinned = &pinned
+			pinnedParams.Limit = 0
+
+			page.Pinned, err = service.comments.List(ctx, pinnedParams)
+			if err != nil {
+				return page, Error.Wrap(err)
+			}
+		}
+	}
+
+	page.Comments, err = service.comments.List(ctx, params)
+	if err != nil {
+		return page, Error.Wrap(err)
+	}
+
+	if len(page.Comments) > limit {
+		page.Comments = page.Comments[:limit]
+		last := page.Comments[limit-1]
+		page.NextCursor = Cursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+	}
+
+	return page, nil
+}
+
+// Edit changes comment body, allowed only to the comment author.
+func (service *Service) Edit(ctx context.Context, id, callerID uuid.UUID, body string) (*Comment, error) {
+	body = strings.TrimSpace(body)
+	if err := validateBody(body); err != nil {
+		return nil, err
+	}
+
+	comment, err := service.comments.Get(ctx, id)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	switch {
+	case comment.AuthorID != callerID:
+		return nil, ParamsError.Wrap(ErrForbidden)
+	case comment.Deleted:
+		return nil, ParamsError.New("deleted comment can not be edited")
+	}
+
+	comment.Body = body
+	comment.UpdatedAt = time.Now().UTC()
+
+	if err = service.comments.Update(ctx, comment); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return &comment, nil
+}
+
+// Delete marks comment as deleted, allowed only to the comment author.
+// NOTE: comment is kept in the thread to preserve replies, its body is erased.
+func (service *Service) Delete(ctx context.Context, id, callerID uuid.UUID) error {
+	comment, err := service.comments.Get(ctx, id)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if comment.AuthorID != callerID {
+		return ParamsError.Wrap(ErrForbidden)
+	}
+
+	comment.Body = ""
+	comment.Deleted = true
+	comment.Pinned = false
+	comment.UpdatedAt = time.Now().UTC()
+
+	return Error.Wrap(service.comments.Update(ctx, comment))
+}
+
+// Pin changes comment pinned state, allowed only to the subject organizer.
+func (service *Service) Pin(ctx context.Context, id, callerID uuid.UUID, pinned bool) (*Comment, error) {
+	comment, err := service.comments.Get(ctx, id)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	_, organizerID, err := service.resolveSubject(ctx, comment.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case organizerID != callerID:
+		return nil, ParamsError.Wrap(ErrForbidden)
+	case comment.IsReply():
+		return nil, ParamsError.New("only root comments can be pinned")
+	case pinned && (comment.Deleted || comment.Hidden):
+		return nil, ParamsError.New("comment is not available")
+	}
+
+	comment.Pinned = pinned
+	if err = service.comments.Update(ctx, comment); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return &comment, nil
+}
+
+// Hide changes comment hidden state, allowed to the subject organizer and moderators.
+func (service *Service) Hide(ctx context.Context, id, callerID uuid.UUID, hidden bool) (*Comment, error) {
+	comment, err := service.comments.Get(ctx, id)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	_, organizerID, err := service.resolveSubject(ctx, comment.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, err := service.canModerate(ctx, callerID, organizerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !allowed {
+		return nil, ParamsError.Wrap(ErrForbidden)
+	}
+
+	comment.Hidden = hidden
+	comment.HiddenBy = uuid.Nil
+	if hidden {
+		comment.HiddenBy = callerID
+		comment.Pinned = false
+	}
+
+	if err = service.comments.Update(ctx, comment); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return &comment, nil
+}
+
+// React adds or removes user reaction on the comment.
+func (service *Service) React(ctx context.Context, id, userID uuid.UUID, reaction string, add bool) error {
+	switch reaction {
+	case ReactionLike, ReactionHeart, ReactionSupport, ReactionFire:
+	default:
+		return ParamsError.New("unknown reaction %q", reaction)
+	}
+
+	comment, err := service.comments.Get(ctx, id)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if !add {
+		return Error.Wrap(service.comments.RemoveReaction(ctx, comment.ID, userID, reaction))
+	}
+
+	if comment.Deleted || comment.Hidden {
+		return ParamsError.New("comment is not available")
+	}
+
+	return Error.Wrap(service.comments.AddReaction(ctx, comment.ID, userID, reaction))
+}
+
+// resolveSubject checks subject existence and returns related fundraise and its organizer ids.
+func (service *Service) resolveSubject(ctx context.Context, subject Subject) (fundraiseID, organizerID uuid.UUID, err error) {
+	switch subject.Type {
+	case SubjectFundraise:
+		fundraiseID = subject.ID
+	case SubjectEvent:
+		event, err := service.events.Get(ctx, subject.ID)
+		if err != nil {
+			if errors.Is(err, events.ErrNoEvents) {
+				return fundraiseID, organizerID, ParamsError.Wrap(events.ErrNoEvents)
+			}
+
+			return fundraiseID, organizerID, Error.Wrap(err)
+		}
+		fundraiseID = event.FundraiseId
+	default:
+		return fundraiseID, organizerID, ParamsError.New("unknown subject type %q", subject.Type)
+	}
+
+	fundraise, err := service.fundraises.Get(ctx, fundraiseID)
+	if err != nil {
+		if errors.Is(err, fundraises.ErrNoFundraise) {
+			return fundraiseID, organizerID, ParamsError.Wrap(fundraises.ErrNoFundraise)
+		}
+
+		return fundraiseID, organizerID, Error.Wrap(err)
+	}
+
+	return fundraise.ID, fundraise.OrganizerId, nil
+}
+
+// canModerate returns true if caller is the subject organizer or a moderator.
+func (service *Service) canModerate(ctx context.Context, callerID, organizerID uuid.UUID) (bool, error) {
+	if callerID == organizerID {
+		return true, nil
+	}
+
+	caller, err := service.users.Get(ctx, callerID)
+	if err != nil {
+		return false, Error.Wrap(err)
+	}
+
+	return caller.IsModerator(), nil
+}
+
+// validateBody returns error in case of incorrect comment body.
+func validateBody(body string) error {
+	switch {
+	case body == "":
+		return ParamsError.New("comment body is required")
+	case utf8.RuneCountInString(body) > MaxBodyLength:
+		return ParamsError.New("comment body must not exceed %d symbols", MaxBodyLength)
+	}
+
+	return nil
+}
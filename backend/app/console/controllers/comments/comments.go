@@ -0,0 +1,407 @@
+package comments
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/zeebo/errs"
+
+	"one-help/app/comments"
+	"one-help/app/console/controllers/common"
+	"one-help/app/events"
+	"one-help/app/fundraises"
+	"one-help/app/users/credentials"
+	"one-help/internal/logger"
+)
+
+var (
+	// ErrComments is an internal error type for comments controller.
+	ErrComments = errs.Class("comments controller")
+)
+
+// Comments is a controller that handles all comments related routes.
+type Comments struct {
+	log logger.Logger
+
+	comments *comments.Service
+}
+
+// NewComments is a constructor for comments controller.
+func NewComments(log logger.Logger, comments *comments.Service) *Comments {
+	commentsController := &Comments{
+		log:      log,
+		comments: comments,
+	}
+
+	return commentsController
+}
+
+// ListFundraiseComments is an endpoint for listing fundraise comments.
+// @Summary	Returns cursor paginated list of fundraise comments
+// @Tags	Comments
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Param	limit			query	integer	false	"Items per page (positive number expected) [default value: 20]"
+// @Param	cursor			query	string	false	"Cursor of the next page received in the previous response"
+// @Param	parentId		query	string	false	"Comment id to list replies of"
+// @Success	200		{object}	PageView
+// @Failure	400,401,404,500	{object}	common.ErrResponseCode
+// @Router	/fundraises/{id}/comments	[get].
+func (controller *Comments) ListFundraiseComments(w http.ResponseWriter, r *http.Request) {
+	controller.list(w, r, comments.SubjectFundraise)
+}
+
+// ListEventComments is an endpoint for listing event comments.
+// @Summary	Returns cursor paginated list of event comments
+// @Tags	Comments
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Param	limit			query	integer	false	"Items per page (positive number expected) [default value: 20]"
+// @Param	cursor			query	string	false	"Cursor of the next page received in the previous response"
+// @Param	parentId		query	string	false	"Comment id to list replies of"
+// @Success	200		{object}	PageView
+// @Failure	400,401,404,500	{object}	common.ErrResponseCode
+// @Router	/events/{id}/comments	[get].
+func (controller *Comments) ListEventComments(w http.ResponseWriter, r *http.Request) {
+	controller.list(w, r, comments.SubjectEvent)
+}
+
+// CreateFundraiseComment is an endpoint for commenting fundraise.
+// @Summary	Creates new fundraise comment or reply
+// @Tags	Comments
+// @Accept	json
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Param	request	body	CreateRequest	true	"Comment data fields"
+// @Success	200		{object}	CommentView
+// @Failure	400,401,404,500	{object}	common.ErrResponseCode
+// @Router	/fundraises/{id}/comments	[post].
+func (controller *Comments) CreateFundraiseComment(w http.ResponseWriter, r *http.Request) {
+	controller.create(w, r, comments.SubjectFundraise)
+}
+
+// CreateEventComment is an endpoint for commenting event.
+// @Summary	Creates new event comment or reply
+// @Tags	Comments
+// @Accept	json
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Param	request	body	CreateRequest	true	"Comment data fields"
+// @Success	200		{object}	CommentView
+// @Failure	400,401,404,500	{object}	common.ErrResponseCode
+// @Router	/events/{id}/comments	[post].
+func (controller *Comments) CreateEventComment(w http.ResponseWriter, r *http.Request) {
+	controller.create(w, r, comments.SubjectEvent)
+}
+
+// Edit is an endpoint for editing comment by its author.
+// @Summary	Edits comment body
+// @Tags	Comments
+// @Accept	json
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Param	request	body	EditRequest	true	"New comment body"
+// @Success	200		{object}	CommentView
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/comments/{id}	[patch].
+func (controller *Comments) Edit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	creds, commentID, ok := controller.callerAndID(w, r)
+	if !ok {
+		return
+	}
+
+	var request EditRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		controller.log.Error("failed to decode edit request body", ErrComments.Wrap(err))
+		common.NewErrResponse(http.StatusBadRequest, err).Serve(controller.log, ErrComments, w)
+		return
+	}
+
+	comment, err := controller.comments.Edit(ctx, commentID, creds.UserID, request.Body)
+	if err != nil {
+		controller.serveError(w, err, "failed to edit comment")
+		return
+	}
+
+	controller.serveComment(w, comment)
+}
+
+// Delete is an endpoint for deleting comment by its author.
+// @Summary	Deletes comment
+// @Tags	Comments
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Success	204
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/comments/{id}	[delete].
+func (controller *Comments) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	creds, commentID, ok := controller.callerAndID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := controller.comments.Delete(ctx, commentID, creds.UserID); err != nil {
+		controller.serveError(w, err, "failed to delete comment")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Pin is an endpoint for pinning comment by the organizer.
+// @Summary	Pins comment on top of the list
+// @Tags	Comments
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Success	200		{object}	CommentView
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/comments/{id}/pin	[post].
+func (controller *Comments) Pin(w http.ResponseWriter, r *http.Request) {
+	controller.pin(w, r, true)
+}
+
+// Unpin is an endpoint for unpinning comment by the organizer.
+// @Summary	Unpins comment
+// @Tags	Comments
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Success	200		{object}	CommentView
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/comments/{id}/pin	[delete].
+func (controller *Comments) Unpin(w http.ResponseWriter, r *http.Request) {
+	controller.pin(w, r, false)
+}
+
+// Hide is an endpoint for hiding comment by the organizer or moderator.
+// @Summary	Hides comment from the public list
+// @Tags	Comments
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Success	200		{object}	CommentView
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/comments/{id}/hide	[post].
+func (controller *Comments) Hide(w http.ResponseWriter, r *http.Request) {
+	controller.hide(w, r, true)
+}
+
+// Unhide is an endpoint for restoring hidden comment by the organizer or moderator.
+// @Summary	Restores hidden comment
+// @Tags	Comments
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Success	200		{object}	CommentView
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/comments/{id}/hide	[delete].
+func (controller *Comments) Unhide(w http.ResponseWriter, r *http.Request) {
+	controller.hide(w, r, false)
+}
+
+// AddReaction is an endpoint for reacting on comment.
+// @Summary	Adds caller reaction to the comment
+// @Tags	Comments
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Success	204
+// @Failure	400,401,404,500	{object}	common.ErrResponseCode
+// @Router	/comments/{id}/reactions/{reaction}	[put].
+func (controller *Comments) AddReaction(w http.ResponseWriter, r *http.Request) {
+	controller.react(w, r, true)
+}
+
+// RemoveReaction is an endpoint for removing reaction from comment.
+// @Summary	Removes caller reaction from the comment
+// @Tags	Comments
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Success	204
+// @Failure	400,401,404,500	{object}	common.ErrResponseCode
+// @Router	/comments/{id}/reactions/{reaction}	[delete].
+func (controller *Comments) RemoveReaction(w http.ResponseWriter, r *http.Request) {
+	controller.react(w, r, false)
+}
+
+// list serves comments list of the subject by type.
+func (controller *Comments) list(w http.ResponseWriter, r *http.Request, subjectType string) {
+	ctx := r.Context()
+
+	creds, subjectID, ok := controller.callerAndID(w, r)
+	if !ok {
+		return
+	}
+
+	var (
+		limit    = 20
+		parentID *uuid.UUID
+		err      error
+	)
+	if val := r.URL.Query().Get("limit"); val != "" {
+		limit, err = strconv.Atoi(val)
+		if err != nil {
+			controller.log.Error("failed to parse 'limit' query parameter", ErrComments.Wrap(err))
+			common.NewErrResponse(http.StatusBadRequest, errors.New("invalid limit value")).Serve(controller.log, ErrComments, w)
+			return
+		}
+	}
+	if val := r.URL.Query().Get("parentId"); val != "" {
+		id, err := uuid.Parse(val)
+		if err != nil {
+			controller.log.Error("failed to parse 'parentId' query parameter", ErrComments.Wrap(err))
+			common.NewErrResponse(http.StatusBadRequest, errors.New("invalid parent id value")).Serve(controller.log, ErrComments, w)
+			return
+		}
+		parentID = &id
+	}
+
+	subject := comments.Subject{Type: subjectType, ID: subjectID}
+	page, err := controller.comments.List(ctx, creds.UserID, subject, parentID, r.URL.Query().Get("cursor"), limit)
+	if err != nil {
+		controller.serveError(w, err, "failed to list comments")
+		return
+	}
+
+	resp := &PageView{
+		Data:       ToCommentViews(page.Comments),
+		Pinned:     ToCommentViews(page.Pinned),
+		NextCursor: page.NextCursor,
+	}
+
+	if err = json.NewEncoder(w).Encode(resp); err != nil {
+		controller.log.Error("error while encoding response", ErrComments.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrComments, w)
+		return
+	}
+}
+
+// create serves new comment creation on the subject by type.
+func (controller *Comments) create(w http.ResponseWriter, r *http.Request, subjectType string) {
+	ctx := r.Context()
+
+	creds, subjectID, ok := controller.callerAndID(w, r)
+	if !ok {
+		return
+	}
+
+	var request CreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		controller.log.Error("failed to decode create request body", ErrComments.Wrap(err))
+		common.NewErrResponse(http.StatusBadRequest, err).Serve(controller.log, ErrComments, w)
+		return
+	}
+
+	comment, err := controller.comments.Create(ctx, comments.CreateParams{
+		Subject:  comments.Subject{Type: subjectType, ID: subjectID},
+		ParentID: request.ParentID,
+		AuthorID: creds.UserID,
+		Body:     request.Body,
+	})
+	if err != nil {
+		controller.serveError(w, err, "failed to create comment")
+		return
+	}
+
+	controller.serveComment(w, comment)
+}
+
+// pin serves comment pinned state change.
+func (controller *Comments) pin(w http.ResponseWriter, r *http.Request, pinned bool) {
+	ctx := r.Context()
+
+	creds, commentID, ok := controller.callerAndID(w, r)
+	if !ok {
+		return
+	}
+
+	comment, err := controller.comments.Pin(ctx, commentID, creds.UserID, pinned)
+	if err != nil {
+		controller.serveError(w, err, "failed to change comment pin")
+		return
+	}
+
+	controller.serveComment(w, comment)
+}
+
+// hide serves comment hidden state change.
+func (controller *Comments) hide(w http.ResponseWriter, r *http.Request, hidden bool) {
+	ctx := r.Context()
+
+	creds, commentID, ok := controller.callerAndID(w, r)
+	if !ok {
+		return
+	}
+
+	comment, err := controller.comments.Hide(ctx, commentID, creds.UserID, hidden)
+	if err != nil {
+		controller.serveError(w, err, "failed to change comment visibility")
+		return
+	}
+
+	controller.serveComment(w, comment)
+}
+
+// react serves comment reaction change.
+func (controller *Comments) react(w http.ResponseWriter, r *http.Request, add bool) {
+	ctx := r.Context()
+
+	creds, commentID, ok := controller.callerAndID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := controller.comments.React(ctx, commentID, creds.UserID, mux.Vars(r)["reaction"], add); err != nil {
+		controller.serveError(w, err, "failed to change comment reaction")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// callerAndID returns caller credentials and id path parameter, serves error response on failure.
+func (controller *Comments) callerAndID(w http.ResponseWriter, r *http.Request) (*credentials.Credentials, uuid.UUID, bool) {
+	// INFO: Caller creds.
+	creds, err := credentials.GetFromContext(r.Context())
+	if err != nil {
+		common.NewErrResponse(http.StatusUnauthorized, err).Serve(controller.log, ErrComments, w)
+		return nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse id")).Serve(controller.log, ErrComments, w)
+		return nil, uuid.Nil, false
+	}
+
+	return creds, id, true
+}
+
+// serveComment encodes comment view into response.
+func (controller *Comments) serveComment(w http.ResponseWriter, comment *comments.Comment) {
+	if err := json.NewEncoder(w).Encode(ToCommentView(comment)); err != nil {
+		controller.log.Error("error while encoding response", ErrComments.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrComments, w)
+	}
+}
+
+// serveError replies with status code matching the comments service error.
+func (controller *Comments) serveError(w http.ResponseWriter, err error, message string) {
+	controller.log.Error(message, ErrComments.Wrap(err))
+
+	switch {
+	case errors.Is(err, comments.ErrForbidden):
+		common.NewErrResponse(http.StatusForbidden, comments.ErrForbidden).Serve(controller.log, ErrComments, w)
+	case errors.Is(err, comments.ErrNoComment):
+		common.NewErrResponse(http.StatusNotFound, comments.ErrNoComment).Serve(controller.log, ErrComments, w)
+	case errors.Is(err, fundraises.ErrNoFundraise):
+		common.NewErrResponse(http.StatusNotFound, fundraises.ErrNoFundraise).Serve(controller.log, ErrComments, w)
+	case errors.Is(err, events.ErrNoEvents):
+		common.NewErrResponse(http.StatusNotFound, events.ErrNoEvents).Serve(controller.log, ErrComments, w)
+	case comments.ParamsError.Has(err):
+		common.NewErrResponse(http.StatusBadRequest, errors.Unwrap(err)).Serve(controller.log, ErrComments, w)
+	default:
+		common.NewErrResponse(http.StatusInternalServerError, errors.New(message)).Serve(controller.log, ErrComments, w)
+	}
+}
@@ -0,0 +1,93 @@
+package comments
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"one-help/app/comments"
+)
+
+// BadgeDonor defines badge of the author who donated to the commented fundraise.
+const BadgeDonor = "donor"
+
+// CreateRequest defines request values for create endpoint.
+type CreateRequest struct {
+	ParentID uuid.UUID `json:"parentId"`
+	Body     string    `json:"body"`
+}
+
+// EditRequest defines request values for edit endpoint.
+type EditRequest struct {
+	Body string `json:"body"`
+}
+
+// CommentView defines comment view type.
+type CommentView struct {
+	ID          uuid.UUID      `json:"id"`
+	ParentID    *uuid.UUID     `json:"parentId,omitempty"`
+	SubjectType string         `json:"subjectType"`
+	SubjectID   uuid.UUID      `json:"subjectId"`
+	AuthorID    uuid.UUID      `json:"authorId"`
+	Body        string         `json:"body"`
+	Pinned      bool           `json:"pinned"`
+	Hidden      bool           `json:"hidden"`
+	Deleted     bool           `json:"deleted"`
+	Edited      bool           `json:"edited"`
+	Badges      []string       `json:"badges"`
+	Replies     int            `json:"replies"`
+	Reactions   map[string]int `json:"reactions"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+}
+
+// ToCommentView builds comment view.
+func ToCommentView(comment *comments.Comment) CommentView {
+	view := CommentView{
+		ID:          comment.ID,
+		SubjectType: comment.Subject.Type,
+		SubjectID:   comment.Subject.ID,
+		AuthorID:    comment.AuthorID,
+		Body:        comment.Body,
+		Pinned:      comment.Pinned,
+		Hidden:      comment.Hidden,
+		Deleted:     comment.Deleted,
+		Edited:      !comment.Deleted && comment.UpdatedAt.After(comment.CreatedAt),
+		Badges:      []string{},
+		Replies:     comment.Replies,
+		Reactions:   comment.Reactions,
+		CreatedAt:   comment.CreatedAt,
+		UpdatedAt:   comment.UpdatedAt,
+	}
+
+	if comment.IsReply() {
+		view.ParentID = &comment.ParentID
+	}
+
+	if comment.IsDonor {
+		view.Badges = append(view.Badges, BadgeDonor)
+	}
+
+	if view.Reactions == nil {
+		view.Reactions = map[string]int{}
+	}
+
+	return view
+}
+
+// ToCommentViews builds list of comment views.
+func ToCommentViews(list []comments.Comment) []CommentView {
+	views := make([]CommentView, len(list))
+	for i := range list {
+		views[i] = ToCommentView(&list[i])
+	}
+
+	return views
+}
+
+// PageView defines cursor paginated comments list view.
+type PageView struct {
+	Data       []CommentView `json:"data"`
+	Pinned     []CommentView `json:"pinned"`
+	NextCursor string        `json:"nextCursor,omitempty"`
+}
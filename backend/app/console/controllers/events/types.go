@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"one-help/app/console/controllers/fundraises"
+	"one-help/app/currencies"
 	"one-help/app/events"
 
 	eventparticipants "one-help/app/events/participants"
@@ -13,35 +14,35 @@ import (
 
 // CreateRequest defines parameters needed to create event.
 type CreateRequest struct {
-	Title           string    `json:"title"`
-	Description     string    `json:"description"`
-	StartDate       time.Time `json:"startDate"`
-	EndDate         time.Time `json:"endDate"`
-	Format          string    `json:"format"`
-	MaxParticipants int       `json:"maxParticipants"`
-	MinimumDonation float64   `json:"minimumDonation"`
-	Address         string    `json:"address"`
-	FundraiseId     uuid.UUID `json:"fundraiseId"`
-	ImageUrl        string    `json:"imageUrl"`
-	FormUrl         string    `json:"formUrl"`
+	Title           string            `json:"title"`
+	Description     string            `json:"description"`
+	StartDate       time.Time         `json:"startDate"`
+	EndDate         time.Time         `json:"endDate"`
+	Format          string            `json:"format"`
+	MaxParticipants int               `json:"maxParticipants"`
+	MinimumDonation currencies.Amount `json:"minimumDonation"`
+	Address         string            `json:"address"`
+	FundraiseId     uuid.UUID         `json:"fundraiseId"`
+	ImageUrl        string            `json:"imageUrl"`
+	FormUrl         string            `json:"formUrl"`
 }
 
 // EventView defines event view type.
 type EventView struct {
-	ID              uuid.UUID `json:"id"`
-	Title           string    `json:"title"`
-	Description     string    `json:"description"`
-	StartDate       time.Time `json:"startDate"`
-	EndDate         time.Time `json:"endDate,omitempty"`
-	Format          string    `json:"format"`
-	MaxParticipants int       `json:"maxParticipants"`
-	MinimumDonation float64   `json:"minimumDonation"`
-	Address         string    `json:"address"`
-	Status          string    `json:"status"`
-	FundraiseId     uuid.UUID `json:"fundraiseId"`
-	CreatedAt       time.Time `json:"createdAt"`
-	ImageUrl        string    `json:"imageUrl"`
-	FormUrl         string    `json:"formUrl"`
+	ID              uuid.UUID         `json:"id"`
+	Title           string            `json:"title"`
+	Description     string            `json:"description"`
+	StartDate       time.Time         `json:"startDate"`
+	EndDate         time.Time         `json:"endDate,omitempty"`
+	Format          string            `json:"format"`
+	MaxParticipants int               `json:"maxParticipants"`
+	MinimumDonation currencies.Amount `json:"minimumDonation"`
+	Address         string            `json:"address"`
+	Status          string            `json:"status"`
+	FundraiseId     uuid.UUID         `json:"fundraiseId"`
+	CreatedAt       time.Time         `json:"createdAt"`
+	ImageUrl        string            `json:"imageUrl"`
+	FormUrl         string            `json:"formUrl"`
 }
 
 // EventViewExtended defines event view type with additional data.
@@ -1,17 +1,30 @@
 package fundraises
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
+	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/zeebo/errs"
 
 	"one-help/app/console/controllers/common"
+	"one-help/app/currencies"
+	"one-help/app/donations"
+	"one-help/app/donations/plans"
+	"one-help/app/donations/tributes"
 	"one-help/app/fundraises"
+	"one-help/app/fundraises/analytics"
+	"one-help/app/fundraises/matching"
+	"one-help/app/fundraises/payouts"
+	"one-help/app/payments/refunds"
+	"one-help/app/payments/statements"
 	"one-help/app/users/credentials"
 	"one-help/internal/logger"
 )
@@ -26,15 +39,31 @@ type Fundraises struct {
 	log logger.Logger
 
 	fundraises *fundraises.Service
+	plans      *plans.Service
+	refunds    *refunds.Service
+	payouts    *payouts.Service
+	statements *statements.Service
 
 	frontEndRedirectUrl string
 }
 
 // NewFundraises is a constructor for fundraises controller.
-func NewFundraises(log logger.Logger, fundraises *fundraises.Service, frontEndRedirectUrl string) *Fundraises {
+func NewFundraises(
+	log logger.Logger,
+	fundraises *fundraises.Service,
+	plans *plans.Service,
+	refunds *refunds.Service,
+	payouts *payouts.Service,
+	statements *statements.Service,
+	frontEndRedirectUrl string,
+) *Fundraises {
 	fundraisesController := &Fundraises{
 		log:                 log,
 		fundraises:          fundraises,
+		plans:               plans,
+		refunds:             refunds,
+		payouts:             payouts,
+		statements:          statements,
 		frontEndRedirectUrl: frontEndRedirectUrl,
 	}
 
@@ -72,6 +101,9 @@ func (controller *Fundraises) Create(w http.ResponseWriter, r *http.Request) {
 		Title:        request.Title,
 		Description:  request.Description,
 		TargetAmount: request.TargetAmount,
+		Currency:     request.Currency,
+		MinDonation:  request.MinDonation,
+		Presets:      request.Presets,
 		EndDate:      request.EndDate,
 		ImageUrl:     request.ImageUrl,
 	}
@@ -88,7 +120,7 @@ func (controller *Fundraises) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err = json.NewEncoder(w).Encode(ToFundraiseView(fundraise, 0)); err != nil {
+	if err = json.NewEncoder(w).Encode(ToFundraiseView(fundraise, currencies.Zero)); err != nil {
 		controller.log.Error("error while encoding response:", ErrFundraises.Wrap(err))
 		common.NewErrResponse(http.StatusInternalServerError, errors.Unwrap(err)).Serve(controller.log, ErrFundraises, w)
 		return
@@ -142,7 +174,7 @@ func (controller *Fundraises) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var filled float64
+	var filled currencies.Amount
 	var viewList = make([]*FundraiseView, len(list))
 	for i, fundraise := range list {
 		filled, err = controller.fundraises.Filled(ctx, fundraise.ID)
@@ -185,7 +217,13 @@ func (controller *Fundraises) GetByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	fundraise, err := controller.fundraises.Get(ctx, fundsraiseID)
+	// INFO: Caller creds are optional, anonymous caller sees only reviewed fundraises.
+	var callerID uuid.UUID
+	if creds, err := credentials.GetFromContext(ctx); err == nil {
+		callerID = creds.UserID
+	}
+
+	fundraise, err := controller.fundraises.GetVisible(ctx, fundsraiseID, callerID)
 	if err != nil {
 		controller.log.Error("failed to get fundraise by id", ErrFundraises.Wrap(err))
 		if errors.Is(err, fundraises.ErrNoFundraise) {
@@ -204,7 +242,20 @@ func (controller *Fundraises) GetByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err = json.NewEncoder(w).Encode(ToFundraiseView(fundraise, filled)); err != nil {
+	pledges, err := controller.fundraises.Pledges(ctx, fundraise.ID)
+	if err != nil {
+		controller.log.Error("failed to get fundraise matching pledges", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, errors.New("failed to get fundraise matching pledges")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	view := ToFundraiseView(fundraise, filled)
+	if len(pledges) > 0 {
+		budget := matching.NewBudget(pledges, time.Now())
+		view.Matching = &MatchingView{Matched: budget.Matched, Remaining: budget.Remaining}
+	}
+
+	if err = json.NewEncoder(w).Encode(view); err != nil {
 		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
 		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
 		return
@@ -264,7 +315,7 @@ func (controller *Fundraises) ListMy(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var filled float64
+	var filled currencies.Amount
 	var viewList = make([]*FundraiseView, len(list))
 	for i, fundraise := range list {
 		filled, err = controller.fundraises.Filled(ctx, fundraise.ID)
@@ -293,8 +344,10 @@ func (controller *Fundraises) ListMy(w http.ResponseWriter, r *http.Request) {
 // Donate is an endpoint creating new payment url to donate to fundraise.
 // @Summary	Creates new payment url to donate to fundraise.
 // @Tags	Fundraises
+// @Accept	json
 // @Produce	json
 // @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Param	request	body	DonateRequest	false	"Donation options"
 // @Success	200	{object}	DonateResponse
 // @Failure	400,401,404,500	{object}	common.ErrResponseCode
 // @Router	/fundraises/{id}/donate	[post].
@@ -326,17 +379,299 @@ func (controller *Fundraises) Donate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var request DonateRequest
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+		controller.log.Error("failed to decode donate request body", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusBadRequest, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
 	result, err := controller.fundraises.RegisterDonate(ctx, fundraises.RegisterDonateParams{
 		FundraiseID: fundraise.ID,
 		UserID:      creds.UserID,
+		Currency:    request.Currency,
+		Amount:      request.Amount,
+		PaymentType: request.PaymentType,
+		Anonymous:   request.Anonymous,
+		Message:     request.Message,
+		CoverFee:    request.CoverFee,
+		TeamCode:    request.TeamCode,
+		Tribute: tributes.Tribute{
+			Type:           request.TributeType,
+			HonoreeName:    request.HonoreeName,
+			RecipientName:  request.TributeRecipientName,
+			RecipientEmail: request.TributeRecipientEmail,
+		},
 	})
 	if err != nil {
 		controller.log.Error("failed to register payment", ErrFundraises.Wrap(err))
+		if errors.Is(err, fundraises.ErrNotApproved) {
+			common.NewErrResponse(http.StatusForbidden, fundraises.ErrNotApproved).Serve(controller.log, ErrFundraises, w)
+			return
+		}
+		if fundraises.ParamsError.Has(err) {
+			common.NewErrResponse(http.StatusBadRequest, errors.Unwrap(err)).Serve(controller.log, ErrFundraises, w)
+			return
+		}
+
 		common.NewErrResponse(http.StatusInternalServerError, errors.New("failed to register payment")).Serve(controller.log, ErrFundraises, w)
 		return
 	}
 
-	if err = json.NewEncoder(w).Encode(&DonateResponse{PaymentURL: result.PaymentURL}); err != nil {
+	if err = json.NewEncoder(w).Encode(&DonateResponse{
+		PaymentURL: result.PaymentURL,
+		Amount:     result.Amount,
+		Fee:        result.Fee,
+	}); err != nil {
+		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+}
+
+// Transfer is an endpoint for transferring collected funds to another fundraise.
+// @Summary	Transfers funds of overfunded or cancelled fundraise to another active fundraise
+// @Tags	Fundraises
+// @Accept	json
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Param	request	body	TransferRequest	true	"Transfer data fields"
+// @Success	200	{object}	TransferView
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/fundraises/{id}/transfer	[post].
+func (controller *Fundraises) Transfer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// INFO: Caller creds.
+	creds, err := credentials.GetFromContext(ctx)
+	if err != nil {
+		common.NewErrResponse(http.StatusUnauthorized, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	fundsraiseID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse id")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	var request TransferRequest
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil {
+		controller.log.Error("failed to decode transfer request body", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusBadRequest, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	transfer, err := controller.fundraises.Transfer(ctx, fundraises.TransferParams{
+		FromFundraiseID: fundsraiseID,
+		ToFundraiseID:   request.ToFundraiseID,
+		CallerID:        creds.UserID,
+		Amount:          request.Amount,
+		Reason:          request.Reason,
+	})
+	if err != nil {
+		controller.log.Error("failed to transfer funds", ErrFundraises.Wrap(err))
+		switch {
+		case errors.Is(err, fundraises.ErrNoFundraise):
+			common.NewErrResponse(http.StatusNotFound, fundraises.ErrNoFundraise).Serve(controller.log, ErrFundraises, w)
+		case errors.Is(err, fundraises.ErrNotOrganizer):
+			common.NewErrResponse(http.StatusForbidden, fundraises.ErrNotOrganizer).Serve(controller.log, ErrFundraises, w)
+		case fundraises.ParamsError.Has(err):
+			common.NewErrResponse(http.StatusBadRequest, errors.Unwrap(err)).Serve(controller.log, ErrFundraises, w)
+		default:
+			common.NewErrResponse(http.StatusInternalServerError, errors.New("failed to transfer funds")).Serve(controller.log, ErrFundraises, w)
+		}
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(ToTransferView(transfer)); err != nil {
+		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+}
+
+// ListTransfers is an endpoint for listing fundraise transfers.
+// @Summary	Returns incoming and outgoing transfers of the fundraise
+// @Tags	Fundraises
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Success	200	{object}	[]TransferView
+// @Failure	400,401,500	{object}	common.ErrResponseCode
+// @Router	/fundraises/{id}/transfers	[get].
+func (controller *Fundraises) ListTransfers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	fundsraiseID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse id")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	list, err := controller.fundraises.ListTransfers(ctx, fundsraiseID)
+	if err != nil {
+		controller.log.Error("failed to list fundraise transfers", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, errors.New("failed to list fundraise transfers")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(ToTransferViews(list)); err != nil {
+		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+}
+
+// ListMyTransfers is an endpoint for listing transfers of funds the caller donated.
+// @Summary	Returns transfers out of fundraises the user by bearer token donated to
+// @Tags	Fundraises
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Success	200	{object}	[]TransferView
+// @Failure	401,500	{object}	common.ErrResponseCode
+// @Router	/fundraises/transfers/my	[get].
+func (controller *Fundraises) ListMyTransfers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// INFO: Caller creds.
+	creds, err := credentials.GetFromContext(ctx)
+	if err != nil {
+		common.NewErrResponse(http.StatusUnauthorized, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	list, err := controller.fundraises.ListDonorTransfers(ctx, creds.UserID)
+	if err != nil {
+		controller.log.Error("failed to list donor transfers", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, errors.New("failed to list donor transfers")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(ToTransferViews(list)); err != nil {
+		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+}
+
+// Analytics is an endpoint for fundraise donations analytics available to its organizer.
+// @Summary	Returns donations time series, donors and conversion statistics of the fundraise
+// @Tags	Fundraises
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Param	from	query	string	false	"Period start in RFC3339 format [default value: fundraise start date]"
+// @Param	to	query	string	false	"Period end in RFC3339 format [default value: now]"
+// @Success	200	{object}	AnalyticsView
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/fundraises/{id}/analytics	[get].
+func (controller *Fundraises) Analytics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// INFO: Caller creds.
+	creds, err := credentials.GetFromContext(ctx)
+	if err != nil {
+		common.NewErrResponse(http.StatusUnauthorized, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	params := analytics.Params{}
+	params.FundraiseID, err = uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse id")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	if val := r.URL.Query().Get("from"); val != "" {
+		if params.From, err = time.Parse(time.RFC3339, val); err != nil {
+			common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse from")).Serve(controller.log, ErrFundraises, w)
+			return
+		}
+	}
+
+	if val := r.URL.Query().Get("to"); val != "" {
+		if params.To, err = time.Parse(time.RFC3339, val); err != nil {
+			common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse to")).Serve(controller.log, ErrFundraises, w)
+			return
+		}
+	}
+
+	report, err := controller.fundraises.Analytics(ctx, params, creds.UserID)
+	if err != nil {
+		controller.log.Error("failed to get fundraise analytics", ErrFundraises.Wrap(err))
+		switch {
+		case errors.Is(err, fundraises.ErrNoFundraise):
+			common.NewErrResponse(http.StatusNotFound, fundraises.ErrNoFundraise).Serve(controller.log, ErrFundraises, w)
+		case errors.Is(err, fundraises.ErrNotOrganizer):
+			common.NewErrResponse(http.StatusForbidden, fundraises.ErrNotOrganizer).Serve(controller.log, ErrFundraises, w)
+		case fundraises.ParamsError.Has(err):
+			common.NewErrResponse(http.StatusBadRequest, errors.Unwrap(err)).Serve(controller.log, ErrFundraises, w)
+		default:
+			common.NewErrResponse(http.StatusInternalServerError, errors.New("failed to get fundraise analytics")).Serve(controller.log, ErrFundraises, w)
+		}
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(ToAnalyticsView(report)); err != nil {
+		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+}
+
+// ListDonations is an endpoint for listing fundraise donations by its organizer.
+// @Summary	Returns donations of the fundraise with original and converted amounts
+// @Tags	Fundraises
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Success	200	{object}	[]DonationView
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/fundraises/{id}/donations	[get].
+func (controller *Fundraises) ListDonations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// INFO: Caller creds.
+	creds, err := credentials.GetFromContext(ctx)
+	if err != nil {
+		common.NewErrResponse(http.StatusUnauthorized, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	fundsraiseID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse id")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	fundraise, err := controller.fundraises.Get(ctx, fundsraiseID)
+	if err != nil {
+		controller.log.Error("failed to get fundraise by id", ErrFundraises.Wrap(err))
+		if errors.Is(err, fundraises.ErrNoFundraise) {
+			common.NewErrResponse(http.StatusNotFound, fundraises.ErrNoFundraise).Serve(controller.log, ErrFundraises, w)
+			return
+		}
+
+		common.NewErrResponse(http.StatusInternalServerError, errors.New("failed to get fundraise by id")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	list, err := controller.fundraises.ListDonations(ctx, fundraise.ID, creds.UserID)
+	if err != nil {
+		controller.log.Error("failed to list fundraise donations", ErrFundraises.Wrap(err))
+		if errors.Is(err, fundraises.ErrNotOrganizer) {
+			common.NewErrResponse(http.StatusForbidden, fundraises.ErrNotOrganizer).Serve(controller.log, ErrFundraises, w)
+			return
+		}
+
+		common.NewErrResponse(http.StatusInternalServerError, errors.New("failed to list fundraise donations")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	viewList := make([]DonationView, len(list))
+	for i := range list {
+		viewList[i] = ToDonationView(&list[i], fundraise.Currency)
+	}
+
+	if err = json.NewEncoder(w).Encode(viewList); err != nil {
 		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
 		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
 		return
@@ -344,7 +679,9 @@ func (controller *Fundraises) Donate(w http.ResponseWriter, r *http.Request) {
 }
 
 // FinishDonation is an endpoint payment callback.
-// @Summary	Finishes payment process (for internal use).
+// Donation is confirmed by the payment provider's webhook, so this endpoint only redirects donor to the fundraise
+// with current payment status, which may still be PENDING.
+// @Summary	Redirects donor back to the fundraise after payment (for internal use).
 // @Tags	Donations
 // @Produce	json
 // @Success	301
@@ -362,31 +699,279 @@ func (controller *Fundraises) FinishDonation(w http.ResponseWriter, r *http.Requ
 	donation, err := controller.fundraises.GetDonation(ctx, donationID)
 	if err != nil {
 		controller.log.Error("failed to get donation", ErrFundraises.Wrap(err))
+		if errors.Is(err, donations.ErrNoDonation) {
+			common.NewErrResponse(http.StatusNotFound, errors.New("donation not found")).Serve(controller.log, ErrFundraises, w)
+			return
+		}
+
 		common.NewErrResponse(http.StatusInternalServerError, errors.New("failed to get donation")).Serve(controller.log, ErrFundraises, w)
 		return
 	}
 
-	redirectURL := controller.frontEndRedirectUrl + "/" + donation.FundraiseId.String()
+	status, err := controller.fundraises.DonationStatus(ctx, donation.ID)
+	if err != nil {
+		controller.log.Error("failed to get donation status", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, errors.New("failed to get donation status")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	redirectURL := controller.frontEndRedirectUrl + "/" + donation.FundraiseId.String() + "?status=" + url.QueryEscape(status)
+	http.Redirect(w, r, redirectURL, http.StatusMovedPermanently)
+}
+
+// ReviewQueue is an endpoint for listing fundraises awaiting moderation review.
+// @Summary	Returns fundraises pending review, the oldest first
+// @Tags	Moderation
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Param	limit			query	integer	false	"Items per page (positive number expected) [default value: 20]"
+// @Param	page			query	integer	false	"Number of the page (1...) [default value: 1]"
+// @Success	200		{object}	common.Page[FundraiseView]
+// @Failure	400,401,403,500	{object}	common.ErrResponseCode
+// @Router	/moderation/fundraises	[get].
+func (controller *Fundraises) ReviewQueue(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// INFO: Caller creds.
+	creds, err := credentials.GetFromContext(ctx)
+	if err != nil {
+		common.NewErrResponse(http.StatusUnauthorized, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
 
-	switch {
-	case r.URL.Query().Get("success") == "true":
-		if err = controller.fundraises.ConfirmDonation(ctx, donation); err != nil {
-			controller.log.Error("failed to confirm donation", ErrFundraises.Wrap(err))
-			common.NewErrResponse(http.StatusInternalServerError, errors.New("failed to confirm donation")).Serve(controller.log, ErrFundraises, w)
+	var (
+		limit = 20
+		page  = 1
+	)
+	if val := r.URL.Query().Get("limit"); val != "" {
+		limit, err = strconv.Atoi(val)
+		if err != nil {
+			controller.log.Error("failed to parse 'limit' query parameter", ErrFundraises.Wrap(err))
+			common.NewErrResponse(http.StatusBadRequest, errors.New("invalid limit value")).Serve(controller.log, ErrFundraises, w)
 			return
 		}
-
-		http.Redirect(w, r, redirectURL+"?success=true", http.StatusMovedPermanently)
-	case r.URL.Query().Get("canceled") == "true":
-		if err = controller.fundraises.CancelDonation(ctx, donation); err != nil {
-			controller.log.Error("failed to cancel donation", ErrFundraises.Wrap(err))
-			common.NewErrResponse(http.StatusInternalServerError, errors.New("failed to cancel donation")).Serve(controller.log, ErrFundraises, w)
+	}
+	if val := r.URL.Query().Get("page"); val != "" {
+		page, err = strconv.Atoi(val)
+		if err != nil {
+			controller.log.Error("failed to parse 'page' query parameter", ErrFundraises.Wrap(err))
+			common.NewErrResponse(http.StatusBadRequest, errors.New("invalid page value")).Serve(controller.log, ErrFundraises, w)
 			return
 		}
+	}
+
+	list, err := controller.fundraises.ReviewQueue(ctx, creds.UserID, limit, page)
+	if err != nil {
+		controller.log.Error("failed to list review queue", ErrFundraises.Wrap(err))
+		switch {
+		case errors.Is(err, fundraises.ErrNotModerator):
+			common.NewErrResponse(http.StatusForbidden, fundraises.ErrNotModerator).Serve(controller.log, ErrFundraises, w)
+		case fundraises.ParamsError.Has(err):
+			common.NewErrResponse(http.StatusBadRequest, errors.Unwrap(err)).Serve(controller.log, ErrFundraises, w)
+		default:
+			common.NewErrResponse(http.StatusInternalServerError, errors.New("failed to list review queue")).Serve(controller.log, ErrFundraises, w)
+		}
+		return
+	}
+
+	// INFO: fundraises pending review can not accept donations, so filled amount is always zero.
+	var viewList = make([]*FundraiseView, len(list))
+	for i := range list {
+		viewList[i] = ToFundraiseView(&list[i], currencies.Zero)
+	}
+
+	resp := &common.Page[*FundraiseView]{
+		Data:  viewList,
+		Page:  page,
+		Limit: limit,
+	}
+
+	if err = json.NewEncoder(w).Encode(resp); err != nil {
+		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+}
+
+// Approve is an endpoint for approving fundraise pending review.
+// @Summary	Approves fundraise, allowing it to accept donations
+// @Tags	Moderation
+// @Accept	json
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Param	request	body	ReviewRequest	false	"Optional approval note"
+// @Success	200
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/moderation/fundraises/{id}/approve	[post].
+func (controller *Fundraises) Approve(w http.ResponseWriter, r *http.Request) {
+	controller.review(w, r, controller.fundraises.Approve)
+}
+
+// Reject is an endpoint for rejecting fundraise pending review.
+// @Summary	Rejects fundraise with the reason, organizer may fix and resubmit it
+// @Tags	Moderation
+// @Accept	json
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Param	request	body	ReviewRequest	true	"Rejection reason"
+// @Success	200
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/moderation/fundraises/{id}/reject	[post].
+func (controller *Fundraises) Reject(w http.ResponseWriter, r *http.Request) {
+	controller.review(w, r, controller.fundraises.Reject)
+}
+
+// review handles moderator's decision on the fundraise.
+func (controller *Fundraises) review(w http.ResponseWriter, r *http.Request, decide func(ctx context.Context, params fundraises.ReviewParams) error) {
+	ctx := r.Context()
+
+	// INFO: Caller creds.
+	creds, err := credentials.GetFromContext(ctx)
+	if err != nil {
+		common.NewErrResponse(http.StatusUnauthorized, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	fundsraiseID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse id")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	var request ReviewRequest
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+		controller.log.Error("failed to decode review request body", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusBadRequest, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	err = decide(ctx, fundraises.ReviewParams{
+		FundraiseID: fundsraiseID,
+		CallerID:    creds.UserID,
+		Reason:      request.Reason,
+	})
+	if err != nil {
+		controller.log.Error("failed to review fundraise", ErrFundraises.Wrap(err))
+		switch {
+		case errors.Is(err, fundraises.ErrNoFundraise):
+			common.NewErrResponse(http.StatusNotFound, fundraises.ErrNoFundraise).Serve(controller.log, ErrFundraises, w)
+		case errors.Is(err, fundraises.ErrNotModerator):
+			common.NewErrResponse(http.StatusForbidden, fundraises.ErrNotModerator).Serve(controller.log, ErrFundraises, w)
+		case fundraises.ParamsError.Has(err):
+			common.NewErrResponse(http.StatusBadRequest, errors.Unwrap(err)).Serve(controller.log, ErrFundraises, w)
+		default:
+			common.NewErrResponse(http.StatusInternalServerError, errors.New("failed to review fundraise")).Serve(controller.log, ErrFundraises, w)
+		}
+		return
+	}
+}
+
+// Resubmit is an endpoint for resubmitting rejected fundraise for review.
+// @Summary	Applies fixes to the rejected fundraise and sends it back to the review queue
+// @Tags	Fundraises
+// @Accept	json
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Param	request	body	ResubmitRequest	true	"Fixed fundraise fields, omitted fields are kept"
+// @Success	200	{object}	FundraiseView
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/fundraises/{id}/resubmit	[post].
+func (controller *Fundraises) Resubmit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// INFO: Caller creds.
+	creds, err := credentials.GetFromContext(ctx)
+	if err != nil {
+		common.NewErrResponse(http.StatusUnauthorized, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	fundsraiseID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse id")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	var request ResubmitRequest
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+		controller.log.Error("failed to decode resubmit request body", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusBadRequest, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	fundraise, err := controller.fundraises.Resubmit(ctx, fundraises.ResubmitParams{
+		FundraiseID:  fundsraiseID,
+		CallerID:     creds.UserID,
+		Title:        request.Title,
+		Description:  request.Description,
+		TargetAmount: request.TargetAmount,
+		EndDate:      request.EndDate,
+		ImageUrl:     request.ImageUrl,
+		Comment:      request.Comment,
+	})
+	if err != nil {
+		controller.log.Error("failed to resubmit fundraise", ErrFundraises.Wrap(err))
+		switch {
+		case errors.Is(err, fundraises.ErrNoFundraise):
+			common.NewErrResponse(http.StatusNotFound, fundraises.ErrNoFundraise).Serve(controller.log, ErrFundraises, w)
+		case errors.Is(err, fundraises.ErrNotOrganizer):
+			common.NewErrResponse(http.StatusForbidden, fundraises.ErrNotOrganizer).Serve(controller.log, ErrFundraises, w)
+		case fundraises.ParamsError.Has(err):
+			common.NewErrResponse(http.StatusBadRequest, errors.Unwrap(err)).Serve(controller.log, ErrFundraises, w)
+		default:
+			common.NewErrResponse(http.StatusInternalServerError, errors.New("failed to resubmit fundraise")).Serve(controller.log, ErrFundraises, w)
+		}
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(ToFundraiseView(fundraise, currencies.Zero)); err != nil {
+		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+}
+
+// ListReviews is an endpoint for listing moderation history of the fundraise.
+// @Summary	Returns moderation decisions on the fundraise, available to its organizer and moderators
+// @Tags	Fundraises
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Success	200	{object}	[]ReviewView
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/fundraises/{id}/reviews	[get].
+func (controller *Fundraises) ListReviews(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// INFO: Caller creds.
+	creds, err := credentials.GetFromContext(ctx)
+	if err != nil {
+		common.NewErrResponse(http.StatusUnauthorized, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	fundsraiseID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse id")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	list, err := controller.fundraises.ListReviews(ctx, fundsraiseID, creds.UserID)
+	if err != nil {
+		controller.log.Error("failed to list fundraise reviews", ErrFundraises.Wrap(err))
+		switch {
+		case errors.Is(err, fundraises.ErrNoFundraise):
+			common.NewErrResponse(http.StatusNotFound, fundraises.ErrNoFundraise).Serve(controller.log, ErrFundraises, w)
+		case errors.Is(err, fundraises.ErrNotModerator):
+			common.NewErrResponse(http.StatusForbidden, fundraises.ErrNotModerator).Serve(controller.log, ErrFundraises, w)
+		default:
+			common.NewErrResponse(http.StatusInternalServerError, errors.New("failed to list fundraise reviews")).Serve(controller.log, ErrFundraises, w)
+		}
+		return
+	}
 
-		http.Redirect(w, r, redirectURL+"?canceled=true", http.StatusMovedPermanently)
-	default:
-		controller.log.Warn("invalid callback url format received")
-		common.NewErrResponse(http.StatusBadRequest, errors.New("failed to cancel donation")).Serve(controller.log, ErrFundraises, w)
+	if err = json.NewEncoder(w).Encode(ToReviewViews(list)); err != nil {
+		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
+		return
 	}
 }
@@ -0,0 +1,97 @@
+package fundraises
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+
+	"one-help/app/console/controllers/common"
+	"one-help/app/donations"
+	"one-help/app/fundraises"
+	"one-help/app/users/credentials"
+)
+
+// DonationHistory is an endpoint for listing donations of the caller.
+// @Summary	Returns page of the caller's donations with payment status, yearly totals and contributions to every fundraise
+// @Tags	Donations
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Param	limit		query	integer	false	"Items per page (positive number expected) [default value: 20]"
+// @Param	page		query	integer	false	"Number of the page (1...) [default value: 1]"
+// @Param	fundraiseId	query	string	false	"Only donations to the fundraise"
+// @Param	from		query	string	false	"Period start in RFC3339 format"
+// @Param	to			query	string	false	"Period end (exclusive) in RFC3339 format"
+// @Success	200		{object}	DonationHistoryView
+// @Failure	400,401,500	{object}	common.ErrResponseCode
+// @Router	/users/me/donations	[get].
+func (controller *Fundraises) DonationHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// INFO: Caller creds.
+	creds, err := credentials.GetFromContext(ctx)
+	if err != nil {
+		common.NewErrResponse(http.StatusUnauthorized, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	params := donations.HistoryParams{
+		UserID: creds.UserID,
+		Limit:  20,
+		Page:   1,
+	}
+
+	query := r.URL.Query()
+	if val := query.Get("limit"); val != "" {
+		if params.Limit, err = strconv.Atoi(val); err != nil {
+			common.NewErrResponse(http.StatusBadRequest, errors.New("invalid limit value")).Serve(controller.log, ErrFundraises, w)
+			return
+		}
+	}
+	if val := query.Get("page"); val != "" {
+		if params.Page, err = strconv.Atoi(val); err != nil {
+			common.NewErrResponse(http.StatusBadRequest, errors.New("invalid page value")).Serve(controller.log, ErrFundraises, w)
+			return
+		}
+	}
+	if val := query.Get("fundraiseId"); val != "" {
+		if params.FundraiseID, err = uuid.Parse(val); err != nil {
+			common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse fundraise id")).Serve(controller.log, ErrFundraises, w)
+			return
+		}
+	}
+	if val := query.Get("from"); val != "" {
+		if params.From, err = time.Parse(time.RFC3339, val); err != nil {
+			common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse from")).Serve(controller.log, ErrFundraises, w)
+			return
+		}
+	}
+	if val := query.Get("to"); val != "" {
+		if params.To, err = time.Parse(time.RFC3339, val); err != nil {
+			common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse to")).Serve(controller.log, ErrFundraises, w)
+			return
+		}
+	}
+
+	history, err := controller.fundraises.DonationHistory(ctx, params)
+	if err != nil {
+		controller.log.Error("failed to get donation history", ErrFundraises.Wrap(err))
+		if fundraises.ParamsError.Has(err) {
+			common.NewErrResponse(http.StatusBadRequest, errors.Unwrap(err)).Serve(controller.log, ErrFundraises, w)
+			return
+		}
+
+		common.NewErrResponse(http.StatusInternalServerError, errors.New("failed to get donation history")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(ToDonationHistoryView(history, params.Page, params.Limit)); err != nil {
+		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+}
@@ -0,0 +1,92 @@
+package fundraises
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/zeebo/errs"
+
+	"one-help/app/console/controllers/common"
+	"one-help/app/fundraises"
+	"one-help/app/fundraises/leaderboards"
+)
+
+// Leaderboard is a public endpoint for ranking top donors or teams of the fundraise.
+// @Summary	Returns top donors or teams of the fundraise, anonymous donations count only towards teams, private donors are hidden
+// @Tags	Fundraises
+// @Produce	json
+// @Param	kind	query	string	false	"donors or teams [default value: donors]"
+// @Param	period	query	string	false	"all or month in YYYY-MM format [default value: all]"
+// @Param	limit	query	integer	false	"Number of entries (positive number expected) [default value: 10, max value: 100]"
+// @Success	200	{object}	LeaderboardView
+// @Failure	400,404,500	{object}	common.ErrResponseCode
+// @Router	/fundraises/{id}/leaderboard	[get].
+func (controller *Fundraises) Leaderboard(w http.ResponseWriter, r *http.Request) {
+	fundraiseID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse id")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	controller.leaderboard(w, r, fundraiseID)
+}
+
+// PlatformLeaderboard is a public endpoint for ranking top donors or teams across all fundraises.
+// @Summary	Returns top donors or teams of the platform in the default currency, anonymous donations count only towards teams, private donors are hidden
+// @Tags	Fundraises
+// @Produce	json
+// @Param	kind	query	string	false	"donors or teams [default value: donors]"
+// @Param	period	query	string	false	"all or month in YYYY-MM format [default value: all]"
+// @Param	limit	query	integer	false	"Number of entries (positive number expected) [default value: 10, max value: 100]"
+// @Success	200	{object}	LeaderboardView
+// @Failure	400,500	{object}	common.ErrResponseCode
+// @Router	/leaderboard	[get].
+func (controller *Fundraises) PlatformLeaderboard(w http.ResponseWriter, r *http.Request) {
+	controller.leaderboard(w, r, uuid.Nil)
+}
+
+// leaderboard serves leaderboard of the fundraise, or of the platform for nil fundraise id.
+func (controller *Fundraises) leaderboard(w http.ResponseWriter, r *http.Request, fundraiseID uuid.UUID) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	limit := 10
+	if val := query.Get("limit"); val != "" {
+		var err error
+		limit, err = strconv.Atoi(val)
+		if err != nil {
+			controller.log.Error("failed to parse 'limit' query parameter", ErrFundraises.Wrap(err))
+			common.NewErrResponse(http.StatusBadRequest, errors.New("invalid limit value")).Serve(controller.log, ErrFundraises, w)
+			return
+		}
+	}
+
+	board, err := controller.fundraises.Leaderboard(ctx, leaderboards.Params{
+		FundraiseID: fundraiseID,
+		Kind:        query.Get("kind"),
+		Period:      query.Get("period"),
+		Limit:       limit,
+	})
+	if err != nil {
+		controller.log.Error("failed to get leaderboard", ErrFundraises.Wrap(err))
+		switch {
+		case fundraises.ParamsError.Has(err):
+			common.NewErrResponse(http.StatusBadRequest, errors.Unwrap(err)).Serve(controller.log, ErrFundraises, w)
+		case errors.Is(err, fundraises.ErrNoFundraise):
+			common.NewErrResponse(http.StatusNotFound, fundraises.ErrNoFundraise).Serve(controller.log, ErrFundraises, w)
+		default:
+			common.NewErrResponse(http.StatusInternalServerError, errors.New("failed to get leaderboard")).Serve(controller.log, ErrFundraises, w)
+		}
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(ToLeaderboardView(board)); err != nil {
+		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+}
@@ -0,0 +1,131 @@
+package fundraises
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/zeebo/errs"
+
+	"one-help/app/console/controllers/common"
+	"one-help/app/fundraises"
+	"one-help/app/users/credentials"
+)
+
+// RecordOfflineDonation is an endpoint for recording donation received outside of payment providers.
+// @Summary	Records self-reported donation, e.g. bank transfer or cash, with optional donor and evidence
+// @Tags	Fundraises
+// @Accept	json
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Param	request	body	OfflineDonationRequest	true	"Offline donation data fields"
+// @Success	200	{object}	OfflineDonationView
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/fundraises/{id}/offline-donations	[post].
+func (controller *Fundraises) RecordOfflineDonation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// INFO: Caller creds.
+	creds, err := credentials.GetFromContext(ctx)
+	if err != nil {
+		common.NewErrResponse(http.StatusUnauthorized, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	fundraiseID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse id")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	var request OfflineDonationRequest
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil {
+		controller.log.Error("failed to decode offline donation request body", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusBadRequest, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	entry, err := controller.fundraises.RecordOfflineDonation(ctx, fundraises.OfflineDonationParams{
+		FundraiseID: fundraiseID,
+		CallerID:    creds.UserID,
+		DonorID:     request.DonorID,
+		PaymentType: request.PaymentType,
+		Amount:      request.Amount,
+		Currency:    request.Currency,
+		ReceivedAt:  request.ReceivedAt,
+		Reference:   request.Reference,
+		EvidenceURL: request.EvidenceURL,
+		Notes:       request.Notes,
+		Message:     request.Message,
+	})
+	if err != nil {
+		controller.log.Error("failed to record offline donation", ErrFundraises.Wrap(err))
+		controller.serveOfflineError(w, err, "failed to record offline donation")
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(ToOfflineDonationView(entry)); err != nil {
+		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+}
+
+// ListOfflineDonations is an endpoint for listing offline donations of the fundraise.
+// @Summary	Returns self-reported donations of the fundraise with their evidence and notes, allowed only to the organizer
+// @Tags	Fundraises
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Success	200	{object}	[]OfflineDonationView
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/fundraises/{id}/offline-donations	[get].
+func (controller *Fundraises) ListOfflineDonations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// INFO: Caller creds.
+	creds, err := credentials.GetFromContext(ctx)
+	if err != nil {
+		common.NewErrResponse(http.StatusUnauthorized, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	fundraiseID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse id")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	entries, err := controller.fundraises.OfflineDonations(ctx, fundraiseID, creds.UserID)
+	if err != nil {
+		controller.log.Error("failed to list offline donations", ErrFundraises.Wrap(err))
+		controller.serveOfflineError(w, err, "failed to list offline donations")
+		return
+	}
+
+	views := make([]OfflineDonationView, len(entries))
+	for i, entry := range entries {
+		views[i] = ToOfflineDonationView(entry)
+	}
+
+	if err = json.NewEncoder(w).Encode(views); err != nil {
+		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+}
+
+// serveOfflineError maps offline donations service errors to response codes.
+func (controller *Fundraises) serveOfflineError(w http.ResponseWriter, err error, failure string) {
+	switch {
+	case errors.Is(err, fundraises.ErrNoFundraise):
+		common.NewErrResponse(http.StatusNotFound, fundraises.ErrNoFundraise).Serve(controller.log, ErrFundraises, w)
+	case errors.Is(err, fundraises.ErrNotOrganizer):
+		common.NewErrResponse(http.StatusForbidden, fundraises.ErrNotOrganizer).Serve(controller.log, ErrFundraises, w)
+	case fundraises.ParamsError.Has(err):
+		common.NewErrResponse(http.StatusBadRequest, errors.Unwrap(err)).Serve(controller.log, ErrFundraises, w)
+	default:
+		common.NewErrResponse(http.StatusInternalServerError, errors.New(failure)).Serve(controller.log, ErrFundraises, w)
+	}
+}
@@ -0,0 +1,311 @@
+package fundraises
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/zeebo/errs"
+
+	"one-help/app/console/controllers/common"
+	"one-help/app/fundraises"
+	"one-help/app/fundraises/payouts"
+	"one-help/app/users/credentials"
+)
+
+// SaveBankDetails is an endpoint for saving caller's bank details.
+// @Summary	Saves bank account that payouts of caller's fundraises are sent to, returns it masked
+// @Tags	Payouts
+// @Accept	json
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Param	request	body	BankDetailsRequest	true	"Bank details"
+// @Success	200	{object}	BankDetailsView
+// @Failure	400,401,500	{object}	common.ErrResponseCode
+// @Router	/users/me/bank-details	[put].
+func (controller *Fundraises) SaveBankDetails(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// INFO: Caller creds.
+	creds, err := credentials.GetFromContext(ctx)
+	if err != nil {
+		common.NewErrResponse(http.StatusUnauthorized, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	var request BankDetailsRequest
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil {
+		controller.log.Error("failed to decode bank details request body", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusBadRequest, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	details, err := controller.payouts.SaveBankDetails(ctx, payouts.BankDetailsParams{
+		UserID:     creds.UserID,
+		HolderName: request.HolderName,
+		IBAN:       request.IBAN,
+		BankName:   request.BankName,
+	})
+	if err != nil {
+		controller.log.Error("failed to save bank details", ErrFundraises.Wrap(err))
+		controller.servePayoutError(w, err, "failed to save bank details")
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(ToBankDetailsView(details)); err != nil {
+		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+}
+
+// BankDetails is an endpoint for getting caller's bank details.
+// @Summary	Returns bank account that payouts of caller's fundraises are sent to, with masked account number
+// @Tags	Payouts
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Success	200	{object}	BankDetailsView
+// @Failure	401,404,500	{object}	common.ErrResponseCode
+// @Router	/users/me/bank-details	[get].
+func (controller *Fundraises) BankDetails(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// INFO: Caller creds.
+	creds, err := credentials.GetFromContext(ctx)
+	if err != nil {
+		common.NewErrResponse(http.StatusUnauthorized, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	details, err := controller.payouts.BankDetails(ctx, creds.UserID)
+	if err != nil {
+		controller.log.Error("failed to get bank details", ErrFundraises.Wrap(err))
+		controller.servePayoutError(w, err, "failed to get bank details")
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(ToBankDetailsView(details)); err != nil {
+		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+}
+
+// RequestPayout is an endpoint for requesting payout of the fundraise funds.
+// @Summary	Requests payout of the fundraise funds to organizer's bank account, payout waits for administrator's approval
+// @Tags	Payouts
+// @Accept	json
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Param	request	body	PayoutRequest	true	"Payout amount"
+// @Success	200	{object}	PayoutView
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/fundraises/{id}/payouts	[post].
+func (controller *Fundraises) RequestPayout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// INFO: Caller creds.
+	creds, err := credentials.GetFromContext(ctx)
+	if err != nil {
+		common.NewErrResponse(http.StatusUnauthorized, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	fundraiseID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse id")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	var request PayoutRequest
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+		controller.log.Error("failed to decode payout request body", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusBadRequest, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	payout, err := controller.payouts.Request(ctx, payouts.RequestParams{
+		FundraiseID: fundraiseID,
+		CallerID:    creds.UserID,
+		Amount:      request.Amount,
+	})
+	if err != nil {
+		controller.log.Error("failed to request payout", ErrFundraises.Wrap(err))
+		controller.servePayoutError(w, err, "failed to request payout")
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(ToPayoutView(payout)); err != nil {
+		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+}
+
+// ListPayouts is an endpoint for listing payouts of the fundraise.
+// @Summary	Returns balance and payouts of the fundraise, allowed to the organizer and administrators
+// @Tags	Payouts
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Success	200	{object}	PayoutsView
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/fundraises/{id}/payouts	[get].
+func (controller *Fundraises) ListPayouts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// INFO: Caller creds.
+	creds, err := credentials.GetFromContext(ctx)
+	if err != nil {
+		common.NewErrResponse(http.StatusUnauthorized, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	fundraiseID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse id")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	balance, list, err := controller.payouts.List(ctx, fundraiseID, creds.UserID)
+	if err != nil {
+		controller.log.Error("failed to list payouts", ErrFundraises.Wrap(err))
+		controller.servePayoutError(w, err, "failed to list payouts")
+		return
+	}
+
+	view := PayoutsView{
+		Balance: ToBalanceView(balance),
+		Payouts: ToPayoutViews(list),
+	}
+	if err = json.NewEncoder(w).Encode(view); err != nil {
+		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+}
+
+// PayoutQueue is an endpoint for listing payouts awaiting approval.
+// @Summary	Returns payouts awaiting administrator's approval, the oldest first
+// @Tags	Moderation
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Success	200	{object}	[]PayoutView
+// @Failure	401,403,500	{object}	common.ErrResponseCode
+// @Router	/moderation/payouts	[get].
+func (controller *Fundraises) PayoutQueue(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// INFO: Caller creds.
+	creds, err := credentials.GetFromContext(ctx)
+	if err != nil {
+		common.NewErrResponse(http.StatusUnauthorized, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	list, err := controller.payouts.Queue(ctx, creds.UserID)
+	if err != nil {
+		controller.log.Error("failed to list payout queue", ErrFundraises.Wrap(err))
+		controller.servePayoutError(w, err, "failed to list payout queue")
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(ToPayoutViews(list)); err != nil {
+		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+}
+
+// ApprovePayout is an endpoint for approving payout pending approval.
+// @Summary	Approves payout and sends it to organizer's bank account
+// @Tags	Moderation
+// @Accept	json
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Param	request	body	ReviewRequest	false	"Optional approval note"
+// @Success	200	{object}	PayoutView
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/moderation/payouts/{id}/approve	[post].
+func (controller *Fundraises) ApprovePayout(w http.ResponseWriter, r *http.Request) {
+	controller.reviewPayout(w, r, controller.payouts.Approve)
+}
+
+// RejectPayout is an endpoint for rejecting payout pending approval.
+// @Summary	Rejects payout with the reason, its amount is released
+// @Tags	Moderation
+// @Accept	json
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Param	request	body	ReviewRequest	true	"Rejection reason"
+// @Success	200	{object}	PayoutView
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/moderation/payouts/{id}/reject	[post].
+func (controller *Fundraises) RejectPayout(w http.ResponseWriter, r *http.Request) {
+	controller.reviewPayout(w, r, controller.payouts.Reject)
+}
+
+// reviewPayout handles administrator's decision on the payout.
+func (controller *Fundraises) reviewPayout(w http.ResponseWriter, r *http.Request, decide func(ctx context.Context, params payouts.ReviewParams) (payouts.Payout, error)) {
+	ctx := r.Context()
+
+	// INFO: Caller creds.
+	creds, err := credentials.GetFromContext(ctx)
+	if err != nil {
+		common.NewErrResponse(http.StatusUnauthorized, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	payoutID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse payout id")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	var request ReviewRequest
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+		controller.log.Error("failed to decode review request body", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusBadRequest, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	payout, err := decide(ctx, payouts.ReviewParams{
+		PayoutID: payoutID,
+		CallerID: creds.UserID,
+		Reason:   request.Reason,
+	})
+	if err != nil {
+		controller.log.Error("failed to review payout", ErrFundraises.Wrap(err))
+		controller.servePayoutError(w, err, "failed to review payout")
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(ToPayoutView(payout)); err != nil {
+		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+}
+
+// servePayoutError maps payout service errors to response codes.
+func (controller *Fundraises) servePayoutError(w http.ResponseWriter, err error, failure string) {
+	switch {
+	case errors.Is(err, fundraises.ErrNoFundraise):
+		common.NewErrResponse(http.StatusNotFound, fundraises.ErrNoFundraise).Serve(controller.log, ErrFundraises, w)
+	case errors.Is(err, payouts.ErrNoPayout):
+		common.NewErrResponse(http.StatusNotFound, payouts.ErrNoPayout).Serve(controller.log, ErrFundraises, w)
+	case errors.Is(err, payouts.ErrNoBankDetails) && !payouts.ParamsError.Has(err):
+		common.NewErrResponse(http.StatusNotFound, payouts.ErrNoBankDetails).Serve(controller.log, ErrFundraises, w)
+	case errors.Is(err, fundraises.ErrNotOrganizer):
+		common.NewErrResponse(http.StatusForbidden, fundraises.ErrNotOrganizer).Serve(controller.log, ErrFundraises, w)
+	case errors.Is(err, fundraises.ErrNotAdmin):
+		common.NewErrResponse(http.StatusForbidden, fundraises.ErrNotAdmin).Serve(controller.log, ErrFundraises, w)
+	case payouts.ParamsError.Has(err):
+		common.NewErrResponse(http.StatusBadRequest, errors.Unwrap(err)).Serve(controller.log, ErrFundraises, w)
+	default:
+		common.NewErrResponse(http.StatusInternalServerError, errors.New(failure)).Serve(controller.log, ErrFundraises, w)
+	}
+}
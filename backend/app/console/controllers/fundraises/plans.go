@@ -0,0 +1,251 @@
+package fundraises
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/zeebo/errs"
+
+	"one-help/app/console/controllers/common"
+	"one-help/app/donations/plans"
+	"one-help/app/fundraises"
+	"one-help/app/users/credentials"
+)
+
+// CreatePlan is an endpoint for subscribing to recurring donations.
+// @Summary	Creates recurring donation plan for the fundraise or all fundraises of the organizer, provides subscription checkout url
+// @Tags	Plans
+// @Accept	json
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Param	request	body	CreatePlanRequest	true	"Plan values"
+// @Success	200	{object}	CreatePlanResponse
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/plans	[post].
+func (controller *Fundraises) CreatePlan(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// INFO: Caller creds.
+	creds, err := credentials.GetFromContext(ctx)
+	if err != nil {
+		common.NewErrResponse(http.StatusUnauthorized, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	var request CreatePlanRequest
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil {
+		controller.log.Error("failed to decode create plan request body", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusBadRequest, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	result, err := controller.plans.Create(ctx, plans.CreateParams{
+		UserID:      creds.UserID,
+		FundraiseID: request.FundraiseID,
+		OrganizerID: request.OrganizerID,
+		Amount:      request.Amount,
+		Currency:    request.Currency,
+		Interval:    request.Interval,
+		PaymentType: request.PaymentType,
+	})
+	if err != nil {
+		controller.log.Error("failed to create plan", ErrFundraises.Wrap(err))
+		controller.servePlanError(w, err, "failed to create plan")
+		return
+	}
+
+	response := CreatePlanResponse{Plan: ToPlanView(result.Plan), PaymentURL: result.PaymentURL}
+	if err = json.NewEncoder(w).Encode(response); err != nil {
+		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+}
+
+// ListPlans is an endpoint for listing caller's recurring donation plans.
+// @Summary	Returns recurring donation plans of the caller, the newest first
+// @Tags	Plans
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Success	200	{object}	[]PlanView
+// @Failure	401,500	{object}	common.ErrResponseCode
+// @Router	/plans	[get].
+func (controller *Fundraises) ListPlans(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// INFO: Caller creds.
+	creds, err := credentials.GetFromContext(ctx)
+	if err != nil {
+		common.NewErrResponse(http.StatusUnauthorized, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	list, err := controller.plans.List(ctx, creds.UserID)
+	if err != nil {
+		controller.log.Error("failed to list plans", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, errors.New("failed to list plans")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	views := make([]PlanView, len(list))
+	for i, plan := range list {
+		views[i] = ToPlanView(plan)
+	}
+
+	if err = json.NewEncoder(w).Encode(views); err != nil {
+		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+}
+
+// GetPlan is an endpoint for getting caller's recurring donation plan.
+// @Summary	Returns recurring donation plan of the caller
+// @Tags	Plans
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Success	200	{object}	PlanView
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/plans/{id}	[get].
+func (controller *Fundraises) GetPlan(w http.ResponseWriter, r *http.Request) {
+	controller.servePlan(w, r, "failed to get plan", controller.plans.Get)
+}
+
+// ChangePlan is an endpoint for changing amount of caller's recurring donation plan.
+// @Summary	Changes amount of the next charges of the plan
+// @Tags	Plans
+// @Accept	json
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Param	request	body	ChangePlanRequest	true	"New plan values"
+// @Success	200	{object}	PlanView
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/plans/{id}	[patch].
+func (controller *Fundraises) ChangePlan(w http.ResponseWriter, r *http.Request) {
+	var request ChangePlanRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		controller.log.Error("failed to decode change plan request body", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusBadRequest, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	controller.servePlan(w, r, "failed to change plan", func(ctx context.Context, planID, callerID uuid.UUID) (plans.Plan, error) {
+		return controller.plans.Change(ctx, plans.ChangeParams{
+			PlanID: planID,
+			UserID: callerID,
+			Amount: request.Amount,
+		})
+	})
+}
+
+// PausePlan is an endpoint for pausing caller's recurring donation plan.
+// @Summary	Stops charges of the active plan until it is resumed
+// @Tags	Plans
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Success	200	{object}	PlanView
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/plans/{id}/pause	[post].
+func (controller *Fundraises) PausePlan(w http.ResponseWriter, r *http.Request) {
+	controller.servePlan(w, r, "failed to pause plan", controller.plans.Pause)
+}
+
+// ResumePlan is an endpoint for resuming caller's paused recurring donation plan.
+// @Summary	Resumes charges of the paused plan
+// @Tags	Plans
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Success	200	{object}	PlanView
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/plans/{id}/resume	[post].
+func (controller *Fundraises) ResumePlan(w http.ResponseWriter, r *http.Request) {
+	controller.servePlan(w, r, "failed to resume plan", controller.plans.Resume)
+}
+
+// CancelPlan is an endpoint for canceling caller's recurring donation plan.
+// @Summary	Stops charges of the plan permanently
+// @Tags	Plans
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Success	200	{object}	PlanView
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/plans/{id}	[delete].
+func (controller *Fundraises) CancelPlan(w http.ResponseWriter, r *http.Request) {
+	controller.servePlan(w, r, "failed to cancel plan", controller.plans.Cancel)
+}
+
+// FinishPlanCheckout is an endpoint subscription checkout callback.
+// Plan is activated by the payment provider's webhook, so this endpoint only redirects donor back to the frontend.
+// @Summary	Redirects donor back after subscription checkout (for internal use).
+// @Tags	Plans
+// @Success	301
+// @Failure	400	{object}	common.ErrResponseCode
+// @Router	/plans/checkout/{id}	[get].
+func (controller *Fundraises) FinishPlanCheckout(w http.ResponseWriter, r *http.Request) {
+	planID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse plan id")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	query := url.Values{"plan": {planID.String()}}
+	if r.URL.Query().Get("canceled") == "true" {
+		query.Set("canceled", "true")
+	}
+
+	http.Redirect(w, r, controller.frontEndRedirectUrl+"?"+query.Encode(), http.StatusMovedPermanently)
+}
+
+// servePlan applies plan action of the caller and responds with the resulting plan.
+func (controller *Fundraises) servePlan(w http.ResponseWriter, r *http.Request, failure string, action func(ctx context.Context, planID, callerID uuid.UUID) (plans.Plan, error)) {
+	ctx := r.Context()
+
+	// INFO: Caller creds.
+	creds, err := credentials.GetFromContext(ctx)
+	if err != nil {
+		common.NewErrResponse(http.StatusUnauthorized, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	planID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse plan id")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	plan, err := action(ctx, planID, creds.UserID)
+	if err != nil {
+		controller.log.Error(failure, ErrFundraises.Wrap(err))
+		controller.servePlanError(w, err, failure)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(ToPlanView(plan)); err != nil {
+		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+}
+
+// servePlanError maps plan service errors to response codes.
+func (controller *Fundraises) servePlanError(w http.ResponseWriter, err error, failure string) {
+	switch {
+	case errors.Is(err, plans.ErrNoPlan):
+		common.NewErrResponse(http.StatusNotFound, plans.ErrNoPlan).Serve(controller.log, ErrFundraises, w)
+	case errors.Is(err, fundraises.ErrNoFundraise):
+		common.NewErrResponse(http.StatusNotFound, fundraises.ErrNoFundraise).Serve(controller.log, ErrFundraises, w)
+	case errors.Is(err, plans.ErrNotPlanOwner):
+		common.NewErrResponse(http.StatusForbidden, plans.ErrNotPlanOwner).Serve(controller.log, ErrFundraises, w)
+	case errors.Is(err, fundraises.ErrNotApproved):
+		common.NewErrResponse(http.StatusForbidden, fundraises.ErrNotApproved).Serve(controller.log, ErrFundraises, w)
+	case plans.ParamsError.Has(err):
+		common.NewErrResponse(http.StatusBadRequest, errors.Unwrap(err)).Serve(controller.log, ErrFundraises, w)
+	default:
+		common.NewErrResponse(http.StatusInternalServerError, errors.New(failure)).Serve(controller.log, ErrFundraises, w)
+	}
+}
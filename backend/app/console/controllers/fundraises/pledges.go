@@ -0,0 +1,115 @@
+package fundraises
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/zeebo/errs"
+
+	"one-help/app/console/controllers/common"
+	"one-help/app/fundraises"
+	"one-help/app/users/credentials"
+)
+
+// CreatePledge is an endpoint for attaching sponsor's matching pledge to the fundraise.
+// @Summary	Attaches pledge to match donations with the ratio, up to the cap, within the time window
+// @Tags	Fundraises
+// @Accept	json
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Param	request	body	PledgeRequest	true	"Pledge data fields"
+// @Success	200	{object}	PledgeView
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/fundraises/{id}/pledges	[post].
+func (controller *Fundraises) CreatePledge(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// INFO: Caller creds.
+	creds, err := credentials.GetFromContext(ctx)
+	if err != nil {
+		common.NewErrResponse(http.StatusUnauthorized, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	fundraiseID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse id")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	var request PledgeRequest
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil {
+		controller.log.Error("failed to decode pledge request body", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusBadRequest, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	pledge, err := controller.fundraises.CreatePledge(ctx, fundraises.PledgeParams{
+		FundraiseID: fundraiseID,
+		CallerID:    creds.UserID,
+		SponsorName: request.SponsorName,
+		Ratio:       request.Ratio,
+		Cap:         request.Cap,
+		StartsAt:    request.StartsAt,
+		EndsAt:      request.EndsAt,
+	})
+	if err != nil {
+		controller.log.Error("failed to create matching pledge", ErrFundraises.Wrap(err))
+		switch {
+		case errors.Is(err, fundraises.ErrNoFundraise):
+			common.NewErrResponse(http.StatusNotFound, fundraises.ErrNoFundraise).Serve(controller.log, ErrFundraises, w)
+		case errors.Is(err, fundraises.ErrNotOrganizer):
+			common.NewErrResponse(http.StatusForbidden, fundraises.ErrNotOrganizer).Serve(controller.log, ErrFundraises, w)
+		case fundraises.ParamsError.Has(err):
+			common.NewErrResponse(http.StatusBadRequest, errors.Unwrap(err)).Serve(controller.log, ErrFundraises, w)
+		default:
+			common.NewErrResponse(http.StatusInternalServerError, errors.New("failed to create matching pledge")).Serve(controller.log, ErrFundraises, w)
+		}
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(ToPledgeView(pledge)); err != nil {
+		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+}
+
+// ListPledges is an endpoint for listing matching pledges of the fundraise.
+// @Summary	Returns matching pledges of the fundraise with their remaining budget
+// @Tags	Fundraises
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Success	200	{object}	[]PledgeView
+// @Failure	400,401,500	{object}	common.ErrResponseCode
+// @Router	/fundraises/{id}/pledges	[get].
+func (controller *Fundraises) ListPledges(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	fundraiseID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse id")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	pledges, err := controller.fundraises.Pledges(ctx, fundraiseID)
+	if err != nil {
+		controller.log.Error("failed to list matching pledges", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, errors.New("failed to list matching pledges")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	views := make([]PledgeView, len(pledges))
+	for i, pledge := range pledges {
+		views[i] = ToPledgeView(pledge)
+	}
+
+	if err = json.NewEncoder(w).Encode(views); err != nil {
+		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+}
@@ -0,0 +1,68 @@
+package fundraises
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/zeebo/errs"
+
+	"one-help/app/console/controllers/common"
+	"one-help/app/donations"
+	"one-help/app/fundraises"
+	"one-help/app/payments"
+	"one-help/app/users/credentials"
+)
+
+// Receipt is an endpoint for downloading receipt of the donation.
+// @Summary	Returns numbered PDF receipt of the confirmed donation, allowed to the donor and the fundraise organizer
+// @Tags	Donations
+// @Produce	application/pdf
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Success	200	{file}	file
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/fundraises/donations/{id}/receipt	[get].
+func (controller *Fundraises) Receipt(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// INFO: Caller creds.
+	creds, err := credentials.GetFromContext(ctx)
+	if err != nil {
+		common.NewErrResponse(http.StatusUnauthorized, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	donationID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse donation id")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	receipt, document, err := controller.fundraises.Receipt(ctx, donationID, creds.UserID)
+	if err != nil {
+		controller.log.Error("failed to get donation receipt", ErrFundraises.Wrap(err))
+		switch {
+		case errors.Is(err, donations.ErrNoDonation):
+			common.NewErrResponse(http.StatusNotFound, donations.ErrNoDonation).Serve(controller.log, ErrFundraises, w)
+		case errors.Is(err, payments.ErrNoPayment):
+			common.NewErrResponse(http.StatusNotFound, payments.ErrNoPayment).Serve(controller.log, ErrFundraises, w)
+		case errors.Is(err, fundraises.ErrNotDonor):
+			common.NewErrResponse(http.StatusForbidden, fundraises.ErrNotDonor).Serve(controller.log, ErrFundraises, w)
+		case fundraises.ParamsError.Has(err):
+			common.NewErrResponse(http.StatusBadRequest, errors.Unwrap(err)).Serve(controller.log, ErrFundraises, w)
+		default:
+			common.NewErrResponse(http.StatusInternalServerError, errors.New("failed to get donation receipt")).Serve(controller.log, ErrFundraises, w)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "receipt-"+receipt.Code()+".pdf"))
+	w.Header().Set("Content-Length", strconv.Itoa(len(document)))
+	if _, err = w.Write(document); err != nil {
+		controller.log.Error("error while writing receipt", ErrFundraises.Wrap(err))
+	}
+}
@@ -0,0 +1,60 @@
+package fundraises
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"one-help/app/console/controllers/common"
+	"one-help/app/fundraises"
+	"one-help/app/users/credentials"
+)
+
+// Reconciliation is an endpoint for getting daily payment reconciliation report.
+// @Summary	Returns totals of confirmed donations compared with amounts collected by payment providers for the day
+// @Tags	Moderation
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Param	date	query	string	false	"UTC day in YYYY-MM-DD format, yesterday when omitted"
+// @Success	200	{object}	[]ReconciliationView
+// @Failure	400,401,403,500	{object}	common.ErrResponseCode
+// @Router	/moderation/reconciliation	[get].
+func (controller *Fundraises) Reconciliation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// INFO: Caller creds.
+	creds, err := credentials.GetFromContext(ctx)
+	if err != nil {
+		common.NewErrResponse(http.StatusUnauthorized, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	date := time.Now().UTC().AddDate(0, 0, -1)
+	if val := r.URL.Query().Get("date"); val != "" {
+		if date, err = time.Parse(time.DateOnly, val); err != nil {
+			common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse date")).Serve(controller.log, ErrFundraises, w)
+			return
+		}
+	}
+
+	reports, err := controller.fundraises.ReconciliationReports(ctx, date, creds.UserID)
+	if err != nil {
+		controller.log.Error("failed to get reconciliation reports", ErrFundraises.Wrap(err))
+		switch {
+		case errors.Is(err, fundraises.ErrNotAdmin):
+			common.NewErrResponse(http.StatusForbidden, fundraises.ErrNotAdmin).Serve(controller.log, ErrFundraises, w)
+		default:
+			common.NewErrResponse(http.StatusInternalServerError, errors.New("failed to get reconciliation reports")).Serve(controller.log, ErrFundraises, w)
+		}
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(ToReconciliationViews(reports)); err != nil {
+		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+}
@@ -0,0 +1,232 @@
+package fundraises
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/zeebo/errs"
+
+	"one-help/app/console/controllers/common"
+	"one-help/app/donations"
+	"one-help/app/fundraises"
+	"one-help/app/payments"
+	"one-help/app/payments/refunds"
+	"one-help/app/users/credentials"
+)
+
+// Refund is an endpoint for refunding the donation.
+// @Summary	Refunds whole or part of the confirmed donation, refund after funds were transferred or spent waits for administrator's approval
+// @Tags	Refunds
+// @Accept	json
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Param	request	body	RefundRequest	true	"Refund amount and reason"
+// @Success	200	{object}	RefundView
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/fundraises/donations/{id}/refunds	[post].
+func (controller *Fundraises) Refund(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// INFO: Caller creds.
+	creds, err := credentials.GetFromContext(ctx)
+	if err != nil {
+		common.NewErrResponse(http.StatusUnauthorized, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	donationID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse donation id")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	var request RefundRequest
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil {
+		controller.log.Error("failed to decode refund request body", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusBadRequest, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	refund, err := controller.refunds.Create(ctx, refunds.CreateParams{
+		DonationID: donationID,
+		CallerID:   creds.UserID,
+		Amount:     request.Amount,
+		Reason:     request.Reason,
+	})
+	if err != nil {
+		controller.log.Error("failed to refund donation", ErrFundraises.Wrap(err))
+		controller.serveRefundError(w, err, "failed to refund donation")
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(ToRefundView(refund)); err != nil {
+		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+}
+
+// ListRefunds is an endpoint for listing refunds of the donation.
+// @Summary	Returns refunds of the donation with their reasons, allowed to the donor, the fundraise organizer and administrators
+// @Tags	Refunds
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Success	200	{object}	[]RefundView
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/fundraises/donations/{id}/refunds	[get].
+func (controller *Fundraises) ListRefunds(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// INFO: Caller creds.
+	creds, err := credentials.GetFromContext(ctx)
+	if err != nil {
+		common.NewErrResponse(http.StatusUnauthorized, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	donationID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse donation id")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	list, err := controller.refunds.ListByDonation(ctx, donationID, creds.UserID)
+	if err != nil {
+		controller.log.Error("failed to list refunds", ErrFundraises.Wrap(err))
+		controller.serveRefundError(w, err, "failed to list refunds")
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(ToRefundViews(list)); err != nil {
+		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+}
+
+// RefundQueue is an endpoint for listing refunds awaiting approval.
+// @Summary	Returns refunds awaiting administrator's approval, the oldest first
+// @Tags	Moderation
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Success	200	{object}	[]RefundView
+// @Failure	401,403,500	{object}	common.ErrResponseCode
+// @Router	/moderation/refunds	[get].
+func (controller *Fundraises) RefundQueue(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// INFO: Caller creds.
+	creds, err := credentials.GetFromContext(ctx)
+	if err != nil {
+		common.NewErrResponse(http.StatusUnauthorized, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	list, err := controller.refunds.Queue(ctx, creds.UserID)
+	if err != nil {
+		controller.log.Error("failed to list refund queue", ErrFundraises.Wrap(err))
+		controller.serveRefundError(w, err, "failed to list refund queue")
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(ToRefundViews(list)); err != nil {
+		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+}
+
+// ApproveRefund is an endpoint for approving refund pending approval.
+// @Summary	Approves refund and sends it to the payment provider
+// @Tags	Moderation
+// @Accept	json
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Param	request	body	ReviewRequest	false	"Optional approval note"
+// @Success	200	{object}	RefundView
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/moderation/refunds/{id}/approve	[post].
+func (controller *Fundraises) ApproveRefund(w http.ResponseWriter, r *http.Request) {
+	controller.reviewRefund(w, r, controller.refunds.Approve)
+}
+
+// RejectRefund is an endpoint for rejecting refund pending approval.
+// @Summary	Rejects refund with the reason
+// @Tags	Moderation
+// @Accept	json
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Param	request	body	ReviewRequest	true	"Rejection reason"
+// @Success	200	{object}	RefundView
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/moderation/refunds/{id}/reject	[post].
+func (controller *Fundraises) RejectRefund(w http.ResponseWriter, r *http.Request) {
+	controller.reviewRefund(w, r, controller.refunds.Reject)
+}
+
+// reviewRefund handles administrator's decision on the refund.
+func (controller *Fundraises) reviewRefund(w http.ResponseWriter, r *http.Request, decide func(ctx context.Context, params refunds.ReviewParams) (refunds.Refund, error)) {
+	ctx := r.Context()
+
+	// INFO: Caller creds.
+	creds, err := credentials.GetFromContext(ctx)
+	if err != nil {
+		common.NewErrResponse(http.StatusUnauthorized, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	refundID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse refund id")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	var request ReviewRequest
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+		controller.log.Error("failed to decode review request body", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusBadRequest, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	refund, err := decide(ctx, refunds.ReviewParams{
+		RefundID: refundID,
+		CallerID: creds.UserID,
+		Reason:   request.Reason,
+	})
+	if err != nil {
+		controller.log.Error("failed to review refund", ErrFundraises.Wrap(err))
+		controller.serveRefundError(w, err, "failed to review refund")
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(ToRefundView(refund)); err != nil {
+		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+}
+
+// serveRefundError maps refund service errors to response codes.
+func (controller *Fundraises) serveRefundError(w http.ResponseWriter, err error, failure string) {
+	switch {
+	case errors.Is(err, donations.ErrNoDonation):
+		common.NewErrResponse(http.StatusNotFound, donations.ErrNoDonation).Serve(controller.log, ErrFundraises, w)
+	case errors.Is(err, payments.ErrNoPayment):
+		common.NewErrResponse(http.StatusNotFound, payments.ErrNoPayment).Serve(controller.log, ErrFundraises, w)
+	case errors.Is(err, refunds.ErrNoRefund):
+		common.NewErrResponse(http.StatusNotFound, refunds.ErrNoRefund).Serve(controller.log, ErrFundraises, w)
+	case errors.Is(err, fundraises.ErrNotOrganizer):
+		common.NewErrResponse(http.StatusForbidden, fundraises.ErrNotOrganizer).Serve(controller.log, ErrFundraises, w)
+	case errors.Is(err, fundraises.ErrNotAdmin):
+		common.NewErrResponse(http.StatusForbidden, fundraises.ErrNotAdmin).Serve(controller.log, ErrFundraises, w)
+	case refunds.ParamsError.Has(err):
+		common.NewErrResponse(http.StatusBadRequest, errors.Unwrap(err)).Serve(controller.log, ErrFundraises, w)
+	default:
+		common.NewErrResponse(http.StatusInternalServerError, errors.New(failure)).Serve(controller.log, ErrFundraises, w)
+	}
+}
@@ -0,0 +1,199 @@
+package fundraises
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/zeebo/errs"
+
+	"one-help/app/console/controllers/common"
+	"one-help/app/fundraises"
+	"one-help/app/payments/statements"
+	"one-help/app/users/credentials"
+)
+
+// ImportStatement is an endpoint for importing bank statement of the fundraise account.
+// @Summary	Imports CSV or CAMT.053 bank statement, matching incoming transfers to offline donations
+// @Tags	Fundraises
+// @Accept	plain
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Param	format	query	string	true	"Statement format: csv or camt.053"
+// @Param	request	body	string	true	"Statement file content"
+// @Success	200	{object}	StatementSummaryView
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/fundraises/{id}/statements	[post].
+func (controller *Fundraises) ImportStatement(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// INFO: Caller creds.
+	creds, err := credentials.GetFromContext(ctx)
+	if err != nil {
+		common.NewErrResponse(http.StatusUnauthorized, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	fundraiseID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse id")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	summary, err := controller.statements.Import(ctx, statements.ImportParams{
+		FundraiseID: fundraiseID,
+		CallerID:    creds.UserID,
+		Format:      r.URL.Query().Get("format"),
+		Statement:   http.MaxBytesReader(w, r.Body, statements.MaxSize),
+	})
+	if err != nil {
+		controller.log.Error("failed to import bank statement", ErrFundraises.Wrap(err))
+		controller.serveStatementError(w, err, "failed to import bank statement")
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(ToStatementSummaryView(summary)); err != nil {
+		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+}
+
+// ListStatementLines is an endpoint for listing incoming transfers of imported bank statements.
+// @Summary	Returns incoming transfers of the fundraise statements, optionally filtered by status
+// @Tags	Fundraises
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Param	status	query	string	false	"MATCHED, UNMATCHED, CONFIRMED or IGNORED"
+// @Success	200	{object}	[]StatementLineView
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/fundraises/{id}/statements/lines	[get].
+func (controller *Fundraises) ListStatementLines(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// INFO: Caller creds.
+	creds, err := credentials.GetFromContext(ctx)
+	if err != nil {
+		common.NewErrResponse(http.StatusUnauthorized, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	fundraiseID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse id")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	lines, err := controller.statements.Lines(ctx, fundraiseID, creds.UserID, r.URL.Query().Get("status"))
+	if err != nil {
+		controller.log.Error("failed to list statement lines", ErrFundraises.Wrap(err))
+		controller.serveStatementError(w, err, "failed to list statement lines")
+		return
+	}
+
+	views := make([]StatementLineView, len(lines))
+	for i, line := range lines {
+		views[i] = ToStatementLineView(line)
+	}
+
+	if err = json.NewEncoder(w).Encode(views); err != nil {
+		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+}
+
+// ConfirmStatementLine is an endpoint for recording unmatched incoming transfer as a donation.
+// @Summary	Records unmatched incoming transfer as the offline bank transfer donation
+// @Tags	Fundraises
+// @Accept	json
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Param	request	body	StatementLineReviewRequest	false	"Optional donor and message"
+// @Success	200	{object}	StatementLineView
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/fundraises/statements/lines/{id}/confirm	[post].
+func (controller *Fundraises) ConfirmStatementLine(w http.ResponseWriter, r *http.Request) {
+	controller.reviewStatementLine(w, r, controller.statements.ConfirmLine, "failed to confirm statement line")
+}
+
+// IgnoreStatementLine is an endpoint for marking unmatched incoming transfer as not a donation.
+// @Summary	Marks unmatched incoming transfer as not a donation
+// @Tags	Fundraises
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Success	200	{object}	StatementLineView
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/fundraises/statements/lines/{id}/ignore	[post].
+func (controller *Fundraises) IgnoreStatementLine(w http.ResponseWriter, r *http.Request) {
+	controller.reviewStatementLine(w, r, controller.statements.IgnoreLine, "failed to ignore statement line")
+}
+
+// reviewStatementLine applies organizer's review decision to the statement line.
+func (controller *Fundraises) reviewStatementLine(
+	w http.ResponseWriter,
+	r *http.Request,
+	review func(ctx context.Context, params statements.LineParams) (statements.Line, error),
+	failure string,
+) {
+	ctx := r.Context()
+
+	// INFO: Caller creds.
+	creds, err := credentials.GetFromContext(ctx)
+	if err != nil {
+		common.NewErrResponse(http.StatusUnauthorized, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	lineID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse id")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	// INFO: Request body is optional.
+	var request StatementLineReviewRequest
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+		controller.log.Error("failed to decode statement line review request body", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusBadRequest, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	line, err := review(ctx, statements.LineParams{
+		LineID:   lineID,
+		CallerID: creds.UserID,
+		DonorID:  request.DonorID,
+		Message:  request.Message,
+	})
+	if err != nil {
+		controller.log.Error(failure, ErrFundraises.Wrap(err))
+		controller.serveStatementError(w, err, failure)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(ToStatementLineView(line)); err != nil {
+		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+}
+
+// serveStatementError maps bank statement service errors to response codes.
+func (controller *Fundraises) serveStatementError(w http.ResponseWriter, err error, failure string) {
+	switch {
+	case errors.Is(err, fundraises.ErrNoFundraise):
+		common.NewErrResponse(http.StatusNotFound, fundraises.ErrNoFundraise).Serve(controller.log, ErrFundraises, w)
+	case errors.Is(err, statements.ErrNoLine):
+		common.NewErrResponse(http.StatusNotFound, statements.ErrNoLine).Serve(controller.log, ErrFundraises, w)
+	case errors.Is(err, fundraises.ErrNotOrganizer):
+		common.NewErrResponse(http.StatusForbidden, fundraises.ErrNotOrganizer).Serve(controller.log, ErrFundraises, w)
+	case statements.ParamsError.Has(err):
+		common.NewErrResponse(http.StatusBadRequest, errors.Unwrap(err)).Serve(controller.log, ErrFundraises, w)
+	default:
+		common.NewErrResponse(http.StatusInternalServerError, errors.New(failure)).Serve(controller.log, ErrFundraises, w)
+	}
+}
@@ -0,0 +1,61 @@
+package fundraises
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/zeebo/errs"
+
+	"one-help/app/console/controllers/common"
+	"one-help/app/fundraises"
+)
+
+// RecentSupporters is a public endpoint for listing the latest donations of the fundraise.
+// @Summary	Returns the latest confirmed donations with messages, names of anonymous donors are hidden
+// @Tags	Fundraises
+// @Produce	json
+// @Param	limit	query	integer	false	"Number of supporters (positive number expected) [default value: 20, max value: 50]"
+// @Success	200	{object}	[]SupporterView
+// @Failure	400,404,500	{object}	common.ErrResponseCode
+// @Router	/fundraises/{id}/supporters	[get].
+func (controller *Fundraises) RecentSupporters(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	fundraiseID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse id")).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+
+	limit := 20
+	if val := r.URL.Query().Get("limit"); val != "" {
+		limit, err = strconv.Atoi(val)
+		if err != nil {
+			controller.log.Error("failed to parse 'limit' query parameter", ErrFundraises.Wrap(err))
+			common.NewErrResponse(http.StatusBadRequest, errors.New("invalid limit value")).Serve(controller.log, ErrFundraises, w)
+			return
+		}
+	}
+
+	supporters, err := controller.fundraises.RecentSupporters(ctx, fundraiseID, limit)
+	if err != nil {
+		controller.log.Error("failed to list recent supporters", ErrFundraises.Wrap(err))
+		switch {
+		case errors.Is(err, fundraises.ErrNoFundraise):
+			common.NewErrResponse(http.StatusNotFound, fundraises.ErrNoFundraise).Serve(controller.log, ErrFundraises, w)
+		default:
+			common.NewErrResponse(http.StatusInternalServerError, errors.New("failed to list recent supporters")).Serve(controller.log, ErrFundraises, w)
+		}
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(ToSupporterViews(supporters)); err != nil {
+		controller.log.Error("error while encoding response", ErrFundraises.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, err).Serve(controller.log, ErrFundraises, w)
+		return
+	}
+}
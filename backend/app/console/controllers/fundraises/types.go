@@ -5,34 +5,57 @@ import (
 
 	"github.com/google/uuid"
 
+	"one-help/app/console/controllers/common"
+	"one-help/app/currencies"
+	"one-help/app/donations"
+	"one-help/app/donations/offline"
+	"one-help/app/donations/plans"
 	"one-help/app/fundraises"
+	"one-help/app/fundraises/analytics"
+	"one-help/app/fundraises/leaderboards"
+	"one-help/app/fundraises/matching"
+	"one-help/app/fundraises/payouts"
+	"one-help/app/fundraises/reviews"
+	"one-help/app/fundraises/transfers"
+	"one-help/app/payments"
+	"one-help/app/payments/reconciliation"
+	"one-help/app/payments/refunds"
+	"one-help/app/payments/statements"
 )
 
 // CreateRequest defines request values for create endpoint.
 type CreateRequest struct {
-	Title        string    `json:"title"`
-	Description  string    `json:"description"`
-	TargetAmount float64   `json:"targetAmount"`
-	EndDate      time.Time `json:"endDate"`
-	ImageUrl     string    `json:"imageUrl"`
+	Title        string              `json:"title"`
+	Description  string              `json:"description"`
+	TargetAmount currencies.Amount   `json:"targetAmount"`
+	Currency     string              `json:"currency"`
+	MinDonation  currencies.Amount   `json:"minDonation"`
+	Presets      []currencies.Amount `json:"presets"`
+	EndDate      time.Time           `json:"endDate"`
+	ImageUrl     string              `json:"imageUrl"`
 }
 
 // FundraiseView defines fundraise view type.
 type FundraiseView struct {
-	ID           uuid.UUID `json:"id"`
-	OrganizerId  uuid.UUID `json:"organizerId"`
-	Title        string    `json:"title"`
-	Description  string    `json:"description"`
-	TargetAmount float64   `json:"targetAmount"`
-	FilledAmount float64   `json:"filledAmount"`
-	StartDate    time.Time `json:"startDate"`
-	EndDate      time.Time `json:"endDate,omitempty"`
-	Status       string    `json:"status"`
-	ImageUrl     string    `json:"imageUrl"`
+	ID           uuid.UUID           `json:"id"`
+	OrganizerId  uuid.UUID           `json:"organizerId"`
+	Title        string              `json:"title"`
+	Description  string              `json:"description"`
+	TargetAmount currencies.Amount   `json:"targetAmount"`
+	FilledAmount currencies.Amount   `json:"filledAmount"`
+	Currency     string              `json:"currency"`
+	MinDonation  currencies.Amount   `json:"minDonation"`
+	Presets      []currencies.Amount `json:"presets"`
+	StartDate    time.Time           `json:"startDate"`
+	EndDate      time.Time           `json:"endDate,omitempty"`
+	Status       string              `json:"status"`
+	ImageUrl     string              `json:"imageUrl"`
+	// Matching is the budget of sponsors' matching pledges, set only for a single fundraise with pledges.
+	Matching *MatchingView `json:"matching,omitempty"`
 }
 
 // ToFundraiseView builds fundraise view.
-func ToFundraiseView(fundraise *fundraises.Fundraise, filled float64) *FundraiseView {
+func ToFundraiseView(fundraise *fundraises.Fundraise, filled currencies.Amount) *FundraiseView {
 	return &FundraiseView{
 		ID:           fundraise.ID,
 		OrganizerId:  fundraise.OrganizerId,
@@ -40,6 +63,9 @@ func ToFundraiseView(fundraise *fundraises.Fundraise, filled float64) *Fundraise
 		Description:  fundraise.Description,
 		TargetAmount: fundraise.TargetAmount,
 		FilledAmount: filled,
+		Currency:     fundraise.Currency,
+		MinDonation:  fundraise.MinDonation,
+		Presets:      fundraise.Presets,
 		StartDate:    fundraise.StartDate,
 		EndDate:      fundraise.EndDate,
 		Status:       fundraise.Status,
@@ -47,7 +73,787 @@ func ToFundraiseView(fundraise *fundraises.Fundraise, filled float64) *Fundraise
 	}
 }
 
+// DonateRequest defines optional request values for donate endpoint.
+type DonateRequest struct {
+	Currency    string            `json:"currency"`    // INFO: fundraise currency is used when omitted.
+	Amount      currencies.Amount `json:"amount"`      // INFO: default price is charged when omitted.
+	PaymentType string            `json:"paymentType"` // INFO: STRIPE or LIQPAY, STRIPE is used when omitted.
+	Anonymous   bool              `json:"anonymous"`   // INFO: hides donor's name from the public, organizer still sees it.
+	Message     string            `json:"message"`     // INFO: optional message of support.
+	CoverFee    bool              `json:"coverFee"`    // INFO: adds estimated processing fee to the amount, requires amount.
+	TeamCode    string            `json:"teamCode"`    // INFO: optional team or referral code, counted on team leaderboards.
+	// INFO: optional tribute, IN_MEMORY or IN_HONOUR of the honoree, dedication card is emailed to the recipient on confirmation.
+	TributeType           string `json:"tributeType"`
+	HonoreeName           string `json:"honoreeName"`
+	TributeRecipientName  string `json:"tributeRecipientName"`
+	TributeRecipientEmail string `json:"tributeRecipientEmail"`
+}
+
 // DonateResponse defines donate endpoint response object.
 type DonateResponse struct {
-	PaymentURL string `json:"paymentUrl"`
+	PaymentURL string            `json:"paymentUrl"`
+	Amount     currencies.Amount `json:"amount"` // INFO: charged amount, zero when default price is charged.
+	Fee        currencies.Amount `json:"fee"`    // INFO: estimated processing fee covered by donor.
+}
+
+// TransferRequest defines request values for transfer endpoint.
+type TransferRequest struct {
+	ToFundraiseID uuid.UUID         `json:"toFundraiseId"`
+	Amount        currencies.Amount `json:"amount"` // INFO: zero or omitted amount transfers whole available balance.
+	Reason        string            `json:"reason"`
+}
+
+// TransferView defines transfer view type.
+type TransferView struct {
+	ID              uuid.UUID         `json:"id"`
+	FromFundraiseID uuid.UUID         `json:"fromFundraiseId"`
+	ToFundraiseID   uuid.UUID         `json:"toFundraiseId"`
+	Amount          currencies.Amount `json:"amount"`
+	ExchangeRate    float64           `json:"exchangeRate"`
+	ConvertedAmount currencies.Amount `json:"convertedAmount"`
+	InitiatedBy     uuid.UUID         `json:"initiatedBy"`
+	Reason          string            `json:"reason"`
+	CreatedAt       time.Time         `json:"createdAt"`
+}
+
+// ToTransferView builds transfer view.
+func ToTransferView(transfer *transfers.Transfer) TransferView {
+	return TransferView{
+		ID:              transfer.ID,
+		FromFundraiseID: transfer.FromFundraiseID,
+		ToFundraiseID:   transfer.ToFundraiseID,
+		Amount:          transfer.Amount,
+		ExchangeRate:    transfer.ExchangeRate,
+		ConvertedAmount: transfer.ConvertedAmount(),
+		InitiatedBy:     transfer.InitiatedBy,
+		Reason:          transfer.Reason,
+		CreatedAt:       transfer.CreatedAt,
+	}
+}
+
+// ToTransferViews builds list of transfer views.
+func ToTransferViews(list []transfers.Transfer) []TransferView {
+	views := make([]TransferView, len(list))
+	for i := range list {
+		views[i] = ToTransferView(&list[i])
+	}
+
+	return views
+}
+
+// DonationView defines donation view type with original and converted to the fundraise currency amounts.
+type DonationView struct {
+	ID                uuid.UUID         `json:"id"`
+	UserID            uuid.UUID         `json:"userId"`
+	FundraiseID       uuid.UUID         `json:"fundraiseId"`
+	Amount            currencies.Amount `json:"amount"`
+	RequestedAmount   currencies.Amount `json:"requestedAmount"`
+	Currency          string            `json:"currency"`
+	ExchangeRate      float64           `json:"exchangeRate"`
+	ConvertedAmount   currencies.Amount `json:"convertedAmount"`
+	FundraiseCurrency string            `json:"fundraiseCurrency"`
+	RatedAt           time.Time         `json:"ratedAt,omitempty"`
+	CreatedAt         time.Time         `json:"createdAt"`
+	PlanID            uuid.UUID         `json:"planId"` // INFO: nil uuid for one-time donations.
+	Anonymous         bool              `json:"anonymous"`
+	Message           string            `json:"message"`
+	TributeType       string            `json:"tributeType"` // INFO: empty for donations without tribute.
+	HonoreeName       string            `json:"honoreeName"`
+}
+
+// ToDonationView builds donation view.
+func ToDonationView(donation *donations.Donation, fundraiseCurrency string) DonationView {
+	return DonationView{
+		ID:                donation.ID,
+		UserID:            donation.UserId,
+		FundraiseID:       donation.FundraiseId,
+		Amount:            donation.Amount,
+		RequestedAmount:   donation.RequestedAmount,
+		Currency:          donation.Currency,
+		ExchangeRate:      donation.ExchangeRate,
+		ConvertedAmount:   donation.ConvertedAmount(),
+		FundraiseCurrency: fundraiseCurrency,
+		RatedAt:           donation.RatedAt,
+		CreatedAt:         donation.CreatedAt,
+		PlanID:            donation.PlanID,
+		Anonymous:         donation.Anonymous,
+		Message:           donation.Message,
+		TributeType:       donation.Tribute.Type,
+		HonoreeName:       donation.Tribute.HonoreeName,
+	}
+}
+
+// PointView defines analytics time series bucket view type.
+type PointView struct {
+	Time       time.Time         `json:"time"`
+	Donations  int               `json:"donations"`
+	Donors     int               `json:"donors"`
+	Amount     currencies.Amount `json:"amount"`
+	Cumulative currencies.Amount `json:"cumulative"`
+}
+
+// AnalyticsView defines fundraise analytics view type. Amounts are in the fundraise currency.
+type AnalyticsView struct {
+	From             time.Time         `json:"from"`
+	To               time.Time         `json:"to"`
+	Clicks           int               `json:"clicks"`
+	Confirmed        int               `json:"confirmed"`
+	Conversion       float64           `json:"conversion"`
+	UniqueDonors     int               `json:"uniqueDonors"`
+	RepeatDonors     int               `json:"repeatDonors"`
+	RepeatDonorRatio float64           `json:"repeatDonorRatio"`
+	Total            currencies.Amount `json:"total"`
+	Average          currencies.Amount `json:"average"`
+	Median           currencies.Amount `json:"median"`
+	Matched          currencies.Amount `json:"matched"`
+	// SelfReported is the total of offline donations recorded by the organizer, included into the total.
+	SelfReported          currencies.Amount `json:"selfReported"`
+	SelfReportedDonations int               `json:"selfReportedDonations"`
+	Daily                 []PointView       `json:"daily"`
+	Hourly                []PointView       `json:"hourly"`
+	// Verified is the part of self-reported total found in imported bank statements.
+	Verified          currencies.Amount `json:"verified"`
+	VerifiedDonations int               `json:"verifiedDonations"`
+}
+
+// ToAnalyticsView builds fundraise analytics view.
+func ToAnalyticsView(report analytics.Report) AnalyticsView {
+	return AnalyticsView{
+		From:                  report.Params.From,
+		To:                    report.Params.To,
+		Clicks:                report.Summary.Clicks,
+		Confirmed:             report.Summary.Confirmed,
+		Conversion:            report.Summary.Conversion(),
+		UniqueDonors:          report.Summary.UniqueDonors,
+		RepeatDonors:          report.Summary.RepeatDonors,
+		RepeatDonorRatio:      report.Summary.RepeatDonorRatio(),
+		Total:                 report.Summary.Total,
+		Average:               report.Summary.Average,
+		Median:                report.Summary.Median,
+		Matched:               report.Summary.Matched,
+		SelfReported:          report.Summary.SelfReported,
+		SelfReportedDonations: report.Summary.SelfReportedDonations,
+		Verified:              report.Summary.Verified,
+		VerifiedDonations:     report.Summary.VerifiedDonations,
+		Daily:                 toPointViews(report.Daily),
+		Hourly:                toPointViews(report.Hourly),
+	}
+}
+
+// toPointViews builds time series view.
+func toPointViews(points []analytics.Point) []PointView {
+	views := make([]PointView, len(points))
+	for i, point := range points {
+		views[i] = PointView(point)
+	}
+
+	return views
+}
+
+// ReviewRequest defines request values for approve and reject endpoints.
+type ReviewRequest struct {
+	Reason string `json:"reason"` // INFO: required for rejection.
+}
+
+// ResubmitRequest defines request values for resubmit endpoint, omitted fields are kept.
+type ResubmitRequest struct {
+	Title        string            `json:"title"`
+	Description  string            `json:"description"`
+	TargetAmount currencies.Amount `json:"targetAmount"`
+	EndDate      time.Time         `json:"endDate"`
+	ImageUrl     string            `json:"imageUrl"`
+	Comment      string            `json:"comment"`
+}
+
+// ReviewView defines moderation decision view type.
+type ReviewView struct {
+	ID        uuid.UUID `json:"id"`
+	ActorID   uuid.UUID `json:"actorId"`
+	Decision  string    `json:"decision"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ToReviewViews builds moderation decisions view list.
+func ToReviewViews(list []reviews.Review) []ReviewView {
+	views := make([]ReviewView, len(list))
+	for i, review := range list {
+		views[i] = ReviewView{
+			ID:        review.ID,
+			ActorID:   review.ActorID,
+			Decision:  review.Decision,
+			Reason:    review.Reason,
+			CreatedAt: review.CreatedAt,
+		}
+	}
+
+	return views
+}
+
+// CreatePlanRequest defines request values for recurring donation plan creation endpoint.
+// Exactly one of fundraiseId and organizerId must be provided.
+type CreatePlanRequest struct {
+	FundraiseID uuid.UUID         `json:"fundraiseId"`
+	OrganizerID uuid.UUID         `json:"organizerId"`
+	Amount      currencies.Amount `json:"amount"`
+	Currency    string            `json:"currency"`    // INFO: fundraise or default currency is used when omitted.
+	Interval    string            `json:"interval"`    // INFO: MONTH or YEAR, MONTH is used when omitted.
+	PaymentType string            `json:"paymentType"` // INFO: STRIPE is used when omitted.
+}
+
+// ChangePlanRequest defines request values for recurring donation plan change endpoint.
+type ChangePlanRequest struct {
+	Amount currencies.Amount `json:"amount"`
+}
+
+// PlanView defines recurring donation plan view type.
+type PlanView struct {
+	ID             uuid.UUID         `json:"id"`
+	FundraiseID    uuid.UUID         `json:"fundraiseId"`
+	OrganizerID    uuid.UUID         `json:"organizerId"`
+	Amount         currencies.Amount `json:"amount"`
+	Currency       string            `json:"currency"`
+	Interval       string            `json:"interval"`
+	PaymentType    string            `json:"paymentType"`
+	Status         string            `json:"status"`
+	FailedAttempts int               `json:"failedAttempts"`
+	NextRetryAt    time.Time         `json:"nextRetryAt,omitempty"`
+	CreatedAt      time.Time         `json:"createdAt"`
+}
+
+// ToPlanView builds recurring donation plan view.
+func ToPlanView(plan plans.Plan) PlanView {
+	return PlanView{
+		ID:             plan.ID,
+		FundraiseID:    plan.FundraiseID,
+		OrganizerID:    plan.OrganizerID,
+		Amount:         plan.Amount,
+		Currency:       plan.Currency,
+		Interval:       plan.Interval,
+		PaymentType:    plan.PaymentType,
+		Status:         plan.Status,
+		FailedAttempts: plan.FailedAttempts,
+		NextRetryAt:    plan.NextRetryAt,
+		CreatedAt:      plan.CreatedAt,
+	}
+}
+
+// CreatePlanResponse defines recurring donation plan creation endpoint response object.
+type CreatePlanResponse struct {
+	Plan       PlanView `json:"plan"`
+	PaymentURL string   `json:"paymentUrl"`
+}
+
+// RefundRequest defines request values for donation refund endpoint.
+type RefundRequest struct {
+	Amount currencies.Amount `json:"amount"` // INFO: zero or omitted amount refunds whole remaining amount of the donation.
+	Reason string            `json:"reason"`
+}
+
+// RefundView defines donation refund view type.
+type RefundView struct {
+	ID           uuid.UUID         `json:"id"`
+	DonationID   uuid.UUID         `json:"donationId"`
+	Amount       currencies.Amount `json:"amount"`
+	Currency     string            `json:"currency"`
+	Reason       string            `json:"reason"`
+	Status       string            `json:"status"`
+	RequestedBy  uuid.UUID         `json:"requestedBy"`
+	ReviewedBy   uuid.UUID         `json:"reviewedBy"`
+	ReviewReason string            `json:"reviewReason"`
+	CreatedAt    time.Time         `json:"createdAt"`
+	UpdatedAt    time.Time         `json:"updatedAt"`
+}
+
+// ToRefundView builds donation refund view.
+func ToRefundView(refund refunds.Refund) RefundView {
+	return RefundView{
+		ID:           refund.ID,
+		DonationID:   refund.DonationID,
+		Amount:       refund.Amount,
+		Currency:     refund.Currency,
+		Reason:       refund.Reason,
+		Status:       refund.Status,
+		RequestedBy:  refund.RequestedBy,
+		ReviewedBy:   refund.ReviewedBy,
+		ReviewReason: refund.ReviewReason,
+		CreatedAt:    refund.CreatedAt,
+		UpdatedAt:    refund.UpdatedAt,
+	}
+}
+
+// ToRefundViews builds list of donation refund views.
+func ToRefundViews(list []refunds.Refund) []RefundView {
+	views := make([]RefundView, len(list))
+	for i, refund := range list {
+		views[i] = ToRefundView(refund)
+	}
+
+	return views
+}
+
+// BankDetailsRequest defines request values for saving bank details endpoint.
+type BankDetailsRequest struct {
+	HolderName string `json:"holderName"`
+	IBAN       string `json:"iban"`
+	BankName   string `json:"bankName"`
+}
+
+// BankDetailsView defines bank details view type with masked account number.
+type BankDetailsView struct {
+	HolderName string    `json:"holderName"`
+	IBAN       string    `json:"iban"`
+	BankName   string    `json:"bankName"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// ToBankDetailsView builds bank details view, account number is masked.
+func ToBankDetailsView(details payouts.BankDetails) BankDetailsView {
+	return BankDetailsView{
+		HolderName: details.HolderName,
+		IBAN:       details.MaskedIBAN(),
+		BankName:   details.BankName,
+		UpdatedAt:  details.UpdatedAt,
+	}
+}
+
+// PayoutRequest defines request values for fundraise payout endpoint.
+type PayoutRequest struct {
+	Amount currencies.Amount `json:"amount"` // INFO: zero or omitted amount requests whole available balance.
+}
+
+// PayoutView defines fundraise payout view type.
+type PayoutView struct {
+	ID             uuid.UUID         `json:"id"`
+	FundraiseID    uuid.UUID         `json:"fundraiseId"`
+	Amount         currencies.Amount `json:"amount"`
+	Currency       string            `json:"currency"`
+	Status         string            `json:"status"`
+	Destination    string            `json:"destination"`
+	RequestedBy    uuid.UUID         `json:"requestedBy"`
+	ReviewedBy     uuid.UUID         `json:"reviewedBy"`
+	ReviewReason   string            `json:"reviewReason"`
+	FailureMessage string            `json:"failureMessage,omitempty"`
+	CreatedAt      time.Time         `json:"createdAt"`
+	UpdatedAt      time.Time         `json:"updatedAt"`
+}
+
+// ToPayoutView builds fundraise payout view.
+func ToPayoutView(payout payouts.Payout) PayoutView {
+	return PayoutView{
+		ID:             payout.ID,
+		FundraiseID:    payout.FundraiseID,
+		Amount:         payout.Amount,
+		Currency:       payout.Currency,
+		Status:         payout.Status,
+		Destination:    payout.Destination,
+		RequestedBy:    payout.RequestedBy,
+		ReviewedBy:     payout.ReviewedBy,
+		ReviewReason:   payout.ReviewReason,
+		FailureMessage: payout.FailureMessage,
+		CreatedAt:      payout.CreatedAt,
+		UpdatedAt:      payout.UpdatedAt,
+	}
+}
+
+// ToPayoutViews builds list of fundraise payout views.
+func ToPayoutViews(list []payouts.Payout) []PayoutView {
+	views := make([]PayoutView, len(list))
+	for i, payout := range list {
+		views[i] = ToPayoutView(payout)
+	}
+
+	return views
+}
+
+// BalanceView defines fundraise balance view type, amounts are in the fundraise currency.
+type BalanceView struct {
+	Collected currencies.Amount `json:"collected"`
+	PaidOut   currencies.Amount `json:"paidOut"`
+	Reserved  currencies.Amount `json:"reserved"`
+	Available currencies.Amount `json:"available"`
+}
+
+// ToBalanceView builds fundraise balance view.
+func ToBalanceView(balance payouts.Balance) BalanceView {
+	return BalanceView{
+		Collected: balance.Collected,
+		PaidOut:   balance.PaidOut,
+		Reserved:  balance.Reserved,
+		Available: balance.Available,
+	}
+}
+
+// PayoutsView defines view type of fundraise balance with its payouts.
+type PayoutsView struct {
+	Balance BalanceView  `json:"balance"`
+	Payouts []PayoutView `json:"payouts"`
+}
+
+// ReconciliationView defines daily payment reconciliation report view type.
+type ReconciliationView struct {
+	Date        string            `json:"date"`
+	PaymentType string            `json:"paymentType"`
+	Currency    string            `json:"currency"`
+	Confirmed   currencies.Amount `json:"confirmed"`
+	Collected   currencies.Amount `json:"collected"`
+	Difference  currencies.Amount `json:"difference"`
+	Balanced    bool              `json:"balanced"`
+}
+
+// ToReconciliationViews builds list of reconciliation report views.
+func ToReconciliationViews(reports []reconciliation.Report) []ReconciliationView {
+	views := make([]ReconciliationView, len(reports))
+	for i, report := range reports {
+		views[i] = ReconciliationView{
+			Date:        report.Date.Format(time.DateOnly),
+			PaymentType: report.PaymentType,
+			Currency:    report.Currency,
+			Confirmed:   report.Confirmed,
+			Collected:   report.Collected,
+			Difference:  report.Difference(),
+			Balanced:    report.IsBalanced(),
+		}
+	}
+
+	return views
+}
+
+// SupporterView defines public view of the confirmed donation, identity of anonymous donor is empty.
+type SupporterView struct {
+	UserID          uuid.UUID         `json:"userId"`
+	FirstName       string            `json:"firstName"`
+	LastName        string            `json:"lastName"`
+	Anonymous       bool              `json:"anonymous"`
+	Amount          currencies.Amount `json:"amount"`
+	Currency        string            `json:"currency"`
+	ConvertedAmount currencies.Amount `json:"convertedAmount"`
+	Message         string            `json:"message"`
+	TributeType     string            `json:"tributeType"` // INFO: empty for donations without tribute.
+	HonoreeName     string            `json:"honoreeName"`
+	CreatedAt       time.Time         `json:"createdAt"`
+}
+
+// ToSupporterViews builds list of supporter views.
+func ToSupporterViews(supporters []donations.Supporter) []SupporterView {
+	views := make([]SupporterView, len(supporters))
+	for i, supporter := range supporters {
+		views[i] = SupporterView{
+			UserID:          supporter.Donation.UserId,
+			FirstName:       supporter.FirstName,
+			LastName:        supporter.LastName,
+			Anonymous:       supporter.Donation.Anonymous,
+			Amount:          supporter.Donation.Amount,
+			Currency:        supporter.Donation.Currency,
+			ConvertedAmount: supporter.Donation.ConvertedAmount(),
+			Message:         supporter.Donation.Message,
+			TributeType:     supporter.Donation.Tribute.Type,
+			HonoreeName:     supporter.Donation.Tribute.HonoreeName,
+			CreatedAt:       supporter.Donation.CreatedAt,
+		}
+	}
+
+	return views
+}
+
+// LeaderboardView defines public view of the ranked leaderboard.
+type LeaderboardView struct {
+	FundraiseID uuid.UUID              `json:"fundraiseId"` // INFO: nil uuid for platform-wide leaderboard.
+	Kind        string                 `json:"kind"`
+	Period      string                 `json:"period"`
+	Currency    string                 `json:"currency"`
+	Entries     []LeaderboardEntryView `json:"entries"`
+}
+
+// LeaderboardEntryView defines donor or team position on the leaderboard.
+type LeaderboardEntryView struct {
+	Rank      int               `json:"rank"`
+	UserID    uuid.UUID         `json:"userId"` // INFO: nil uuid on teams leaderboards.
+	FirstName string            `json:"firstName"`
+	LastName  string            `json:"lastName"`
+	TeamCode  string            `json:"teamCode"` // INFO: empty on donors leaderboards.
+	Amount    currencies.Amount `json:"amount"`
+	Donations int               `json:"donations"`
+}
+
+// ToLeaderboardView builds leaderboard view.
+func ToLeaderboardView(board leaderboards.Board) LeaderboardView {
+	entries := make([]LeaderboardEntryView, len(board.Entries))
+	for i, entry := range board.Entries {
+		entries[i] = LeaderboardEntryView{
+			Rank:      entry.Rank,
+			UserID:    entry.DonorID,
+			FirstName: entry.FirstName,
+			LastName:  entry.LastName,
+			TeamCode:  entry.TeamCode,
+			Amount:    entry.Amount,
+			Donations: entry.Donations,
+		}
+	}
+
+	return LeaderboardView{
+		FundraiseID: board.FundraiseID,
+		Kind:        board.Kind,
+		Period:      board.Period,
+		Currency:    board.Currency,
+		Entries:     entries,
+	}
+}
+
+// HistoryEntryView defines donation view type in the donor's history.
+type HistoryEntryView struct {
+	ID              uuid.UUID         `json:"id"`
+	FundraiseID     uuid.UUID         `json:"fundraiseId"`
+	FundraiseTitle  string            `json:"fundraiseTitle"`
+	FundraiseStatus string            `json:"fundraiseStatus"`
+	Amount          currencies.Amount `json:"amount"`
+	Currency        string            `json:"currency"`
+	CreatedAt       time.Time         `json:"createdAt"`
+	PlanID          uuid.UUID         `json:"planId"` // INFO: nil uuid for one-time donations.
+	Anonymous       bool              `json:"anonymous"`
+	Message         string            `json:"message"`
+	PaymentType     string            `json:"paymentType"`
+	PaymentStatus   string            `json:"paymentStatus"`
+	RefundedAmount  currencies.Amount `json:"refundedAmount"`
+	// SelfReported is true for offline donation recorded by the organizer, not confirmed by payment provider.
+	SelfReported bool `json:"selfReported"`
+	// ReceiptPath is the receipt download path relative to the API base, empty unless donation is confirmed.
+	ReceiptPath string `json:"receiptPath,omitempty"`
+}
+
+// ToHistoryEntryView builds donation view of the donor's history.
+func ToHistoryEntryView(entry donations.HistoryEntry) HistoryEntryView {
+	view := HistoryEntryView{
+		ID:              entry.Donation.ID,
+		FundraiseID:     entry.Donation.FundraiseId,
+		FundraiseTitle:  entry.FundraiseTitle,
+		FundraiseStatus: entry.FundraiseStatus,
+		Amount:          entry.Donation.Amount,
+		Currency:        entry.Donation.Currency,
+		CreatedAt:       entry.Donation.CreatedAt,
+		PlanID:          entry.Donation.PlanID,
+		Anonymous:       entry.Donation.Anonymous,
+		Message:         entry.Donation.Message,
+		PaymentType:     entry.PaymentType,
+		PaymentStatus:   entry.PaymentStatus,
+		RefundedAmount:  entry.RefundedAmount,
+		SelfReported:    payments.IsSelfReported(entry.PaymentType),
+	}
+	if entry.PaymentStatus == payments.StatusConfirmed || entry.PaymentStatus == payments.StatusPartiallyRefunded {
+		view.ReceiptPath = "/fundraises/donations/" + entry.Donation.ID.String() + "/receipt"
+	}
+
+	return view
+}
+
+// YearTotalView defines donor's yearly total view type.
+type YearTotalView struct {
+	Year     int               `json:"year"`
+	Currency string            `json:"currency"`
+	Amount   currencies.Amount `json:"amount"`
+}
+
+// ContributionView defines donor's contribution to the fundraise view type.
+type ContributionView struct {
+	FundraiseID     uuid.UUID         `json:"fundraiseId"`
+	FundraiseTitle  string            `json:"fundraiseTitle"`
+	FundraiseStatus string            `json:"fundraiseStatus"`
+	Currency        string            `json:"currency"`
+	Amount          currencies.Amount `json:"amount"`
+	Donations       int               `json:"donations"`
+	LastDonatedAt   time.Time         `json:"lastDonatedAt"`
+}
+
+// DonationHistoryView defines page of the donor's donation history view type.
+type DonationHistoryView struct {
+	common.Page[HistoryEntryView]
+	YearlyTotals []YearTotalView    `json:"yearlyTotals"`
+	Fundraises   []ContributionView `json:"fundraises"`
+}
+
+// ToDonationHistoryView builds donor's donation history view.
+func ToDonationHistoryView(history fundraises.DonationHistory, page, limit int) DonationHistoryView {
+	view := DonationHistoryView{
+		Page: common.Page[HistoryEntryView]{
+			Data:  make([]HistoryEntryView, len(history.Donations)),
+			Page:  page,
+			Limit: limit,
+		},
+		YearlyTotals: make([]YearTotalView, len(history.YearlyTotals)),
+		Fundraises:   make([]ContributionView, len(history.Contributions)),
+	}
+
+	for i, entry := range history.Donations {
+		view.Data[i] = ToHistoryEntryView(entry)
+	}
+	for i, total := range history.YearlyTotals {
+		view.YearlyTotals[i] = YearTotalView{Year: total.Year, Currency: total.Currency, Amount: total.Amount}
+	}
+	for i, contribution := range history.Contributions {
+		view.Fundraises[i] = ContributionView{
+			FundraiseID:     contribution.FundraiseID,
+			FundraiseTitle:  contribution.FundraiseTitle,
+			FundraiseStatus: contribution.FundraiseStatus,
+			Currency:        contribution.Currency,
+			Amount:          contribution.Amount,
+			Donations:       contribution.Donations,
+			LastDonatedAt:   contribution.LastDonatedAt,
+		}
+	}
+
+	return view
+}
+
+// PledgeRequest defines request values for matching pledge endpoint.
+type PledgeRequest struct {
+	SponsorName string            `json:"sponsorName"`
+	Ratio       float64           `json:"ratio"`
+	Cap         currencies.Amount `json:"cap"`
+	StartsAt    time.Time         `json:"startsAt"` // INFO: zero or omitted value starts the pledge immediately.
+	EndsAt      time.Time         `json:"endsAt"`
+}
+
+// PledgeView defines matching pledge view type.
+type PledgeView struct {
+	ID          uuid.UUID         `json:"id"`
+	FundraiseID uuid.UUID         `json:"fundraiseId"`
+	SponsorName string            `json:"sponsorName"`
+	Ratio       float64           `json:"ratio"`
+	Cap         currencies.Amount `json:"cap"`
+	Matched     currencies.Amount `json:"matched"`
+	Remaining   currencies.Amount `json:"remaining"`
+	StartsAt    time.Time         `json:"startsAt"`
+	EndsAt      time.Time         `json:"endsAt"`
+}
+
+// ToPledgeView builds matching pledge view.
+func ToPledgeView(pledge matching.Pledge) PledgeView {
+	return PledgeView{
+		ID:          pledge.ID,
+		FundraiseID: pledge.FundraiseID,
+		SponsorName: pledge.SponsorName,
+		Ratio:       pledge.Ratio,
+		Cap:         pledge.Cap,
+		Matched:     pledge.Matched,
+		Remaining:   pledge.Remaining(),
+		StartsAt:    pledge.StartsAt,
+		EndsAt:      pledge.EndsAt,
+	}
+}
+
+// MatchingView defines matching budget of the fundraise view type.
+type MatchingView struct {
+	Matched   currencies.Amount `json:"matched"`
+	Remaining currencies.Amount `json:"remaining"`
+}
+
+// OfflineDonationRequest defines request to record offline donation.
+type OfflineDonationRequest struct {
+	DonorID     uuid.UUID         `json:"donorId"` // INFO: optional, nil uuid for unknown donor.
+	PaymentType string            `json:"paymentType"`
+	Amount      currencies.Amount `json:"amount"`
+	Currency    string            `json:"currency"`
+	ReceivedAt  time.Time         `json:"receivedAt"`
+	Reference   string            `json:"reference"`
+	EvidenceURL string            `json:"evidenceUrl"`
+	Notes       string            `json:"notes"`
+	Message     string            `json:"message"`
+}
+
+// OfflineDonationView defines offline donation view type, shown only to the organizer.
+type OfflineDonationView struct {
+	ID           uuid.UUID         `json:"id"`
+	DonorID      uuid.UUID         `json:"donorId"`
+	PaymentType  string            `json:"paymentType"`
+	Amount       currencies.Amount `json:"amount"`
+	Currency     string            `json:"currency"`
+	ExchangeRate float64           `json:"exchangeRate"`
+	Message      string            `json:"message"`
+	RecordedBy   uuid.UUID         `json:"recordedBy"`
+	EvidenceURL  string            `json:"evidenceUrl"`
+	Notes        string            `json:"notes"`
+	ReceivedAt   time.Time         `json:"receivedAt"`
+	VerifiedAt   time.Time         `json:"verifiedAt,omitempty"` // INFO: set when found in imported bank statement.
+	CreatedAt    time.Time         `json:"createdAt"`
+}
+
+// ToOfflineDonationView builds offline donation view.
+func ToOfflineDonationView(entry offline.Entry) OfflineDonationView {
+	return OfflineDonationView{
+		ID:           entry.Donation.ID,
+		DonorID:      entry.Donation.UserId,
+		PaymentType:  entry.PaymentType,
+		Amount:       entry.Donation.Amount,
+		Currency:     entry.Donation.Currency,
+		ExchangeRate: entry.Donation.ExchangeRate,
+		Message:      entry.Donation.Message,
+		RecordedBy:   entry.Record.RecordedBy,
+		EvidenceURL:  entry.Record.EvidenceURL,
+		Notes:        entry.Record.Notes,
+		ReceivedAt:   entry.Record.ReceivedAt,
+		VerifiedAt:   entry.Record.VerifiedAt,
+		CreatedAt:    entry.Record.CreatedAt,
+	}
+}
+
+// StatementSummaryView defines bank statement import result view type.
+type StatementSummaryView struct {
+	ID         uuid.UUID `json:"id"`
+	Format     string    `json:"format"`
+	Matched    int       `json:"matched"`
+	Unmatched  int       `json:"unmatched"`
+	Duplicates int       `json:"duplicates"`
+	Outgoing   int       `json:"outgoing"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// ToStatementSummaryView builds bank statement import result view.
+func ToStatementSummaryView(summary statements.Summary) StatementSummaryView {
+	return StatementSummaryView{
+		ID:         summary.Statement.ID,
+		Format:     summary.Statement.Format,
+		Matched:    summary.Matched,
+		Unmatched:  summary.Unmatched,
+		Duplicates: summary.Duplicates,
+		Outgoing:   summary.Outgoing,
+		CreatedAt:  summary.Statement.CreatedAt,
+	}
+}
+
+// StatementLineView defines incoming transfer of the imported statement view type.
+type StatementLineView struct {
+	ID           uuid.UUID         `json:"id"`
+	StatementID  uuid.UUID         `json:"statementId"`
+	ExternalID   string            `json:"externalId"`
+	BookedAt     time.Time         `json:"bookedAt"`
+	Amount       currencies.Amount `json:"amount"`
+	Currency     string            `json:"currency"`
+	Reference    string            `json:"reference"`
+	Counterparty string            `json:"counterparty"`
+	Status       string            `json:"status"`
+	DonationID   uuid.UUID         `json:"donationId"` // INFO: nil uuid until matched or confirmed.
+	UpdatedAt    time.Time         `json:"updatedAt"`
+}
+
+// ToStatementLineView builds statement line view.
+func ToStatementLineView(line statements.Line) StatementLineView {
+	return StatementLineView{
+		ID:           line.ID,
+		StatementID:  line.StatementID,
+		ExternalID:   line.Transaction.ExternalID,
+		BookedAt:     line.Transaction.BookedAt,
+		Amount:       line.Transaction.Amount,
+		Currency:     line.Transaction.Currency,
+		Reference:    line.Transaction.Reference,
+		Counterparty: line.Transaction.Counterparty,
+		Status:       line.Status,
+		DonationID:   line.DonationID,
+		UpdatedAt:    line.UpdatedAt,
+	}
+}
+
+// StatementLineReviewRequest defines request to confirm incoming transfer as a donation.
+type StatementLineReviewRequest struct {
+	DonorID uuid.UUID `json:"donorId"` // INFO: optional.
+	Message string    `json:"message"`
 }
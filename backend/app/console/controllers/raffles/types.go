@@ -5,6 +5,7 @@ import (
 
 	"github.com/google/uuid"
 
+	"one-help/app/currencies"
 	"one-help/app/raffles"
 )
 
@@ -12,7 +13,7 @@ import (
 type CreateRequest struct {
 	Title           string              `json:"title"`
 	Description     string              `json:"description"`
-	MinimumDonation float64             `json:"minimumDonation"`
+	MinimumDonation currencies.Amount   `json:"minimumDonation"`
 	StartDate       time.Time           `json:"startDate"`
 	EndDate         time.Time           `json:"endDate"`
 	FundraiseID     uuid.UUID           `json:"fundraiseId"`
@@ -28,14 +29,14 @@ type CreateGiftRequest struct {
 
 // RaffleView defines raffle view type.
 type RaffleView struct {
-	ID              uuid.UUID  `json:"id"`
-	Title           string     `json:"title"`
-	Description     string     `json:"description"`
-	MinimumDonation float64    `json:"minimumDonation"`
-	StartDate       time.Time  `json:"startDate"`
-	EndDate         time.Time  `json:"endDate"`
-	FundraiseID     uuid.UUID  `json:"fundraiseId"`
-	Gifts           []GiftView `json:"gifts"`
+	ID              uuid.UUID         `json:"id"`
+	Title           string            `json:"title"`
+	Description     string            `json:"description"`
+	MinimumDonation currencies.Amount `json:"minimumDonation"`
+	StartDate       time.Time         `json:"startDate"`
+	EndDate         time.Time         `json:"endDate"`
+	FundraiseID     uuid.UUID         `json:"fundraiseId"`
+	Gifts           []GiftView        `json:"gifts"`
 }
 
 // GiftView describes gift view.
@@ -19,6 +19,7 @@ type RegisterRequest struct {
 	Email          string `json:"email"`
 	Password       string `json:"password"`
 	ImageUrl       string `json:"imageUrl"`
+	Private        bool   `json:"private"` // INFO: hides user's name from public leaderboards.
 }
 
 // AuthResponse contains user and auth token.
@@ -34,6 +35,7 @@ type UserView struct {
 	PostDepartment string `json:"postDepartment"`
 	PhoneNumber    string `json:"phoneNumber"`
 	Email          string `json:"email"`
+	Private        bool   `json:"private"`
 }
 
 // ToUserView builds user view.
@@ -44,6 +46,7 @@ func ToUserView(user *users.User, userCreds *credentials.Credentials) *UserView
 		PostDepartment: user.PostDepartment,
 		PhoneNumber:    userCreds.PhoneNumber,
 		Email:          userCreds.Email,
+		Private:        user.Private,
 	}
 }
 
@@ -67,6 +70,7 @@ type UserPublicView struct {
 	Website   string    `json:"website"`
 	ImageUrl  string    `json:"imageUrl"`
 	City      string    `json:"city"`
+	Trusted   bool      `json:"trusted"`
 }
 
 // ToUserPublicView builds user public view.
@@ -78,6 +82,7 @@ func ToUserPublicView(user *users.User) *UserPublicView {
 		Website:   user.Website,
 		ImageUrl:  user.ImageUrl,
 		City:      user.City,
+		Trusted:   user.Trusted,
 	}
 }
 
@@ -90,4 +95,5 @@ type UpdateRequest struct {
 	Post           string `json:"post"`
 	PostDepartment string `json:"postDepartment"`
 	ImageUrl       string `json:"imageUrl"`
+	Private        *bool  `json:"private"` // INFO: hides user's name from public leaderboards, kept when omitted.
 }
@@ -67,6 +67,7 @@ func (controller *Users) Register(w http.ResponseWriter, r *http.Request) {
 		Email:          request.Email,
 		Password:       request.Password,
 		ImageUrl:       request.ImageUrl,
+		Private:        request.Private,
 	}
 
 	user, err := controller.users.Register(ctx, registerParams)
@@ -231,6 +232,60 @@ func (controller *Users) GetByID(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// Trust is an endpoint for marking user as trusted organizer, whose fundraises skip moderation review.
+// @Summary	Marks user as trusted organizer
+// @Tags	Users
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Success	200
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/users/{id}/trusted	[put].
+func (controller *Users) Trust(w http.ResponseWriter, r *http.Request) {
+	controller.setTrusted(w, r, true)
+}
+
+// Distrust is an endpoint for removing user's trusted organizer mark.
+// @Summary	Removes trusted organizer mark from user
+// @Tags	Users
+// @Produce	json
+// @Param	Authorization	header	string	true	"Bearer token to authorize access"
+// @Success	200
+// @Failure	400,401,403,404,500	{object}	common.ErrResponseCode
+// @Router	/users/{id}/trusted	[delete].
+func (controller *Users) Distrust(w http.ResponseWriter, r *http.Request) {
+	controller.setTrusted(w, r, false)
+}
+
+// setTrusted updates user's trusted organizer mark by moderator.
+func (controller *Users) setTrusted(w http.ResponseWriter, r *http.Request, trusted bool) {
+	ctx := r.Context()
+
+	creds, err := credentials.GetFromContext(ctx)
+	if err != nil {
+		common.NewErrResponse(http.StatusUnauthorized, errors.Unwrap(err)).Serve(controller.log, ErrUsers, w)
+		return
+	}
+
+	userID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse id")).Serve(controller.log, ErrUsers, w)
+		return
+	}
+
+	if err = controller.users.SetTrusted(ctx, creds.UserID, userID, trusted); err != nil {
+		controller.log.Error("failed to set trusted organizer mark", ErrUsers.Wrap(err))
+		switch {
+		case errors.Is(err, users.ErrNotModerator):
+			common.NewErrResponse(http.StatusForbidden, users.ErrNotModerator).Serve(controller.log, ErrUsers, w)
+		case errors.Is(err, users.ErrNoUser):
+			common.NewErrResponse(http.StatusNotFound, users.ErrNoUser).Serve(controller.log, ErrUsers, w)
+		default:
+			common.NewErrResponse(http.StatusInternalServerError, errors.New("failed to set trusted organizer mark")).Serve(controller.log, ErrUsers, w)
+		}
+		return
+	}
+}
+
 // ChangePassword is an endpoint for changing user's password.
 // @Summary	Update user's password
 // @Tags	Users
@@ -314,7 +369,7 @@ func (controller *Users) Update(w http.ResponseWriter, r *http.Request) {
 			Post:           request.Post,
 			PostDepartment: request.PostDepartment,
 		},
-	})
+	}, request.Private)
 	if err != nil {
 		controller.log.Error("error while update:", ErrUsers.Wrap(err))
 		if users.ParamsError.Has(err) {
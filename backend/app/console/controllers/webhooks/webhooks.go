@@ -0,0 +1,73 @@
+package webhooks
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/zeebo/errs"
+
+	"one-help/app/console/controllers/common"
+	"one-help/app/payments/webhooks"
+	"one-help/internal/logger"
+)
+
+var (
+	// ErrWebhooks is an internal error type for webhooks controller.
+	ErrWebhooks = errs.Class("webhooks controller")
+)
+
+// maxPayloadSize limits size of webhook request body.
+const maxPayloadSize = 64 * 1024
+
+// Webhooks is a controller that handles payment providers' webhooks.
+type Webhooks struct {
+	log logger.Logger
+
+	webhooks *webhooks.Service
+}
+
+// NewWebhooks is a constructor for webhooks controller.
+func NewWebhooks(log logger.Logger, webhooks *webhooks.Service) *Webhooks {
+	webhooksController := &Webhooks{
+		log:      log,
+		webhooks: webhooks,
+	}
+
+	return webhooksController
+}
+
+// Payment is an endpoint for payment providers' webhook events, signature is verified by the provider.
+// @Summary	Applies payment provider events to donations (for internal use).
+// @Tags	Webhooks
+// @Param	provider	path	string	true	"Payment provider, e.g. stripe or liqpay"
+// @Success	200
+// @Failure	400,500	{object}	common.ErrResponseCode
+// @Router	/webhooks/{provider}	[post].
+func (controller *Webhooks) Payment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPayloadSize))
+	if err != nil {
+		common.NewErrResponse(http.StatusBadRequest, errors.New("failed to read payload")).Serve(controller.log, ErrWebhooks, w)
+		return
+	}
+
+	paymentType := strings.ToUpper(mux.Vars(r)["provider"])
+	err = controller.webhooks.Process(ctx, paymentType, payload, r.Header)
+	if err != nil {
+		controller.log.Error("failed to process payment webhook", ErrWebhooks.Wrap(err))
+		if webhooks.ParamsError.Has(err) {
+			common.NewErrResponse(http.StatusBadRequest, errors.Unwrap(err)).Serve(controller.log, ErrWebhooks, w)
+			return
+		}
+
+		// INFO: providers retry delivery on non-2xx responses.
+		common.NewErrResponse(http.StatusInternalServerError, errors.New("failed to process webhook")).Serve(controller.log, ErrWebhooks, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,211 @@
+package widgets
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+
+	"one-help/app/currencies"
+
+	fundraisescontroller "one-help/app/console/controllers/fundraises"
+)
+
+const (
+	// cardWidth and cardHeight are the recommended social preview image size.
+	cardWidth  = 1200
+	cardHeight = 630
+	// maxTitleLength limits title length on the badge and card.
+	maxTitleLength = 48
+)
+
+// progress holds values shared by all widget formats.
+type progress struct {
+	Title    string
+	Filled   string
+	Target   string
+	Currency string
+	Percent  int
+	// Ratio is a filled part of the target in [0, 1], to draw a progress bar.
+	Ratio float64
+	Theme Theme
+}
+
+// newProgress builds widget values from fundraise view.
+func newProgress(view *fundraisescontroller.FundraiseView, theme Theme) progress {
+	ratio := 0.
+	if view.TargetAmount.IsPositive() {
+		ratio = view.FilledAmount.Float64() / view.TargetAmount.Float64()
+	}
+
+	return progress{
+		Title:    truncate(view.Title, maxTitleLength),
+		Filled:   formatAmount(view.FilledAmount),
+		Target:   formatAmount(view.TargetAmount),
+		Currency: view.Currency,
+		Percent:  int(math.Floor(ratio * 100)),
+		Ratio:    math.Min(math.Max(ratio, 0), 1),
+		Theme:    theme,
+	}
+}
+
+// formatAmount formats amount without fraction, with space as thousands separator.
+func formatAmount(amount currencies.Amount) string {
+	digits := fmt.Sprintf("%.0f", math.Floor(amount.Float64()))
+
+	var builder strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			builder.WriteRune(' ')
+		}
+		builder.WriteRune(digit)
+	}
+
+	return builder.String()
+}
+
+// truncate shortens text to the limit of runes, appending ellipsis.
+func truncate(text string, limit int) string {
+	if utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+
+	return string([]rune(text)[:limit-1]) + "…"
+}
+
+var badgeTemplate = template.Must(template.New("badge").Parse(`<svg xmlns="http://www.w3.org/2000/svg" width="360" height="56" viewBox="0 0 360 56" role="img" aria-label="{{.Title}}: {{.Percent}}%">
+<title>{{.Title}}: {{.Filled}} / {{.Target}} {{.Currency}}</title>
+<rect width="360" height="56" rx="6" fill="{{.Theme.Background}}"/>
+<text x="12" y="22" font-family="Verdana,DejaVu Sans,sans-serif" font-size="13" fill="{{.Theme.Text}}">{{.Title}}</text>
+<rect x="12" y="32" width="240" height="12" rx="6" fill="{{.Theme.Track}}"/>
+<rect x="12" y="32" width="{{.BarWidth}}" height="12" rx="6" fill="{{.Theme.Bar}}"/>
+<text x="262" y="43" font-family="Verdana,DejaVu Sans,sans-serif" font-size="12" fill="{{.Theme.Muted}}">{{.Percent}}% · {{.Filled}} {{.Currency}}</text>
+</svg>
+`))
+
+// RenderBadge renders fundraise progress as an SVG badge.
+func RenderBadge(view *fundraisescontroller.FundraiseView, theme Theme) ([]byte, error) {
+	values := struct {
+		progress
+		BarWidth string
+	}{
+		progress: newProgress(view, theme),
+	}
+	values.BarWidth = fmt.Sprintf("%.1f", 240*values.Ratio)
+
+	var buf bytes.Buffer
+	if err := badgeTemplate.Execute(&buf, values); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+var widgetTemplate = template.Must(template.New("widget").Parse(`<!DOCTYPE html>
+<html lang="uk">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { margin: 0; font-family: system-ui, sans-serif; background: {{.Theme.Background}}; color: {{.Theme.Text}}; }
+.widget { padding: 12px 16px; }
+.title { font-size: 16px; font-weight: 600; margin-bottom: 8px; white-space: nowrap; overflow: hidden; text-overflow: ellipsis; }
+.track { height: 14px; border-radius: 7px; background: {{.Theme.Track}}; overflow: hidden; }
+.bar { height: 100%; width: {{.BarPercent}}%; background: {{.Theme.Bar}}; }
+.amounts { margin-top: 6px; font-size: 13px; color: {{.Theme.Muted}}; display: flex; justify-content: space-between; }
+</style>
+</head>
+<body>
+<div class="widget">
+<div class="title">{{.Title}}</div>
+<div class="track"><div class="bar"></div></div>
+<div class="amounts"><span>{{.Filled}} / {{.Target}} {{.Currency}}</span><span>{{.Percent}}%</span></div>
+</div>
+</body>
+</html>
+`))
+
+// RenderWidget renders fundraise progress as an embeddable HTML page, intended to be used in iframe.
+func RenderWidget(view *fundraisescontroller.FundraiseView, theme Theme) ([]byte, error) {
+	values := struct {
+		progress
+		BarPercent string
+	}{
+		progress: newProgress(view, theme),
+	}
+	values.BarPercent = fmt.Sprintf("%.1f", 100*values.Ratio)
+
+	var buf bytes.Buffer
+	if err := widgetTemplate.Execute(&buf, values); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// RenderCard renders fundraise progress as a PNG card for social previews.
+// Only ASCII glyphs are available in the embedded font, other characters are drawn as placeholders.
+func RenderCard(view *fundraisescontroller.FundraiseView, theme Theme) ([]byte, error) {
+	values := newProgress(view, theme)
+
+	img := image.NewRGBA(image.Rect(0, 0, cardWidth, cardHeight))
+	fill(img, img.Bounds(), rgba(theme.Background))
+
+	drawText(img, values.Title, image.Pt(80, 140), 5, rgba(theme.Text))
+
+	track := image.Rect(80, 300, cardWidth-80, 360)
+	fill(img, track, rgba(theme.Track))
+	bar := track
+	bar.Max.X = bar.Min.X + int(float64(track.Dx())*values.Ratio)
+	fill(img, bar, rgba(theme.Bar))
+
+	drawText(img, fmt.Sprintf("%s / %s %s", values.Filled, values.Target, values.Currency), image.Pt(80, 420), 4, rgba(theme.Text))
+	drawText(img, fmt.Sprintf("%d%%", values.Percent), image.Pt(80, 500), 4, rgba(theme.Muted))
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// fill paints rectangle with solid color.
+func fill(img draw.Image, rect image.Rectangle, c color.Color) {
+	draw.Draw(img, rect, image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+// drawText draws single line of text, scaling embedded bitmap font by the factor.
+// Scale is reduced for the text to fit card paddings.
+func drawText(img draw.Image, text string, at image.Point, scale int, c color.Color) {
+	face := basicfont.Face7x13
+	width := font.MeasureString(face, text).Ceil()
+	if width == 0 {
+		return
+	}
+
+	scale = max(min(scale, (img.Bounds().Dx()-2*at.X)/width), 1)
+
+	line := image.NewRGBA(image.Rect(0, 0, width, face.Height))
+	drawer := font.Drawer{
+		Dst:  line,
+		Src:  image.NewUniform(c),
+		Face: face,
+		Dot:  fixed.P(0, face.Ascent),
+	}
+	drawer.DrawString(text)
+
+	target := image.Rect(at.X, at.Y, at.X+width*scale, at.Y+face.Height*scale)
+	draw.NearestNeighbor.Scale(img, target, line, line.Bounds(), draw.Over, nil)
+}
@@ -0,0 +1,66 @@
+package widgets
+
+import (
+	"image/color"
+	"regexp"
+	"strconv"
+)
+
+// Theme defines widget colors.
+type Theme struct {
+	Name       string
+	Background string
+	Text       string
+	Muted      string
+	Track      string
+	Bar        string
+}
+
+var (
+	// LightTheme is a default widget theme.
+	LightTheme = Theme{
+		Name:       "light",
+		Background: "#ffffff",
+		Text:       "#1f2328",
+		Muted:      "#59636e",
+		Track:      "#e6e8eb",
+		Bar:        "#2da44e",
+	}
+	// DarkTheme is a widget theme for dark pages and streams.
+	DarkTheme = Theme{
+		Name:       "dark",
+		Background: "#0d1117",
+		Text:       "#f0f6fc",
+		Muted:      "#9198a1",
+		Track:      "#30363d",
+		Bar:        "#3fb950",
+	}
+)
+
+// hexColorRegexp matches 6 digit hex color without leading hash.
+var hexColorRegexp = regexp.MustCompile(`^[0-9a-fA-F]{6}$`)
+
+// ParseTheme returns theme by its name, with optional bar color override in "rrggbb" format.
+// Unknown names and malformed colors fall back to defaults.
+func ParseTheme(name, barColor string) Theme {
+	theme := LightTheme
+	if name == DarkTheme.Name {
+		theme = DarkTheme
+	}
+
+	if hexColorRegexp.MatchString(barColor) {
+		theme.Bar = "#" + barColor
+	}
+
+	return theme
+}
+
+// rgba converts "#rrggbb" color to color.RGBA.
+func rgba(hex string) color.RGBA {
+	value, err := strconv.ParseUint(hex[1:], 16, 32)
+	if err != nil {
+		return color.RGBA{A: 0xff}
+	}
+
+	return color.RGBA{R: uint8(value >> 16), G: uint8(value >> 8), B: uint8(value), A: 0xff}
+}
@@ -0,0 +1,149 @@
+package widgets
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/zeebo/errs"
+
+	"one-help/app/console/controllers/common"
+	fundraisescontroller "one-help/app/console/controllers/fundraises"
+	"one-help/app/fundraises"
+	"one-help/internal/logger"
+)
+
+var (
+	// ErrWidgets is an internal error type for widgets controller.
+	ErrWidgets = errs.Class("widgets controller")
+)
+
+// cacheMaxAge defines how long clients and CDNs may reuse rendered widgets.
+const cacheMaxAge = 5 * time.Minute
+
+// renderer renders fundraise progress in one of the widget formats.
+type renderer func(view *fundraisescontroller.FundraiseView, theme Theme) ([]byte, error)
+
+// Widgets is a controller that handles public embeddable fundraise widgets.
+type Widgets struct {
+	log logger.Logger
+
+	fundraises *fundraises.Service
+}
+
+// NewWidgets is a constructor for widgets controller.
+func NewWidgets(log logger.Logger, fundraises *fundraises.Service) *Widgets {
+	widgetsController := &Widgets{
+		log:        log,
+		fundraises: fundraises,
+	}
+
+	return widgetsController
+}
+
+// Badge is an endpoint that renders fundraise progress as SVG badge.
+// @Summary	Returns SVG progress badge of the fundraise
+// @Tags	Widgets
+// @Produce	image/svg+xml
+// @Param	theme	query	string	false	"Widget theme: light or dark [default value: light]"
+// @Param	color	query	string	false	"Progress bar color in rrggbb format"
+// @Success	200
+// @Failure	400,404,500	{object}	common.ErrResponseCode
+// @Router	/widgets/fundraises/{id}/badge.svg	[get].
+func (controller *Widgets) Badge(w http.ResponseWriter, r *http.Request) {
+	controller.serve(w, r, "image/svg+xml; charset=utf-8", RenderBadge)
+}
+
+// Card is an endpoint that renders fundraise progress as PNG card for social previews.
+// @Summary	Returns PNG progress card of the fundraise
+// @Tags	Widgets
+// @Produce	image/png
+// @Param	theme	query	string	false	"Widget theme: light or dark [default value: light]"
+// @Param	color	query	string	false	"Progress bar color in rrggbb format"
+// @Success	200
+// @Failure	400,404,500	{object}	common.ErrResponseCode
+// @Router	/widgets/fundraises/{id}/card.png	[get].
+func (controller *Widgets) Card(w http.ResponseWriter, r *http.Request) {
+	controller.serve(w, r, "image/png", RenderCard)
+}
+
+// Widget is an endpoint that renders fundraise progress as embeddable HTML widget.
+// @Summary	Returns HTML progress widget of the fundraise to embed with iframe
+// @Tags	Widgets
+// @Produce	html
+// @Param	theme	query	string	false	"Widget theme: light or dark [default value: light]"
+// @Param	color	query	string	false	"Progress bar color in rrggbb format"
+// @Success	200
+// @Failure	400,404,500	{object}	common.ErrResponseCode
+// @Router	/widgets/fundraises/{id}/widget	[get].
+func (controller *Widgets) Widget(w http.ResponseWriter, r *http.Request) {
+	controller.serve(w, r, "text/html; charset=utf-8", RenderWidget)
+}
+
+// serve renders fundraise progress and writes it with caching headers.
+func (controller *Widgets) serve(w http.ResponseWriter, r *http.Request, contentType string, render renderer) {
+	ctx := r.Context()
+
+	fundraiseID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		common.NewErrResponse(http.StatusBadRequest, errs.New("failed to parse id")).Serve(controller.log, ErrWidgets, w)
+		return
+	}
+
+	// INFO: widgets are public, so fundraises under review are not served to anyone.
+	fundraise, err := controller.fundraises.GetVisible(ctx, fundraiseID, uuid.Nil)
+	if err != nil {
+		controller.log.Error("failed to get fundraise by id", ErrWidgets.Wrap(err))
+		if errors.Is(err, fundraises.ErrNoFundraise) {
+			common.NewErrResponse(http.StatusNotFound, fundraises.ErrNoFundraise).Serve(controller.log, ErrWidgets, w)
+			return
+		}
+
+		common.NewErrResponse(http.StatusInternalServerError, errors.New("failed to get fundraise by id")).Serve(controller.log, ErrWidgets, w)
+		return
+	}
+
+	filled, err := controller.fundraises.Filled(ctx, fundraise.ID)
+	if err != nil {
+		controller.log.Error("failed to get fundraise filled value", ErrWidgets.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, errors.New("failed to get fundraise filled value")).Serve(controller.log, ErrWidgets, w)
+		return
+	}
+
+	theme := ParseTheme(r.URL.Query().Get("theme"), r.URL.Query().Get("color"))
+
+	body, err := render(fundraisescontroller.ToFundraiseView(fundraise, filled), theme)
+	if err != nil {
+		controller.log.Error("failed to render widget", ErrWidgets.Wrap(err))
+		common.NewErrResponse(http.StatusInternalServerError, errors.New("failed to render widget")).Serve(controller.log, ErrWidgets, w)
+		return
+	}
+
+	if err = WriteCached(w, r, contentType, body); err != nil {
+		controller.log.Error("failed to write widget", ErrWidgets.Wrap(err))
+	}
+}
+
+// WriteCached writes rendered widget with caching headers, or only not modified status if the client
+// has the same widget cached.
+func WriteCached(w http.ResponseWriter, r *http.Request, contentType string, body []byte) error {
+	hash := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(hash[:16]) + `"`
+
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(cacheMaxAge.Seconds())))
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Vary", "Accept-Encoding")
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	_, err := w.Write(body)
+	return err
+}
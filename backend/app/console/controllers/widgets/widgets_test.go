@@ -0,0 +1,160 @@
+package widgets_test
+
+import (
+	"bytes"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app/console/controllers/widgets"
+	"one-help/app/currencies"
+
+	fundraisescontroller "one-help/app/console/controllers/fundraises"
+)
+
+func TestRender(t *testing.T) {
+	view := &fundraisescontroller.FundraiseView{
+		Title:        "Drones for the brigade",
+		TargetAmount: currencies.Major(10000),
+		FilledAmount: currencies.Major(2500),
+		Currency:     currencies.UAH,
+	}
+	hostile := &fundraisescontroller.FundraiseView{
+		Title:        `</title><script>alert("x")</script>`,
+		TargetAmount: currencies.Major(100),
+		FilledAmount: currencies.Major(250),
+		Currency:     currencies.UAH,
+	}
+
+	tests := []struct {
+		name     string
+		render   func(view *fundraisescontroller.FundraiseView, theme widgets.Theme) ([]byte, error)
+		view     *fundraisescontroller.FundraiseView
+		contains []string
+	}{
+		{
+			name:     "badge",
+			render:   widgets.RenderBadge,
+			view:     view,
+			contains: []string{"Drones for the brigade", "2 500 / 10 000 UAH", `width="60.0"`, widgets.LightTheme.Bar},
+		},
+		{
+			name:     "badge with hostile title",
+			render:   widgets.RenderBadge,
+			view:     hostile,
+			contains: []string{"&lt;script&gt;", `width="240.0"`, "250%"},
+		},
+		{
+			name:     "widget",
+			render:   widgets.RenderWidget,
+			view:     view,
+			contains: []string{"<title>Drones for the brigade</title>", "2 500 / 10 000 UAH", "width: 25.0%", "<span>25%</span>"},
+		},
+		{
+			name:     "widget with hostile title",
+			render:   widgets.RenderWidget,
+			view:     hostile,
+			contains: []string{"&lt;/title&gt;&lt;script&gt;", "width: 100.0%"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			body, err := test.render(test.view, widgets.LightTheme)
+			require.NoError(t, err)
+
+			for _, expected := range test.contains {
+				assert.Contains(t, string(body), expected)
+			}
+			assert.NotContains(t, string(body), "<script>")
+		})
+	}
+
+	t.Run("card", func(t *testing.T) {
+		for _, view := range []*fundraisescontroller.FundraiseView{view, hostile} {
+			body, err := widgets.RenderCard(view, widgets.DarkTheme)
+			require.NoError(t, err)
+
+			img, err := png.Decode(bytes.NewReader(body))
+			require.NoError(t, err)
+			assert.Equal(t, 1200, img.Bounds().Dx())
+			assert.Equal(t, 630, img.Bounds().Dy())
+
+			r, g, b, _ := img.At(0, 0).RGBA()
+			assert.Equal(t, [3]uint32{0x0d, 0x11, 0x17}, [3]uint32{r >> 8, g >> 8, b >> 8})
+		}
+	})
+}
+
+func TestParseTheme(t *testing.T) {
+	tests := []struct {
+		name     string
+		theme    string
+		color    string
+		expected widgets.Theme
+	}{
+		{name: "default", expected: widgets.LightTheme},
+		{name: "dark", theme: "dark", expected: widgets.DarkTheme},
+		{name: "unknown", theme: "solarized", expected: widgets.LightTheme},
+		{name: "color", color: "ff00AA", expected: withBar(widgets.LightTheme, "#ff00AA")},
+		{name: "dark with color", theme: "dark", color: "123456", expected: withBar(widgets.DarkTheme, "#123456")},
+		{name: "color with hash", color: "#ff00aa", expected: widgets.LightTheme},
+		{name: "short color", color: "f0a", expected: widgets.LightTheme},
+		{name: "long color", color: "ff00aa0", expected: widgets.LightTheme},
+		{name: "not hex color", color: "gg00aa", expected: widgets.LightTheme},
+		{name: "css injection", color: "000000;}body{display:none", expected: widgets.LightTheme},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, widgets.ParseTheme(test.theme, test.color))
+		})
+	}
+}
+
+func TestWriteCached(t *testing.T) {
+	body := []byte("<svg></svg>")
+
+	first := httptest.NewRecorder()
+	require.NoError(t, widgets.WriteCached(first, httptest.NewRequest(http.MethodGet, "/", nil), "image/svg+xml", body))
+	etag := first.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		status      int
+		body        []byte
+	}{
+		{name: "no cached version", status: http.StatusOK, body: body},
+		{name: "same version", ifNoneMatch: etag, status: http.StatusNotModified},
+		{name: "stale version", ifNoneMatch: `"stale"`, status: http.StatusOK, body: body},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			if test.ifNoneMatch != "" {
+				request.Header.Set("If-None-Match", test.ifNoneMatch)
+			}
+
+			recorder := httptest.NewRecorder()
+			require.NoError(t, widgets.WriteCached(recorder, request, "image/svg+xml", body))
+
+			assert.Equal(t, test.status, recorder.Code)
+			assert.Equal(t, etag, recorder.Header().Get("ETag"))
+			assert.Equal(t, "public, max-age=300", recorder.Header().Get("Cache-Control"))
+			assert.Equal(t, test.body, recorder.Body.Bytes())
+		})
+	}
+}
+
+// withBar returns theme with provided bar color.
+func withBar(theme widgets.Theme, bar string) widgets.Theme {
+	theme.Bar = bar
+	return theme
+}
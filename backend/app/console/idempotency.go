@@ -0,0 +1,142 @@
+package console
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"one-help/app/console/controllers/common"
+	"one-help/app/idempotency"
+	"one-help/app/users/credentials"
+	"one-help/internal/logger"
+)
+
+const (
+	// idempotencyKeyHeader is the request header with the client generated key of the request.
+	idempotencyKeyHeader = "Idempotency-Key"
+	// idempotentReplayedHeader marks response replayed for the repeated request.
+	idempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// Idempotent returns middleware that replays stored response to repeated authorized POST requests with the same
+// Idempotency-Key header. Responses with server errors are not stored, so such requests can be retried with the same key.
+// Bodies of such requests larger than configured limit are rejected before they are read.
+func Idempotent(log logger.Logger, service *idempotency.Service) mux.MiddlewareFunc {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			key := r.Header.Get(idempotencyKeyHeader)
+			if r.Method != http.MethodPost || key == "" {
+				handler.ServeHTTP(w, r)
+				return
+			}
+
+			// INFO: Caller creds.
+			creds, err := credentials.GetFromContext(ctx)
+			if err != nil {
+				common.NewErrResponse(http.StatusUnauthorized, err).Serve(log, Error, w)
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, service.MaxBodySize()))
+			if err != nil {
+				var maxBytesErr *http.MaxBytesError
+				if errors.As(err, &maxBytesErr) {
+					common.NewErrResponse(http.StatusRequestEntityTooLarge, errors.New("request body is too large")).Serve(log, Error, w)
+					return
+				}
+
+				common.NewErrResponse(http.StatusBadRequest, errors.New("failed to read request body")).Serve(log, Error, w)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			fingerprint := idempotency.Fingerprint(r.Method, r.URL.Path, body)
+			record, err := service.Begin(ctx, creds.UserID, key, fingerprint)
+			if err != nil {
+				switch {
+				case errors.Is(err, idempotency.ErrKeyReused):
+					common.NewErrResponse(http.StatusConflict, idempotency.ErrKeyReused).Serve(log, Error, w)
+				case errors.Is(err, idempotency.ErrInProgress):
+					common.NewErrResponse(http.StatusConflict, idempotency.ErrInProgress).Serve(log, Error, w)
+				case idempotency.ParamsError.Has(err):
+					common.NewErrResponse(http.StatusBadRequest, errors.Unwrap(err)).Serve(log, Error, w)
+				default:
+					log.Error("failed to begin idempotent request", Error.Wrap(err))
+					common.NewErrResponse(http.StatusInternalServerError, errors.New("failed to check idempotency key")).Serve(log, Error, w)
+				}
+				return
+			}
+
+			if record.Completed {
+				w.Header().Set("Content-Type", record.ContentType)
+				w.Header().Set(idempotentReplayedHeader, "true")
+				w.WriteHeader(record.Status)
+				if _, err = w.Write(record.Body); err != nil {
+					log.Error("failed to replay idempotent response", Error.Wrap(err))
+				}
+				return
+			}
+
+			// INFO: request context might be canceled by the client, the outcome is saved anyway.
+			release := func() {
+				if err := service.Release(context.WithoutCancel(ctx), record); err != nil {
+					log.Error("failed to release idempotency key", Error.Wrap(err))
+				}
+			}
+
+			// INFO: panicked request is not completed, so its key is freed for retry before the panic goes on.
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					release()
+					panic(recovered)
+				}
+			}()
+
+			recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+			handler.ServeHTTP(recorder, r)
+
+			if recorder.status >= http.StatusInternalServerError {
+				release()
+				return
+			}
+
+			err = service.Finish(context.WithoutCancel(ctx), record, recorder.status, w.Header().Get("Content-Type"), recorder.body.Bytes())
+			if err != nil {
+				log.Error("failed to store idempotent response", Error.Wrap(err))
+				release()
+			}
+		})
+	}
+}
+
+// responseRecorder passes response through, keeping its status and body.
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+// WriteHeader keeps the status and sends it.
+func (recorder *responseRecorder) WriteHeader(status int) {
+	if !recorder.wroteHeader {
+		recorder.status = status
+		recorder.wroteHeader = true
+	}
+
+	recorder.ResponseWriter.WriteHeader(status)
+}
+
+// Write keeps the body part and sends it.
+func (recorder *responseRecorder) Write(data []byte) (int, error) {
+	recorder.wroteHeader = true
+	recorder.body.Write(data)
+
+	return recorder.ResponseWriter.Write(data)
+}
@@ -0,0 +1,224 @@
+package console_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app/console"
+	"one-help/app/idempotency"
+	"one-help/app/users/credentials"
+	"one-help/internal/logger"
+)
+
+func TestIdempotent(t *testing.T) {
+	userID := uuid.New()
+
+	// INFO: handler answers with the status from the request body and counts processed requests.
+	var calls int
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		switch string(body) {
+		case "panic":
+			panic("handler failed")
+		case "error":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"` + uuid.NewString() + `"}`))
+		}
+	})
+
+	newRequest := func(key, body string) *http.Request {
+		request := httptest.NewRequest(http.MethodPost, "/fundraises", strings.NewReader(body))
+		request.Header.Set("Idempotency-Key", key)
+		return request.WithContext(credentials.SetIntoContext(request.Context(), &credentials.Credentials{UserID: userID}))
+	}
+
+	setup := func() (http.Handler, *records, *errorLog) {
+		calls = 0
+		db, log := &records{stored: make(map[string]idempotency.Record)}, &errorLog{}
+		service := idempotency.NewService(log, idempotency.Config{Window: time.Hour, MaxBodySize: 16}, db)
+		return console.Idempotent(log, service)(handler), db, log
+	}
+
+	t.Run("replay", func(t *testing.T) {
+		middleware, _, log := setup()
+
+		first := httptest.NewRecorder()
+		middleware.ServeHTTP(first, newRequest("key", "create"))
+		require.Equal(t, http.StatusCreated, first.Code)
+		assert.Empty(t, first.Header().Get("Idempotent-Replayed"))
+
+		replayed := httptest.NewRecorder()
+		middleware.ServeHTTP(replayed, newRequest("key", "create"))
+		assert.Equal(t, http.StatusCreated, replayed.Code)
+		assert.Equal(t, "true", replayed.Header().Get("Idempotent-Replayed"))
+		assert.Equal(t, "application/json", replayed.Header().Get("Content-Type"))
+		assert.Equal(t, first.Body.String(), replayed.Body.String())
+		assert.Equal(t, 1, calls)
+
+		// INFO: requests without key or not POST are not affected.
+		recorder := httptest.NewRecorder()
+		middleware.ServeHTTP(recorder, newRequest("", "create"))
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+		assert.Equal(t, 2, calls)
+		assert.Empty(t, log.errors)
+	})
+
+	t.Run("conflicts", func(t *testing.T) {
+		middleware, db, log := setup()
+
+		middleware.ServeHTTP(httptest.NewRecorder(), newRequest("key", "create"))
+
+		tests := []struct {
+			name   string
+			key    string
+			body   string
+			status int
+		}{
+			{name: "key reused for different request", key: "key", body: "update", status: http.StatusConflict},
+			{name: "request in progress", key: "in progress", body: "create", status: http.StatusConflict},
+			{name: "too long key", key: strings.Repeat("k", idempotency.MaxKeyLength+1), body: "create", status: http.StatusBadRequest},
+			{name: "too large body", key: "large", body: strings.Repeat("b", 17), status: http.StatusRequestEntityTooLarge},
+		}
+
+		db.stored["in progress"] = idempotency.Record{
+			UserID:      userID,
+			Key:         "in progress",
+			Fingerprint: idempotency.Fingerprint(http.MethodPost, "/fundraises", []byte("create")),
+			ExpiresAt:   time.Now().Add(time.Hour),
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				recorder := httptest.NewRecorder()
+				middleware.ServeHTTP(recorder, newRequest(test.key, test.body))
+				assert.Equal(t, test.status, recorder.Code)
+			})
+		}
+
+		assert.Equal(t, 1, calls)
+		// INFO: client misuse is not logged as an error.
+		assert.Empty(t, log.errors)
+	})
+
+	t.Run("released on server error", func(t *testing.T) {
+		middleware, db, _ := setup()
+
+		recorder := httptest.NewRecorder()
+		middleware.ServeHTTP(recorder, newRequest("key", "error"))
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.Empty(t, db.stored)
+
+		// INFO: retried request with the same key is processed again.
+		middleware.ServeHTTP(httptest.NewRecorder(), newRequest("key", "error"))
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("released on panic", func(t *testing.T) {
+		middleware, db, _ := setup()
+
+		assert.PanicsWithValue(t, "handler failed", func() {
+			middleware.ServeHTTP(httptest.NewRecorder(), newRequest("key", "panic"))
+		})
+		assert.Empty(t, db.stored)
+	})
+
+	t.Run("released when response is not stored", func(t *testing.T) {
+		middleware, db, log := setup()
+		db.completeErr = errors.New("connection lost")
+
+		recorder := httptest.NewRecorder()
+		middleware.ServeHTTP(recorder, newRequest("key", "create"))
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+		assert.Empty(t, db.stored)
+		assert.Len(t, log.errors, 1)
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		middleware, db, log := setup()
+		db.reserveErr = errors.New("connection lost")
+
+		recorder := httptest.NewRecorder()
+		middleware.ServeHTTP(recorder, newRequest("key", "create"))
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.Zero(t, calls)
+		assert.Len(t, log.errors, 1)
+	})
+}
+
+// records is an in-memory idempotency records db of a single user.
+type records struct {
+	mu          sync.Mutex
+	stored      map[string]idempotency.Record
+	reserveErr  error
+	completeErr error
+}
+
+// Reserve inserts record if there is no record with the key.
+func (db *records) Reserve(ctx context.Context, record idempotency.Record) (idempotency.Record, bool, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.reserveErr != nil {
+		return idempotency.Record{}, false, db.reserveErr
+	}
+
+	if stored, ok := db.stored[record.Key]; ok {
+		return stored, false, nil
+	}
+
+	db.stored[record.Key] = record
+	return record, true, nil
+}
+
+// Complete stores the response of the record.
+func (db *records) Complete(ctx context.Context, record idempotency.Record) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.completeErr != nil {
+		return db.completeErr
+	}
+
+	db.stored[record.Key] = record
+	return nil
+}
+
+// Delete deletes record by key.
+func (db *records) Delete(ctx context.Context, userID uuid.UUID, key string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	delete(db.stored, key)
+	return nil
+}
+
+// DeleteExpired deletes records expired before provided time.
+func (db *records) DeleteExpired(ctx context.Context, before time.Time) error {
+	return nil
+}
+
+// errorLog is a logger that keeps logged errors.
+type errorLog struct {
+	logger.Logger
+	errors []error
+}
+
+// Error keeps the error.
+func (log *errorLog) Error(msg string, err error) {
+	log.errors = append(log.errors, err)
+}
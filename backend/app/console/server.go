@@ -13,15 +13,25 @@ import (
 	"github.com/zeebo/errs"
 	"golang.org/x/sync/errgroup"
 
+	"one-help/app/comments"
+	commentscontroller "one-help/app/console/controllers/comments"
 	"one-help/app/console/controllers/common"
 	eventscontroller "one-help/app/console/controllers/events"
 	fundraisescontroller "one-help/app/console/controllers/fundraises"
 	infocontroller "one-help/app/console/controllers/info"
 	rafflescontroller "one-help/app/console/controllers/raffles"
 	userscontroller "one-help/app/console/controllers/users"
+	webhookscontroller "one-help/app/console/controllers/webhooks"
+	widgetscontroller "one-help/app/console/controllers/widgets"
 	_ "one-help/app/console/docs"
+	"one-help/app/donations/plans"
 	"one-help/app/events"
 	"one-help/app/fundraises"
+	"one-help/app/fundraises/payouts"
+	"one-help/app/idempotency"
+	"one-help/app/payments/refunds"
+	"one-help/app/payments/statements"
+	"one-help/app/payments/webhooks"
 	"one-help/app/raffles"
 	"one-help/app/users"
 	"one-help/internal/logger"
@@ -54,8 +64,16 @@ type Server struct {
 
 	users      *users.Service
 	fundraises *fundraises.Service
+	plans      *plans.Service
+	refunds    *refunds.Service
+	payouts    *payouts.Service
+	statements *statements.Service
+	webhooks   *webhooks.Service
 	events     *events.Service
 	raffles    *raffles.Service
+	comments   *comments.Service
+
+	idempotency *idempotency.Service
 }
 
 // NewServer is a constructor for console web server.
@@ -69,8 +87,15 @@ func NewServer(
 	listener net.Listener,
 	users *users.Service,
 	fundraises *fundraises.Service,
+	plans *plans.Service,
+	refunds *refunds.Service,
+	payouts *payouts.Service,
+	statements *statements.Service,
+	webhooks *webhooks.Service,
 	events *events.Service,
 	raffles *raffles.Service,
+	comments *comments.Service,
+	idempotency *idempotency.Service,
 ) *Server {
 	server := &Server{
 		log:        log,
@@ -78,15 +103,28 @@ func NewServer(
 		listener:   listener,
 		users:      users,
 		fundraises: fundraises,
+		plans:      plans,
+		refunds:    refunds,
+		payouts:    payouts,
+		statements: statements,
+		webhooks:   webhooks,
 		events:     events,
 		raffles:    raffles,
+		comments:   comments,
+
+		idempotency: idempotency,
 	}
 
 	infoController := infocontroller.NewInfo(log)
 	usersController := userscontroller.NewUsers(log, users)
-	fundraisesController := fundraisescontroller.NewFundraises(log, fundraises, config.FrontEndPaymentRedirectUrl)
+	fundraisesController := fundraisescontroller.NewFundraises(log, fundraises, plans, refunds, payouts, statements, config.FrontEndPaymentRedirectUrl)
 	eventsController := eventscontroller.NewEvents(log, events, fundraises)
 	rafflesController := rafflescontroller.NewRaffles(log, raffles, fundraises)
+	commentsController := commentscontroller.NewComments(log, comments)
+	widgetsController := widgetscontroller.NewWidgets(log, fundraises)
+	webhooksController := webhookscontroller.NewWebhooks(log, webhooks)
+
+	idempotent := Idempotent(log, idempotency)
 
 	router := mux.NewRouter()
 	apiRouter := router.PathPrefix("/api/v0").Subrouter()
@@ -109,41 +147,143 @@ func NewServer(
 	usersRouter.HandleFunc("/", usersController.Get).Methods(http.MethodGet, http.MethodOptions)
 	usersRouter.HandleFunc("/", usersController.Update).Methods(http.MethodPatch, http.MethodOptions)
 	usersRouter.HandleFunc("/change-password", usersController.ChangePassword).Methods(http.MethodPatch, http.MethodOptions)
+	usersRouter.HandleFunc("/me/donations", fundraisesController.DonationHistory).Methods(http.MethodGet, http.MethodOptions)
+	usersRouter.HandleFunc("/me/bank-details", fundraisesController.BankDetails).Methods(http.MethodGet, http.MethodOptions)
+	usersRouter.HandleFunc("/me/bank-details", fundraisesController.SaveBankDetails).Methods(http.MethodPut, http.MethodOptions)
 	usersRouter.HandleFunc("/{id}", usersController.GetByID).Methods(http.MethodGet, http.MethodOptions)
+	usersRouter.HandleFunc("/{id}/trusted", usersController.Trust).Methods(http.MethodPut, http.MethodOptions)
+	usersRouter.HandleFunc("/{id}/trusted", usersController.Distrust).Methods(http.MethodDelete, http.MethodOptions)
 	usersRouter.HandleFunc("/raffle-participants/{id}", usersController.GetRaffleParticipants).Methods(http.MethodGet, http.MethodOptions)
 
+	// INFO: recent supporters are public, identities of anonymous donors are hidden by the service.
+	supportersRouter := apiRouter.PathPrefix("/fundraises/{id}/supporters").Subrouter()
+	supportersRouter.Use(server.jsonResponse)
+	supportersRouter.HandleFunc("", fundraisesController.RecentSupporters).Methods(http.MethodGet, http.MethodOptions)
+
+	// INFO: leaderboards are public, anonymous donations and private donors are excluded by the service.
+	leaderboardRouter := apiRouter.PathPrefix("/fundraises/{id}/leaderboard").Subrouter()
+	leaderboardRouter.Use(server.jsonResponse)
+	leaderboardRouter.HandleFunc("", fundraisesController.Leaderboard).Methods(http.MethodGet, http.MethodOptions)
+
+	platformLeaderboardRouter := apiRouter.PathPrefix("/leaderboard").Subrouter()
+	platformLeaderboardRouter.Use(server.jsonResponse)
+	platformLeaderboardRouter.HandleFunc("", fundraisesController.PlatformLeaderboard).Methods(http.MethodGet, http.MethodOptions)
+
 	fundraisesRouter := apiRouter.PathPrefix("/fundraises").Subrouter()
 	fundraisesRouter.Use(server.jsonResponse)
 	fundraisesRouter.Use(server.withAuthMiddleware)
+	fundraisesRouter.Use(idempotent)
 	fundraisesRouter.StrictSlash(true)
 	fundraisesRouter.HandleFunc("/", fundraisesController.List).Methods(http.MethodGet, http.MethodOptions)
 	fundraisesRouter.HandleFunc("/my", fundraisesController.ListMy).Methods(http.MethodGet, http.MethodOptions)
+	fundraisesRouter.HandleFunc("/transfers/my", fundraisesController.ListMyTransfers).Methods(http.MethodGet, http.MethodOptions)
+	fundraisesRouter.HandleFunc("/donations/{id}/refunds", fundraisesController.ListRefunds).Methods(http.MethodGet, http.MethodOptions)
+	fundraisesRouter.HandleFunc("/donations/{id}/refunds", fundraisesController.Refund).Methods(http.MethodPost, http.MethodOptions)
+	fundraisesRouter.HandleFunc("/donations/{id}/receipt", fundraisesController.Receipt).Methods(http.MethodGet, http.MethodOptions)
+	fundraisesRouter.HandleFunc("/statements/lines/{id}/confirm", fundraisesController.ConfirmStatementLine).Methods(http.MethodPost, http.MethodOptions)
+	fundraisesRouter.HandleFunc("/statements/lines/{id}/ignore", fundraisesController.IgnoreStatementLine).Methods(http.MethodPost, http.MethodOptions)
 	fundraisesRouter.HandleFunc("/{id}", fundraisesController.GetByID).Methods(http.MethodGet, http.MethodOptions)
 	fundraisesRouter.HandleFunc("/", fundraisesController.Create).Methods(http.MethodPost, http.MethodOptions)
 	fundraisesRouter.HandleFunc("/{id}/donate", fundraisesController.Donate).Methods(http.MethodPost, http.MethodOptions)
+	fundraisesRouter.HandleFunc("/{id}/analytics", fundraisesController.Analytics).Methods(http.MethodGet, http.MethodOptions)
+	fundraisesRouter.HandleFunc("/{id}/donations", fundraisesController.ListDonations).Methods(http.MethodGet, http.MethodOptions)
+	fundraisesRouter.HandleFunc("/{id}/resubmit", fundraisesController.Resubmit).Methods(http.MethodPost, http.MethodOptions)
+	fundraisesRouter.HandleFunc("/{id}/reviews", fundraisesController.ListReviews).Methods(http.MethodGet, http.MethodOptions)
+	fundraisesRouter.HandleFunc("/{id}/transfer", fundraisesController.Transfer).Methods(http.MethodPost, http.MethodOptions)
+	fundraisesRouter.HandleFunc("/{id}/transfers", fundraisesController.ListTransfers).Methods(http.MethodGet, http.MethodOptions)
+	fundraisesRouter.HandleFunc("/{id}/pledges", fundraisesController.ListPledges).Methods(http.MethodGet, http.MethodOptions)
+	fundraisesRouter.HandleFunc("/{id}/pledges", fundraisesController.CreatePledge).Methods(http.MethodPost, http.MethodOptions)
+	fundraisesRouter.HandleFunc("/{id}/payouts", fundraisesController.ListPayouts).Methods(http.MethodGet, http.MethodOptions)
+	fundraisesRouter.HandleFunc("/{id}/payouts", fundraisesController.RequestPayout).Methods(http.MethodPost, http.MethodOptions)
+	fundraisesRouter.HandleFunc("/{id}/offline-donations", fundraisesController.ListOfflineDonations).Methods(http.MethodGet, http.MethodOptions)
+	fundraisesRouter.HandleFunc("/{id}/offline-donations", fundraisesController.RecordOfflineDonation).Methods(http.MethodPost, http.MethodOptions)
+	fundraisesRouter.HandleFunc("/{id}/statements", fundraisesController.ImportStatement).Methods(http.MethodPost, http.MethodOptions)
+	fundraisesRouter.HandleFunc("/{id}/statements/lines", fundraisesController.ListStatementLines).Methods(http.MethodGet, http.MethodOptions)
+	fundraisesRouter.HandleFunc("/{id}/comments", commentsController.ListFundraiseComments).Methods(http.MethodGet, http.MethodOptions)
+	fundraisesRouter.HandleFunc("/{id}/comments", commentsController.CreateFundraiseComment).Methods(http.MethodPost, http.MethodOptions)
+
+	moderationRouter := apiRouter.PathPrefix("/moderation").Subrouter()
+	moderationRouter.Use(server.jsonResponse)
+	moderationRouter.Use(server.withAuthMiddleware)
+	moderationRouter.Use(idempotent)
+	moderationRouter.StrictSlash(true)
+	moderationRouter.HandleFunc("/fundraises", fundraisesController.ReviewQueue).Methods(http.MethodGet, http.MethodOptions)
+	moderationRouter.HandleFunc("/fundraises/{id}/approve", fundraisesController.Approve).Methods(http.MethodPost, http.MethodOptions)
+	moderationRouter.HandleFunc("/fundraises/{id}/reject", fundraisesController.Reject).Methods(http.MethodPost, http.MethodOptions)
+	moderationRouter.HandleFunc("/reconciliation", fundraisesController.Reconciliation).Methods(http.MethodGet, http.MethodOptions)
+	moderationRouter.HandleFunc("/refunds", fundraisesController.RefundQueue).Methods(http.MethodGet, http.MethodOptions)
+	moderationRouter.HandleFunc("/refunds/{id}/approve", fundraisesController.ApproveRefund).Methods(http.MethodPost, http.MethodOptions)
+	moderationRouter.HandleFunc("/refunds/{id}/reject", fundraisesController.RejectRefund).Methods(http.MethodPost, http.MethodOptions)
+	moderationRouter.HandleFunc("/payouts", fundraisesController.PayoutQueue).Methods(http.MethodGet, http.MethodOptions)
+	moderationRouter.HandleFunc("/payouts/{id}/approve", fundraisesController.ApprovePayout).Methods(http.MethodPost, http.MethodOptions)
+	moderationRouter.HandleFunc("/payouts/{id}/reject", fundraisesController.RejectPayout).Methods(http.MethodPost, http.MethodOptions)
 
 	donationsRouter := apiRouter.PathPrefix("/fundraises/donations").Subrouter()
 	donationsRouter.Use(server.jsonResponse)
 	donationsRouter.StrictSlash(true)
 	donationsRouter.HandleFunc("/{id}", fundraisesController.FinishDonation).Methods(http.MethodGet, http.MethodOptions)
 
+	plansCheckoutRouter := apiRouter.PathPrefix("/plans/checkout").Subrouter()
+	plansCheckoutRouter.HandleFunc("/{id}", fundraisesController.FinishPlanCheckout).Methods(http.MethodGet, http.MethodOptions)
+
+	plansRouter := apiRouter.PathPrefix("/plans").Subrouter()
+	plansRouter.Use(server.jsonResponse)
+	plansRouter.Use(server.withAuthMiddleware)
+	plansRouter.Use(idempotent)
+	plansRouter.StrictSlash(true)
+	plansRouter.HandleFunc("/", fundraisesController.ListPlans).Methods(http.MethodGet, http.MethodOptions)
+	plansRouter.HandleFunc("/", fundraisesController.CreatePlan).Methods(http.MethodPost, http.MethodOptions)
+	plansRouter.HandleFunc("/{id}", fundraisesController.GetPlan).Methods(http.MethodGet, http.MethodOptions)
+	plansRouter.HandleFunc("/{id}", fundraisesController.ChangePlan).Methods(http.MethodPatch, http.MethodOptions)
+	plansRouter.HandleFunc("/{id}", fundraisesController.CancelPlan).Methods(http.MethodDelete, http.MethodOptions)
+	plansRouter.HandleFunc("/{id}/pause", fundraisesController.PausePlan).Methods(http.MethodPost, http.MethodOptions)
+	plansRouter.HandleFunc("/{id}/resume", fundraisesController.ResumePlan).Methods(http.MethodPost, http.MethodOptions)
+
 	eventsRouter := apiRouter.PathPrefix("/events").Subrouter()
 	eventsRouter.Use(server.jsonResponse)
 	eventsRouter.Use(server.withAuthMiddleware)
+	eventsRouter.Use(idempotent)
 	eventsRouter.StrictSlash(true)
 	eventsRouter.HandleFunc("/", eventsController.List).Methods(http.MethodGet, http.MethodOptions)
 	eventsRouter.HandleFunc("/{id}", eventsController.GetByID).Methods(http.MethodGet, http.MethodOptions)
 	eventsRouter.HandleFunc("/", eventsController.Create).Methods(http.MethodPost, http.MethodOptions)
 	eventsRouter.HandleFunc("/{id}/enroll", eventsController.Enroll).Methods(http.MethodPost, http.MethodOptions)
+	eventsRouter.HandleFunc("/{id}/comments", commentsController.ListEventComments).Methods(http.MethodGet, http.MethodOptions)
+	eventsRouter.HandleFunc("/{id}/comments", commentsController.CreateEventComment).Methods(http.MethodPost, http.MethodOptions)
 
 	rafflesRouter := apiRouter.PathPrefix("/raffles").Subrouter()
 	rafflesRouter.Use(server.jsonResponse)
 	rafflesRouter.Use(server.withAuthMiddleware)
+	rafflesRouter.Use(idempotent)
 	rafflesRouter.StrictSlash(true)
 	rafflesRouter.HandleFunc("/", rafflesController.List).Methods(http.MethodGet, http.MethodOptions)
 	rafflesRouter.HandleFunc("/{id}", rafflesController.GetByID).Methods(http.MethodGet, http.MethodOptions)
 	rafflesRouter.HandleFunc("/", rafflesController.Create).Methods(http.MethodPost, http.MethodOptions)
 
+	commentsRouter := apiRouter.PathPrefix("/comments").Subrouter()
+	commentsRouter.Use(server.jsonResponse)
+	commentsRouter.Use(server.withAuthMiddleware)
+	commentsRouter.Use(idempotent)
+	commentsRouter.StrictSlash(true)
+	commentsRouter.HandleFunc("/{id}", commentsController.Edit).Methods(http.MethodPatch, http.MethodOptions)
+	commentsRouter.HandleFunc("/{id}", commentsController.Delete).Methods(http.MethodDelete, http.MethodOptions)
+	commentsRouter.HandleFunc("/{id}/pin", commentsController.Pin).Methods(http.MethodPost, http.MethodOptions)
+	commentsRouter.HandleFunc("/{id}/pin", commentsController.Unpin).Methods(http.MethodDelete, http.MethodOptions)
+	commentsRouter.HandleFunc("/{id}/hide", commentsController.Hide).Methods(http.MethodPost, http.MethodOptions)
+	commentsRouter.HandleFunc("/{id}/hide", commentsController.Unhide).Methods(http.MethodDelete, http.MethodOptions)
+	commentsRouter.HandleFunc("/{id}/reactions/{reaction}", commentsController.AddReaction).Methods(http.MethodPut, http.MethodOptions)
+	commentsRouter.HandleFunc("/{id}/reactions/{reaction}", commentsController.RemoveReaction).Methods(http.MethodDelete, http.MethodOptions)
+
+	// INFO: widgets are public and embedded into third-party pages, so they are served without auth and json content type.
+	widgetsRouter := apiRouter.PathPrefix("/widgets").Subrouter()
+	widgetsRouter.HandleFunc("/fundraises/{id}/badge.svg", widgetsController.Badge).Methods(http.MethodGet, http.MethodOptions)
+	widgetsRouter.HandleFunc("/fundraises/{id}/card.png", widgetsController.Card).Methods(http.MethodGet, http.MethodOptions)
+	widgetsRouter.HandleFunc("/fundraises/{id}/widget", widgetsController.Widget).Methods(http.MethodGet, http.MethodOptions)
+
+	// INFO: webhooks are authenticated by provider's payload signature.
+	webhooksRouter := apiRouter.PathPrefix("/webhooks").Subrouter()
+	webhooksRouter.HandleFunc("/{provider}", webhooksController.Payment).Methods(http.MethodPost)
+
 	apiRouter.PathPrefix("/docs/swagger/").Handler(httpswagger.WrapHandler)
 
 	server.server = http.Server{
@@ -0,0 +1,257 @@
+package currencies
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/zeebo/errs"
+)
+
+// ErrInvalidAmount indicates that amount could not be parsed.
+var ErrInvalidAmount = errs.Class("invalid amount")
+
+// Amount is an exact money amount, stored as integer number of minor units of its currency.
+// Amount does not carry its currency, every supported currency has two decimal places. Money pairs amount with
+// its currency where amounts in different currencies meet, e.g. payment provider events and ledger postings.
+//
+// Amount is encoded to JSON as a number with exactly two decimal places, e.g. 150.50, so clients that
+// treat amounts as numbers keep working. Numbers and decimal strings are both accepted on decoding.
+type Amount struct {
+	units int64
+}
+
+// ensures that Amount is stored in database as exact decimal.
+var (
+	_ driver.Valuer = Amount{}
+	_ sql.Scanner   = (*Amount)(nil)
+)
+
+// Zero is a zero amount.
+var Zero = Amount{}
+
+// MinorUnits returns amount of integer number of minor units, e.g. cents.
+func MinorUnits(units int64) Amount {
+	return Amount{units: units}
+}
+
+// Major returns amount of integer number of major units.
+func Major(units int64) Amount {
+	return Amount{units: units * minorUnitsPerMajor}
+}
+
+// FromFloat converts floating point amount, rounding it to minor units half away from zero.
+// Used only at boundaries that provide floating point numbers.
+func FromFloat(amount float64) Amount {
+	return Amount{units: int64(math.Round(amount * minorUnitsPerMajor))}
+}
+
+// ParseAmount parses decimal amount, e.g. "-1500.5". Digits beyond minor units are rounded half away from zero.
+func ParseAmount(value string) (Amount, error) {
+	value = strings.TrimSpace(value)
+	digits, negative := strings.CutPrefix(value, "-")
+	if !negative {
+		digits = strings.TrimPrefix(digits, "+")
+	}
+
+	whole, fraction, _ := strings.Cut(digits, ".")
+	if whole == "" && fraction == "" || !isDigits(whole) || !isDigits(fraction) {
+		return Amount{}, ErrInvalidAmount.New("%q", value)
+	}
+
+	units := int64(0)
+	if whole != "" {
+		major, err := strconv.ParseInt(whole, 10, 64)
+		if err != nil || major > math.MaxInt64/minorUnitsPerMajor-1 {
+			return Amount{}, ErrInvalidAmount.New("%q is out of range", value)
+		}
+		units = major * minorUnitsPerMajor
+	}
+
+	fraction += "000"
+	minor, _ := strconv.ParseInt(fraction[:2], 10, 64)
+	units += minor
+	if fraction[2] >= '5' {
+		units++
+	}
+
+	if negative {
+		units = -units
+	}
+
+	return Amount{units: units}, nil
+}
+
+// isDigits returns true if value consists of decimal digits only.
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MinorUnits returns integer number of minor units of the amount.
+func (a Amount) MinorUnits() int64 {
+	return a.units
+}
+
+// Float64 returns floating point approximation of the amount, used for ratios and rendering only.
+func (a Amount) Float64() float64 {
+	return float64(a.units) / minorUnitsPerMajor
+}
+
+// Add returns sum of the amounts.
+func (a Amount) Add(b Amount) Amount {
+	return Amount{units: a.units + b.units}
+}
+
+// Sub returns difference of the amounts.
+func (a Amount) Sub(b Amount) Amount {
+	return Amount{units: a.units - b.units}
+}
+
+// Neg returns negated amount.
+func (a Amount) Neg() Amount {
+	return Amount{units: -a.units}
+}
+
+// Mul returns amount multiplied by the factor, e.g. exchange rate or ratio, rounded half away from zero.
+func (a Amount) Mul(factor float64) Amount {
+	return Amount{units: int64(math.Round(float64(a.units) * factor))}
+}
+
+// Cmp compares amounts and returns -1, 0 or +1 if the amount is less, equal or greater than b.
+func (a Amount) Cmp(b Amount) int {
+	switch {
+	case a.units < b.units:
+		return -1
+	case a.units > b.units:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Sign returns -1, 0 or +1 for negative, zero or positive amount.
+func (a Amount) Sign() int {
+	return a.Cmp(Zero)
+}
+
+// IsZero returns true if amount is zero.
+func (a Amount) IsZero() bool {
+	return a.units == 0
+}
+
+// IsPositive returns true if amount is greater than zero.
+func (a Amount) IsPositive() bool {
+	return a.units > 0
+}
+
+// Min returns the lesser of the amounts.
+func (a Amount) Min(b Amount) Amount {
+	if b.units < a.units {
+		return b
+	}
+	return a
+}
+
+// Max returns the greater of the amounts.
+func (a Amount) Max(b Amount) Amount {
+	if b.units > a.units {
+		return b
+	}
+	return a
+}
+
+// String returns decimal representation of the amount with two decimal places, e.g. "-1500.50".
+func (a Amount) String() string {
+	units := a.units
+	sign := ""
+	if units < 0 {
+		sign, units = "-", -units
+	}
+
+	minor := strconv.FormatInt(units%minorUnitsPerMajor, 10)
+	if len(minor) < 2 {
+		minor = "0" + minor
+	}
+
+	return sign + strconv.FormatInt(units/minorUnitsPerMajor, 10) + "." + minor
+}
+
+// MarshalJSON encodes amount as a JSON number with two decimal places.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON decodes amount from JSON number or decimal string, null leaves amount unchanged.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	if unquoted, err := strconv.Unquote(string(data)); err == nil {
+		data = []byte(unquoted)
+	}
+
+	return a.UnmarshalText(data)
+}
+
+// MarshalText encodes amount as decimal text.
+func (a Amount) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText decodes amount from decimal text, exponent notation of JSON numbers is accepted as well.
+func (a *Amount) UnmarshalText(data []byte) error {
+	text := string(data)
+	if !strings.ContainsAny(text, "eE") {
+		amount, err := ParseAmount(text)
+		if err != nil {
+			return err
+		}
+
+		*a = amount
+		return nil
+	}
+
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil || math.IsInf(value, 0) || math.IsNaN(value) {
+		return ErrInvalidAmount.New("%q", text)
+	}
+
+	*a = FromFloat(value)
+	return nil
+}
+
+// Value returns amount as decimal text, stored exactly in NUMERIC columns.
+func (a Amount) Value() (driver.Value, error) {
+	return a.String(), nil
+}
+
+// Scan reads amount from NUMERIC column or aggregate, rounding it to minor units.
+func (a *Amount) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*a = Zero
+		return nil
+	case []byte:
+		return a.UnmarshalText(value)
+	case string:
+		return a.UnmarshalText([]byte(value))
+	case int64:
+		*a = Major(value)
+		return nil
+	case float64:
+		*a = FromFloat(value)
+		return nil
+	default:
+		return ErrInvalidAmount.New("unsupported type %T", src)
+	}
+}
@@ -0,0 +1,51 @@
+package currencies
+
+import (
+	"time"
+)
+
+const (
+	// UAH defines ukrainian hryvnia currency code.
+	UAH string = "UAH"
+	// EUR defines euro currency code.
+	EUR string = "EUR"
+	// USD defines united states dollar currency code.
+	USD string = "USD"
+	// PLN defines polish zloty currency code.
+	PLN string = "PLN"
+)
+
+// Default defines currency used when none is specified.
+const Default = UAH
+
+// IsSupported returns true if currency code is supported by the platform.
+func IsSupported(code string) bool {
+	switch code {
+	case UAH, EUR, USD, PLN:
+		return true
+	default:
+		return false
+	}
+}
+
+// minorUnitsPerMajor defines number of minor units, e.g. cents, in the major unit of all supported currencies.
+const minorUnitsPerMajor = 100
+
+// Rate describes exchange rate snapshot between two currencies.
+// Amount in From currency multiplied by Value gives amount in To currency.
+type Rate struct {
+	From  string
+	To    string
+	Value float64
+	Date  time.Time
+}
+
+// Convert returns amount converted with the rate, rounded to minor units.
+func (r Rate) Convert(amount Amount) Amount {
+	return amount.Mul(r.Value)
+}
+
+// Identity returns rate of the currency to itself.
+func Identity(code string, date time.Time) Rate {
+	return Rate{From: code, To: code, Value: 1, Date: date}
+}
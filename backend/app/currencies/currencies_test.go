@@ -0,0 +1,153 @@
+package currencies_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app/currencies"
+)
+
+func TestAmount(t *testing.T) {
+	t.Run("parse", func(t *testing.T) {
+		for value, units := range map[string]int64{
+			"150.5":                   15050,
+			"19.99":                   1999,
+			"-0.5":                    -50,
+			".25":                     25,
+			"100":                     10000,
+			"+7.10":                   710,
+			"0.005":                   1,
+			"-0.005":                  -1,
+			"0.004999":                0,
+			"1500.000000000000000000": 150000,
+		} {
+			amount, err := currencies.ParseAmount(value)
+			require.NoError(t, err, value)
+			assert.Equal(t, units, amount.MinorUnits(), value)
+		}
+
+		for _, value := range []string{"", "-", ".", "1,5", "1.2.3", "1e2", "abc", "99999999999999999999"} {
+			_, err := currencies.ParseAmount(value)
+			require.True(t, currencies.ErrInvalidAmount.Has(err), value)
+		}
+	})
+
+	t.Run("arithmetic", func(t *testing.T) {
+		assert.Equal(t, currencies.MinorUnits(30), currencies.FromFloat(0.1).Add(currencies.FromFloat(0.2)))
+		assert.Equal(t, currencies.MinorUnits(-5), currencies.MinorUnits(10).Sub(currencies.MinorUnits(15)))
+		assert.Equal(t, currencies.MinorUnits(3333), currencies.Major(100).Mul(1./3))
+		assert.Equal(t, 1, currencies.Major(1).Cmp(currencies.MinorUnits(99)))
+		assert.Equal(t, currencies.MinorUnits(99), currencies.Major(1).Min(currencies.MinorUnits(99)))
+		assert.Equal(t, -1, currencies.MinorUnits(-1).Sign())
+		assert.Equal(t, "-1500.05", currencies.MinorUnits(-150005).String())
+		assert.Equal(t, "0.00", currencies.Zero.String())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		type view struct {
+			Amount currencies.Amount `json:"amount"`
+		}
+
+		data, err := json.Marshal(view{Amount: currencies.MinorUnits(15050)})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"amount": 150.5}`, string(data))
+		assert.Equal(t, `{"amount":150.50}`, string(data))
+
+		for payload, units := range map[string]int64{
+			`{"amount": 150.5}`:               15050,
+			`{"amount": "150.50"}`:            15050,
+			`{"amount": 0.30000000000000004}`: 30,
+			`{"amount": 1e2}`:                 10000,
+			`{"amount": null}`:                0,
+			`{}`:                              0,
+		} {
+			var decoded view
+			require.NoError(t, json.Unmarshal([]byte(payload), &decoded), payload)
+			assert.Equal(t, units, decoded.Amount.MinorUnits(), payload)
+		}
+
+		var decoded view
+		require.Error(t, json.Unmarshal([]byte(`{"amount": "ten"}`), &decoded))
+	})
+
+	t.Run("database", func(t *testing.T) {
+		value, err := currencies.MinorUnits(-1999).Value()
+		require.NoError(t, err)
+		assert.Equal(t, "-19.99", value)
+
+		var amount currencies.Amount
+		require.NoError(t, amount.Scan([]byte("1500.500000000000000000")))
+		assert.Equal(t, currencies.MinorUnits(150050), amount)
+
+		require.NoError(t, amount.Scan(nil))
+		assert.True(t, amount.IsZero())
+	})
+}
+
+func TestMoney(t *testing.T) {
+	uah := currencies.NewMoney(currencies.Major(100), currencies.UAH)
+	usd := currencies.NewMoney(currencies.MinorUnits(250), currencies.USD)
+
+	t.Run("arithmetic", func(t *testing.T) {
+		sum, err := uah.Add(currencies.NewMoney(currencies.MinorUnits(50), currencies.UAH))
+		require.NoError(t, err)
+		assert.Equal(t, currencies.NewMoney(currencies.MinorUnits(10050), currencies.UAH), sum)
+
+		difference, err := uah.Sub(sum)
+		require.NoError(t, err)
+		assert.Equal(t, "-0.50 UAH", difference.String())
+		assert.Equal(t, currencies.NewMoney(currencies.MinorUnits(50), currencies.UAH), difference.Neg())
+
+		cmp, err := sum.Cmp(uah)
+		require.NoError(t, err)
+		assert.Equal(t, 1, cmp)
+		assert.True(t, uah.Equal(currencies.NewMoney(currencies.Major(100), currencies.UAH)))
+		assert.False(t, uah.Equal(currencies.NewMoney(currencies.Major(100), currencies.USD)))
+	})
+
+	t.Run("currency mismatch", func(t *testing.T) {
+		_, err := uah.Add(usd)
+		assert.True(t, currencies.ErrCurrencyMismatch.Has(err))
+
+		_, err = uah.Sub(usd)
+		assert.True(t, currencies.ErrCurrencyMismatch.Has(err))
+
+		_, err = uah.Cmp(usd)
+		assert.True(t, currencies.ErrCurrencyMismatch.Has(err))
+
+		_, err = uah.Convert(currencies.Rate{From: currencies.USD, To: currencies.UAH, Value: 41.37})
+		assert.True(t, currencies.ErrCurrencyMismatch.Has(err))
+	})
+
+	t.Run("convert", func(t *testing.T) {
+		converted, err := usd.Convert(currencies.Rate{From: currencies.USD, To: currencies.UAH, Value: 41.37})
+		require.NoError(t, err)
+		assert.Equal(t, currencies.NewMoney(currencies.MinorUnits(10343), currencies.UAH), converted)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		data, err := json.Marshal(currencies.NewMoney(currencies.MinorUnits(15050), currencies.UAH))
+		require.NoError(t, err)
+		assert.Equal(t, `{"amount":150.50,"currency":"UAH"}`, string(data))
+
+		var decoded currencies.Money
+		require.NoError(t, json.Unmarshal([]byte(`{"amount": "150.5", "currency": "EUR"}`), &decoded))
+		assert.Equal(t, currencies.NewMoney(currencies.MinorUnits(15050), currencies.EUR), decoded)
+	})
+}
+
+func TestIdentityProvider(t *testing.T) {
+	ctx := context.Background()
+
+	rate, err := currencies.IdentityProvider{}.Rate(ctx, currencies.EUR, currencies.EUR)
+	require.NoError(t, err)
+	assert.Equal(t, 1., rate.Value)
+	assert.Equal(t, currencies.EUR, rate.To)
+
+	_, err = currencies.IdentityProvider{}.Rate(ctx, currencies.EUR, currencies.UAH)
+	assert.ErrorIs(t, err, currencies.ErrNoRate)
+}
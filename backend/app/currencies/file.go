@@ -0,0 +1,126 @@
+package currencies
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+)
+
+// Error is an error class that indicates about rate provider errors.
+var Error = errs.Class("currency rates")
+
+// ensures that FileProvider implements RateProvider.
+var _ RateProvider = (*FileProvider)(nil)
+
+// Config holds configurable values for currency rates.
+type Config struct {
+	// RatesFile is a path to the rates file, only identity rates are provided if it is not set.
+	RatesFile string `env:"RATES_FILE" envDefault:""`
+}
+
+// ratesFile describes rates file format. Every rate is the price of one currency unit in base currency.
+//
+// Example:
+//
+//	{"base": "UAH", "date": "2026-10-19T00:00:00Z", "rates": {"EUR": 45.2, "USD": 41.5, "PLN": 10.4}}
+type ratesFile struct {
+	Base  string             `json:"base"`
+	Date  time.Time          `json:"date"`
+	Rates map[string]float64 `json:"rates"`
+}
+
+// FileProvider provides exchange rates from the local json file, for offline use.
+// File is re-read when its modification time changes.
+type FileProvider struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	rates   ratesFile
+}
+
+// NewFileProvider is a constructor for FileProvider.
+func NewFileProvider(path string) (*FileProvider, error) {
+	provider := &FileProvider{path: path}
+	if err := provider.reload(); err != nil {
+		return nil, err
+	}
+
+	return provider, nil
+}
+
+// Rate returns exchange rate from one currency to another.
+func (provider *FileProvider) Rate(ctx context.Context, from, to string) (Rate, error) {
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+
+	if err := provider.reload(); err != nil {
+		return Rate{}, err
+	}
+
+	if from == to {
+		return Identity(from, provider.rates.Date), nil
+	}
+
+	fromRate, ok := provider.unitPrice(from)
+	if !ok {
+		return Rate{}, Error.Wrap(ErrNoRate)
+	}
+
+	toRate, ok := provider.unitPrice(to)
+	if !ok {
+		return Rate{}, Error.Wrap(ErrNoRate)
+	}
+
+	return Rate{
+		From:  from,
+		To:    to,
+		Value: fromRate / toRate,
+		Date:  provider.rates.Date,
+	}, nil
+}
+
+// unitPrice returns price of the currency unit in base currency.
+func (provider *FileProvider) unitPrice(code string) (float64, bool) {
+	if code == provider.rates.Base {
+		return 1, true
+	}
+
+	price, ok := provider.rates.Rates[code]
+	return price, ok && price > 0
+}
+
+// reload reads rates file if it was changed since the last read.
+func (provider *FileProvider) reload() error {
+	info, err := os.Stat(provider.path)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if info.ModTime().Equal(provider.modTime) {
+		return nil
+	}
+
+	data, err := os.ReadFile(provider.path)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	var rates ratesFile
+	if err = json.Unmarshal(data, &rates); err != nil {
+		return Error.Wrap(err)
+	}
+
+	if rates.Base == "" {
+		return Error.New("base currency is not specified in %s", provider.path)
+	}
+
+	provider.rates = rates
+	provider.modTime = info.ModTime()
+
+	return nil
+}
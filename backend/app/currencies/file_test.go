@@ -0,0 +1,49 @@
+package currencies_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app/currencies"
+)
+
+func TestFileProvider(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "rates.json")
+
+	err := os.WriteFile(path, []byte(`{"base": "UAH", "date": "2026-10-19T00:00:00Z", "rates": {"EUR": 45, "USD": 40}}`), 0o600)
+	require.NoError(t, err)
+
+	provider, err := currencies.NewFileProvider(path)
+	require.NoError(t, err)
+
+	t.Run("to base", func(t *testing.T) {
+		rate, err := provider.Rate(ctx, currencies.EUR, currencies.UAH)
+		require.NoError(t, err)
+		assert.Equal(t, 45., rate.Value)
+		assert.Equal(t, currencies.Major(450), rate.Convert(currencies.Major(10)))
+	})
+
+	t.Run("cross rate", func(t *testing.T) {
+		rate, err := provider.Rate(ctx, currencies.EUR, currencies.USD)
+		require.NoError(t, err)
+		assert.Equal(t, 1.125, rate.Value)
+	})
+
+	t.Run("identity", func(t *testing.T) {
+		rate, err := provider.Rate(ctx, currencies.PLN, currencies.PLN)
+		require.NoError(t, err)
+		assert.Equal(t, 1., rate.Value)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		_, err := provider.Rate(ctx, currencies.PLN, currencies.UAH)
+		require.Error(t, err)
+		require.ErrorIs(t, err, currencies.ErrNoRate)
+	})
+}
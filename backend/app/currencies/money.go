@@ -0,0 +1,89 @@
+package currencies
+
+import (
+	"github.com/zeebo/errs"
+)
+
+// ErrCurrencyMismatch indicates that money in different currencies is combined without conversion.
+var ErrCurrencyMismatch = errs.Class("currency mismatch")
+
+// Money is an exact amount in its currency.
+// Money in different currencies is never added or compared, it has to be converted with an exchange rate first.
+//
+// Money is encoded to JSON as an object with amount and currency, e.g. {"amount": 150.50, "currency": "UAH"},
+// so it matches the amount and currency fields of existing views.
+type Money struct {
+	Amount   Amount `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// NewMoney is a constructor for money.
+func NewMoney(amount Amount, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// Add returns sum of the money, ErrCurrencyMismatch is returned if currencies differ.
+func (m Money) Add(b Money) (Money, error) {
+	if err := m.sameCurrency(b); err != nil {
+		return Money{}, err
+	}
+
+	return Money{Amount: m.Amount.Add(b.Amount), Currency: m.Currency}, nil
+}
+
+// Sub returns difference of the money, ErrCurrencyMismatch is returned if currencies differ.
+func (m Money) Sub(b Money) (Money, error) {
+	if err := m.sameCurrency(b); err != nil {
+		return Money{}, err
+	}
+
+	return Money{Amount: m.Amount.Sub(b.Amount), Currency: m.Currency}, nil
+}
+
+// Neg returns negated money.
+func (m Money) Neg() Money {
+	return Money{Amount: m.Amount.Neg(), Currency: m.Currency}
+}
+
+// Cmp compares money and returns -1, 0 or +1 if the money is less, equal or greater than b.
+// ErrCurrencyMismatch is returned if currencies differ.
+func (m Money) Cmp(b Money) (int, error) {
+	if err := m.sameCurrency(b); err != nil {
+		return 0, err
+	}
+
+	return m.Amount.Cmp(b.Amount), nil
+}
+
+// Equal returns true if both amount and currency are equal.
+func (m Money) Equal(b Money) bool {
+	return m.Currency == b.Currency && m.Amount == b.Amount
+}
+
+// IsZero returns true if amount is zero.
+func (m Money) IsZero() bool {
+	return m.Amount.IsZero()
+}
+
+// Convert returns money converted with the rate, rate has to be from the money currency.
+func (m Money) Convert(rate Rate) (Money, error) {
+	if rate.From != m.Currency {
+		return Money{}, ErrCurrencyMismatch.New("rate from %s applied to %s", rate.From, m.Currency)
+	}
+
+	return Money{Amount: rate.Convert(m.Amount), Currency: rate.To}, nil
+}
+
+// String returns decimal amount followed by currency code, e.g. "150.50 UAH".
+func (m Money) String() string {
+	return m.Amount.String() + " " + m.Currency
+}
+
+// sameCurrency returns ErrCurrencyMismatch if currencies of the money differ.
+func (m Money) sameCurrency(b Money) error {
+	if m.Currency != b.Currency {
+		return ErrCurrencyMismatch.New("%s and %s", m.Currency, b.Currency)
+	}
+
+	return nil
+}
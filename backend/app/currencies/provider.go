@@ -0,0 +1,34 @@
+package currencies
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+)
+
+var (
+	// ErrNoRate indicates that exchange rate between currencies is unknown.
+	ErrNoRate = errs.New("exchange rate is unknown")
+)
+
+// RateProvider provides exchange rates between currencies.
+type RateProvider interface {
+	// Rate returns current exchange rate from one currency to another.
+	Rate(ctx context.Context, from, to string) (Rate, error)
+}
+
+// ensures that IdentityProvider implements RateProvider.
+var _ RateProvider = IdentityProvider{}
+
+// IdentityProvider provides rates of currencies to themselves only, used when no rates source is configured.
+type IdentityProvider struct{}
+
+// Rate returns identity rate of the currency, ErrNoRate is returned for different currencies.
+func (IdentityProvider) Rate(ctx context.Context, from, to string) (Rate, error) {
+	if from != to {
+		return Rate{}, Error.Wrap(ErrNoRate)
+	}
+
+	return Identity(from, time.Now().UTC()), nil
+}
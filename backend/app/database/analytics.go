@@ -0,0 +1,137 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+	"github.com/zeebo/errs"
+
+	"one-help/app/fundraises/analytics"
+	"one-help/app/payments"
+)
+
+// ErrAnalytics indicates that there was an error in the database.
+var ErrAnalytics = errs.Class("analytics repository")
+
+// analyticsDB provides access to fundraise analytics computed from donations and payments.
+//
+// architecture: Database
+type analyticsDB struct {
+	conn *sql.DB
+}
+
+// newAnalyticsDB is a constructor for base analyticsDB.
+func newAnalyticsDB(baseConn *sql.DB) analytics.DB {
+	return &analyticsDB{
+		conn: baseConn,
+	}
+}
+
+// Summary returns aggregated donations statistics for the period.
+func (db *analyticsDB) Summary(ctx context.Context, params analytics.Params) (analytics.Summary, error) {
+	var summary analytics.Summary
+
+	// INFO: every donate-link click registers a donation with pending payment, so unconfirmed donations are clicks too.
+	// Self-reported donations are recorded by the organizer and are not clicks. Fully refunded donations are not counted.
+	query := `WITH donated AS (
+                  SELECT donations.user_id,
+                         (donations.amount - COALESCE(payments.refunded_amount, 0)) * donations.exchange_rate AS amount,
+                         COALESCE(payments.confirmed AND payments.status <> 'REFUNDED', FALSE) AS confirmed,
+                         COALESCE(payments.payment_type = ANY($4), FALSE) AS self_reported,
+                         offline_donations.verified_at IS NOT NULL AS verified
+                  FROM donations
+                  LEFT JOIN payments ON donations.donation_id = payments.donation_id
+                  LEFT JOIN offline_donations ON donations.donation_id = offline_donations.donation_id
+                  WHERE donations.fundraise_id = $1 AND donations.created_at >= $2 AND donations.created_at < $3
+              ), confirmed AS (
+                  SELECT user_id, amount, self_reported, verified, COUNT(*) OVER (PARTITION BY user_id) AS donor_donations
+                  FROM donated
+                  WHERE confirmed
+              )
+              SELECT (SELECT COUNT(*) FROM donated WHERE NOT self_reported),
+                     COUNT(*),
+                     COUNT(DISTINCT user_id),
+                     COUNT(DISTINCT user_id) FILTER (WHERE donor_donations > 1),
+                     COALESCE(SUM(amount), 0),
+                     COALESCE(AVG(amount), 0),
+                     COALESCE(PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY amount), 0),
+                     COUNT(*) FILTER (WHERE self_reported),
+                     COALESCE(SUM(amount) FILTER (WHERE self_reported), 0),
+                     COUNT(*) FILTER (WHERE verified),
+                     COALESCE(SUM(amount) FILTER (WHERE verified), 0)
+              FROM confirmed`
+
+	err := db.conn.QueryRowContext(ctx, query, params.FundraiseID, params.From, params.To, pq.Array(payments.SelfReportedTypes)).Scan(
+		&summary.Clicks,
+		&summary.Confirmed,
+		&summary.UniqueDonors,
+		&summary.RepeatDonors,
+		&summary.Total,
+		&summary.Average,
+		&summary.Median,
+		&summary.SelfReportedDonations,
+		&summary.SelfReported,
+		&summary.VerifiedDonations,
+		&summary.Verified,
+	)
+	if err != nil {
+		return summary, ErrAnalytics.Wrap(err)
+	}
+
+	return summary, nil
+}
+
+// TimeSeries returns confirmed donations grouped into buckets of provided granularity, including empty ones.
+// Buckets are aligned in UTC.
+func (db *analyticsDB) TimeSeries(ctx context.Context, params analytics.Params, granularity analytics.Granularity) (_ []analytics.Point, err error) {
+	query := `WITH buckets AS (
+                  SELECT generate_series(
+                      date_trunc($4, $2::TIMESTAMPTZ AT TIME ZONE 'UTC'),
+                      date_trunc($4, $3::TIMESTAMPTZ AT TIME ZONE 'UTC'),
+                      ('1 ' || $4)::INTERVAL
+                  ) AS bucket
+              ), confirmed AS (
+                  SELECT date_trunc($4, donations.created_at AT TIME ZONE 'UTC') AS bucket,
+                         COUNT(*) AS donations,
+                         COUNT(DISTINCT donations.user_id) AS donors,
+                         SUM((donations.amount - payments.refunded_amount) * donations.exchange_rate) AS amount
+                  FROM donations
+                  INNER JOIN payments ON donations.donation_id = payments.donation_id
+                  WHERE donations.fundraise_id = $1 AND payments.confirmed AND payments.status <> 'REFUNDED'
+                    AND donations.created_at >= $2 AND donations.created_at < $3
+                  GROUP BY 1
+              )
+              SELECT buckets.bucket,
+                     COALESCE(confirmed.donations, 0),
+                     COALESCE(confirmed.donors, 0),
+                     COALESCE(confirmed.amount, 0),
+                     SUM(COALESCE(confirmed.amount, 0)) OVER (ORDER BY buckets.bucket)
+              FROM buckets
+              LEFT JOIN confirmed ON buckets.bucket = confirmed.bucket
+              ORDER BY buckets.bucket`
+
+	rows, err := db.conn.QueryContext(ctx, query, params.FundraiseID, params.From, params.To, string(granularity))
+	if err != nil {
+		return nil, ErrAnalytics.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var points []analytics.Point
+	for rows.Next() {
+		var point analytics.Point
+		err = rows.Scan(&point.Time, &point.Donations, &point.Donors, &point.Amount, &point.Cumulative)
+		if err != nil {
+			return nil, ErrAnalytics.Wrap(err)
+		}
+
+		point.Time = point.Time.UTC()
+		points = append(points, point)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, ErrAnalytics.Wrap(err)
+	}
+
+	return points, nil
+}
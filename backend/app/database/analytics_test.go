@@ -0,0 +1,106 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app"
+	"one-help/app/currencies"
+	"one-help/app/database"
+	"one-help/app/database/dbtesting"
+	"one-help/app/donations"
+	"one-help/app/fundraises"
+	"one-help/app/fundraises/analytics"
+	"one-help/app/fundraises/statuses"
+	"one-help/app/payments"
+	"one-help/app/users"
+)
+
+func TestAnalytics(t *testing.T) {
+	first := users.User{ID: uuid.New(), FirstName: "John", LastName: "Doe"}
+	second := users.User{ID: uuid.New(), FirstName: "Jane", LastName: "Doe"}
+
+	start := time.Date(2026, time.October, 1, 10, 0, 0, 0, time.UTC)
+
+	fundraise := fundraises.Fundraise{
+		ID:           uuid.New(),
+		OrganizerId:  first.ID,
+		Title:        "Test",
+		Description:  "Test Description",
+		TargetAmount: currencies.Major(1000),
+		StartDate:    start,
+		Status:       statuses.ActiveStatus,
+	}
+
+	donate := func(ctx context.Context, t *testing.T, db app.DB, user users.User, amount currencies.Amount, createdAt time.Time, confirmed bool) {
+		donation := donations.Donation{
+			ID:          uuid.New(),
+			UserId:      user.ID,
+			FundraiseId: fundraise.ID,
+			Amount:      amount,
+			CreatedAt:   createdAt,
+		}
+		require.NoError(t, db.Donations().Create(ctx, donation))
+		require.NoError(t, db.Payments().Create(ctx, payments.Payment{
+			DonationId:    donation.ID,
+			PaymentType:   payments.TypeStripe,
+			TransactionId: "cs_test",
+			Confirmed:     confirmed,
+		}))
+	}
+
+	params := analytics.Params{
+		FundraiseID: fundraise.ID,
+		From:        start,
+		To:          start.Add(48 * time.Hour),
+	}
+
+	dbtesting.Run(t, database.Config{}, func(ctx context.Context, t *testing.T, db app.DB) {
+		analyticsRepository := db.FundraiseAnalytics()
+
+		require.NoError(t, db.Users().Create(ctx, first))
+		require.NoError(t, db.Users().Create(ctx, second))
+		require.NoError(t, db.Fundraises().Create(ctx, fundraise))
+
+		donate(ctx, t, db, first, currencies.Major(100), start.Add(time.Hour), true)
+		donate(ctx, t, db, first, currencies.Major(300), start.Add(25*time.Hour), true)
+		donate(ctx, t, db, second, currencies.Major(200), start.Add(25*time.Hour), true)
+		donate(ctx, t, db, second, currencies.Zero, start.Add(26*time.Hour), false)
+
+		t.Run("Summary", func(t *testing.T) {
+			summary, err := analyticsRepository.Summary(ctx, params)
+			require.NoError(t, err)
+			assert.Equal(t, 4, summary.Clicks)
+			assert.Equal(t, 3, summary.Confirmed)
+			assert.Equal(t, 2, summary.UniqueDonors)
+			assert.Equal(t, 1, summary.RepeatDonors)
+			assert.Equal(t, currencies.Major(600), summary.Total)
+			assert.Equal(t, currencies.Major(200), summary.Average)
+			assert.Equal(t, currencies.Major(200), summary.Median)
+			assert.Equal(t, 0.75, summary.Conversion())
+			assert.Equal(t, 0.5, summary.RepeatDonorRatio())
+		})
+
+		t.Run("TimeSeries", func(t *testing.T) {
+			daily, err := analyticsRepository.TimeSeries(ctx, params, analytics.Day)
+			require.NoError(t, err)
+			require.Len(t, daily, 3)
+			assert.Equal(t, 1, daily[0].Donations)
+			assert.Equal(t, 2, daily[1].Donations)
+			assert.Equal(t, 2, daily[1].Donors)
+			assert.Equal(t, currencies.Major(500), daily[1].Amount)
+			assert.Equal(t, currencies.Major(600), daily[2].Cumulative)
+
+			hourly, err := analyticsRepository.TimeSeries(ctx, params, analytics.Hour)
+			require.NoError(t, err)
+			require.Len(t, hourly, 49)
+			assert.Equal(t, currencies.Major(100), hourly[1].Amount)
+			assert.Equal(t, currencies.Zero, hourly[2].Amount)
+		})
+	})
+}
@@ -0,0 +1,298 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+
+	"one-help/app/comments"
+)
+
+// ErrComments indicates that there was an error in the database.
+var ErrComments = errs.Class("comments repository")
+
+// commentsDB provides access to comments db.
+//
+// architecture: Database
+type commentsDB struct {
+	conn *sql.DB
+}
+
+// newCommentsDB is a constructor for base commentsDB.
+func newCommentsDB(baseConn *sql.DB) comments.DB {
+	return &commentsDB{
+		conn: baseConn,
+	}
+}
+
+// Create inserts comment into the database.
+func (db *commentsDB) Create(ctx context.Context, comment comments.Comment) error {
+	fundraiseID, eventID, err := subjectColumns(comment.Subject)
+	if err != nil {
+		return ErrComments.Wrap(err)
+	}
+
+	query := `INSERT INTO comments(comment_id, parent_id, fundraise_id, event_id, author_id, body, pinned, hidden, hidden_by, deleted, created_at, updated_at)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+	_, err = db.conn.ExecContext(ctx, query,
+		comment.ID,
+		nullUUID(comment.ParentID),
+		fundraiseID,
+		eventID,
+		comment.AuthorID,
+		comment.Body,
+		comment.Pinned,
+		comment.Hidden,
+		nullUUID(comment.HiddenBy),
+		comment.Deleted,
+		comment.CreatedAt,
+		comment.UpdatedAt,
+	)
+
+	return ErrComments.Wrap(err)
+}
+
+// Get returns comment from the database by ID.
+func (db *commentsDB) Get(ctx context.Context, id uuid.UUID) (comments.Comment, error) {
+	query := `SELECT comment_id, parent_id, fundraise_id, event_id, author_id, body, pinned, hidden, hidden_by, deleted, created_at, updated_at
+              FROM comments
+              WHERE comment_id = $1`
+
+	comment, err := scanComment(db.conn.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return comments.Comment{}, ErrComments.Wrap(comments.ErrNoComment)
+		}
+
+		return comment, ErrComments.Wrap(err)
+	}
+
+	return comment, nil
+}
+
+// List returns comments of the subject ordered from the newest to the oldest.
+func (db *commentsDB) List(ctx context.Context, params comments.ListParams) (_ []comments.Comment, err error) {
+	var (
+		args       = make([]any, 0, 6)
+		conditions []string
+	)
+
+	args = append(args, params.DonorFundraiseID)
+	query := `SELECT c.comment_id, c.parent_id, c.fundraise_id, c.event_id, c.author_id, c.body, c.pinned, c.hidden, c.hidden_by, c.deleted, c.created_at, c.updated_at,
+                     EXISTS(
+                         SELECT 1
+                         FROM donations d
+                         INNER JOIN payments p ON d.donation_id = p.donation_id
+                         WHERE d.user_id = c.author_id AND d.fundraise_id = $1 AND p.confirmed
+                     ),
+                     (SELECT COUNT(*) FROM comments r WHERE r.parent_id = c.comment_id AND NOT r.hidden),
+                     COALESCE((
+                         SELECT json_object_agg(t.type, t.count)
+                         FROM (SELECT type, COUNT(*) AS count FROM comment_reactions WHERE comment_id = c.comment_id GROUP BY type) t
+                     ), '{}')
+              FROM comments c`
+
+	switch params.Subject.Type {
+	case comments.SubjectFundraise:
+		args = append(args, params.Subject.ID)
+		conditions = append(conditions, fmt.Sprintf("c.fundraise_id = $%d", len(args)))
+	case comments.SubjectEvent:
+		args = append(args, params.Subject.ID)
+		conditions = append(conditions, fmt.Sprintf("c.event_id = $%d", len(args)))
+	default:
+		return nil, ErrComments.New("unknown subject type %q", params.Subject.Type)
+	}
+
+	if params.ParentID != nil {
+		args = append(args, *params.ParentID)
+		conditions = append(conditions, fmt.Sprintf("c.parent_id = $%d", len(args)))
+	} else {
+		conditions = append(conditions, "c.parent_id IS NULL")
+	}
+
+	if params.Pinned != nil {
+		args = append(args, *params.Pinned)
+		conditions = append(conditions, fmt.Sprintf("c.pinned = $%d", len(args)))
+	}
+
+	if !params.IncludeHidden {
+		conditions = append(conditions, "NOT c.hidden")
+	}
+
+	if params.Cursor != nil {
+		args = append(args, params.Cursor.CreatedAt, params.Cursor.ID)
+		conditions = append(conditions, fmt.Sprintf("(c.created_at, c.comment_id) < ($%d, $%d)", len(args)-1, len(args)))
+	}
+
+	query += " WHERE " + strings.Join(conditions, " AND ")
+	query += " ORDER BY c.created_at DESC, c.comment_id DESC"
+
+	if params.Limit > 0 {
+		args = append(args, params.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := db.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, ErrComments.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var commentsList []comments.Comment
+	for rows.Next() {
+		var (
+			comment                                  comments.Comment
+			parentID, fundraiseID, eventID, hiddenBy uuid.NullUUID
+			reactions                                []byte
+		)
+		err = rows.Scan(
+			&comment.ID,
+			&parentID,
+			&fundraiseID,
+			&eventID,
+			&comment.AuthorID,
+			&comment.Body,
+			&comment.Pinned,
+			&comment.Hidden,
+			&hiddenBy,
+			&comment.Deleted,
+			&comment.CreatedAt,
+			&comment.UpdatedAt,
+			&comment.IsDonor,
+			&comment.Replies,
+			&reactions,
+		)
+		if err != nil {
+			return nil, ErrComments.Wrap(err)
+		}
+
+		fillComment(&comment, parentID, fundraiseID, eventID, hiddenBy)
+		if err = json.Unmarshal(reactions, &comment.Reactions); err != nil {
+			return nil, ErrComments.Wrap(err)
+		}
+
+		commentsList = append(commentsList, comment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, ErrComments.Wrap(err)
+	}
+
+	return commentsList, nil
+}
+
+// Update updates comment in database by id.
+func (db *commentsDB) Update(ctx context.Context, comment comments.Comment) error {
+	query := `UPDATE comments
+	          SET body = $2, pinned = $3, hidden = $4, hidden_by = $5, deleted = $6, updated_at = $7
+	          WHERE comment_id = $1`
+
+	result, err := db.conn.ExecContext(ctx, query,
+		comment.ID,
+		comment.Body,
+		comment.Pinned,
+		comment.Hidden,
+		nullUUID(comment.HiddenBy),
+		comment.Deleted,
+		comment.UpdatedAt,
+	)
+	if err != nil {
+		return ErrComments.Wrap(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return ErrComments.Wrap(err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrComments.Wrap(comments.ErrNoComment)
+	}
+
+	return nil
+}
+
+// AddReaction adds user reaction to the comment.
+func (db *commentsDB) AddReaction(ctx context.Context, commentID, userID uuid.UUID, reaction string) error {
+	query := `INSERT INTO comment_reactions(comment_id, user_id, type)
+              VALUES ($1, $2, $3)
+              ON CONFLICT DO NOTHING`
+	_, err := db.conn.ExecContext(ctx, query, commentID, userID, reaction)
+	return ErrComments.Wrap(err)
+}
+
+// RemoveReaction removes user reaction from the comment.
+func (db *commentsDB) RemoveReaction(ctx context.Context, commentID, userID uuid.UUID, reaction string) error {
+	query := `DELETE FROM comment_reactions WHERE comment_id = $1 AND user_id = $2 AND type = $3`
+	_, err := db.conn.ExecContext(ctx, query, commentID, userID, reaction)
+	return ErrComments.Wrap(err)
+}
+
+// scanComment scans single comment row without derived values.
+func scanComment(row *sql.Row) (comments.Comment, error) {
+	var (
+		comment                                  comments.Comment
+		parentID, fundraiseID, eventID, hiddenBy uuid.NullUUID
+	)
+
+	err := row.Scan(
+		&comment.ID,
+		&parentID,
+		&fundraiseID,
+		&eventID,
+		&comment.AuthorID,
+		&comment.Body,
+		&comment.Pinned,
+		&comment.Hidden,
+		&hiddenBy,
+		&comment.Deleted,
+		&comment.CreatedAt,
+		&comment.UpdatedAt,
+	)
+	if err != nil {
+		return comment, err
+	}
+
+	fillComment(&comment, parentID, fundraiseID, eventID, hiddenBy)
+
+	return comment, nil
+}
+
+// fillComment sets nullable columns values into the comment.
+func fillComment(comment *comments.Comment, parentID, fundraiseID, eventID, hiddenBy uuid.NullUUID) {
+	comment.ParentID = parentID.UUID
+	comment.HiddenBy = hiddenBy.UUID
+
+	if fundraiseID.Valid {
+		comment.Subject = comments.Subject{Type: comments.SubjectFundraise, ID: fundraiseID.UUID}
+	} else {
+		comment.Subject = comments.Subject{Type: comments.SubjectEvent, ID: eventID.UUID}
+	}
+}
+
+// subjectColumns maps comment subject to fundraise and event columns values.
+func subjectColumns(subject comments.Subject) (fundraiseID, eventID any, err error) {
+	switch subject.Type {
+	case comments.SubjectFundraise:
+		return subject.ID, nil, nil
+	case comments.SubjectEvent:
+		return nil, subject.ID, nil
+	default:
+		return nil, nil, errs.New("unknown subject type %q", subject.Type)
+	}
+}
+
+// nullUUID returns nil for empty uuid to be stored as NULL.
+func nullUUID(id uuid.UUID) any {
+	if id == uuid.Nil {
+		return nil
+	}
+
+	return id
+}
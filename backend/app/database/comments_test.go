@@ -0,0 +1,174 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app"
+	"one-help/app/comments"
+	"one-help/app/currencies"
+	"one-help/app/database"
+	"one-help/app/database/dbtesting"
+	"one-help/app/donations"
+	"one-help/app/fundraises"
+	"one-help/app/fundraises/statuses"
+	"one-help/app/payments"
+	"one-help/app/users"
+)
+
+func TestComments(t *testing.T) {
+	user := users.User{
+		ID:        uuid.New(),
+		FirstName: "John",
+		LastName:  "Doe",
+	}
+
+	fundraise := fundraises.Fundraise{
+		ID:           uuid.New(),
+		OrganizerId:  user.ID,
+		Title:        "Test",
+		Description:  "Test Description",
+		TargetAmount: currencies.MinorUnits(23440),
+		StartDate:    time.Now(),
+		Status:       statuses.ActiveStatus,
+	}
+
+	subject := comments.Subject{Type: comments.SubjectFundraise, ID: fundraise.ID}
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	comment := comments.Comment{
+		ID:        uuid.New(),
+		Subject:   subject,
+		AuthorID:  user.ID,
+		Body:      "Glory to the defenders",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	reply := comments.Comment{
+		ID:        uuid.New(),
+		ParentID:  comment.ID,
+		Subject:   subject,
+		AuthorID:  user.ID,
+		Body:      "Indeed",
+		CreatedAt: now.Add(time.Second),
+		UpdatedAt: now.Add(time.Second),
+	}
+
+	dbtesting.Run(t, database.Config{}, func(ctx context.Context, t *testing.T, db app.DB) {
+		commentsRepository := db.Comments()
+
+		t.Run("Create&Get", func(t *testing.T) {
+			require.NoError(t, db.Users().Create(ctx, user))
+			require.NoError(t, db.Fundraises().Create(ctx, fundraise))
+			require.NoError(t, commentsRepository.Create(ctx, comment))
+			require.NoError(t, commentsRepository.Create(ctx, reply))
+
+			storedComment, err := commentsRepository.Get(ctx, reply.ID)
+			require.NoError(t, err)
+			commentsAreEqual(t, reply, storedComment)
+		})
+
+		t.Run("Get(negative)", func(t *testing.T) {
+			_, err := commentsRepository.Get(ctx, uuid.New())
+			require.Error(t, err)
+			require.ErrorIs(t, err, comments.ErrNoComment)
+		})
+
+		t.Run("List", func(t *testing.T) {
+			list, err := commentsRepository.List(ctx, comments.ListParams{Subject: subject, DonorFundraiseID: fundraise.ID})
+			require.NoError(t, err)
+			require.Len(t, list, 1)
+			commentsAreEqual(t, comment, list[0])
+			assert.Equal(t, 1, list[0].Replies)
+			assert.False(t, list[0].IsDonor)
+
+			list, err = commentsRepository.List(ctx, comments.ListParams{Subject: subject, ParentID: &comment.ID})
+			require.NoError(t, err)
+			require.Len(t, list, 1)
+			commentsAreEqual(t, reply, list[0])
+		})
+
+		t.Run("List donor badge", func(t *testing.T) {
+			donation := donations.Donation{
+				ID:          uuid.New(),
+				UserId:      user.ID,
+				FundraiseId: fundraise.ID,
+				Amount:      currencies.Major(100),
+				CreatedAt:   now,
+			}
+			require.NoError(t, db.Donations().Create(ctx, donation))
+			require.NoError(t, db.Payments().Create(ctx, payments.Payment{
+				DonationId:    donation.ID,
+				PaymentType:   payments.TypeStripe,
+				TransactionId: "cs_test",
+				Confirmed:     true,
+			}))
+
+			list, err := commentsRepository.List(ctx, comments.ListParams{Subject: subject, DonorFundraiseID: fundraise.ID})
+			require.NoError(t, err)
+			require.Len(t, list, 1)
+			assert.True(t, list[0].IsDonor)
+		})
+
+		t.Run("List with cursor", func(t *testing.T) {
+			cursor := comments.Cursor{CreatedAt: comment.CreatedAt, ID: comment.ID}
+			list, err := commentsRepository.List(ctx, comments.ListParams{Subject: subject, Cursor: &cursor})
+			require.NoError(t, err)
+			require.Len(t, list, 0)
+		})
+
+		t.Run("Reactions", func(t *testing.T) {
+			require.NoError(t, commentsRepository.AddReaction(ctx, comment.ID, user.ID, comments.ReactionLike))
+			require.NoError(t, commentsRepository.AddReaction(ctx, comment.ID, user.ID, comments.ReactionLike))
+
+			list, err := commentsRepository.List(ctx, comments.ListParams{Subject: subject})
+			require.NoError(t, err)
+			require.Len(t, list, 1)
+			assert.Equal(t, map[string]int{comments.ReactionLike: 1}, list[0].Reactions)
+
+			require.NoError(t, commentsRepository.RemoveReaction(ctx, comment.ID, user.ID, comments.ReactionLike))
+
+			list, err = commentsRepository.List(ctx, comments.ListParams{Subject: subject})
+			require.NoError(t, err)
+			require.Len(t, list, 1)
+			assert.Empty(t, list[0].Reactions)
+		})
+
+		t.Run("Update", func(t *testing.T) {
+			comment.Hidden = true
+			comment.HiddenBy = user.ID
+			comment.UpdatedAt = now.Add(time.Minute)
+			require.NoError(t, commentsRepository.Update(ctx, comment))
+
+			storedComment, err := commentsRepository.Get(ctx, comment.ID)
+			require.NoError(t, err)
+			commentsAreEqual(t, comment, storedComment)
+
+			list, err := commentsRepository.List(ctx, comments.ListParams{Subject: subject})
+			require.NoError(t, err)
+			require.Len(t, list, 0)
+
+			list, err = commentsRepository.List(ctx, comments.ListParams{Subject: subject, IncludeHidden: true})
+			require.NoError(t, err)
+			require.Len(t, list, 1)
+		})
+	})
+}
+
+func commentsAreEqual(t *testing.T, expected, actual comments.Comment) {
+	assert.Equal(t, expected.ID, actual.ID)
+	assert.Equal(t, expected.ParentID, actual.ParentID)
+	assert.Equal(t, expected.Subject, actual.Subject)
+	assert.Equal(t, expected.AuthorID, actual.AuthorID)
+	assert.Equal(t, expected.Body, actual.Body)
+	assert.Equal(t, expected.Pinned, actual.Pinned)
+	assert.Equal(t, expected.Hidden, actual.Hidden)
+	assert.Equal(t, expected.HiddenBy, actual.HiddenBy)
+	assert.Equal(t, expected.Deleted, actual.Deleted)
+}
@@ -12,15 +12,32 @@ import (
 	"github.com/zeebo/errs"
 
 	"one-help/app"
+	"one-help/app/comments"
 	"one-help/app/donations"
+	"one-help/app/donations/offline"
+	"one-help/app/donations/plans"
+	"one-help/app/donations/receipts"
 	"one-help/app/events"
 	eventformats "one-help/app/events/formats"
 	eventparticipants "one-help/app/events/participants"
 	eventstatuses "one-help/app/events/statuses"
 	"one-help/app/fundraises"
+	fundraiseanalytics "one-help/app/fundraises/analytics"
+	"one-help/app/fundraises/leaderboards"
+	"one-help/app/fundraises/matching"
+	"one-help/app/fundraises/payouts"
+	fundraisereviews "one-help/app/fundraises/reviews"
 	fundraisestatuses "one-help/app/fundraises/statuses"
+	fundraisetransfers "one-help/app/fundraises/transfers"
+	"one-help/app/idempotency"
+	"one-help/app/ledger"
+	"one-help/app/notifications"
 	"one-help/app/payments"
+	"one-help/app/payments/reconciliation"
+	"one-help/app/payments/refunds"
+	"one-help/app/payments/statements"
 	paymenttypes "one-help/app/payments/types"
+	"one-help/app/payments/webhooks"
 	"one-help/app/posts"
 	"one-help/app/raffles"
 	"one-help/app/users"
@@ -105,6 +122,21 @@ func (db *database) Fundraises() fundraises.DB {
 	return newFundraisesDB(db.conn)
 }
 
+// FundraiseTransfers provides access to fundraise transfers DB.
+func (db *database) FundraiseTransfers() fundraisetransfers.DB {
+	return newTransfersDB(db.conn)
+}
+
+// FundraiseAnalytics provides access to fundraise analytics DB.
+func (db *database) FundraiseAnalytics() fundraiseanalytics.DB {
+	return newAnalyticsDB(db.conn)
+}
+
+// FundraiseReviews provides access to fundraise reviews DB.
+func (db *database) FundraiseReviews() fundraisereviews.DB {
+	return newReviewsDB(db.conn)
+}
+
 // Events provides access to events DB.
 func (db *database) Events() events.DB {
 	return newEventsDB(db.conn)
@@ -140,11 +172,81 @@ func (db *database) Payments() payments.DB {
 	return newPaymentsDB(db.conn)
 }
 
+// DonationPlans provides access to recurring donation plans DB.
+func (db *database) DonationPlans() plans.DB {
+	return newPlansDB(db.conn)
+}
+
+// WebhookEvents provides access to payment webhook events DB.
+func (db *database) WebhookEvents() webhooks.DB {
+	return newWebhooksDB(db.conn)
+}
+
+// Refunds provides access to donation refunds DB.
+func (db *database) Refunds() refunds.DB {
+	return newRefundsDB(db.conn)
+}
+
+// ReconciliationReports provides access to payment reconciliation reports DB.
+func (db *database) ReconciliationReports() reconciliation.DB {
+	return newReconciliationDB(db.conn)
+}
+
+// Receipts provides access to donation receipts DB.
+func (db *database) Receipts() receipts.DB {
+	return newReceiptsDB(db.conn)
+}
+
+// IdempotencyKeys provides access to idempotency keys DB.
+func (db *database) IdempotencyKeys() idempotency.DB {
+	return newIdempotencyDB(db.conn)
+}
+
+// Matching provides access to fundraise matching pledges DB.
+func (db *database) Matching() matching.DB {
+	return newMatchingDB(db.conn)
+}
+
+// Ledger provides access to ledger DB.
+func (db *database) Ledger() ledger.DB {
+	return newLedgerDB(db.conn)
+}
+
+// Payouts provides access to fundraise payouts DB.
+func (db *database) Payouts() payouts.DB {
+	return newPayoutsDB(db.conn)
+}
+
+// OfflineDonations provides access to offline donations DB.
+func (db *database) OfflineDonations() offline.DB {
+	return newOfflineDB(db.conn)
+}
+
+// BankStatements provides access to imported bank statements DB.
+func (db *database) BankStatements() statements.DB {
+	return newStatementsDB(db.conn)
+}
+
+// Leaderboards provides access to donor and team leaderboards DB.
+func (db *database) Leaderboards() leaderboards.DB {
+	return newLeaderboardsDB(db.conn)
+}
+
+// Notifications provides access to notifications DB.
+func (db *database) Notifications() notifications.DB {
+	return newNotificationsDB(db.conn)
+}
+
 // Raffles provides access to raffles DB.
 func (db *database) Raffles() raffles.DB {
 	return newRafflesDB(db.conn)
 }
 
+// Comments provides access to comments DB.
+func (db *database) Comments() comments.DB {
+	return newCommentsDB(db.conn)
+}
+
 // Close closes underlying db connection.
 func (db *database) Close() error {
 	return Error.Wrap(db.conn.Close())
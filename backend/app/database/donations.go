@@ -6,8 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	"one-help/app/currencies"
 	"one-help/app/donations"
+	"one-help/app/payments"
 
 	"github.com/google/uuid"
 	"github.com/zeebo/errs"
@@ -39,19 +42,71 @@ func (db *donationsDB) Create(ctx context.Context, donation donations.Donation)
 
 	defer DeferCommitRollback(tx, &err)
 
-	query := `INSERT INTO donations(donation_id, user_id, fundraise_id, amount, created_at)
-              VALUES ($1, $2, $3, $4, $5)`
-	_, err = tx.ExecContext(ctx, query, donation.ID, donation.UserId, donation.FundraiseId, donation.Amount, donation.CreatedAt)
+	err = insertDonation(ctx, tx, donation)
 	return ErrDonations.Wrap(err)
 }
 
+// CreateWithPayment inserts donation with its payment in one transaction.
+func (db *donationsDB) CreateWithPayment(ctx context.Context, donation donations.Donation, payment payments.Payment) error {
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return ErrDonations.Wrap(err)
+	}
+
+	defer DeferCommitRollback(tx, &err)
+
+	if err = insertDonation(ctx, tx, donation); err != nil {
+		return ErrDonations.Wrap(err)
+	}
+
+	err = insertPayment(ctx, tx, payment)
+	return ErrDonations.Wrap(err)
+}
+
+// insertDonation inserts donation within provided database transaction.
+func insertDonation(ctx context.Context, tx *sql.Tx, donation donations.Donation) error {
+	if donation.Currency == "" { // INFO: Fallback to default currency.
+		donation.Currency = currencies.Default
+	}
+	if donation.ExchangeRate == 0 { // INFO: Not rated donation is considered to be in the fundraise currency.
+		donation.ExchangeRate = 1
+	}
+
+	query := `INSERT INTO donations(donation_id, user_id, fundraise_id, amount, currency, exchange_rate, rated_at, created_at, requested_amount, plan_id, anonymous, message, team_code,
+                                  tribute_type, honoree_name, tribute_recipient_name, tribute_recipient_email)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)`
+	_, err := tx.ExecContext(ctx, query,
+		donation.ID,
+		nullUUID(donation.UserId),
+		donation.FundraiseId,
+		donation.Amount,
+		donation.Currency,
+		donation.ExchangeRate,
+		nullTime(donation.RatedAt),
+		donation.CreatedAt,
+		donation.RequestedAmount,
+		nullUUID(donation.PlanID),
+		donation.Anonymous,
+		donation.Message,
+		donation.TeamCode,
+		donation.Tribute.Type,
+		donation.Tribute.HonoreeName,
+		donation.Tribute.RecipientName,
+		donation.Tribute.RecipientEmail,
+	)
+	return err
+}
+
 // Get returns donation from the database by ID.
 func (db *donationsDB) Get(ctx context.Context, id uuid.UUID) (donations.Donation, error) {
 	var (
 		donation donations.Donation
+		ratedAt  sql.NullTime
+		planID   uuid.NullUUID
 	)
 
-	query := `SELECT donation_id, user_id, fundraise_id, amount, created_at
+	query := `SELECT donation_id, user_id, fundraise_id, amount, currency, exchange_rate, rated_at, created_at, requested_amount, plan_id, anonymous, message, team_code,
+                     tribute_type, honoree_name, tribute_recipient_name, tribute_recipient_email
               FROM donations
               WHERE donation_id = $1`
 
@@ -61,7 +116,19 @@ func (db *donationsDB) Get(ctx context.Context, id uuid.UUID) (donations.Donatio
 		&donation.UserId,
 		&donation.FundraiseId,
 		&donation.Amount,
+		&donation.Currency,
+		&donation.ExchangeRate,
+		&ratedAt,
 		&donation.CreatedAt,
+		&donation.RequestedAmount,
+		&planID,
+		&donation.Anonymous,
+		&donation.Message,
+		&donation.TeamCode,
+		&donation.Tribute.Type,
+		&donation.Tribute.HonoreeName,
+		&donation.Tribute.RecipientName,
+		&donation.Tribute.RecipientEmail,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -70,6 +137,9 @@ func (db *donationsDB) Get(ctx context.Context, id uuid.UUID) (donations.Donatio
 		return donation, ErrDonations.Wrap(err)
 	}
 
+	donation.RatedAt = ratedAt.Time
+	donation.PlanID = planID.UUID
+
 	return donation, nil
 }
 
@@ -79,7 +149,8 @@ func (db *donationsDB) List(ctx context.Context, params donations.ListParams) ([
 
 	var conditions []string
 
-	query := `SELECT donation_id, user_id, fundraise_id, amount, created_at
+	query := `SELECT donation_id, user_id, fundraise_id, amount, currency, exchange_rate, rated_at, created_at, requested_amount, plan_id, anonymous, message, team_code,
+                     tribute_type, honoree_name, tribute_recipient_name, tribute_recipient_email
               FROM donations`
 	if params.UserID != nil {
 		args = append(args, *params.UserID)
@@ -104,17 +175,33 @@ func (db *donationsDB) List(ctx context.Context, params donations.ListParams) ([
 	for rows.Next() {
 		var (
 			donation donations.Donation
+			ratedAt  sql.NullTime
+			planID   uuid.NullUUID
 		)
 		err = rows.Scan(
 			&donation.ID,
 			&donation.UserId,
 			&donation.FundraiseId,
 			&donation.Amount,
+			&donation.Currency,
+			&donation.ExchangeRate,
+			&ratedAt,
 			&donation.CreatedAt,
+			&donation.RequestedAmount,
+			&planID,
+			&donation.Anonymous,
+			&donation.Message,
+			&donation.TeamCode,
+			&donation.Tribute.Type,
+			&donation.Tribute.HonoreeName,
+			&donation.Tribute.RecipientName,
+			&donation.Tribute.RecipientEmail,
 		)
 		if err != nil {
 			return nil, ErrDonations.Wrap(err)
 		}
+		donation.RatedAt = ratedAt.Time
+		donation.PlanID = planID.UUID
 		donationsList = append(donationsList, donation)
 	}
 
@@ -125,6 +212,213 @@ func (db *donationsDB) List(ctx context.Context, params donations.ListParams) ([
 	return donationsList, nil
 }
 
+// ListSupporters returns the latest confirmed donations of the fundraise with donors' names.
+func (db *donationsDB) ListSupporters(ctx context.Context, fundraiseID uuid.UUID, limit int) (_ []donations.Supporter, err error) {
+	query := `SELECT d.donation_id, d.user_id, d.fundraise_id, d.amount, d.currency, d.exchange_rate, d.created_at,
+                     d.anonymous, d.message, d.tribute_type, d.honoree_name, COALESCE(u.first_name, ''), COALESCE(u.last_name, ''),
+                     COALESCE(u.private, FALSE)
+              FROM donations d
+              JOIN payments p ON p.donation_id = d.donation_id
+              LEFT JOIN users u ON u.user_id = d.user_id
+              WHERE d.fundraise_id = $1 AND p.confirmed
+              ORDER BY d.created_at DESC
+              LIMIT $2`
+	rows, err := db.conn.QueryContext(ctx, query, fundraiseID, limit)
+	if err != nil {
+		return nil, ErrDonations.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var supporters []donations.Supporter
+	for rows.Next() {
+		var supporter donations.Supporter
+		err = rows.Scan(
+			&supporter.Donation.ID,
+			&supporter.Donation.UserId,
+			&supporter.Donation.FundraiseId,
+			&supporter.Donation.Amount,
+			&supporter.Donation.Currency,
+			&supporter.Donation.ExchangeRate,
+			&supporter.Donation.CreatedAt,
+			&supporter.Donation.Anonymous,
+			&supporter.Donation.Message,
+			&supporter.Donation.Tribute.Type,
+			&supporter.Donation.Tribute.HonoreeName,
+			&supporter.FirstName,
+			&supporter.LastName,
+			&supporter.Private,
+		)
+		if err != nil {
+			return nil, ErrDonations.Wrap(err)
+		}
+
+		supporters = append(supporters, supporter)
+	}
+
+	return supporters, ErrDonations.Wrap(rows.Err())
+}
+
+// ListHistory returns donations of the donor with their payment state, the latest first.
+func (db *donationsDB) ListHistory(ctx context.Context, params donations.HistoryParams) (_ []donations.HistoryEntry, err error) {
+	if params.Limit == 0 {
+		params.Limit = 20
+	}
+	if params.Page == 0 {
+		params.Page = 1
+	}
+
+	conditions, args := historyConditions(params)
+	query := `SELECT d.donation_id, d.user_id, d.fundraise_id, d.amount, d.currency, d.exchange_rate, d.rated_at, d.created_at,
+                     d.requested_amount, d.plan_id, d.anonymous, d.message, f.title, f.status,
+                     COALESCE(p.payment_type, ''), COALESCE(p.status, ''), COALESCE(p.refunded_amount, 0)
+              FROM donations d
+              JOIN fundraises f ON f.fundraise_id = d.fundraise_id
+              LEFT JOIN payments p ON p.donation_id = d.donation_id
+              WHERE ` + conditions + `
+              ORDER BY d.created_at DESC, d.donation_id`
+
+	{ // INFO: Paging.
+		args = append(args, params.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+		args = append(args, (params.Page-1)*params.Limit)
+		query += fmt.Sprintf(" OFFSET $%d ", len(args))
+	}
+
+	rows, err := db.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, ErrDonations.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var history []donations.HistoryEntry
+	for rows.Next() {
+		var (
+			entry   donations.HistoryEntry
+			ratedAt sql.NullTime
+			planID  uuid.NullUUID
+		)
+		err = rows.Scan(
+			&entry.Donation.ID,
+			&entry.Donation.UserId,
+			&entry.Donation.FundraiseId,
+			&entry.Donation.Amount,
+			&entry.Donation.Currency,
+			&entry.Donation.ExchangeRate,
+			&ratedAt,
+			&entry.Donation.CreatedAt,
+			&entry.Donation.RequestedAmount,
+			&planID,
+			&entry.Donation.Anonymous,
+			&entry.Donation.Message,
+			&entry.FundraiseTitle,
+			&entry.FundraiseStatus,
+			&entry.PaymentType,
+			&entry.PaymentStatus,
+			&entry.RefundedAmount,
+		)
+		if err != nil {
+			return nil, ErrDonations.Wrap(err)
+		}
+		entry.Donation.RatedAt = ratedAt.Time
+		entry.Donation.PlanID = planID.UUID
+
+		history = append(history, entry)
+	}
+
+	return history, ErrDonations.Wrap(rows.Err())
+}
+
+// YearlyTotals returns sums of the donor's confirmed donations by year and currency, the latest year first.
+func (db *donationsDB) YearlyTotals(ctx context.Context, params donations.HistoryParams) (_ []donations.YearTotal, err error) {
+	conditions, args := historyConditions(params)
+	query := `SELECT EXTRACT(YEAR FROM d.created_at AT TIME ZONE 'UTC')::INTEGER AS year, d.currency,
+                     SUM(d.amount - p.refunded_amount)
+              FROM donations d
+              JOIN payments p ON p.donation_id = d.donation_id
+              WHERE p.confirmed AND p.status <> 'REFUNDED' AND ` + conditions + `
+              GROUP BY year, d.currency
+              ORDER BY year DESC, d.currency`
+
+	rows, err := db.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, ErrDonations.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var totals []donations.YearTotal
+	for rows.Next() {
+		var total donations.YearTotal
+		if err = rows.Scan(&total.Year, &total.Currency, &total.Amount); err != nil {
+			return nil, ErrDonations.Wrap(err)
+		}
+
+		totals = append(totals, total)
+	}
+
+	return totals, ErrDonations.Wrap(rows.Err())
+}
+
+// Contributions returns sums of the donor's confirmed donations by fundraise, the latest donated first.
+func (db *donationsDB) Contributions(ctx context.Context, params donations.HistoryParams) (_ []donations.Contribution, err error) {
+	conditions, args := historyConditions(params)
+	query := `SELECT f.fundraise_id, f.title, f.status, f.currency,
+                     SUM((d.amount - p.refunded_amount) * d.exchange_rate), COUNT(*), MAX(d.created_at)
+              FROM donations d
+              JOIN payments p ON p.donation_id = d.donation_id
+              JOIN fundraises f ON f.fundraise_id = d.fundraise_id
+              WHERE p.confirmed AND p.status <> 'REFUNDED' AND ` + conditions + `
+              GROUP BY f.fundraise_id
+              ORDER BY MAX(d.created_at) DESC, f.fundraise_id`
+
+	rows, err := db.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, ErrDonations.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var contributions []donations.Contribution
+	for rows.Next() {
+		var contribution donations.Contribution
+		err = rows.Scan(
+			&contribution.FundraiseID,
+			&contribution.FundraiseTitle,
+			&contribution.FundraiseStatus,
+			&contribution.Currency,
+			&contribution.Amount,
+			&contribution.Donations,
+			&contribution.LastDonatedAt,
+		)
+		if err != nil {
+			return nil, ErrDonations.Wrap(err)
+		}
+
+		contributions = append(contributions, contribution)
+	}
+
+	return contributions, ErrDonations.Wrap(rows.Err())
+}
+
+// historyConditions returns conditions on donations aliased as d selected by history filters, with their arguments.
+func historyConditions(params donations.HistoryParams) (string, []any) {
+	args := []any{params.UserID}
+	conditions := []string{"d.user_id = $1"}
+
+	if params.FundraiseID != uuid.Nil {
+		args = append(args, params.FundraiseID)
+		conditions = append(conditions, fmt.Sprintf("d.fundraise_id = $%d", len(args)))
+	}
+	if !params.From.IsZero() {
+		args = append(args, params.From)
+		conditions = append(conditions, fmt.Sprintf("d.created_at >= $%d", len(args)))
+	}
+	if !params.To.IsZero() {
+		args = append(args, params.To)
+		conditions = append(conditions, fmt.Sprintf("d.created_at < $%d", len(args)))
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
 // Update updates donation in database by id.
 func (db *donationsDB) Update(ctx context.Context, donation donations.Donation) error {
 	tx, err := db.conn.BeginTx(ctx, nil)
@@ -134,15 +428,29 @@ func (db *donationsDB) Update(ctx context.Context, donation donations.Donation)
 	defer DeferCommitRollback(tx, &err)
 
 	query := `UPDATE donations
-	          SET user_id = $2, fundraise_id = $3, amount = $4, created_at = $5
+	          SET user_id = $2, fundraise_id = $3, amount = $4, currency = $5, exchange_rate = $6, rated_at = $7, created_at = $8, requested_amount = $9, plan_id = $10,
+	              anonymous = $11, message = $12, team_code = $13,
+	              tribute_type = $14, honoree_name = $15, tribute_recipient_name = $16, tribute_recipient_email = $17
 	          WHERE donation_id = $1`
 
 	_, err = tx.ExecContext(ctx, query,
 		donation.ID,
-		donation.UserId,
+		nullUUID(donation.UserId),
 		donation.FundraiseId,
 		donation.Amount,
+		donation.Currency,
+		donation.ExchangeRate,
+		nullTime(donation.RatedAt),
 		donation.CreatedAt,
+		donation.RequestedAmount,
+		nullUUID(donation.PlanID),
+		donation.Anonymous,
+		donation.Message,
+		donation.TeamCode,
+		donation.Tribute.Type,
+		donation.Tribute.HonoreeName,
+		donation.Tribute.RecipientName,
+		donation.Tribute.RecipientEmail,
 	)
 	if err != nil {
 		return ErrDonations.Wrap(err)
@@ -157,3 +465,12 @@ func (db *donationsDB) Delete(ctx context.Context, id uuid.UUID) error {
 	_, err := db.conn.ExecContext(ctx, query, id)
 	return ErrDonations.Wrap(err)
 }
+
+// nullTime returns nil for zero time to be stored as NULL.
+func nullTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+
+	return t
+}
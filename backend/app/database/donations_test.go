@@ -6,10 +6,13 @@ import (
 	"time"
 
 	"one-help/app"
+	"one-help/app/currencies"
 	"one-help/app/database"
 	"one-help/app/database/dbtesting"
 	"one-help/app/donations"
+	"one-help/app/donations/tributes"
 	"one-help/app/fundraises"
+	"one-help/app/payments"
 	"one-help/app/users"
 
 	"github.com/google/uuid"
@@ -33,18 +36,26 @@ func TestDonations(t *testing.T) {
 		OrganizerId:  user.ID,
 		Title:        "Test",
 		Description:  "Test Description",
-		TargetAmount: 234.4,
+		TargetAmount: currencies.MinorUnits(23440),
 		StartDate:    time.Now(),
 		EndDate:      time.Time{},
 		Status:       fundraiseStatus,
 	}
 
 	donation := donations.Donation{
-		ID:          uuid.New(),
-		UserId:      user.ID,
-		FundraiseId: fundraise.ID,
-		Amount:      100.0,
-		CreatedAt:   time.Now(),
+		ID:              uuid.New(),
+		UserId:          user.ID,
+		FundraiseId:     fundraise.ID,
+		Amount:          currencies.Major(100),
+		CreatedAt:       time.Now(),
+		RequestedAmount: currencies.Major(100),
+		Anonymous:       true,
+		Message:         "Stay strong",
+		Tribute: tributes.Tribute{
+			Type:           tributes.TypeInMemory,
+			HonoreeName:    "Taras",
+			RecipientEmail: "family@example.com",
+		},
 	}
 
 	dbtesting.Run(t, database.Config{}, func(ctx context.Context, t *testing.T, db app.DB) {
@@ -71,7 +82,7 @@ func TestDonations(t *testing.T) {
 		})
 
 		t.Run("Update", func(t *testing.T) {
-			donation.Amount = 25
+			donation.Amount = currencies.Major(25)
 
 			storedDonation, err := donationsRepository.Get(ctx, donation.ID)
 			require.NoError(t, err)
@@ -92,6 +103,93 @@ func TestDonations(t *testing.T) {
 			assert.Equal(t, 1, len(storedDonations))
 		})
 
+		t.Run("ListSupporters", func(t *testing.T) {
+			supporters, err := donationsRepository.ListSupporters(ctx, fundraise.ID, 10)
+			require.NoError(t, err)
+			assert.Empty(t, supporters)
+
+			require.NoError(t, db.Payments().Create(ctx, payments.Payment{
+				DonationId:    donation.ID,
+				PaymentType:   payments.TypeStripe,
+				TransactionId: "cs_test",
+				Confirmed:     true,
+			}))
+
+			supporters, err = donationsRepository.ListSupporters(ctx, fundraise.ID, 10)
+			require.NoError(t, err)
+			require.Len(t, supporters, 1)
+			assert.Equal(t, donation.ID, supporters[0].Donation.ID)
+			assert.Equal(t, donation.Amount, supporters[0].Donation.Amount)
+			assert.Equal(t, donation.Anonymous, supporters[0].Donation.Anonymous)
+			assert.Equal(t, donation.Message, supporters[0].Donation.Message)
+			assert.Equal(t, donation.Tribute.HonoreeName, supporters[0].Donation.Tribute.HonoreeName)
+			assert.Empty(t, supporters[0].Donation.Tribute.RecipientEmail)
+			assert.Equal(t, user.FirstName, supporters[0].FirstName)
+			assert.Equal(t, user.LastName, supporters[0].LastName)
+			assert.False(t, supporters[0].Private)
+		})
+
+		t.Run("ListHistory&Totals", func(t *testing.T) {
+			params := donations.HistoryParams{UserID: user.ID, Limit: 10, Page: 1}
+
+			history, err := donationsRepository.ListHistory(ctx, params)
+			require.NoError(t, err)
+			require.Len(t, history, 1)
+			donationsAreEqual(t, donation, history[0].Donation)
+			assert.Equal(t, fundraise.Title, history[0].FundraiseTitle)
+			assert.Equal(t, fundraiseStatus, history[0].FundraiseStatus)
+			assert.Equal(t, payments.TypeStripe, history[0].PaymentType)
+
+			totals, err := donationsRepository.YearlyTotals(ctx, params)
+			require.NoError(t, err)
+			require.Len(t, totals, 1)
+			assert.Equal(t, donation.CreatedAt.UTC().Year(), totals[0].Year)
+			assert.Equal(t, donation.Amount, totals[0].Amount)
+
+			contributions, err := donationsRepository.Contributions(ctx, params)
+			require.NoError(t, err)
+			require.Len(t, contributions, 1)
+			assert.Equal(t, fundraise.ID, contributions[0].FundraiseID)
+			assert.Equal(t, donation.Amount, contributions[0].Amount)
+			assert.Equal(t, 1, contributions[0].Donations)
+
+			params.From = donation.CreatedAt.Add(time.Hour)
+			history, err = donationsRepository.ListHistory(ctx, params)
+			require.NoError(t, err)
+			assert.Empty(t, history)
+
+			totals, err = donationsRepository.YearlyTotals(ctx, params)
+			require.NoError(t, err)
+			assert.Empty(t, totals)
+		})
+
+		t.Run("CreateWithPayment", func(t *testing.T) {
+			charged := donation
+			charged.ID = uuid.New()
+			payment := payments.Payment{
+				DonationId:    charged.ID,
+				PaymentType:   "unknown",
+				TransactionId: "in_test",
+				Confirmed:     true,
+			}
+
+			// INFO: donation is not stored when its payment can't be stored.
+			require.Error(t, donationsRepository.CreateWithPayment(ctx, charged, payment))
+			_, err := donationsRepository.Get(ctx, charged.ID)
+			require.ErrorIs(t, err, donations.ErrNoDonation)
+
+			payment.PaymentType = payments.TypeStripe
+			require.NoError(t, donationsRepository.CreateWithPayment(ctx, charged, payment))
+
+			storedDonation, err := donationsRepository.Get(ctx, charged.ID)
+			require.NoError(t, err)
+			donationsAreEqual(t, charged, storedDonation)
+
+			storedPayment, err := db.Payments().GetByTransaction(ctx, payments.TypeStripe, payment.TransactionId)
+			require.NoError(t, err)
+			assert.Equal(t, charged.ID, storedPayment.DonationId)
+		})
+
 		t.Run("Delete", func(t *testing.T) {
 			err := donationsRepository.Delete(ctx, donation.ID)
 			require.NoError(t, err)
@@ -108,4 +206,7 @@ func donationsAreEqual(t *testing.T, expected, actual donations.Donation) {
 	assert.Equal(t, expected.FundraiseId, actual.FundraiseId)
 	assert.Equal(t, expected.UserId, actual.UserId)
 	assert.Equal(t, expected.Amount, actual.Amount)
+	assert.Equal(t, expected.Anonymous, actual.Anonymous)
+	assert.Equal(t, expected.Message, actual.Message)
+	assert.Equal(t, expected.Tribute, actual.Tribute)
 }
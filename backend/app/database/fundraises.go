@@ -5,11 +5,16 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"one-help/app/currencies"
 	"one-help/app/fundraises"
+	"one-help/app/fundraises/reviews"
+	"one-help/app/ledger"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/zeebo/errs"
 )
 
@@ -39,9 +44,36 @@ func (db *fundraisesDB) Create(ctx context.Context, fundraise fundraises.Fundrai
 
 	defer DeferCommitRollback(tx, &err)
 
-	query := `INSERT INTO fundraises(fundraise_id, organizer_id, title, description, target_amount, start_date, end_date, status, file_name)
-              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
-	_, err = tx.ExecContext(
+	err = insertFundraise(ctx, tx, fundraise)
+	return ErrFundraises.Wrap(err)
+}
+
+// CreateWithReview inserts fundraise with its first review in the same transaction.
+func (db *fundraisesDB) CreateWithReview(ctx context.Context, fundraise fundraises.Fundraise, review reviews.Review) error {
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return ErrFundraises.Wrap(err)
+	}
+
+	defer DeferCommitRollback(tx, &err)
+
+	if err = insertFundraise(ctx, tx, fundraise); err != nil {
+		return ErrFundraises.Wrap(err)
+	}
+
+	err = insertReview(ctx, tx, review)
+	return ErrFundraises.Wrap(err)
+}
+
+// insertFundraise inserts fundraise within provided database transaction.
+func insertFundraise(ctx context.Context, tx *sql.Tx, fundraise fundraises.Fundraise) error {
+	if fundraise.Currency == "" { // INFO: Fallback to default currency.
+		fundraise.Currency = currencies.Default
+	}
+
+	query := `INSERT INTO fundraises(fundraise_id, organizer_id, title, description, target_amount, start_date, end_date, status, file_name, currency, min_donation, donation_presets)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, COALESCE($12::NUMERIC[], '{}'))`
+	_, err := tx.ExecContext(
 		ctx,
 		query,
 		fundraise.ID,
@@ -53,9 +85,12 @@ func (db *fundraisesDB) Create(ctx context.Context, fundraise fundraises.Fundrai
 		fundraise.EndDate,
 		fundraise.Status,
 		fundraise.ImageUrl,
+		fundraise.Currency,
+		fundraise.MinDonation,
+		pq.Array(fundraise.Presets),
 	)
 
-	return ErrFundraises.Wrap(err)
+	return err
 }
 
 // Get returns fundraise from the database by ID.
@@ -65,7 +100,7 @@ func (db *fundraisesDB) Get(ctx context.Context, id uuid.UUID) (fundraises.Fundr
 		endDate   sql.NullTime
 	)
 
-	query := `SELECT fundraise_id, organizer_id, title, description, target_amount, start_date, end_date, status, file_name
+	query := `SELECT fundraise_id, organizer_id, title, description, target_amount, start_date, end_date, status, file_name, currency, min_donation, donation_presets
               FROM fundraises
               WHERE fundraise_id = $1`
 
@@ -80,6 +115,9 @@ func (db *fundraisesDB) Get(ctx context.Context, id uuid.UUID) (fundraises.Fundr
 		&endDate,
 		&fundraise.Status,
 		&fundraise.ImageUrl,
+		&fundraise.Currency,
+		&fundraise.MinDonation,
+		pq.Array(&fundraise.Presets),
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -108,12 +146,28 @@ func (db *fundraisesDB) List(ctx context.Context, params fundraises.ListParams)
 		params.Page = 1
 	}
 
-	query := `SELECT fundraise_id, organizer_id, title, description, target_amount, start_date, end_date, status, file_name
+	query := `SELECT fundraise_id, organizer_id, title, description, target_amount, start_date, end_date, status, file_name, currency, min_donation, donation_presets
               FROM fundraises`
 
+	var conditions []string
 	if params.OrganizerID != nil {
 		args = append(args, *params.OrganizerID)
-		query += fmt.Sprintf(" WHERE organizer_id = $%d ", len(args))
+		conditions = append(conditions, fmt.Sprintf("organizer_id = $%d", len(args)))
+	}
+	if len(params.Statuses) > 0 {
+		args = append(args, pq.Array(params.Statuses))
+		conditions = append(conditions, fmt.Sprintf("status = ANY($%d)", len(args)))
+	}
+	if len(params.ExcludeStatuses) > 0 {
+		args = append(args, pq.Array(params.ExcludeStatuses))
+		conditions = append(conditions, fmt.Sprintf("status <> ALL($%d)", len(args)))
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	if params.OldestFirst {
+		query += " ORDER BY start_date, fundraise_id"
 	}
 
 	{ // INFO: Paging.
@@ -145,6 +199,9 @@ func (db *fundraisesDB) List(ctx context.Context, params fundraises.ListParams)
 			&endDate,
 			&fundraise.Status,
 			&fundraise.ImageUrl,
+			&fundraise.Currency,
+			&fundraise.MinDonation,
+			pq.Array(&fundraise.Presets),
 		)
 		if err != nil {
 			return nil, ErrFundraises.Wrap(err)
@@ -174,7 +231,7 @@ func (db *fundraisesDB) Update(ctx context.Context, fundraise fundraises.Fundrai
 	defer DeferCommitRollback(tx, &err)
 
 	query := `UPDATE fundraises
-	          SET organizer_id = $2, title = $3, description = $4, target_amount = $5, start_date = $6, end_date = $7, status = $8, file_name = $9
+	          SET organizer_id = $2, title = $3, description = $4, target_amount = $5, start_date = $6, end_date = $7, status = $8, file_name = $9, currency = $10, min_donation = $11, donation_presets = COALESCE($12::NUMERIC[], '{}')
 	          WHERE fundraise_id = $1`
 
 	var endDate interface{}
@@ -194,6 +251,9 @@ func (db *fundraisesDB) Update(ctx context.Context, fundraise fundraises.Fundrai
 		endDate,
 		fundraise.Status,
 		fundraise.ImageUrl,
+		fundraise.Currency,
+		fundraise.MinDonation,
+		pq.Array(fundraise.Presets),
 	)
 	if err != nil {
 		return ErrFundraises.Wrap(err)
@@ -209,18 +269,19 @@ func (db *fundraisesDB) Delete(ctx context.Context, id uuid.UUID) error {
 	return ErrFundraises.Wrap(err)
 }
 
-// GetFilled returns collected funds on the provided fundraise.
-func (db *fundraisesDB) GetFilled(ctx context.Context, id uuid.UUID) (filled float64, err error) {
-	query := `SELECT COALESCE(SUM(amount), 0)
-              FROM fundraises
-              INNER JOIN donations ON fundraises.fundraise_id = donations.fundraise_id
-              INNER JOIN payments ON donations.donation_id = payments.donation_id
-              WHERE fundraises.fundraise_id = $1 AND payments.confirmed`
+// fundraiseCollectedQuery selects funds collected on the fundraise ledger account provided as $1, not reduced by payouts.
+const fundraiseCollectedQuery = `SELECT COALESCE(-SUM(e.amount), 0)
+                                 FROM ledger_entries e
+                                 INNER JOIN ledger_transactions t ON e.transaction_id = t.transaction_id
+                                 WHERE e.account = $1 AND t.kind <> '` + ledger.KindPayout + `'`
 
-	row := db.conn.QueryRowContext(ctx, query, id)
+// GetFilled returns collected funds on the provided fundraise, derived from its ledger account.
+// NOTE: payouts spend collected funds, so they do not reduce the filled amount.
+func (db *fundraisesDB) GetFilled(ctx context.Context, id uuid.UUID) (filled currencies.Amount, err error) {
+	row := db.conn.QueryRowContext(ctx, fundraiseCollectedQuery, ledger.FundraiseBalance(id))
 	err = row.Scan(&filled)
 	if err != nil {
-		return -1, ErrFundraises.Wrap(err)
+		return currencies.Major(-1), ErrFundraises.Wrap(err)
 	}
 
 	return filled, nil
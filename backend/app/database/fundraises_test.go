@@ -6,9 +6,11 @@ import (
 	"time"
 
 	"one-help/app"
+	"one-help/app/currencies"
 	"one-help/app/database"
 	"one-help/app/database/dbtesting"
 	"one-help/app/fundraises"
+	"one-help/app/fundraises/reviews"
 	"one-help/app/fundraises/statuses"
 	"one-help/app/users"
 
@@ -31,7 +33,10 @@ func TestFundraises(t *testing.T) {
 		OrganizerId:  user.ID,
 		Title:        "Test",
 		Description:  "Test Description",
-		TargetAmount: 234.4,
+		TargetAmount: currencies.MinorUnits(23440),
+		Currency:     currencies.UAH,
+		MinDonation:  currencies.Major(50),
+		Presets:      []currencies.Amount{currencies.Major(100), currencies.Major(250), currencies.Major(500)},
 		StartDate:    time.Now(),
 		EndDate:      time.Time{},
 		Status:       statuses.ActiveStatus,
@@ -91,6 +96,50 @@ func TestFundraises(t *testing.T) {
 			fundraisesAreEqual(t, fundraise, storedFundraises[0])
 		})
 
+		t.Run("List by statuses", func(t *testing.T) {
+			storedFundraises, err := fundraiseRepository.List(ctx, fundraises.ListParams{Statuses: []string{statuses.PendingReviewStatus}})
+			require.NoError(t, err)
+			require.Len(t, storedFundraises, 0)
+
+			storedFundraises, err = fundraiseRepository.List(ctx, fundraises.ListParams{
+				ExcludeStatuses: []string{statuses.PendingReviewStatus, statuses.RejectedStatus},
+				OldestFirst:     true,
+			})
+			require.NoError(t, err)
+			require.Len(t, storedFundraises, 1)
+			fundraisesAreEqual(t, fundraise, storedFundraises[0])
+		})
+
+		t.Run("CreateWithReview", func(t *testing.T) {
+			submitted := fundraise
+			submitted.ID = uuid.New()
+			submitted.Status = statuses.PendingReviewStatus
+			review := reviews.Review{
+				ID:          uuid.New(),
+				FundraiseID: submitted.ID,
+				ActorID:     uuid.New(),
+				Decision:    reviews.DecisionSubmitted,
+				CreatedAt:   time.Now(),
+			}
+
+			// INFO: fundraise is not stored when its review can't be stored.
+			require.Error(t, fundraiseRepository.CreateWithReview(ctx, submitted, review))
+			_, err := fundraiseRepository.Get(ctx, submitted.ID)
+			require.ErrorIs(t, err, fundraises.ErrNoFundraise)
+
+			review.ActorID = user.ID
+			require.NoError(t, fundraiseRepository.CreateWithReview(ctx, submitted, review))
+
+			storedFundraise, err := fundraiseRepository.Get(ctx, submitted.ID)
+			require.NoError(t, err)
+			fundraisesAreEqual(t, submitted, storedFundraise)
+
+			storedReviews, err := db.FundraiseReviews().List(ctx, submitted.ID)
+			require.NoError(t, err)
+			require.Len(t, storedReviews, 1)
+			assert.Equal(t, review.ID, storedReviews[0].ID)
+		})
+
 		t.Run("Delete", func(t *testing.T) {
 			err := fundraiseRepository.Delete(ctx, fundraise.ID)
 			require.NoError(t, err)
@@ -108,5 +157,8 @@ func fundraisesAreEqual(t *testing.T, expected, actual fundraises.Fundraise) {
 	assert.Equal(t, expected.Title, actual.Title)
 	assert.Equal(t, expected.Description, actual.Description)
 	assert.Equal(t, expected.TargetAmount, actual.TargetAmount)
+	assert.Equal(t, expected.Currency, actual.Currency)
+	assert.Equal(t, expected.MinDonation, actual.MinDonation)
+	assert.ElementsMatch(t, expected.Presets, actual.Presets)
 	assert.Equal(t, expected.Status, actual.Status)
 }
@@ -0,0 +1,130 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+
+	"one-help/app/idempotency"
+)
+
+// ErrIdempotency indicates that there was an error in the database.
+var ErrIdempotency = errs.Class("idempotency repository")
+
+// idempotencyDB provides access to idempotency keys db.
+//
+// architecture: Database
+type idempotencyDB struct {
+	conn *sql.DB
+}
+
+// newIdempotencyDB is a constructor for base idempotencyDB.
+func newIdempotencyDB(baseConn *sql.DB) idempotency.DB {
+	return &idempotencyDB{
+		conn: baseConn,
+	}
+}
+
+// idempotencyColumns lists selected idempotency record columns in the scan order.
+const idempotencyColumns = `user_id, key, fingerprint, completed, status, content_type, body, created_at, expires_at`
+
+// Reserve inserts record if the user has no record with the key or it has expired, returns true in that case.
+// Otherwise, returns the stored record and false.
+func (db *idempotencyDB) Reserve(ctx context.Context, record idempotency.Record) (idempotency.Record, bool, error) {
+	query := `INSERT INTO idempotency_keys(user_id, key, fingerprint, created_at, expires_at)
+              VALUES ($1, $2, $3, $4, $5)
+              ON CONFLICT (user_id, key) DO UPDATE
+              SET fingerprint = EXCLUDED.fingerprint, completed = FALSE, status = 0, content_type = '', body = NULL,
+                  created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at
+              WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+              RETURNING ` + idempotencyColumns
+	reserved, err := scanIdempotencyRecord(db.conn.QueryRowContext(ctx, query,
+		record.UserID,
+		record.Key,
+		record.Fingerprint,
+		record.CreatedAt,
+		record.ExpiresAt,
+	))
+	if err == nil {
+		return reserved, true, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return idempotency.Record{}, false, ErrIdempotency.Wrap(err)
+	}
+
+	// INFO: the key holds not expired record.
+	query = `SELECT ` + idempotencyColumns + ` FROM idempotency_keys WHERE user_id = $1 AND key = $2`
+	stored, err := scanIdempotencyRecord(db.conn.QueryRowContext(ctx, query, record.UserID, record.Key))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return idempotency.Record{}, false, ErrIdempotency.Wrap(idempotency.ErrNoRecord)
+		}
+
+		return idempotency.Record{}, false, ErrIdempotency.Wrap(err)
+	}
+
+	return stored, false, nil
+}
+
+// Complete stores the response of the record.
+func (db *idempotencyDB) Complete(ctx context.Context, record idempotency.Record) error {
+	query := `UPDATE idempotency_keys
+              SET completed = $3, status = $4, content_type = $5, body = $6
+              WHERE user_id = $1 AND key = $2`
+	result, err := db.conn.ExecContext(ctx, query,
+		record.UserID,
+		record.Key,
+		record.Completed,
+		record.Status,
+		record.ContentType,
+		record.Body,
+	)
+	if err != nil {
+		return ErrIdempotency.Wrap(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return ErrIdempotency.Wrap(err)
+	}
+	if affected == 0 {
+		return ErrIdempotency.Wrap(idempotency.ErrNoRecord)
+	}
+
+	return nil
+}
+
+// Delete deletes record of the user by key.
+func (db *idempotencyDB) Delete(ctx context.Context, userID uuid.UUID, key string) error {
+	query := `DELETE FROM idempotency_keys WHERE user_id = $1 AND key = $2`
+	_, err := db.conn.ExecContext(ctx, query, userID, key)
+	return ErrIdempotency.Wrap(err)
+}
+
+// DeleteExpired deletes records expired before provided time.
+func (db *idempotencyDB) DeleteExpired(ctx context.Context, before time.Time) error {
+	query := `DELETE FROM idempotency_keys WHERE expires_at <= $1`
+	_, err := db.conn.ExecContext(ctx, query, before)
+	return ErrIdempotency.Wrap(err)
+}
+
+// scanIdempotencyRecord scans idempotency record columns from the row.
+func scanIdempotencyRecord(row interface{ Scan(dest ...any) error }) (idempotency.Record, error) {
+	var record idempotency.Record
+	err := row.Scan(
+		&record.UserID,
+		&record.Key,
+		&record.Fingerprint,
+		&record.Completed,
+		&record.Status,
+		&record.ContentType,
+		&record.Body,
+		&record.CreatedAt,
+		&record.ExpiresAt,
+	)
+	return record, err
+}
@@ -0,0 +1,96 @@
+package database_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app"
+	"one-help/app/database"
+	"one-help/app/database/dbtesting"
+	"one-help/app/idempotency"
+	"one-help/app/users"
+)
+
+func TestIdempotencyKeys(t *testing.T) {
+	user := users.User{
+		ID:        uuid.New(),
+		FirstName: "John",
+		LastName:  "Doe",
+	}
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	record := idempotency.Record{
+		UserID:      user.ID,
+		Key:         "key",
+		Fingerprint: idempotency.Fingerprint(http.MethodPost, "/api/v0/fundraises", []byte(`{"title": "Test"}`)),
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(time.Hour),
+	}
+
+	dbtesting.Run(t, database.Config{}, func(ctx context.Context, t *testing.T, db app.DB) {
+		idempotencyRepository := db.IdempotencyKeys()
+
+		t.Run("Reserve", func(t *testing.T) {
+			require.NoError(t, db.Users().Create(ctx, user))
+
+			reserved, ok, err := idempotencyRepository.Reserve(ctx, record)
+			require.NoError(t, err)
+			assert.True(t, ok)
+			assert.False(t, reserved.Completed)
+			assert.Equal(t, record.Fingerprint, reserved.Fingerprint)
+		})
+
+		t.Run("Complete&Reserve(repeated)", func(t *testing.T) {
+			record.Completed = true
+			record.Status = http.StatusOK
+			record.ContentType = "application/json; charset=utf-8"
+			record.Body = []byte(`{"id": "1"}`)
+			require.NoError(t, idempotencyRepository.Complete(ctx, record))
+
+			repeated := record
+			repeated.Fingerprint = "other"
+			stored, ok, err := idempotencyRepository.Reserve(ctx, repeated)
+			require.NoError(t, err)
+			assert.False(t, ok)
+			assert.Equal(t, record.Fingerprint, stored.Fingerprint)
+			assert.True(t, stored.Completed)
+			assert.Equal(t, record.Status, stored.Status)
+			assert.Equal(t, record.ContentType, stored.ContentType)
+			assert.Equal(t, record.Body, stored.Body)
+		})
+
+		t.Run("Reserve(expired)", func(t *testing.T) {
+			expired := record
+			expired.Fingerprint = "other"
+			expired.CreatedAt = now.Add(2 * time.Hour)
+			expired.ExpiresAt = now.Add(3 * time.Hour)
+
+			reserved, ok, err := idempotencyRepository.Reserve(ctx, expired)
+			require.NoError(t, err)
+			assert.True(t, ok)
+			assert.False(t, reserved.Completed)
+			assert.Equal(t, "other", reserved.Fingerprint)
+		})
+
+		t.Run("Delete&DeleteExpired", func(t *testing.T) {
+			require.NoError(t, idempotencyRepository.Delete(ctx, user.ID, record.Key))
+
+			err := idempotencyRepository.Complete(ctx, record)
+			require.ErrorIs(t, err, idempotency.ErrNoRecord)
+
+			_, _, err = idempotencyRepository.Reserve(ctx, record)
+			require.NoError(t, err)
+			require.NoError(t, idempotencyRepository.DeleteExpired(ctx, record.ExpiresAt))
+
+			_, ok, err := idempotencyRepository.Reserve(ctx, record)
+			require.NoError(t, err)
+			assert.True(t, ok)
+		})
+	})
+}
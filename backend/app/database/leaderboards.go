@@ -0,0 +1,204 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+
+	"one-help/app/currencies"
+	"one-help/app/fundraises/leaderboards"
+)
+
+// ErrLeaderboards indicates that there was an error in the database.
+var ErrLeaderboards = errs.Class("leaderboards repository")
+
+// leaderboardsDB provides access to leaderboards db.
+//
+// architecture: Database
+type leaderboardsDB struct {
+	conn *sql.DB
+}
+
+// newLeaderboardsDB is a constructor for base leaderboardsDB.
+func newLeaderboardsDB(baseConn *sql.DB) leaderboards.DB {
+	return &leaderboardsDB{
+		conn: baseConn,
+	}
+}
+
+// contributionColumns lists selected contribution columns in the scan order.
+const contributionColumns = `donation_id, fundraise_id, donor_id, team_code, amount, refunded, exchange_rate, platform_rate, donated_at`
+
+// Record adds confirmed donation to leaderboard totals, donation is counted only once.
+func (db *leaderboardsDB) Record(ctx context.Context, contribution leaderboards.Contribution) (err error) {
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return ErrLeaderboards.Wrap(err)
+	}
+
+	defer DeferCommitRollback(tx, &err)
+
+	query := `INSERT INTO leaderboard_contributions(` + contributionColumns + `)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+              ON CONFLICT (donation_id) DO NOTHING`
+	result, err := tx.ExecContext(ctx, query,
+		contribution.DonationID,
+		contribution.FundraiseID,
+		nullUUID(contribution.DonorID),
+		contribution.TeamCode,
+		contribution.Amount,
+		contribution.Refunded,
+		contribution.ExchangeRate,
+		contribution.PlatformRate,
+		contribution.DonatedAt,
+	)
+	if err != nil {
+		return ErrLeaderboards.Wrap(err)
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return ErrLeaderboards.Wrap(err)
+	}
+	if inserted == 0 {
+		return nil
+	}
+
+	return ErrLeaderboards.Wrap(addTotals(ctx, tx, contribution, false))
+}
+
+// Refund sets total refunded amount of the counted donation and reduces leaderboard totals accordingly.
+// Donations not counted towards leaderboards are ignored.
+func (db *leaderboardsDB) Refund(ctx context.Context, donationID uuid.UUID, refunded currencies.Amount) (err error) {
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return ErrLeaderboards.Wrap(err)
+	}
+
+	defer DeferCommitRollback(tx, &err)
+
+	var (
+		contribution leaderboards.Contribution
+		donorID      uuid.NullUUID
+	)
+	// INFO: locks the contribution, so concurrent refunds are subtracted from totals once.
+	query := `SELECT ` + contributionColumns + ` FROM leaderboard_contributions WHERE donation_id = $1 FOR UPDATE`
+	err = tx.QueryRowContext(ctx, query, donationID).Scan(
+		&contribution.DonationID,
+		&contribution.FundraiseID,
+		&donorID,
+		&contribution.TeamCode,
+		&contribution.Amount,
+		&contribution.Refunded,
+		&contribution.ExchangeRate,
+		&contribution.PlatformRate,
+		&contribution.DonatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+
+		return ErrLeaderboards.Wrap(err)
+	}
+	contribution.DonorID = donorID.UUID
+
+	if refunded == contribution.Refunded {
+		return nil
+	}
+
+	query = `UPDATE leaderboard_contributions SET refunded = $2 WHERE donation_id = $1`
+	if _, err = tx.ExecContext(ctx, query, donationID, refunded); err != nil {
+		return ErrLeaderboards.Wrap(err)
+	}
+
+	// INFO: previous amounts are taken out of the totals and the reduced ones are added back.
+	if err = addTotals(ctx, tx, contribution, true); err != nil {
+		return ErrLeaderboards.Wrap(err)
+	}
+
+	contribution.Refunded = refunded
+	return ErrLeaderboards.Wrap(addTotals(ctx, tx, contribution, false))
+}
+
+// List returns top entries of the leaderboard with positive totals, the largest first.
+// Donors with private profiles are skipped.
+func (db *leaderboardsDB) List(ctx context.Context, params leaderboards.Params) (_ []leaderboards.Entry, err error) {
+	query := `SELECT t.subject, COALESCE(u.first_name, ''), COALESCE(u.last_name, ''), t.amount, t.donations
+              FROM leaderboard_totals t
+              LEFT JOIN users u ON t.kind = $3 AND u.user_id::text = t.subject
+              WHERE t.fundraise_id = $1 AND t.period = $2 AND t.kind = $4 AND t.amount > 0 AND NOT COALESCE(u.private, FALSE)
+              ORDER BY t.amount DESC, t.donations DESC, t.subject
+              LIMIT $5`
+	rows, err := db.conn.QueryContext(ctx, query, params.FundraiseID, params.Period, leaderboards.KindDonors, params.Kind, params.Limit)
+	if err != nil {
+		return nil, ErrLeaderboards.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var entries []leaderboards.Entry
+	for rows.Next() {
+		var (
+			entry   leaderboards.Entry
+			subject string
+		)
+		if err = rows.Scan(&subject, &entry.FirstName, &entry.LastName, &entry.Amount, &entry.Donations); err != nil {
+			return nil, ErrLeaderboards.Wrap(err)
+		}
+
+		switch params.Kind {
+		case leaderboards.KindDonors:
+			if entry.DonorID, err = uuid.Parse(subject); err != nil {
+				return nil, ErrLeaderboards.Wrap(err)
+			}
+		case leaderboards.KindTeams:
+			entry.TeamCode = subject
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, ErrLeaderboards.Wrap(rows.Err())
+}
+
+// addTotals adds amounts of the contribution to totals of every leaderboard it is counted on, or subtracts them.
+func addTotals(ctx context.Context, tx *sql.Tx, contribution leaderboards.Contribution, subtract bool) error {
+	fundraiseAmount, platformAmount := contribution.FundraiseAmount(), contribution.PlatformAmount()
+
+	var counted int
+	if contribution.Net().IsPositive() {
+		counted = 1
+	}
+
+	if subtract {
+		fundraiseAmount, platformAmount, counted = fundraiseAmount.Neg(), platformAmount.Neg(), -counted
+	}
+
+	var subjects [][2]string
+	if contribution.DonorID != uuid.Nil {
+		subjects = append(subjects, [2]string{leaderboards.KindDonors, contribution.DonorID.String()})
+	}
+	if contribution.TeamCode != "" {
+		subjects = append(subjects, [2]string{leaderboards.KindTeams, contribution.TeamCode})
+	}
+
+	query := `INSERT INTO leaderboard_totals(fundraise_id, period, kind, subject, amount, donations)
+              VALUES ($1, $2, $3, $4, $5, $6)
+              ON CONFLICT (fundraise_id, period, kind, subject) DO UPDATE
+              SET amount = leaderboard_totals.amount + EXCLUDED.amount, donations = leaderboard_totals.donations + EXCLUDED.donations`
+	for _, period := range []string{leaderboards.PeriodAllTime, leaderboards.MonthPeriod(contribution.DonatedAt)} {
+		for _, subject := range subjects {
+			if _, err := tx.ExecContext(ctx, query, contribution.FundraiseID, period, subject[0], subject[1], fundraiseAmount, counted); err != nil {
+				return err
+			}
+			if _, err := tx.ExecContext(ctx, query, uuid.Nil, period, subject[0], subject[1], platformAmount, counted); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
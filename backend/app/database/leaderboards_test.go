@@ -0,0 +1,158 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app"
+	"one-help/app/currencies"
+	"one-help/app/database"
+	"one-help/app/database/dbtesting"
+	"one-help/app/donations"
+	"one-help/app/fundraises"
+	"one-help/app/fundraises/leaderboards"
+	"one-help/app/fundraises/statuses"
+	"one-help/app/users"
+)
+
+func TestLeaderboards(t *testing.T) {
+	first := users.User{ID: uuid.New(), FirstName: "John", LastName: "Doe"}
+	second := users.User{ID: uuid.New(), FirstName: "Jane", LastName: "Doe"}
+	private := users.User{ID: uuid.New(), FirstName: "Private", LastName: "Donor", Private: true}
+
+	donatedAt := time.Date(2026, time.October, 10, 10, 0, 0, 0, time.UTC)
+
+	fundraise := fundraises.Fundraise{
+		ID:           uuid.New(),
+		OrganizerId:  first.ID,
+		Title:        "Test",
+		Description:  "Test Description",
+		TargetAmount: currencies.Major(1000),
+		Currency:     currencies.UAH,
+		StartDate:    donatedAt,
+		Status:       statuses.ActiveStatus,
+	}
+
+	contribute := func(ctx context.Context, t *testing.T, db app.DB, donorID uuid.UUID, teamCode string, amount currencies.Amount, at time.Time) leaderboards.Contribution {
+		donation := donations.Donation{
+			ID:          uuid.New(),
+			UserId:      donorID,
+			FundraiseId: fundraise.ID,
+			Amount:      amount,
+			CreatedAt:   at,
+			TeamCode:    teamCode,
+		}
+		require.NoError(t, db.Donations().Create(ctx, donation))
+
+		contribution := leaderboards.Contribution{
+			DonationID:   donation.ID,
+			FundraiseID:  fundraise.ID,
+			DonorID:      donorID,
+			TeamCode:     teamCode,
+			Amount:       amount,
+			ExchangeRate: 1,
+			PlatformRate: 1,
+			DonatedAt:    at,
+		}
+		require.NoError(t, db.Leaderboards().Record(ctx, contribution))
+		return contribution
+	}
+
+	dbtesting.Run(t, database.Config{}, func(ctx context.Context, t *testing.T, db app.DB) {
+		leaderboardsRepository := db.Leaderboards()
+
+		require.NoError(t, db.Users().Create(ctx, first))
+		require.NoError(t, db.Users().Create(ctx, second))
+		require.NoError(t, db.Users().Create(ctx, private))
+		require.NoError(t, db.Fundraises().Create(ctx, fundraise))
+
+		refunded := contribute(ctx, t, db, first.ID, "runners", currencies.Major(100), donatedAt)
+		contribute(ctx, t, db, first.ID, "", currencies.Major(50), donatedAt.AddDate(0, -1, 0))
+		contribute(ctx, t, db, second.ID, "runners", currencies.Major(120), donatedAt)
+		contribute(ctx, t, db, private.ID, "cyclists", currencies.Major(500), donatedAt)
+		contribute(ctx, t, db, uuid.Nil, "cyclists", currencies.Major(30), donatedAt)
+
+		t.Run("Record(repeated)", func(t *testing.T) {
+			require.NoError(t, leaderboardsRepository.Record(ctx, refunded))
+
+			entries, err := leaderboardsRepository.List(ctx, leaderboards.Params{
+				FundraiseID: fundraise.ID,
+				Kind:        leaderboards.KindDonors,
+				Period:      leaderboards.PeriodAllTime,
+				Limit:       10,
+			})
+			require.NoError(t, err)
+			require.Len(t, entries, 2)
+			assert.Equal(t, first.ID, entries[0].DonorID)
+			assert.Equal(t, first.FirstName, entries[0].FirstName)
+			assert.Equal(t, currencies.Major(150), entries[0].Amount)
+			assert.Equal(t, 2, entries[0].Donations)
+			assert.Equal(t, second.ID, entries[1].DonorID)
+		})
+
+		t.Run("List(month)", func(t *testing.T) {
+			entries, err := leaderboardsRepository.List(ctx, leaderboards.Params{
+				FundraiseID: fundraise.ID,
+				Kind:        leaderboards.KindDonors,
+				Period:      leaderboards.MonthPeriod(donatedAt),
+				Limit:       10,
+			})
+			require.NoError(t, err)
+			require.Len(t, entries, 2)
+			assert.Equal(t, second.ID, entries[0].DonorID)
+			assert.Equal(t, currencies.Major(100), entries[1].Amount)
+		})
+
+		t.Run("List(teams)", func(t *testing.T) {
+			entries, err := leaderboardsRepository.List(ctx, leaderboards.Params{
+				FundraiseID: uuid.Nil,
+				Kind:        leaderboards.KindTeams,
+				Period:      leaderboards.PeriodAllTime,
+				Limit:       10,
+			})
+			require.NoError(t, err)
+			require.Len(t, entries, 2)
+			assert.Equal(t, "cyclists", entries[0].TeamCode)
+			assert.Equal(t, currencies.Major(530), entries[0].Amount)
+			assert.Equal(t, "runners", entries[1].TeamCode)
+			assert.Equal(t, currencies.Major(220), entries[1].Amount)
+		})
+
+		t.Run("Refund", func(t *testing.T) {
+			require.NoError(t, leaderboardsRepository.Refund(ctx, refunded.DonationID, currencies.Major(40)))
+			// INFO: the same refunded total is applied once.
+			require.NoError(t, leaderboardsRepository.Refund(ctx, refunded.DonationID, currencies.Major(40)))
+			require.NoError(t, leaderboardsRepository.Refund(ctx, uuid.New(), currencies.Major(40)))
+
+			entries, err := leaderboardsRepository.List(ctx, leaderboards.Params{
+				FundraiseID: fundraise.ID,
+				Kind:        leaderboards.KindDonors,
+				Period:      leaderboards.PeriodAllTime,
+				Limit:       10,
+			})
+			require.NoError(t, err)
+			require.Len(t, entries, 2)
+			assert.Equal(t, second.ID, entries[0].DonorID)
+			assert.Equal(t, currencies.Major(110), entries[1].Amount)
+			assert.Equal(t, 2, entries[1].Donations)
+
+			require.NoError(t, leaderboardsRepository.Refund(ctx, refunded.DonationID, refunded.Amount))
+
+			entries, err = leaderboardsRepository.List(ctx, leaderboards.Params{
+				FundraiseID: fundraise.ID,
+				Kind:        leaderboards.KindTeams,
+				Period:      leaderboards.MonthPeriod(donatedAt),
+				Limit:       10,
+			})
+			require.NoError(t, err)
+			require.Len(t, entries, 2)
+			assert.Equal(t, currencies.Major(120), entries[1].Amount)
+			assert.Equal(t, 1, entries[1].Donations)
+		})
+	})
+}
@@ -0,0 +1,181 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/zeebo/errs"
+
+	"one-help/app/currencies"
+	"one-help/app/ledger"
+)
+
+// ErrLedger indicates that there was an error in the database.
+var ErrLedger = errs.Class("ledger repository")
+
+// ledgerDB provides access to ledger db.
+//
+// architecture: Database
+type ledgerDB struct {
+	conn *sql.DB
+}
+
+// newLedgerDB is a constructor for base ledgerDB.
+func newLedgerDB(baseConn *sql.DB) ledger.DB {
+	return &ledgerDB{
+		conn: baseConn,
+	}
+}
+
+// accountBalanceQuery selects credit balance of the account provided as $1, used for fundraise balances.
+const accountBalanceQuery = `SELECT COALESCE(-SUM(amount), 0) FROM ledger_entries WHERE account = $1`
+
+// Post appends balanced transaction to the ledger. Returns false without posting if the transaction
+// of the same kind and reference was already posted.
+func (db *ledgerDB) Post(ctx context.Context, transaction ledger.Transaction) (posted bool, err error) {
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return false, ErrLedger.Wrap(err)
+	}
+
+	defer DeferCommitRollback(tx, &err)
+
+	posted, err = postTransaction(ctx, tx, transaction)
+	return posted, ErrLedger.Wrap(err)
+}
+
+// postTransaction inserts transaction with its entries within provided database transaction.
+func postTransaction(ctx context.Context, tx *sql.Tx, transaction ledger.Transaction) (bool, error) {
+	if err := transaction.Validate(); err != nil {
+		return false, err
+	}
+
+	query := `INSERT INTO ledger_transactions(transaction_id, kind, reference, created_at)
+              VALUES ($1, $2, $3, $4)
+              ON CONFLICT (kind, reference) DO NOTHING`
+	result, err := tx.ExecContext(ctx, query, transaction.ID, transaction.Kind, transaction.Reference, transaction.CreatedAt)
+	if err != nil {
+		return false, err
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil || inserted == 0 {
+		return false, err
+	}
+
+	for _, entry := range transaction.Entries {
+		_, err = tx.ExecContext(ctx, `INSERT INTO ledger_entries(transaction_id, account, currency, amount) VALUES ($1, $2, $3, $4)`,
+			transaction.ID, entry.Account, entry.Currency, entry.Amount)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// Balance returns debit balance of the account in provided currency.
+func (db *ledgerDB) Balance(ctx context.Context, account, currency string) (balance currencies.Amount, err error) {
+	query := `SELECT COALESCE(SUM(amount), 0) FROM ledger_entries WHERE account = $1 AND currency = $2`
+	err = db.conn.QueryRowContext(ctx, query, account, currency).Scan(&balance)
+	return balance, ErrLedger.Wrap(err)
+}
+
+// Check verifies that every transaction and currency balances and that fundraise accounts
+// match donations, refunds, transfers and payouts they were posted for. Returns found violations.
+func (db *ledgerDB) Check(ctx context.Context) (_ []ledger.Violation, err error) {
+	var violations []ledger.Violation
+
+	unbalanced := `SELECT t.transaction_id::text, COALESCE(e.currency, ''), COALESCE(SUM(e.amount), 0)
+                   FROM ledger_transactions t
+                   LEFT JOIN ledger_entries e ON t.transaction_id = e.transaction_id
+                   GROUP BY t.transaction_id, e.currency
+                   HAVING ROUND(COALESCE(SUM(e.amount), 0), 2) <> 0 OR COUNT(e.entry_id) = 0`
+	err = db.collectViolations(ctx, &violations, unbalanced, func(currency string, amount currencies.Amount) string {
+		if currency == "" {
+			return "transaction has no entries"
+		}
+		return fmt.Sprintf("transaction entries sum up to %s", amount)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totals := `SELECT 'ledger', currency, SUM(amount)
+               FROM ledger_entries
+               GROUP BY currency
+               HAVING ROUND(SUM(amount), 2) <> 0`
+	err = db.collectViolations(ctx, &violations, totals, func(currency string, amount currencies.Amount) string {
+		return fmt.Sprintf("ledger entries sum up to %s", amount)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// INFO: expected balance is calculated from donations net of fees, payments, transfers and payouts the entries were posted for.
+	// Refunds are taken from the net amount first, refunded fees are not taken from the fundraise.
+	// Converted amounts are rounded to minor units one by one, the same way they are posted.
+	fundraiseBalances := `SELECT account, currency, posted - expected FROM (
+                              SELECT 'fundraise:' || f.fundraise_id AS account, f.currency,
+                                     COALESCE((SELECT -SUM(amount) FROM ledger_entries WHERE account = 'fundraise:' || f.fundraise_id), 0) AS posted,
+                                     COALESCE((
+                                         SELECT SUM(ROUND((d.amount - p.fee_amount) * d.exchange_rate, 2) - ROUND(LEAST(p.refunded_amount, d.amount - p.fee_amount) * d.exchange_rate, 2))
+                                         FROM donations d
+                                         INNER JOIN payments p ON d.donation_id = p.donation_id
+                                         WHERE d.fundraise_id = f.fundraise_id AND p.confirmed AND p.status <> 'REFUNDED'
+                                     ), 0)
+                                     + COALESCE((SELECT SUM(ROUND(amount * exchange_rate, 2)) FROM fundraise_transfers WHERE to_fundraise_id = f.fundraise_id), 0)
+                                     - COALESCE((SELECT SUM(amount) FROM fundraise_transfers WHERE from_fundraise_id = f.fundraise_id), 0)
+                                     - COALESCE((SELECT SUM(amount) FROM payouts WHERE fundraise_id = f.fundraise_id AND status = 'PAID'), 0) AS expected
+                              FROM fundraises f
+                          ) balances
+                          WHERE ROUND(posted, 2) <> ROUND(expected, 2)`
+	err = db.collectViolations(ctx, &violations, fundraiseBalances, func(currency string, amount currencies.Amount) string {
+		return fmt.Sprintf("fundraise balance differs from its donations, transfers and payouts by %s", amount)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	overdrawn := `SELECT account, currency, -SUM(amount)
+                  FROM ledger_entries
+                  WHERE account LIKE 'fundraise:%'
+                  GROUP BY account, currency
+                  HAVING ROUND(-SUM(amount), 2) < 0`
+	err = db.collectViolations(ctx, &violations, overdrawn, func(currency string, amount currencies.Amount) string {
+		return fmt.Sprintf("fundraise balance is negative: %s", amount)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return violations, nil
+}
+
+// collectViolations appends violation for every row of the query, which selects subject, currency and amount.
+func (db *ledgerDB) collectViolations(ctx context.Context, violations *[]ledger.Violation, query string, message func(currency string, amount currencies.Amount) string) (err error) {
+	rows, err := db.conn.QueryContext(ctx, query)
+	if err != nil {
+		return ErrLedger.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	for rows.Next() {
+		var (
+			subject, currency string
+			amount            currencies.Amount
+		)
+		if err = rows.Scan(&subject, &currency, &amount); err != nil {
+			return ErrLedger.Wrap(err)
+		}
+
+		*violations = append(*violations, ledger.Violation{
+			Subject:  subject,
+			Currency: currency,
+			Message:  message(currency, amount),
+		})
+	}
+
+	return ErrLedger.Wrap(rows.Err())
+}
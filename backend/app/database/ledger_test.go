@@ -0,0 +1,134 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app"
+	"one-help/app/currencies"
+	"one-help/app/database"
+	"one-help/app/database/dbtesting"
+	"one-help/app/donations"
+	"one-help/app/fundraises"
+	"one-help/app/fundraises/statuses"
+	"one-help/app/ledger"
+	"one-help/app/payments"
+	"one-help/app/users"
+)
+
+func TestLedger(t *testing.T) {
+	user := users.User{
+		ID:        uuid.New(),
+		FirstName: "John",
+		LastName:  "Doe",
+	}
+
+	fundraise := fundraises.Fundraise{
+		ID:           uuid.New(),
+		OrganizerId:  user.ID,
+		Title:        "Test",
+		Description:  "Test Description",
+		TargetAmount: currencies.Major(1000),
+		Currency:     currencies.UAH,
+		StartDate:    time.Now(),
+		Status:       statuses.ActiveStatus,
+	}
+
+	donation := donations.Donation{
+		ID:           uuid.New(),
+		UserId:       user.ID,
+		FundraiseId:  fundraise.ID,
+		Amount:       currencies.Major(10),
+		Currency:     currencies.USD,
+		ExchangeRate: 40,
+		CreatedAt:    time.Now(),
+	}
+
+	payment := payments.Payment{
+		DonationId:    donation.ID,
+		PaymentType:   payments.TypeStripe,
+		TransactionId: "cs_test",
+		Confirmed:     true,
+		Status:        payments.StatusConfirmed,
+	}
+
+	dbtesting.Run(t, database.Config{}, func(ctx context.Context, t *testing.T, db app.DB) {
+		ledgerRepository := db.Ledger()
+
+		require.NoError(t, db.Users().Create(ctx, user))
+		require.NoError(t, db.Fundraises().Create(ctx, fundraise))
+		require.NoError(t, db.Donations().Create(ctx, donation))
+		require.NoError(t, db.Payments().Create(ctx, payment))
+
+		t.Run("Post", func(t *testing.T) {
+			confirmation := ledger.New(ledger.KindConfirmation, donation.ID.String(), time.Now())
+			confirmation.Move(
+				ledger.DonorClearing(payments.TypeStripe), currencies.NewMoney(donation.Amount, donation.Currency),
+				ledger.FundraiseBalance(fundraise.ID), currencies.NewMoney(donation.ConvertedAmount(), fundraise.Currency),
+			)
+
+			posted, err := ledgerRepository.Post(ctx, confirmation)
+			require.NoError(t, err)
+			assert.True(t, posted)
+
+			// INFO: the same confirmation is posted once.
+			confirmation.ID = uuid.New()
+			posted, err = ledgerRepository.Post(ctx, confirmation)
+			require.NoError(t, err)
+			assert.False(t, posted)
+
+			balance, err := ledgerRepository.Balance(ctx, ledger.DonorClearing(payments.TypeStripe), currencies.USD)
+			require.NoError(t, err)
+			assert.Equal(t, currencies.Major(10), balance)
+
+			balance, err = ledgerRepository.Balance(ctx, ledger.AccountExchange, currencies.UAH)
+			require.NoError(t, err)
+			assert.Equal(t, currencies.Major(400), balance)
+
+			filled, err := db.Fundraises().GetFilled(ctx, fundraise.ID)
+			require.NoError(t, err)
+			assert.Equal(t, currencies.Major(400), filled)
+		})
+
+		t.Run("Post(unbalanced)", func(t *testing.T) {
+			unbalanced := ledger.New(ledger.KindPayout, uuid.NewString(), time.Now())
+			unbalanced.Entries = []ledger.Entry{{Account: ledger.AccountPayouts, Currency: currencies.UAH, Amount: currencies.Major(1)}}
+
+			_, err := ledgerRepository.Post(ctx, unbalanced)
+			require.ErrorIs(t, err, ledger.ErrUnbalanced)
+		})
+
+		t.Run("Check", func(t *testing.T) {
+			violations, err := ledgerRepository.Check(ctx)
+			require.NoError(t, err)
+			assert.Empty(t, violations)
+
+			// INFO: refund recorded only in the ledger makes fundraise balance drift from its payments.
+			refund := ledger.New(ledger.KindRefund, uuid.NewString(), time.Now())
+			refund.Move(
+				ledger.FundraiseBalance(fundraise.ID), currencies.NewMoney(currencies.Major(40), fundraise.Currency),
+				ledger.Refunds(payments.TypeStripe), currencies.NewMoney(currencies.Major(1), donation.Currency),
+			)
+			_, err = ledgerRepository.Post(ctx, refund)
+			require.NoError(t, err)
+
+			violations, err = ledgerRepository.Check(ctx)
+			require.NoError(t, err)
+			require.Len(t, violations, 1)
+			assert.Equal(t, ledger.FundraiseBalance(fundraise.ID), violations[0].Subject)
+
+			payment.RefundedAmount = currencies.Major(1)
+			payment.Status = payments.StatusPartiallyRefunded
+			require.NoError(t, db.Payments().Update(ctx, payment))
+
+			violations, err = ledgerRepository.Check(ctx)
+			require.NoError(t, err)
+			assert.Empty(t, violations)
+		})
+	})
+}
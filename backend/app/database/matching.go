@@ -0,0 +1,249 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+
+	"one-help/app/currencies"
+	"one-help/app/fundraises/matching"
+)
+
+// ErrMatching indicates that there was an error in the database.
+var ErrMatching = errs.Class("matching repository")
+
+// matchingDB provides access to matching pledges db.
+//
+// architecture: Database
+type matchingDB struct {
+	conn *sql.DB
+}
+
+// newMatchingDB is a constructor for base matchingDB.
+func newMatchingDB(baseConn *sql.DB) matching.DB {
+	return &matchingDB{
+		conn: baseConn,
+	}
+}
+
+// pledgeColumns lists selected pledge columns in the scan order.
+const pledgeColumns = `pledge_id, fundraise_id, sponsor_name, ratio, cap, matched, starts_at, ends_at, created_by, created_at`
+
+// CreatePledge inserts pledge into the database.
+func (db *matchingDB) CreatePledge(ctx context.Context, pledge matching.Pledge) error {
+	query := `INSERT INTO matching_pledges(` + pledgeColumns + `)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+	_, err := db.conn.ExecContext(ctx, query,
+		pledge.ID,
+		pledge.FundraiseID,
+		pledge.SponsorName,
+		pledge.Ratio,
+		pledge.Cap,
+		pledge.Matched,
+		pledge.StartsAt,
+		pledge.EndsAt,
+		pledge.CreatedBy,
+		pledge.CreatedAt,
+	)
+	return ErrMatching.Wrap(err)
+}
+
+// ListPledges returns pledges of the fundraise, the oldest first.
+func (db *matchingDB) ListPledges(ctx context.Context, fundraiseID uuid.UUID) (_ []matching.Pledge, err error) {
+	query := `SELECT ` + pledgeColumns + ` FROM matching_pledges WHERE fundraise_id = $1 ORDER BY created_at`
+	rows, err := db.conn.QueryContext(ctx, query, fundraiseID)
+	if err != nil {
+		return nil, ErrMatching.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var pledges []matching.Pledge
+	for rows.Next() {
+		pledge, err := scanPledge(rows)
+		if err != nil {
+			return nil, ErrMatching.Wrap(err)
+		}
+
+		pledges = append(pledges, pledge)
+	}
+
+	return pledges, ErrMatching.Wrap(rows.Err())
+}
+
+// Match inserts match of the donation, reducing its amount to the remaining budget of the pledge, and returns it.
+// Returns stored match if the donation was already matched by the pledge, zero amount match is not stored.
+func (db *matchingDB) Match(ctx context.Context, match matching.Match) (_ matching.Match, err error) {
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return matching.Match{}, ErrMatching.Wrap(err)
+	}
+
+	defer DeferCommitRollback(tx, &err)
+
+	// INFO: locks the pledge, so concurrent matches do not exceed the cap.
+	query := `SELECT ` + pledgeColumns + ` FROM matching_pledges WHERE pledge_id = $1 FOR UPDATE`
+	pledge, err := scanPledge(tx.QueryRowContext(ctx, query, match.PledgeID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return matching.Match{}, ErrMatching.Wrap(matching.ErrNoPledge)
+		}
+
+		return matching.Match{}, ErrMatching.Wrap(err)
+	}
+
+	query = `SELECT pledge_id, donation_id, amount, released, created_at FROM donation_matches WHERE pledge_id = $1 AND donation_id = $2`
+	var stored matching.Match
+	err = tx.QueryRowContext(ctx, query, match.PledgeID, match.DonationID).Scan(
+		&stored.PledgeID,
+		&stored.DonationID,
+		&stored.Amount,
+		&stored.Released,
+		&stored.CreatedAt,
+	)
+	if err == nil {
+		return stored, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return matching.Match{}, ErrMatching.Wrap(err)
+	}
+	err = nil
+
+	match.Amount = match.Amount.Min(pledge.Remaining())
+	if !match.Amount.IsPositive() {
+		match.Amount = currencies.Zero
+		return match, nil
+	}
+
+	query = `INSERT INTO donation_matches(pledge_id, donation_id, amount, created_at) VALUES ($1, $2, $3, $4)`
+	if _, err = tx.ExecContext(ctx, query, match.PledgeID, match.DonationID, match.Amount, match.CreatedAt); err != nil {
+		return matching.Match{}, ErrMatching.Wrap(err)
+	}
+
+	query = `UPDATE matching_pledges SET matched = matched + $2 WHERE pledge_id = $1`
+	if _, err = tx.ExecContext(ctx, query, match.PledgeID, match.Amount); err != nil {
+		return matching.Match{}, ErrMatching.Wrap(err)
+	}
+
+	return match, nil
+}
+
+// Release returns provided share of the donation matches to the budgets of their pledges and returns released amount.
+// Share is the total refunded part of the donation, so repeated release of the same share releases nothing.
+func (db *matchingDB) Release(ctx context.Context, donationID uuid.UUID, share float64) (released currencies.Amount, err error) {
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return currencies.Zero, ErrMatching.Wrap(err)
+	}
+
+	defer DeferCommitRollback(tx, &err)
+
+	// INFO: locks pledges before their matches in the same order as Match, so concurrent match and release do not deadlock.
+	query := `SELECT pledge_id FROM matching_pledges
+              WHERE pledge_id IN (SELECT pledge_id FROM donation_matches WHERE donation_id = $1)
+              ORDER BY pledge_id
+              FOR UPDATE`
+	if _, err = tx.ExecContext(ctx, query, donationID); err != nil {
+		return currencies.Zero, ErrMatching.Wrap(err)
+	}
+
+	query = `SELECT pledge_id, donation_id, amount, released, created_at FROM donation_matches WHERE donation_id = $1`
+	rows, err := tx.QueryContext(ctx, query, donationID)
+	if err != nil {
+		return currencies.Zero, ErrMatching.Wrap(err)
+	}
+
+	var matches []matching.Match
+	for rows.Next() {
+		var match matching.Match
+		if err = rows.Scan(&match.PledgeID, &match.DonationID, &match.Amount, &match.Released, &match.CreatedAt); err != nil {
+			return currencies.Zero, ErrMatching.Wrap(errs.Combine(err, rows.Close()))
+		}
+
+		matches = append(matches, match)
+	}
+	if err = errs.Combine(rows.Err(), rows.Close()); err != nil {
+		return currencies.Zero, ErrMatching.Wrap(err)
+	}
+
+	for _, match := range matches {
+		delta := match.Amount.Mul(share).Min(match.Amount).Sub(match.Released)
+		if !delta.IsPositive() {
+			continue
+		}
+
+		query = `UPDATE donation_matches SET released = released + $3 WHERE pledge_id = $1 AND donation_id = $2`
+		if _, err = tx.ExecContext(ctx, query, match.PledgeID, match.DonationID, delta); err != nil {
+			return currencies.Zero, ErrMatching.Wrap(err)
+		}
+
+		query = `UPDATE matching_pledges SET matched = matched - $2 WHERE pledge_id = $1`
+		if _, err = tx.ExecContext(ctx, query, match.PledgeID, delta); err != nil {
+			return currencies.Zero, ErrMatching.Wrap(err)
+		}
+
+		released = released.Add(delta)
+	}
+
+	return released, nil
+}
+
+// ListUnmatched returns confirmed not refunded donations created since provided time, which were not matched
+// by pledges active at their time and having remaining budget.
+func (db *matchingDB) ListUnmatched(ctx context.Context, since time.Time) (_ []uuid.UUID, err error) {
+	query := `SELECT DISTINCT d.donation_id
+              FROM donations d
+              JOIN payments p ON p.donation_id = d.donation_id
+              JOIN matching_pledges mp ON mp.fundraise_id = d.fundraise_id
+              WHERE d.created_at >= $1 AND p.confirmed AND p.refunded_amount = 0
+                AND d.created_at >= mp.starts_at AND d.created_at < mp.ends_at AND mp.matched < mp.cap
+                AND NOT EXISTS (SELECT 1 FROM donation_matches m WHERE m.pledge_id = mp.pledge_id AND m.donation_id = d.donation_id)`
+	rows, err := db.conn.QueryContext(ctx, query, since)
+	if err != nil {
+		return nil, ErrMatching.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err = rows.Scan(&id); err != nil {
+			return nil, ErrMatching.Wrap(err)
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, ErrMatching.Wrap(rows.Err())
+}
+
+// Matched returns total of the fundraise matches created within the period, excluding released amounts.
+func (db *matchingDB) Matched(ctx context.Context, fundraiseID uuid.UUID, from, to time.Time) (matched currencies.Amount, err error) {
+	query := `SELECT COALESCE(SUM(m.amount - m.released), 0)
+              FROM donation_matches m
+              JOIN matching_pledges p ON p.pledge_id = m.pledge_id
+              WHERE p.fundraise_id = $1 AND m.created_at >= $2 AND m.created_at < $3`
+	err = db.conn.QueryRowContext(ctx, query, fundraiseID, from, to).Scan(&matched)
+	return matched, ErrMatching.Wrap(err)
+}
+
+// scanPledge scans pledge columns from the row.
+func scanPledge(row interface{ Scan(dest ...any) error }) (matching.Pledge, error) {
+	var pledge matching.Pledge
+	err := row.Scan(
+		&pledge.ID,
+		&pledge.FundraiseID,
+		&pledge.SponsorName,
+		&pledge.Ratio,
+		&pledge.Cap,
+		&pledge.Matched,
+		&pledge.StartsAt,
+		&pledge.EndsAt,
+		&pledge.CreatedBy,
+		&pledge.CreatedAt,
+	)
+	return pledge, err
+}
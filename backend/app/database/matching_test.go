@@ -0,0 +1,171 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app"
+	"one-help/app/currencies"
+	"one-help/app/database"
+	"one-help/app/database/dbtesting"
+	"one-help/app/donations"
+	"one-help/app/fundraises"
+	"one-help/app/fundraises/matching"
+	"one-help/app/fundraises/statuses"
+	"one-help/app/payments"
+	"one-help/app/users"
+)
+
+func TestMatching(t *testing.T) {
+	user := users.User{
+		ID:        uuid.New(),
+		FirstName: "John",
+		LastName:  "Doe",
+	}
+
+	fundraise := fundraises.Fundraise{
+		ID:           uuid.New(),
+		OrganizerId:  user.ID,
+		Title:        "Test",
+		Description:  "Test Description",
+		TargetAmount: currencies.Major(1000),
+		Currency:     currencies.UAH,
+		StartDate:    time.Now(),
+		Status:       statuses.ActiveStatus,
+	}
+
+	donation1 := donations.Donation{
+		ID:          uuid.New(),
+		UserId:      user.ID,
+		FundraiseId: fundraise.ID,
+		Amount:      currencies.Major(60),
+		Currency:    currencies.UAH,
+		CreatedAt:   time.Now(),
+	}
+	donation2 := donation1
+	donation2.ID = uuid.New()
+	donation3 := donation1
+	donation3.ID = uuid.New()
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	pledge := matching.Pledge{
+		ID:          uuid.New(),
+		FundraiseID: fundraise.ID,
+		SponsorName: "Sponsor",
+		Ratio:       1,
+		Cap:         currencies.Major(100),
+		StartsAt:    now.Add(-time.Hour),
+		EndsAt:      now.Add(time.Hour),
+		CreatedBy:   user.ID,
+		CreatedAt:   now,
+	}
+
+	dbtesting.Run(t, database.Config{}, func(ctx context.Context, t *testing.T, db app.DB) {
+		matchingRepository := db.Matching()
+
+		t.Run("CreatePledge&ListPledges", func(t *testing.T) {
+			require.NoError(t, db.Users().Create(ctx, user))
+			require.NoError(t, db.Fundraises().Create(ctx, fundraise))
+			require.NoError(t, db.Donations().Create(ctx, donation1))
+			require.NoError(t, db.Donations().Create(ctx, donation2))
+			require.NoError(t, db.Donations().Create(ctx, donation3))
+			require.NoError(t, matchingRepository.CreatePledge(ctx, pledge))
+
+			pledges, err := matchingRepository.ListPledges(ctx, fundraise.ID)
+			require.NoError(t, err)
+			require.Len(t, pledges, 1)
+			assert.Equal(t, pledge.ID, pledges[0].ID)
+			assert.Equal(t, pledge.SponsorName, pledges[0].SponsorName)
+			assert.Equal(t, pledge.Ratio, pledges[0].Ratio)
+			assert.Equal(t, pledge.Cap, pledges[0].Cap)
+			assert.Zero(t, pledges[0].Matched)
+			assert.True(t, pledge.EndsAt.Equal(pledges[0].EndsAt))
+		})
+
+		t.Run("Match", func(t *testing.T) {
+			match := matching.Match{PledgeID: pledge.ID, DonationID: donation1.ID, Amount: currencies.Major(60), CreatedAt: now}
+
+			stored, err := matchingRepository.Match(ctx, match)
+			require.NoError(t, err)
+			assert.Equal(t, currencies.Major(60), stored.Amount)
+
+			// INFO: repeated match of the donation is not applied twice.
+			stored, err = matchingRepository.Match(ctx, match)
+			require.NoError(t, err)
+			assert.Equal(t, currencies.Major(60), stored.Amount)
+
+			// INFO: match is reduced to the remaining budget.
+			stored, err = matchingRepository.Match(ctx, matching.Match{PledgeID: pledge.ID, DonationID: donation2.ID, Amount: currencies.Major(60), CreatedAt: now})
+			require.NoError(t, err)
+			assert.Equal(t, currencies.Major(40), stored.Amount)
+
+			pledges, err := matchingRepository.ListPledges(ctx, fundraise.ID)
+			require.NoError(t, err)
+			assert.Equal(t, currencies.Major(100), pledges[0].Matched)
+
+			matched, err := matchingRepository.Matched(ctx, fundraise.ID, now.Add(-time.Minute), now.Add(time.Minute))
+			require.NoError(t, err)
+			assert.Equal(t, currencies.Major(100), matched)
+		})
+
+		t.Run("Release", func(t *testing.T) {
+			released, err := matchingRepository.Release(ctx, donation1.ID, 0.5)
+			require.NoError(t, err)
+			assert.Equal(t, currencies.Major(30), released)
+
+			// INFO: repeated release of the same share releases nothing.
+			released, err = matchingRepository.Release(ctx, donation1.ID, 0.5)
+			require.NoError(t, err)
+			assert.Zero(t, released)
+
+			released, err = matchingRepository.Release(ctx, donation1.ID, 1)
+			require.NoError(t, err)
+			assert.Equal(t, currencies.Major(30), released)
+
+			pledges, err := matchingRepository.ListPledges(ctx, fundraise.ID)
+			require.NoError(t, err)
+			assert.Equal(t, currencies.Major(40), pledges[0].Matched)
+
+			matched, err := matchingRepository.Matched(ctx, fundraise.ID, now.Add(-time.Minute), now.Add(time.Minute))
+			require.NoError(t, err)
+			assert.Equal(t, currencies.Major(40), matched)
+
+			// INFO: released match is kept, so the donation is not matched again.
+			stored, err := matchingRepository.Match(ctx, matching.Match{PledgeID: pledge.ID, DonationID: donation1.ID, Amount: currencies.Major(60), CreatedAt: now})
+			require.NoError(t, err)
+			assert.Equal(t, currencies.Major(60), stored.Released)
+		})
+
+		t.Run("ListUnmatched", func(t *testing.T) {
+			for _, donation := range []donations.Donation{donation1, donation2, donation3} {
+				require.NoError(t, db.Payments().Create(ctx, payments.Payment{
+					DonationId:    donation.ID,
+					PaymentType:   payments.TypeStripe,
+					TransactionId: "cs_" + donation.ID.String(),
+					Confirmed:     true,
+				}))
+			}
+
+			unmatched, err := matchingRepository.ListUnmatched(ctx, now.Add(-time.Hour))
+			require.NoError(t, err)
+			assert.Equal(t, []uuid.UUID{donation3.ID}, unmatched)
+
+			_, err = matchingRepository.Match(ctx, matching.Match{PledgeID: pledge.ID, DonationID: donation3.ID, Amount: currencies.Major(60), CreatedAt: now})
+			require.NoError(t, err)
+
+			unmatched, err = matchingRepository.ListUnmatched(ctx, now.Add(-time.Hour))
+			require.NoError(t, err)
+			assert.Empty(t, unmatched)
+		})
+
+		t.Run("Match(negative)", func(t *testing.T) {
+			_, err := matchingRepository.Match(ctx, matching.Match{PledgeID: uuid.New(), DonationID: donation1.ID, Amount: currencies.Major(1)})
+			require.ErrorIs(t, err, matching.ErrNoPledge)
+		})
+	})
+}
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS comment_reactions;
+DROP TABLE IF EXISTS comments;
+DROP TABLE IF EXISTS reaction_types;
+
+ALTER TABLE users DROP COLUMN IF EXISTS role;
+DROP TABLE IF EXISTS user_roles;
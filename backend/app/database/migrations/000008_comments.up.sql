@@ -0,0 +1,57 @@
+CREATE TABLE IF NOT EXISTS user_roles (
+role VARCHAR PRIMARY KEY
+);
+
+INSERT INTO user_roles(role) VALUES
+('USER'),
+('MODERATOR'),
+('ADMIN')
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR NOT NULL DEFAULT 'USER' REFERENCES user_roles(role) ON UPDATE CASCADE;
+
+CREATE TABLE IF NOT EXISTS reaction_types (
+type VARCHAR PRIMARY KEY
+);
+
+INSERT INTO reaction_types(type) VALUES
+('LIKE'),
+('HEART'),
+('SUPPORT'),
+('FIRE')
+ON CONFLICT DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS comments (
+comment_id   UUID    PRIMARY KEY      NOT NULL,
+parent_id    UUID                         NULL,
+fundraise_id UUID                         NULL,
+event_id     UUID                         NULL,
+author_id    UUID                     NOT NULL,
+body         VARCHAR                  NOT NULL,
+pinned       BOOLEAN                  NOT NULL DEFAULT FALSE,
+hidden       BOOLEAN                  NOT NULL DEFAULT FALSE,
+hidden_by    UUID                         NULL,
+deleted      BOOLEAN                  NOT NULL DEFAULT FALSE,
+created_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+updated_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+CHECK ((fundraise_id IS NULL) <> (event_id IS NULL)),
+FOREIGN KEY(parent_id) REFERENCES comments(comment_id) ON UPDATE CASCADE ON DELETE CASCADE,
+FOREIGN KEY(fundraise_id) REFERENCES fundraises(fundraise_id) ON UPDATE CASCADE ON DELETE CASCADE,
+FOREIGN KEY(event_id) REFERENCES events(event_id) ON UPDATE CASCADE ON DELETE CASCADE,
+FOREIGN KEY(author_id) REFERENCES users(user_id) ON UPDATE CASCADE ON DELETE CASCADE,
+FOREIGN KEY(hidden_by) REFERENCES users(user_id) ON UPDATE CASCADE ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS comments_fundraise_idx ON comments(fundraise_id, created_at DESC, comment_id DESC);
+CREATE INDEX IF NOT EXISTS comments_event_idx ON comments(event_id, created_at DESC, comment_id DESC);
+CREATE INDEX IF NOT EXISTS comments_parent_idx ON comments(parent_id, created_at DESC, comment_id DESC);
+
+CREATE TABLE IF NOT EXISTS comment_reactions (
+comment_id UUID    NOT NULL,
+user_id    UUID    NOT NULL,
+type       VARCHAR NOT NULL,
+PRIMARY KEY(comment_id, user_id, type),
+FOREIGN KEY(comment_id) REFERENCES comments(comment_id) ON UPDATE CASCADE ON DELETE CASCADE,
+FOREIGN KEY(user_id) REFERENCES users(user_id) ON UPDATE CASCADE ON DELETE CASCADE,
+FOREIGN KEY(type) REFERENCES reaction_types(type) ON UPDATE CASCADE ON DELETE CASCADE
+);
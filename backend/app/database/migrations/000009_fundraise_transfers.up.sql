@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS fundraise_transfers (
+transfer_id       UUID    PRIMARY KEY      NOT NULL,
+from_fundraise_id UUID                     NOT NULL,
+to_fundraise_id   UUID                     NOT NULL,
+amount            NUMERIC(72, 18)          NOT NULL CHECK (amount > 0),
+initiated_by      UUID                     NOT NULL,
+reason            VARCHAR                  NOT NULL,
+created_at        TIMESTAMP WITH TIME ZONE NOT NULL,
+CHECK (from_fundraise_id <> to_fundraise_id),
+FOREIGN KEY(from_fundraise_id) REFERENCES fundraises(fundraise_id) ON UPDATE CASCADE ON DELETE NO ACTION,
+FOREIGN KEY(to_fundraise_id) REFERENCES fundraises(fundraise_id) ON UPDATE CASCADE ON DELETE NO ACTION,
+FOREIGN KEY(initiated_by) REFERENCES users(user_id) ON UPDATE CASCADE ON DELETE NO ACTION
+);
+
+CREATE INDEX IF NOT EXISTS fundraise_transfers_from_idx ON fundraise_transfers(from_fundraise_id);
+CREATE INDEX IF NOT EXISTS fundraise_transfers_to_idx ON fundraise_transfers(to_fundraise_id);
@@ -0,0 +1,9 @@
+ALTER TABLE fundraise_transfers DROP COLUMN IF EXISTS exchange_rate;
+
+ALTER TABLE donations DROP COLUMN IF EXISTS rated_at;
+ALTER TABLE donations DROP COLUMN IF EXISTS exchange_rate;
+ALTER TABLE donations DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE fundraises DROP COLUMN IF EXISTS currency;
+
+DROP TABLE IF EXISTS currencies;
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS currencies (
+code VARCHAR PRIMARY KEY
+);
+
+INSERT INTO currencies(code) VALUES
+('UAH'),
+('EUR'),
+('USD'),
+('PLN')
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE fundraises ADD COLUMN IF NOT EXISTS currency VARCHAR NOT NULL DEFAULT 'UAH' REFERENCES currencies(code) ON UPDATE CASCADE;
+
+ALTER TABLE donations ADD COLUMN IF NOT EXISTS currency      VARCHAR                  NOT NULL DEFAULT 'UAH' REFERENCES currencies(code) ON UPDATE CASCADE;
+ALTER TABLE donations ADD COLUMN IF NOT EXISTS exchange_rate NUMERIC(72, 18)          NOT NULL DEFAULT 1;
+ALTER TABLE donations ADD COLUMN IF NOT EXISTS rated_at      TIMESTAMP WITH TIME ZONE     NULL;
+
+ALTER TABLE fundraise_transfers ADD COLUMN IF NOT EXISTS exchange_rate NUMERIC(72, 18) NOT NULL DEFAULT 1;
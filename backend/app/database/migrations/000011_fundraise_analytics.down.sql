@@ -0,0 +1 @@
+DROP INDEX IF EXISTS donations_fundraise_id_created_at_idx;
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS donations_fundraise_id_created_at_idx ON donations(fundraise_id, created_at);
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS fundraise_reviews;
+DROP TABLE IF EXISTS review_decisions;
+
+ALTER TABLE users DROP COLUMN IF EXISTS trusted;
+
+UPDATE fundraises SET status = 'ACTIVE' WHERE status = 'PENDING_REVIEW';
+UPDATE fundraises SET status = 'CANCELLED' WHERE status = 'REJECTED';
+DELETE FROM fundraise_statuses WHERE status IN ('PENDING_REVIEW', 'REJECTED');
@@ -0,0 +1,31 @@
+INSERT INTO fundraise_statuses(status) VALUES
+('PENDING_REVIEW'),
+('REJECTED')
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS trusted BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS review_decisions (
+decision VARCHAR PRIMARY KEY
+);
+
+INSERT INTO review_decisions(decision) VALUES
+('SUBMITTED'),
+('RESUBMITTED'),
+('APPROVED'),
+('REJECTED')
+ON CONFLICT DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS fundraise_reviews (
+review_id    UUID PRIMARY KEY         NOT NULL,
+fundraise_id UUID                     NOT NULL,
+actor_id     UUID                     NOT NULL,
+decision     VARCHAR                  NOT NULL,
+reason       VARCHAR                  NOT NULL DEFAULT '',
+created_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+FOREIGN KEY(fundraise_id) REFERENCES fundraises(fundraise_id) ON UPDATE CASCADE ON DELETE CASCADE,
+FOREIGN KEY(actor_id) REFERENCES users(user_id) ON UPDATE CASCADE ON DELETE NO ACTION,
+FOREIGN KEY(decision) REFERENCES review_decisions(decision) ON UPDATE CASCADE ON DELETE NO ACTION
+);
+
+CREATE INDEX IF NOT EXISTS fundraise_reviews_fundraise_id_idx ON fundraise_reviews(fundraise_id, created_at);
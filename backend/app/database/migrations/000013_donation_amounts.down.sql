@@ -0,0 +1,4 @@
+ALTER TABLE donations DROP COLUMN IF EXISTS requested_amount;
+
+ALTER TABLE fundraises DROP COLUMN IF EXISTS donation_presets;
+ALTER TABLE fundraises DROP COLUMN IF EXISTS min_donation;
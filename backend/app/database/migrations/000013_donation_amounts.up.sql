@@ -0,0 +1,4 @@
+ALTER TABLE fundraises ADD COLUMN IF NOT EXISTS min_donation     NUMERIC(72, 18)   NOT NULL DEFAULT 0;
+ALTER TABLE fundraises ADD COLUMN IF NOT EXISTS donation_presets NUMERIC(72, 18)[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE donations ADD COLUMN IF NOT EXISTS requested_amount NUMERIC(72, 18) NOT NULL DEFAULT 0;
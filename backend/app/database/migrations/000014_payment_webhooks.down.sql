@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS webhook_events;
+
+DROP INDEX IF EXISTS payments_reference_idx;
+DROP INDEX IF EXISTS payments_transaction_id_idx;
+
+ALTER TABLE payments DROP COLUMN IF EXISTS refunded_amount;
+ALTER TABLE payments DROP COLUMN IF EXISTS reference;
+ALTER TABLE payments DROP COLUMN IF EXISTS status;
+
+DROP TABLE IF EXISTS payment_statuses;
@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS payment_statuses (
+status VARCHAR PRIMARY KEY
+);
+
+INSERT INTO payment_statuses(status) VALUES
+('PENDING'),
+('CONFIRMED'),
+('FAILED'),
+('CANCELED'),
+('PARTIALLY_REFUNDED'),
+('REFUNDED')
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS status          VARCHAR         NOT NULL DEFAULT 'PENDING' REFERENCES payment_statuses(status) ON UPDATE CASCADE;
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS reference       VARCHAR         NOT NULL DEFAULT '';
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS refunded_amount NUMERIC(72, 18) NOT NULL DEFAULT 0;
+
+UPDATE payments SET status = 'CONFIRMED' WHERE confirmed;
+
+CREATE INDEX IF NOT EXISTS payments_transaction_id_idx ON payments(payment_type, transaction_id);
+CREATE INDEX IF NOT EXISTS payments_reference_idx ON payments(payment_type, reference) WHERE reference <> '';
+
+CREATE TABLE IF NOT EXISTS webhook_events (
+provider     VARCHAR                  NOT NULL,
+event_id     VARCHAR                  NOT NULL,
+type         VARCHAR                  NOT NULL,
+payload      JSONB                    NOT NULL,
+received_at  TIMESTAMP WITH TIME ZONE NOT NULL,
+processed_at TIMESTAMP WITH TIME ZONE     NULL,
+PRIMARY KEY(provider, event_id),
+FOREIGN KEY(provider) REFERENCES payment_types(type) ON UPDATE CASCADE ON DELETE CASCADE
+);
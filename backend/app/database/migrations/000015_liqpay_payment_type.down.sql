@@ -0,0 +1 @@
+DELETE FROM payment_types WHERE type IN ('LIQPAY');
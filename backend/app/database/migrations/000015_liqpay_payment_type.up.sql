@@ -0,0 +1,3 @@
+INSERT INTO payment_types(type) VALUES
+('LIQPAY')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,5 @@
+ALTER TABLE donations DROP COLUMN IF EXISTS plan_id;
+
+DROP TABLE IF EXISTS donation_plans;
+DROP TABLE IF EXISTS donation_plan_intervals;
+DROP TABLE IF EXISTS donation_plan_statuses;
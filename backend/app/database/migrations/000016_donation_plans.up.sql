@@ -0,0 +1,51 @@
+CREATE TABLE IF NOT EXISTS donation_plan_statuses (
+status VARCHAR PRIMARY KEY
+);
+
+INSERT INTO donation_plan_statuses(status) VALUES
+('PENDING'),
+('ACTIVE'),
+('PAUSED'),
+('PAST_DUE'),
+('CANCELED')
+ON CONFLICT DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS donation_plan_intervals (
+interval VARCHAR PRIMARY KEY
+);
+
+INSERT INTO donation_plan_intervals(interval) VALUES
+('MONTH'),
+('YEAR')
+ON CONFLICT DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS donation_plans (
+plan_id         UUID PRIMARY KEY         NOT NULL,
+user_id         UUID                     NOT NULL,
+fundraise_id    UUID                         NULL,
+organizer_id    UUID                         NULL,
+amount          NUMERIC(72, 18)          NOT NULL,
+currency        VARCHAR                  NOT NULL,
+interval        VARCHAR                  NOT NULL,
+payment_type    VARCHAR                  NOT NULL,
+transaction_id  VARCHAR                  NOT NULL,
+subscription_id VARCHAR                  NOT NULL DEFAULT '',
+status          VARCHAR                  NOT NULL,
+failed_attempts INTEGER                  NOT NULL DEFAULT 0,
+next_retry_at   TIMESTAMP WITH TIME ZONE     NULL,
+created_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+updated_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+FOREIGN KEY(user_id) REFERENCES users(user_id) ON UPDATE CASCADE ON DELETE CASCADE,
+FOREIGN KEY(fundraise_id) REFERENCES fundraises(fundraise_id) ON UPDATE CASCADE ON DELETE CASCADE,
+FOREIGN KEY(organizer_id) REFERENCES users(user_id) ON UPDATE CASCADE ON DELETE CASCADE,
+FOREIGN KEY(payment_type) REFERENCES payment_types(type) ON UPDATE CASCADE ON DELETE NO ACTION,
+FOREIGN KEY(status) REFERENCES donation_plan_statuses(status) ON UPDATE CASCADE ON DELETE NO ACTION,
+FOREIGN KEY(interval) REFERENCES donation_plan_intervals(interval) ON UPDATE CASCADE ON DELETE NO ACTION,
+CHECK ((fundraise_id IS NULL) <> (organizer_id IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS donation_plans_user_id_idx ON donation_plans(user_id, created_at);
+CREATE INDEX IF NOT EXISTS donation_plans_subscription_idx ON donation_plans(payment_type, subscription_id) WHERE subscription_id <> '';
+CREATE INDEX IF NOT EXISTS donation_plans_next_retry_at_idx ON donation_plans(next_retry_at) WHERE status = 'PAST_DUE';
+
+ALTER TABLE donations ADD COLUMN IF NOT EXISTS plan_id UUID NULL REFERENCES donation_plans(plan_id) ON UPDATE CASCADE ON DELETE SET NULL;
@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS refund_statuses (
+status VARCHAR PRIMARY KEY
+);
+
+INSERT INTO refund_statuses(status) VALUES
+('PENDING_APPROVAL'),
+('PROCESSING'),
+('SUCCEEDED'),
+('FAILED'),
+('REJECTED')
+ON CONFLICT DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS refunds (
+refund_id       UUID PRIMARY KEY         NOT NULL,
+donation_id     UUID                     NOT NULL,
+amount          NUMERIC(72, 18)          NOT NULL,
+currency        VARCHAR                  NOT NULL,
+reason          VARCHAR                  NOT NULL,
+status          VARCHAR                  NOT NULL,
+requested_by    UUID                     NOT NULL,
+reviewed_by     UUID                         NULL,
+review_reason   VARCHAR                  NOT NULL DEFAULT '',
+failure_message VARCHAR                  NOT NULL DEFAULT '',
+created_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+updated_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+FOREIGN KEY(donation_id) REFERENCES payments(donation_id) ON UPDATE CASCADE ON DELETE CASCADE,
+FOREIGN KEY(requested_by) REFERENCES users(user_id) ON UPDATE CASCADE ON DELETE NO ACTION,
+FOREIGN KEY(reviewed_by) REFERENCES users(user_id) ON UPDATE CASCADE ON DELETE NO ACTION,
+FOREIGN KEY(status) REFERENCES refund_statuses(status) ON UPDATE CASCADE ON DELETE NO ACTION
+);
+
+CREATE INDEX IF NOT EXISTS refunds_donation_id_idx ON refunds(donation_id, created_at);
+CREATE INDEX IF NOT EXISTS refunds_pending_approval_idx ON refunds(created_at) WHERE status = 'PENDING_APPROVAL';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS payments_pending_idx;
+DROP TABLE IF EXISTS reconciliation_reports;
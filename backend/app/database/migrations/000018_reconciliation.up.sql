@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS reconciliation_reports (
+report_date  DATE                     NOT NULL,
+payment_type VARCHAR                  NOT NULL,
+currency     VARCHAR                  NOT NULL,
+confirmed    NUMERIC(72, 18)          NOT NULL,
+collected    NUMERIC(72, 18)          NOT NULL,
+created_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+PRIMARY KEY(report_date, payment_type, currency),
+FOREIGN KEY(payment_type) REFERENCES payment_types(type) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS payments_pending_idx ON payments(donation_id) WHERE status = 'PENDING';
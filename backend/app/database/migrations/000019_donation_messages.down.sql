@@ -0,0 +1,2 @@
+ALTER TABLE donations DROP COLUMN IF EXISTS message;
+ALTER TABLE donations DROP COLUMN IF EXISTS anonymous;
@@ -0,0 +1,2 @@
+ALTER TABLE donations ADD COLUMN IF NOT EXISTS anonymous BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE donations ADD COLUMN IF NOT EXISTS message   VARCHAR NOT NULL DEFAULT '';
@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS receipt_counters (
+organizer_id UUID PRIMARY KEY NOT NULL,
+last_number  BIGINT           NOT NULL DEFAULT 0,
+FOREIGN KEY(organizer_id) REFERENCES users(user_id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS receipts (
+receipt_id        UUID PRIMARY KEY         NOT NULL,
+donation_id       UUID                     NOT NULL UNIQUE,
+number            BIGINT                   NOT NULL,
+organizer_id      UUID                     NOT NULL,
+organizer_name    VARCHAR                  NOT NULL,
+organizer_website VARCHAR                  NOT NULL DEFAULT '',
+fundraise_id      UUID                     NOT NULL,
+fundraise_title   VARCHAR                  NOT NULL,
+donor_name        VARCHAR                  NOT NULL DEFAULT '',
+amount            NUMERIC(72, 18)          NOT NULL,
+currency          VARCHAR                  NOT NULL,
+payment_type      VARCHAR                  NOT NULL,
+transaction_id    VARCHAR                  NOT NULL,
+donated_at        TIMESTAMP WITH TIME ZONE NOT NULL,
+issued_at         TIMESTAMP WITH TIME ZONE NOT NULL,
+UNIQUE(organizer_id, number),
+FOREIGN KEY(donation_id) REFERENCES payments(donation_id) ON UPDATE CASCADE ON DELETE CASCADE,
+FOREIGN KEY(organizer_id) REFERENCES receipt_counters(organizer_id) ON UPDATE CASCADE ON DELETE CASCADE
+);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+user_id      UUID                     NOT NULL,
+key          VARCHAR                  NOT NULL,
+fingerprint  VARCHAR                  NOT NULL,
+completed    BOOLEAN                  NOT NULL DEFAULT FALSE,
+status       INTEGER                  NOT NULL DEFAULT 0,
+content_type VARCHAR                  NOT NULL DEFAULT '',
+body         BYTEA                        NULL,
+created_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+expires_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+PRIMARY KEY(user_id, key),
+FOREIGN KEY(user_id) REFERENCES users(user_id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idempotency_keys_expires_at_idx ON idempotency_keys(expires_at);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS donation_matches;
+DROP TABLE IF EXISTS matching_pledges;
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS matching_pledges (
+pledge_id    UUID PRIMARY KEY         NOT NULL,
+fundraise_id UUID                     NOT NULL,
+sponsor_name VARCHAR                  NOT NULL,
+ratio        NUMERIC(72, 18)          NOT NULL,
+cap          NUMERIC(72, 18)          NOT NULL,
+matched      NUMERIC(72, 18)          NOT NULL DEFAULT 0,
+starts_at    TIMESTAMP WITH TIME ZONE NOT NULL,
+ends_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+created_by   UUID                     NOT NULL,
+created_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+CHECK (matched <= cap),
+FOREIGN KEY(fundraise_id) REFERENCES fundraises(fundraise_id) ON UPDATE CASCADE ON DELETE CASCADE,
+FOREIGN KEY(created_by) REFERENCES users(user_id) ON UPDATE CASCADE ON DELETE NO ACTION
+);
+
+CREATE INDEX IF NOT EXISTS matching_pledges_fundraise_id_idx ON matching_pledges(fundraise_id, created_at);
+
+CREATE TABLE IF NOT EXISTS donation_matches (
+pledge_id   UUID                     NOT NULL,
+donation_id UUID                     NOT NULL,
+amount      NUMERIC(72, 18)          NOT NULL,
+created_at  TIMESTAMP WITH TIME ZONE NOT NULL,
+PRIMARY KEY(pledge_id, donation_id),
+FOREIGN KEY(pledge_id) REFERENCES matching_pledges(pledge_id) ON UPDATE CASCADE ON DELETE CASCADE,
+FOREIGN KEY(donation_id) REFERENCES donations(donation_id) ON UPDATE CASCADE ON DELETE CASCADE
+);
@@ -0,0 +1,70 @@
+CREATE TABLE IF NOT EXISTS ledger_transactions (
+transaction_id UUID PRIMARY KEY         NOT NULL,
+kind           VARCHAR                  NOT NULL,
+reference      VARCHAR                  NOT NULL,
+created_at     TIMESTAMP WITH TIME ZONE NOT NULL,
+UNIQUE(kind, reference)
+);
+
+CREATE TABLE IF NOT EXISTS ledger_entries (
+entry_id       BIGSERIAL PRIMARY KEY NOT NULL,
+transaction_id UUID                  NOT NULL,
+account        VARCHAR               NOT NULL,
+currency       VARCHAR               NOT NULL,
+amount         NUMERIC(72, 18)       NOT NULL,
+FOREIGN KEY(transaction_id) REFERENCES ledger_transactions(transaction_id) ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+
+CREATE INDEX IF NOT EXISTS ledger_entries_account_idx ON ledger_entries(account, currency);
+CREATE INDEX IF NOT EXISTS ledger_entries_transaction_id_idx ON ledger_entries(transaction_id);
+
+CREATE OR REPLACE FUNCTION ledger_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'ledger is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER ledger_transactions_append_only BEFORE UPDATE OR DELETE ON ledger_transactions
+FOR EACH ROW EXECUTE PROCEDURE ledger_append_only();
+CREATE TRIGGER ledger_entries_append_only BEFORE UPDATE OR DELETE ON ledger_entries
+FOR EACH ROW EXECUTE PROCEDURE ledger_append_only();
+
+-- INFO: existing confirmations, refunds and transfers are posted once, so that balances stay the same.
+CREATE TEMPORARY TABLE ledger_backfill AS
+SELECT md5('confirmation:' || d.donation_id)::uuid AS transaction_id, 'confirmation' AS kind, d.donation_id::text AS reference,
+       d.created_at, 'donor_clearing:' || p.payment_type AS debit, d.currency AS debit_currency, d.amount AS debit_amount,
+       'fundraise:' || f.fundraise_id AS credit, f.currency AS credit_currency, d.amount * d.exchange_rate AS credit_amount
+FROM donations d
+INNER JOIN payments p ON d.donation_id = p.donation_id
+INNER JOIN fundraises f ON d.fundraise_id = f.fundraise_id
+WHERE p.confirmed OR p.status = 'REFUNDED'
+UNION ALL
+SELECT md5('refund:' || d.donation_id)::uuid, 'refund', d.donation_id::text, d.created_at,
+       'fundraise:' || f.fundraise_id, f.currency, r.amount * d.exchange_rate,
+       'refunds:' || p.payment_type, d.currency, r.amount
+FROM donations d
+INNER JOIN payments p ON d.donation_id = p.donation_id
+INNER JOIN fundraises f ON d.fundraise_id = f.fundraise_id
+CROSS JOIN LATERAL (SELECT CASE WHEN p.confirmed THEN p.refunded_amount ELSE d.amount END AS amount) r
+WHERE (p.confirmed OR p.status = 'REFUNDED') AND r.amount > 0
+UNION ALL
+SELECT md5('transfer:' || t.transfer_id)::uuid, 'transfer', t.transfer_id::text, t.created_at,
+       'fundraise:' || src.fundraise_id, src.currency, t.amount,
+       'fundraise:' || dst.fundraise_id, dst.currency, t.amount * t.exchange_rate
+FROM fundraise_transfers t
+INNER JOIN fundraises src ON t.from_fundraise_id = src.fundraise_id
+INNER JOIN fundraises dst ON t.to_fundraise_id = dst.fundraise_id;
+
+INSERT INTO ledger_transactions(transaction_id, kind, reference, created_at)
+SELECT transaction_id, kind, reference, created_at FROM ledger_backfill;
+
+INSERT INTO ledger_entries(transaction_id, account, currency, amount)
+SELECT transaction_id, debit, debit_currency, debit_amount FROM ledger_backfill
+UNION ALL
+SELECT transaction_id, credit, credit_currency, -credit_amount FROM ledger_backfill
+UNION ALL
+SELECT transaction_id, 'exchange', debit_currency, -debit_amount FROM ledger_backfill WHERE debit_currency <> credit_currency
+UNION ALL
+SELECT transaction_id, 'exchange', credit_currency, credit_amount FROM ledger_backfill WHERE debit_currency <> credit_currency;
+
+DROP TABLE ledger_backfill;
@@ -0,0 +1,44 @@
+CREATE TABLE IF NOT EXISTS bank_details (
+user_id     UUID PRIMARY KEY         NOT NULL,
+holder_name VARCHAR                  NOT NULL,
+iban        VARCHAR                  NOT NULL,
+bank_name   VARCHAR                  NOT NULL DEFAULT '',
+updated_at  TIMESTAMP WITH TIME ZONE NOT NULL,
+FOREIGN KEY(user_id) REFERENCES users(user_id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS payout_statuses (
+status VARCHAR PRIMARY KEY
+);
+
+INSERT INTO payout_statuses(status) VALUES
+('PENDING_APPROVAL'),
+('PROCESSING'),
+('PAID'),
+('FAILED'),
+('REJECTED')
+ON CONFLICT DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS payouts (
+payout_id       UUID PRIMARY KEY         NOT NULL,
+fundraise_id    UUID                     NOT NULL,
+amount          NUMERIC(72, 18)          NOT NULL,
+currency        VARCHAR                  NOT NULL,
+status          VARCHAR                  NOT NULL,
+destination     VARCHAR                  NOT NULL,
+requested_by    UUID                     NOT NULL,
+reviewed_by     UUID                         NULL,
+review_reason   VARCHAR                  NOT NULL DEFAULT '',
+reference       VARCHAR                  NOT NULL DEFAULT '',
+failure_message VARCHAR                  NOT NULL DEFAULT '',
+created_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+updated_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+CHECK (amount > 0),
+FOREIGN KEY(fundraise_id) REFERENCES fundraises(fundraise_id) ON UPDATE CASCADE ON DELETE NO ACTION,
+FOREIGN KEY(requested_by) REFERENCES users(user_id) ON UPDATE CASCADE ON DELETE NO ACTION,
+FOREIGN KEY(reviewed_by) REFERENCES users(user_id) ON UPDATE CASCADE ON DELETE NO ACTION,
+FOREIGN KEY(status) REFERENCES payout_statuses(status) ON UPDATE CASCADE ON DELETE NO ACTION
+);
+
+CREATE INDEX IF NOT EXISTS payouts_fundraise_id_idx ON payouts(fundraise_id, created_at);
+CREATE INDEX IF NOT EXISTS payouts_pending_approval_idx ON payouts(created_at) WHERE status = 'PENDING_APPROVAL';
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS offline_donations;
+DELETE FROM donations WHERE user_id IS NULL;
+ALTER TABLE donations ALTER COLUMN user_id SET NOT NULL;
+DELETE FROM payment_types WHERE type IN ('BANK_TRANSFER', 'CASH', 'MONOBANK_JAR');
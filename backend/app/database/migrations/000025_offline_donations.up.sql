@@ -0,0 +1,19 @@
+INSERT INTO payment_types(type) VALUES
+('BANK_TRANSFER'),
+('CASH'),
+('MONOBANK_JAR')
+ON CONFLICT DO NOTHING;
+
+-- INFO: offline donation may be recorded without the donor.
+ALTER TABLE donations ALTER COLUMN user_id DROP NOT NULL;
+
+CREATE TABLE IF NOT EXISTS offline_donations (
+donation_id  UUID PRIMARY KEY         NOT NULL,
+recorded_by  UUID                     NOT NULL,
+evidence_url VARCHAR                  NOT NULL DEFAULT '',
+notes        VARCHAR                  NOT NULL DEFAULT '',
+received_at  TIMESTAMP WITH TIME ZONE NOT NULL,
+created_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+FOREIGN KEY(donation_id) REFERENCES donations(donation_id) ON UPDATE CASCADE ON DELETE CASCADE,
+FOREIGN KEY(recorded_by) REFERENCES users(user_id) ON UPDATE CASCADE ON DELETE NO ACTION
+);
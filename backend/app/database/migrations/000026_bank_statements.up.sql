@@ -0,0 +1,44 @@
+CREATE TABLE IF NOT EXISTS bank_statements (
+statement_id UUID PRIMARY KEY         NOT NULL,
+fundraise_id UUID                     NOT NULL,
+format       VARCHAR                  NOT NULL,
+imported_by  UUID                     NOT NULL,
+created_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+FOREIGN KEY(fundraise_id) REFERENCES fundraises(fundraise_id) ON UPDATE CASCADE ON DELETE CASCADE,
+FOREIGN KEY(imported_by) REFERENCES users(user_id) ON UPDATE CASCADE ON DELETE NO ACTION
+);
+
+CREATE TABLE IF NOT EXISTS statement_line_statuses (
+status VARCHAR PRIMARY KEY
+);
+
+INSERT INTO statement_line_statuses(status) VALUES
+('MATCHED'),
+('UNMATCHED'),
+('CONFIRMED'),
+('IGNORED')
+ON CONFLICT DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS statement_lines (
+line_id      UUID PRIMARY KEY         NOT NULL,
+statement_id UUID                     NOT NULL,
+fundraise_id UUID                     NOT NULL,
+external_id  VARCHAR                  NOT NULL,
+booked_at    TIMESTAMP WITH TIME ZONE NOT NULL,
+amount       NUMERIC(72, 18)          NOT NULL,
+currency     VARCHAR                  NOT NULL,
+reference    VARCHAR                  NOT NULL DEFAULT '',
+counterparty VARCHAR                  NOT NULL DEFAULT '',
+status       VARCHAR                  NOT NULL,
+donation_id  UUID                         NULL,
+reviewed_by  UUID                         NULL,
+updated_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+UNIQUE (fundraise_id, external_id),
+FOREIGN KEY(statement_id) REFERENCES bank_statements(statement_id) ON UPDATE CASCADE ON DELETE CASCADE,
+FOREIGN KEY(fundraise_id) REFERENCES fundraises(fundraise_id) ON UPDATE CASCADE ON DELETE CASCADE,
+FOREIGN KEY(status) REFERENCES statement_line_statuses(status) ON UPDATE CASCADE ON DELETE NO ACTION,
+FOREIGN KEY(donation_id) REFERENCES donations(donation_id) ON UPDATE CASCADE ON DELETE SET NULL,
+FOREIGN KEY(reviewed_by) REFERENCES users(user_id) ON UPDATE CASCADE ON DELETE NO ACTION
+);
+
+CREATE INDEX IF NOT EXISTS statement_lines_fundraise_status_idx ON statement_lines(fundraise_id, status);
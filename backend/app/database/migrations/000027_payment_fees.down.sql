@@ -0,0 +1,5 @@
+ALTER TABLE payments
+    DROP COLUMN IF EXISTS gross_amount,
+    DROP COLUMN IF EXISTS fee_amount,
+    DROP COLUMN IF EXISTS net_amount,
+    DROP COLUMN IF EXISTS covers_fee;
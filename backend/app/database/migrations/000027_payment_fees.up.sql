@@ -0,0 +1,11 @@
+ALTER TABLE payments
+    ADD COLUMN IF NOT EXISTS gross_amount NUMERIC(72, 18) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS fee_amount   NUMERIC(72, 18) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS net_amount   NUMERIC(72, 18) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS covers_fee   BOOLEAN         NOT NULL DEFAULT FALSE;
+
+-- INFO: fees of the confirmed payments were not recorded, the whole amount was posted to fundraises.
+UPDATE payments p
+SET gross_amount = d.amount, net_amount = d.amount
+FROM donations d
+WHERE d.donation_id = p.donation_id AND p.confirmed;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS leaderboard_totals;
+DROP TABLE IF EXISTS leaderboard_contributions;
+
+ALTER TABLE users DROP COLUMN IF EXISTS private;
+ALTER TABLE donations DROP COLUMN IF EXISTS team_code;
@@ -0,0 +1,58 @@
+ALTER TABLE donations ADD COLUMN IF NOT EXISTS team_code VARCHAR NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS private BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS leaderboard_contributions (
+donation_id   UUID PRIMARY KEY         NOT NULL,
+fundraise_id  UUID                     NOT NULL,
+donor_id      UUID                         NULL,
+team_code     VARCHAR                  NOT NULL DEFAULT '',
+amount        NUMERIC(72, 18)          NOT NULL,
+refunded      NUMERIC(72, 18)          NOT NULL DEFAULT 0,
+exchange_rate NUMERIC(72, 18)          NOT NULL,
+platform_rate NUMERIC(72, 18)          NOT NULL,
+donated_at    TIMESTAMP WITH TIME ZONE NOT NULL,
+FOREIGN KEY(donation_id) REFERENCES donations(donation_id) ON UPDATE CASCADE ON DELETE CASCADE,
+FOREIGN KEY(fundraise_id) REFERENCES fundraises(fundraise_id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+-- INFO: platform-wide totals are kept with nil fundraise id.
+CREATE TABLE IF NOT EXISTS leaderboard_totals (
+fundraise_id UUID            NOT NULL,
+period       VARCHAR         NOT NULL,
+kind         VARCHAR         NOT NULL,
+subject      VARCHAR         NOT NULL,
+amount       NUMERIC(72, 18) NOT NULL DEFAULT 0,
+donations    INTEGER         NOT NULL DEFAULT 0,
+PRIMARY KEY(fundraise_id, period, kind, subject)
+);
+
+CREATE INDEX IF NOT EXISTS leaderboard_totals_rank_idx ON leaderboard_totals(fundraise_id, period, kind, amount DESC);
+
+-- INFO: existing provider-confirmed donations are counted once, self-reported ones are never counted.
+-- NOTE: rate to the default currency was not recorded, so donations which can not be converted to UAH are skipped.
+INSERT INTO leaderboard_contributions(donation_id, fundraise_id, donor_id, team_code, amount, refunded, exchange_rate, platform_rate, donated_at)
+SELECT d.donation_id, d.fundraise_id, CASE WHEN d.anonymous THEN NULL ELSE d.user_id END, d.team_code, d.amount, p.refunded_amount,
+       d.exchange_rate, CASE WHEN d.currency = 'UAH' THEN 1 ELSE d.exchange_rate END, d.created_at
+FROM donations d
+INNER JOIN payments p ON d.donation_id = p.donation_id
+INNER JOIN fundraises f ON d.fundraise_id = f.fundraise_id
+WHERE p.confirmed AND p.payment_type NOT IN ('BANK_TRANSFER', 'CASH', 'MONOBANK_JAR') AND (d.currency = 'UAH' OR f.currency = 'UAH')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO leaderboard_totals(fundraise_id, period, kind, subject, amount, donations)
+SELECT t.fundraise_id, t.period, t.kind, t.subject, SUM(t.amount), COUNT(*) FILTER (WHERE c.amount > c.refunded)
+FROM leaderboard_contributions c
+CROSS JOIN LATERAL (SELECT GREATEST(c.amount - c.refunded, 0) AS net, to_char(c.donated_at AT TIME ZONE 'UTC', 'YYYY-MM') AS month) n
+CROSS JOIN LATERAL (VALUES
+    (c.fundraise_id, 'all', 'donors', c.donor_id::text, ROUND(n.net * c.exchange_rate, 2)),
+    (c.fundraise_id, n.month, 'donors', c.donor_id::text, ROUND(n.net * c.exchange_rate, 2)),
+    ('00000000-0000-0000-0000-000000000000'::uuid, 'all', 'donors', c.donor_id::text, ROUND(n.net * c.platform_rate, 2)),
+    ('00000000-0000-0000-0000-000000000000'::uuid, n.month, 'donors', c.donor_id::text, ROUND(n.net * c.platform_rate, 2)),
+    (c.fundraise_id, 'all', 'teams', NULLIF(c.team_code, ''), ROUND(n.net * c.exchange_rate, 2)),
+    (c.fundraise_id, n.month, 'teams', NULLIF(c.team_code, ''), ROUND(n.net * c.exchange_rate, 2)),
+    ('00000000-0000-0000-0000-000000000000'::uuid, 'all', 'teams', NULLIF(c.team_code, ''), ROUND(n.net * c.platform_rate, 2)),
+    ('00000000-0000-0000-0000-000000000000'::uuid, n.month, 'teams', NULLIF(c.team_code, ''), ROUND(n.net * c.platform_rate, 2))
+) t(fundraise_id, period, kind, subject, amount)
+WHERE t.subject IS NOT NULL
+GROUP BY t.fundraise_id, t.period, t.kind, t.subject
+ON CONFLICT DO NOTHING;
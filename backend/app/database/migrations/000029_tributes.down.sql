@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS notifications;
+
+ALTER TABLE donations DROP COLUMN IF EXISTS tribute_recipient_email;
+ALTER TABLE donations DROP COLUMN IF EXISTS tribute_recipient_name;
+ALTER TABLE donations DROP COLUMN IF EXISTS honoree_name;
+ALTER TABLE donations DROP COLUMN IF EXISTS tribute_type;
@@ -0,0 +1,19 @@
+ALTER TABLE donations ADD COLUMN IF NOT EXISTS tribute_type VARCHAR NOT NULL DEFAULT '';
+ALTER TABLE donations ADD COLUMN IF NOT EXISTS honoree_name VARCHAR NOT NULL DEFAULT '';
+ALTER TABLE donations ADD COLUMN IF NOT EXISTS tribute_recipient_name VARCHAR NOT NULL DEFAULT '';
+ALTER TABLE donations ADD COLUMN IF NOT EXISTS tribute_recipient_email VARCHAR NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS notifications (
+notification_id         UUID PRIMARY KEY         NOT NULL,
+key                     VARCHAR UNIQUE           NOT NULL,
+recipient               VARCHAR                  NOT NULL,
+subject                 VARCHAR                  NOT NULL,
+body                    TEXT                     NOT NULL,
+attachment_name         VARCHAR                  NOT NULL DEFAULT '',
+attachment_content_type VARCHAR                  NOT NULL DEFAULT '',
+attachment              BYTEA                        NULL,
+status                  VARCHAR                  NOT NULL,
+failure_message         VARCHAR                  NOT NULL DEFAULT '',
+created_at              TIMESTAMP WITH TIME ZONE NOT NULL,
+sent_at                 TIMESTAMP WITH TIME ZONE     NULL
+);
@@ -0,0 +1,2 @@
+-- NOTE: payouts requested before the snapshot have no IBAN, so open ones can not be approved and must be requested again.
+ALTER TABLE payouts ADD COLUMN IF NOT EXISTS iban VARCHAR NOT NULL DEFAULT '';
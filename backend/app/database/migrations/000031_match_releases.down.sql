@@ -0,0 +1 @@
+ALTER TABLE donation_matches DROP COLUMN IF EXISTS released;
@@ -0,0 +1,2 @@
+-- INFO: part of the match returned to the pledge budget on refund of the donation.
+ALTER TABLE donation_matches ADD COLUMN IF NOT EXISTS released NUMERIC(72, 18) NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS attempts INTEGER NOT NULL DEFAULT 0;
+
+-- INFO: notifications sent or failed before the column was added were attempted once.
+UPDATE notifications SET attempts = 1 WHERE status <> 'PENDING';
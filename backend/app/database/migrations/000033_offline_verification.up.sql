@@ -0,0 +1,2 @@
+-- INFO: set when the offline donation is found in the imported bank statement.
+ALTER TABLE offline_donations ADD COLUMN IF NOT EXISTS verified_at TIMESTAMP WITH TIME ZONE NULL;
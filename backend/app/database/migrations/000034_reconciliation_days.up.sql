@@ -0,0 +1,9 @@
+-- INFO: day is recorded once its reconciliation is reported, even if there were no totals to report.
+CREATE TABLE IF NOT EXISTS reconciliation_days (
+report_date DATE                     NOT NULL PRIMARY KEY,
+reported_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+INSERT INTO reconciliation_days(report_date, reported_at)
+SELECT report_date, MAX(created_at) FROM reconciliation_reports GROUP BY report_date
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,165 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"one-help/app/notifications"
+)
+
+// ErrNotifications indicates that there was an error in the database.
+var ErrNotifications = errs.Class("notifications repository")
+
+// notificationsDB provides access to notifications db.
+//
+// architecture: Database
+type notificationsDB struct {
+	conn *sql.DB
+}
+
+// newNotificationsDB is a constructor for base notificationsDB.
+func newNotificationsDB(baseConn *sql.DB) notifications.DB {
+	return &notificationsDB{
+		conn: baseConn,
+	}
+}
+
+// notificationColumns lists selected notification columns in the scan order.
+const notificationColumns = `notification_id, key, recipient, subject, body, attachment_name, attachment_content_type,
+                             attachment, status, failure_message, attempts, created_at, sent_at`
+
+// Create inserts notification if there is no notification with its key, returns true in that case.
+func (db *notificationsDB) Create(ctx context.Context, notification notifications.Notification) (bool, error) {
+	query := `INSERT INTO notifications(` + notificationColumns + `)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+              ON CONFLICT (key) DO NOTHING`
+	result, err := db.conn.ExecContext(ctx, query,
+		notification.ID,
+		notification.Key,
+		notification.Recipient,
+		notification.Subject,
+		notification.Body,
+		notification.Attachment.Name,
+		notification.Attachment.ContentType,
+		notification.Attachment.Data,
+		notification.Status,
+		notification.FailureMessage,
+		notification.Attempts,
+		notification.CreatedAt,
+		nullTime(notification.SentAt),
+	)
+	if err != nil {
+		return false, ErrNotifications.Wrap(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, ErrNotifications.Wrap(err)
+	}
+
+	return affected > 0, nil
+}
+
+// GetByKey returns notification by its key.
+func (db *notificationsDB) GetByKey(ctx context.Context, key string) (notifications.Notification, error) {
+	query := `SELECT ` + notificationColumns + ` FROM notifications WHERE key = $1`
+	notification, err := scanNotification(db.conn.QueryRowContext(ctx, query, key))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return notifications.Notification{}, ErrNotifications.Wrap(notifications.ErrNoNotification)
+		}
+
+		return notifications.Notification{}, ErrNotifications.Wrap(err)
+	}
+
+	return notification, nil
+}
+
+// ListUnsent returns failed notifications and pending notifications created before provided time,
+// which were attempted less than maximum number of times, the oldest first.
+func (db *notificationsDB) ListUnsent(ctx context.Context, pendingBefore time.Time) (_ []notifications.Notification, err error) {
+	query := `SELECT ` + notificationColumns + `
+              FROM notifications
+              WHERE attempts < $1 AND (status = $2 OR (status = $3 AND created_at < $4))
+              ORDER BY created_at`
+	rows, err := db.conn.QueryContext(ctx, query,
+		notifications.MaxAttempts,
+		notifications.StatusFailed,
+		notifications.StatusPending,
+		pendingBefore,
+	)
+	if err != nil {
+		return nil, ErrNotifications.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var list []notifications.Notification
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, ErrNotifications.Wrap(err)
+		}
+
+		list = append(list, notification)
+	}
+
+	return list, ErrNotifications.Wrap(rows.Err())
+}
+
+// Update updates status and attempts of the notification.
+func (db *notificationsDB) Update(ctx context.Context, notification notifications.Notification) error {
+	query := `UPDATE notifications
+              SET status = $2, failure_message = $3, attempts = $4, sent_at = $5
+              WHERE notification_id = $1`
+	result, err := db.conn.ExecContext(ctx, query,
+		notification.ID,
+		notification.Status,
+		notification.FailureMessage,
+		notification.Attempts,
+		nullTime(notification.SentAt),
+	)
+	if err != nil {
+		return ErrNotifications.Wrap(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return ErrNotifications.Wrap(err)
+	}
+	if affected == 0 {
+		return ErrNotifications.Wrap(notifications.ErrNoNotification)
+	}
+
+	return nil
+}
+
+// scanNotification scans notification columns from the row.
+func scanNotification(row interface{ Scan(dest ...any) error }) (notifications.Notification, error) {
+	var (
+		notification notifications.Notification
+		sentAt       sql.NullTime
+	)
+
+	err := row.Scan(
+		&notification.ID,
+		&notification.Key,
+		&notification.Recipient,
+		&notification.Subject,
+		&notification.Body,
+		&notification.Attachment.Name,
+		&notification.Attachment.ContentType,
+		&notification.Attachment.Data,
+		&notification.Status,
+		&notification.FailureMessage,
+		&notification.Attempts,
+		&notification.CreatedAt,
+		&sentAt,
+	)
+	notification.SentAt = sentAt.Time
+
+	return notification, err
+}
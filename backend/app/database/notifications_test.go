@@ -0,0 +1,110 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app"
+	"one-help/app/database"
+	"one-help/app/database/dbtesting"
+	"one-help/app/notifications"
+)
+
+func TestNotifications(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	notification := notifications.Notification{
+		ID:        uuid.New(),
+		Key:       "tribute:" + uuid.NewString(),
+		Recipient: "family@example.com",
+		Subject:   "A donation was made in memory of Taras",
+		Body:      "Someone made a donation in memory of Taras.",
+		Attachment: notifications.Attachment{
+			Name:        "dedication-card.pdf",
+			ContentType: "application/pdf",
+			Data:        []byte("%PDF-1.4"),
+		},
+		Status:    notifications.StatusPending,
+		CreatedAt: now,
+	}
+
+	dbtesting.Run(t, database.Config{}, func(ctx context.Context, t *testing.T, db app.DB) {
+		notificationsRepository := db.Notifications()
+
+		t.Run("Create&GetByKey", func(t *testing.T) {
+			created, err := notificationsRepository.Create(ctx, notification)
+			require.NoError(t, err)
+			assert.True(t, created)
+
+			// INFO: notification with the same key is not created again.
+			repeated := notification
+			repeated.ID = uuid.New()
+			created, err = notificationsRepository.Create(ctx, repeated)
+			require.NoError(t, err)
+			assert.False(t, created)
+
+			stored, err := notificationsRepository.GetByKey(ctx, notification.Key)
+			require.NoError(t, err)
+			assert.Equal(t, notification.ID, stored.ID)
+			assert.Equal(t, notification.Recipient, stored.Recipient)
+			assert.Equal(t, notification.Attachment, stored.Attachment)
+			assert.Equal(t, notifications.StatusPending, stored.Status)
+			assert.True(t, stored.SentAt.IsZero())
+		})
+
+		t.Run("ListUnsent", func(t *testing.T) {
+			// INFO: pending notification is resent only when it is stale.
+			unsent, err := notificationsRepository.ListUnsent(ctx, now)
+			require.NoError(t, err)
+			assert.Empty(t, unsent)
+
+			unsent, err = notificationsRepository.ListUnsent(ctx, now.Add(time.Minute))
+			require.NoError(t, err)
+			require.Len(t, unsent, 1)
+			assert.Equal(t, notification.ID, unsent[0].ID)
+			assert.Equal(t, notification.Attachment, unsent[0].Attachment)
+
+			failed := notification
+			failed.Status = notifications.StatusFailed
+			failed.FailureMessage = "mailbox unavailable"
+			failed.Attempts = 1
+			require.NoError(t, notificationsRepository.Update(ctx, failed))
+
+			unsent, err = notificationsRepository.ListUnsent(ctx, now)
+			require.NoError(t, err)
+			require.Len(t, unsent, 1)
+			assert.Equal(t, 1, unsent[0].Attempts)
+
+			// INFO: notification attempted maximum number of times is left failed.
+			failed.Attempts = notifications.MaxAttempts
+			require.NoError(t, notificationsRepository.Update(ctx, failed))
+
+			unsent, err = notificationsRepository.ListUnsent(ctx, now.Add(time.Minute))
+			require.NoError(t, err)
+			assert.Empty(t, unsent)
+		})
+
+		t.Run("Update", func(t *testing.T) {
+			notification.Status = notifications.StatusSent
+			notification.Attempts = 1
+			notification.SentAt = now.Add(time.Second)
+			require.NoError(t, notificationsRepository.Update(ctx, notification))
+
+			stored, err := notificationsRepository.GetByKey(ctx, notification.Key)
+			require.NoError(t, err)
+			assert.Equal(t, notifications.StatusSent, stored.Status)
+			assert.Equal(t, 1, stored.Attempts)
+			assert.True(t, notification.SentAt.Equal(stored.SentAt))
+
+			err = notificationsRepository.Update(ctx, notifications.Notification{ID: uuid.New()})
+			assert.ErrorIs(t, err, notifications.ErrNoNotification)
+
+			_, err = notificationsRepository.GetByKey(ctx, "unknown")
+			assert.ErrorIs(t, err, notifications.ErrNoNotification)
+		})
+	})
+}
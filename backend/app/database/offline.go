@@ -0,0 +1,139 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/zeebo/errs"
+
+	"one-help/app/donations/offline"
+)
+
+// ErrOffline indicates that there was an error in the database.
+var ErrOffline = errs.Class("offline donations repository")
+
+// offlineDB provides access to offline donations db.
+//
+// architecture: Database
+type offlineDB struct {
+	conn *sql.DB
+}
+
+// newOfflineDB is a constructor for base offlineDB.
+func newOfflineDB(baseConn *sql.DB) offline.DB {
+	return &offlineDB{
+		conn: baseConn,
+	}
+}
+
+// Create inserts offline donation record into the database.
+func (db *offlineDB) Create(ctx context.Context, record offline.Record) error {
+	query := `INSERT INTO offline_donations(donation_id, recorded_by, evidence_url, notes, received_at, created_at)
+              VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err := db.conn.ExecContext(ctx, query,
+		record.DonationID,
+		record.RecordedBy,
+		record.EvidenceURL,
+		record.Notes,
+		record.ReceivedAt,
+		record.CreatedAt,
+	)
+	return ErrOffline.Wrap(err)
+}
+
+// Get returns offline donation record by donation id.
+func (db *offlineDB) Get(ctx context.Context, donationID uuid.UUID) (offline.Record, error) {
+	var record offline.Record
+
+	var verifiedAt sql.NullTime
+
+	query := `SELECT donation_id, recorded_by, evidence_url, notes, received_at, verified_at, created_at
+              FROM offline_donations
+              WHERE donation_id = $1`
+	err := db.conn.QueryRowContext(ctx, query, donationID).Scan(
+		&record.DonationID,
+		&record.RecordedBy,
+		&record.EvidenceURL,
+		&record.Notes,
+		&record.ReceivedAt,
+		&verifiedAt,
+		&record.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return offline.Record{}, ErrOffline.Wrap(offline.ErrNoRecord)
+		}
+
+		return offline.Record{}, ErrOffline.Wrap(err)
+	}
+
+	record.VerifiedAt = verifiedAt.Time
+
+	return record, nil
+}
+
+// ListByFundraise returns offline donations of the fundraise with their records, the latest received first.
+func (db *offlineDB) ListByFundraise(ctx context.Context, fundraiseID uuid.UUID) (_ []offline.Entry, err error) {
+	query := `SELECT d.donation_id, d.user_id, d.fundraise_id, d.amount, d.currency, d.exchange_rate, d.created_at,
+                     d.anonymous, d.message, p.payment_type, p.reference,
+                     o.recorded_by, o.evidence_url, o.notes, o.received_at, o.verified_at, o.created_at
+              FROM offline_donations o
+              JOIN donations d ON d.donation_id = o.donation_id
+              JOIN payments p ON p.donation_id = d.donation_id
+              WHERE d.fundraise_id = $1
+              ORDER BY o.received_at DESC, d.donation_id`
+	rows, err := db.conn.QueryContext(ctx, query, fundraiseID)
+	if err != nil {
+		return nil, ErrOffline.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var entries []offline.Entry
+	for rows.Next() {
+		var (
+			entry      offline.Entry
+			verifiedAt sql.NullTime
+		)
+		err = rows.Scan(
+			&entry.Donation.ID,
+			&entry.Donation.UserId, // INFO: nil uuid stays for donations without linked donor.
+			&entry.Donation.FundraiseId,
+			&entry.Donation.Amount,
+			&entry.Donation.Currency,
+			&entry.Donation.ExchangeRate,
+			&entry.Donation.CreatedAt,
+			&entry.Donation.Anonymous,
+			&entry.Donation.Message,
+			&entry.PaymentType,
+			&entry.Reference,
+			&entry.Record.RecordedBy,
+			&entry.Record.EvidenceURL,
+			&entry.Record.Notes,
+			&entry.Record.ReceivedAt,
+			&verifiedAt,
+			&entry.Record.CreatedAt,
+		)
+		if err != nil {
+			return nil, ErrOffline.Wrap(err)
+		}
+		entry.Record.DonationID = entry.Donation.ID
+		entry.Record.VerifiedAt = verifiedAt.Time
+
+		entries = append(entries, entry)
+	}
+
+	return entries, ErrOffline.Wrap(rows.Err())
+}
+
+// Verify marks offline donations as found in the imported bank statement, keeping the time of the first verification.
+func (db *offlineDB) Verify(ctx context.Context, donationIDs []uuid.UUID, at time.Time) error {
+	query := `UPDATE offline_donations
+              SET verified_at = $2
+              WHERE donation_id = ANY($1) AND verified_at IS NULL`
+	_, err := db.conn.ExecContext(ctx, query, pq.Array(donationIDs), at)
+	return ErrOffline.Wrap(err)
+}
@@ -0,0 +1,138 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app"
+	"one-help/app/currencies"
+	"one-help/app/database"
+	"one-help/app/database/dbtesting"
+	"one-help/app/donations"
+	"one-help/app/donations/offline"
+	"one-help/app/fundraises"
+	"one-help/app/fundraises/analytics"
+	"one-help/app/fundraises/statuses"
+	"one-help/app/payments"
+	"one-help/app/users"
+)
+
+func TestOfflineDonations(t *testing.T) {
+	organizer := users.User{ID: uuid.New(), FirstName: "John", LastName: "Doe"}
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	fundraise := fundraises.Fundraise{
+		ID:           uuid.New(),
+		OrganizerId:  organizer.ID,
+		Title:        "Test",
+		Description:  "Test Description",
+		TargetAmount: currencies.Major(1000),
+		StartDate:    now,
+		Status:       statuses.ActiveStatus,
+	}
+
+	// INFO: cash donation of unknown donor.
+	donation := donations.Donation{
+		ID:          uuid.New(),
+		FundraiseId: fundraise.ID,
+		Amount:      currencies.Major(150),
+		CreatedAt:   now.Add(-time.Hour),
+		Anonymous:   true,
+	}
+
+	record := offline.Record{
+		DonationID:  donation.ID,
+		RecordedBy:  organizer.ID,
+		EvidenceURL: "https://example.com/receipt.jpg",
+		Notes:       "collected at the charity fair",
+		ReceivedAt:  donation.CreatedAt,
+		CreatedAt:   now,
+	}
+
+	dbtesting.Run(t, database.Config{}, func(ctx context.Context, t *testing.T, db app.DB) {
+		offlineRepository := db.OfflineDonations()
+
+		require.NoError(t, db.Users().Create(ctx, organizer))
+		require.NoError(t, db.Fundraises().Create(ctx, fundraise))
+		require.NoError(t, db.Donations().Create(ctx, donation))
+		require.NoError(t, db.Payments().Create(ctx, payments.Payment{
+			DonationId:    donation.ID,
+			PaymentType:   payments.TypeCash,
+			TransactionId: donation.ID.String(),
+			Confirmed:     true,
+			Status:        payments.StatusConfirmed,
+		}))
+
+		t.Run("Create&Get", func(t *testing.T) {
+			require.NoError(t, offlineRepository.Create(ctx, record))
+
+			stored, err := offlineRepository.Get(ctx, donation.ID)
+			require.NoError(t, err)
+			assert.Equal(t, record.RecordedBy, stored.RecordedBy)
+			assert.Equal(t, record.EvidenceURL, stored.EvidenceURL)
+			assert.Equal(t, record.Notes, stored.Notes)
+			assert.True(t, record.ReceivedAt.Equal(stored.ReceivedAt))
+		})
+
+		t.Run("ListByFundraise", func(t *testing.T) {
+			list, err := offlineRepository.ListByFundraise(ctx, fundraise.ID)
+			require.NoError(t, err)
+			require.Len(t, list, 1)
+			assert.Equal(t, donation.ID, list[0].Donation.ID)
+			assert.Equal(t, uuid.Nil, list[0].Donation.UserId)
+			assert.Equal(t, payments.TypeCash, list[0].PaymentType)
+			assert.Equal(t, record.Notes, list[0].Record.Notes)
+			assert.True(t, list[0].Record.VerifiedAt.IsZero())
+		})
+
+		t.Run("Verify", func(t *testing.T) {
+			require.NoError(t, offlineRepository.Verify(ctx, []uuid.UUID{donation.ID}, now))
+			// INFO: time of the first verification is kept.
+			require.NoError(t, offlineRepository.Verify(ctx, []uuid.UUID{donation.ID}, now.Add(time.Hour)))
+
+			stored, err := offlineRepository.Get(ctx, donation.ID)
+			require.NoError(t, err)
+			assert.True(t, now.Equal(stored.VerifiedAt))
+
+			list, err := offlineRepository.ListByFundraise(ctx, fundraise.ID)
+			require.NoError(t, err)
+			require.Len(t, list, 1)
+			assert.True(t, now.Equal(list[0].Record.VerifiedAt))
+		})
+
+		t.Run("Supporters", func(t *testing.T) {
+			supporters, err := db.Donations().ListSupporters(ctx, fundraise.ID, 10)
+			require.NoError(t, err)
+			require.Len(t, supporters, 1)
+			assert.Empty(t, supporters[0].FirstName)
+		})
+
+		t.Run("Analytics", func(t *testing.T) {
+			summary, err := db.FundraiseAnalytics().Summary(ctx, analytics.Params{
+				FundraiseID: fundraise.ID,
+				From:        now.Add(-24 * time.Hour),
+				To:          now.Add(time.Hour),
+			})
+			require.NoError(t, err)
+			assert.Zero(t, summary.Clicks)
+			assert.Equal(t, 1, summary.Confirmed)
+			assert.Equal(t, 1, summary.SelfReportedDonations)
+			assert.Equal(t, currencies.Major(150), summary.SelfReported)
+			assert.Equal(t, 1, summary.VerifiedDonations)
+			assert.Equal(t, currencies.Major(150), summary.Verified)
+			assert.Equal(t, currencies.Major(150), summary.Total)
+		})
+
+		t.Run("Get(negative)", func(t *testing.T) {
+			_, err := offlineRepository.Get(ctx, uuid.New())
+			require.Error(t, err)
+			require.ErrorIs(t, err, offline.ErrNoRecord)
+		})
+	})
+}
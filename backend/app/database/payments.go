@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"one-help/app/payments"
 
@@ -28,6 +29,10 @@ func newPaymentsDB(baseConn *sql.DB) payments.DB {
 	}
 }
 
+// paymentColumns lists selected payment columns in the scan order.
+const paymentColumns = `donation_id, payment_type, transaction_id, confirmed, status, reference, refunded_amount,
+                        gross_amount, fee_amount, net_amount, covers_fee`
+
 // Create inserts payment into the database.
 func (db *paymentsDB) Create(ctx context.Context, payment payments.Payment) error {
 	tx, err := db.conn.BeginTx(ctx, nil)
@@ -37,28 +42,81 @@ func (db *paymentsDB) Create(ctx context.Context, payment payments.Payment) erro
 
 	defer DeferCommitRollback(tx, &err)
 
-	query := `INSERT INTO payments(donation_id, payment_type, transaction_id, confirmed)
-              VALUES ($1, $2, $3, $4)`
-	_, err = tx.ExecContext(ctx, query, payment.DonationId, payment.PaymentType, payment.TransactionId, payment.Confirmed)
+	err = insertPayment(ctx, tx, payment)
 	return ErrPayments.Wrap(err)
 }
 
-// Get returns payment from the database by donation ID.
-func (db *paymentsDB) Get(ctx context.Context, id uuid.UUID) (payments.Payment, error) {
-	var (
-		payment payments.Payment
+// insertPayment inserts payment within provided database transaction.
+func insertPayment(ctx context.Context, tx *sql.Tx, payment payments.Payment) error {
+	if payment.Status == "" { // INFO: Fallback to status matching confirmation flag.
+		payment.Status = payments.StatusPending
+		if payment.Confirmed {
+			payment.Status = payments.StatusConfirmed
+		}
+	}
+
+	query := `INSERT INTO payments(` + paymentColumns + `)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+	_, err := tx.ExecContext(ctx, query,
+		payment.DonationId,
+		payment.PaymentType,
+		payment.TransactionId,
+		payment.Confirmed,
+		payment.Status,
+		payment.Reference,
+		payment.RefundedAmount,
+		payment.GrossAmount,
+		payment.FeeAmount,
+		payment.NetAmount,
+		payment.CoversFee,
 	)
+	return err
+}
 
-	query := `SELECT donation_id, payment_type, transaction_id, confirmed
+// Get returns payment from the database by donation ID.
+func (db *paymentsDB) Get(ctx context.Context, id uuid.UUID) (payments.Payment, error) {
+	query := `SELECT ` + paymentColumns + `
 	          FROM payments
               WHERE donation_id = $1`
 
-	row := db.conn.QueryRowContext(ctx, query, id)
+	return db.get(ctx, query, id)
+}
+
+// GetByTransaction returns payment by provider's checkout transaction id.
+func (db *paymentsDB) GetByTransaction(ctx context.Context, paymentType, transactionID string) (payments.Payment, error) {
+	query := `SELECT ` + paymentColumns + `
+	          FROM payments
+              WHERE payment_type = $1 AND transaction_id = $2`
+
+	return db.get(ctx, query, paymentType, transactionID)
+}
+
+// GetByReference returns payment by provider's payment reference.
+func (db *paymentsDB) GetByReference(ctx context.Context, paymentType, reference string) (payments.Payment, error) {
+	query := `SELECT ` + paymentColumns + `
+	          FROM payments
+              WHERE payment_type = $1 AND reference = $2 AND reference <> ''`
+
+	return db.get(ctx, query, paymentType, reference)
+}
+
+// get returns single payment selected by the query.
+func (db *paymentsDB) get(ctx context.Context, query string, args ...any) (payments.Payment, error) {
+	var payment payments.Payment
+
+	row := db.conn.QueryRowContext(ctx, query, args...)
 	err := row.Scan(
 		&payment.DonationId,
 		&payment.PaymentType,
 		&payment.TransactionId,
 		&payment.Confirmed,
+		&payment.Status,
+		&payment.Reference,
+		&payment.RefundedAmount,
+		&payment.GrossAmount,
+		&payment.FeeAmount,
+		&payment.NetAmount,
+		&payment.CoversFee,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -70,11 +128,29 @@ func (db *paymentsDB) Get(ctx context.Context, id uuid.UUID) (payments.Payment,
 	return payment, nil
 }
 
+// ListPending returns pending payments of donations created before provided time, the oldest first.
+func (db *paymentsDB) ListPending(ctx context.Context, before time.Time) ([]payments.Payment, error) {
+	query := `SELECT p.donation_id, p.payment_type, p.transaction_id, p.confirmed, p.status, p.reference, p.refunded_amount,
+                     p.gross_amount, p.fee_amount, p.net_amount, p.covers_fee
+              FROM payments p
+              JOIN donations d ON d.donation_id = p.donation_id
+              WHERE p.status = $1 AND d.created_at < $2
+              ORDER BY d.created_at`
+
+	return db.list(ctx, query, payments.StatusPending, before)
+}
+
 // List returns all the payments.
 func (db *paymentsDB) List(ctx context.Context) ([]payments.Payment, error) {
-	query := `SELECT donation_id, payment_type, transaction_id, confirmed
+	query := `SELECT ` + paymentColumns + `
               FROM payments`
-	rows, err := db.conn.QueryContext(ctx, query)
+
+	return db.list(ctx, query)
+}
+
+// list returns payments selected by the query.
+func (db *paymentsDB) list(ctx context.Context, query string, args ...any) (_ []payments.Payment, err error) {
+	rows, err := db.conn.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, ErrPayments.Wrap(err)
 	}
@@ -90,6 +166,13 @@ func (db *paymentsDB) List(ctx context.Context) ([]payments.Payment, error) {
 			&payment.PaymentType,
 			&payment.TransactionId,
 			&payment.Confirmed,
+			&payment.Status,
+			&payment.Reference,
+			&payment.RefundedAmount,
+			&payment.GrossAmount,
+			&payment.FeeAmount,
+			&payment.NetAmount,
+			&payment.CoversFee,
 		)
 		if err != nil {
 			return nil, ErrPayments.Wrap(err)
@@ -113,7 +196,8 @@ func (db *paymentsDB) Update(ctx context.Context, payment payments.Payment) erro
 	defer DeferCommitRollback(tx, &err)
 
 	query := `UPDATE payments
-	          SET payment_type = $2, transaction_id = $3, confirmed = $4
+	          SET payment_type = $2, transaction_id = $3, confirmed = $4, status = $5, reference = $6, refunded_amount = $7,
+	              gross_amount = $8, fee_amount = $9, net_amount = $10, covers_fee = $11
 	          WHERE donation_id = $1`
 
 	_, err = tx.ExecContext(ctx, query,
@@ -121,6 +205,13 @@ func (db *paymentsDB) Update(ctx context.Context, payment payments.Payment) erro
 		payment.PaymentType,
 		payment.TransactionId,
 		payment.Confirmed,
+		payment.Status,
+		payment.Reference,
+		payment.RefundedAmount,
+		payment.GrossAmount,
+		payment.FeeAmount,
+		payment.NetAmount,
+		payment.CoversFee,
 	)
 	if err != nil {
 		return ErrPayments.Wrap(err)
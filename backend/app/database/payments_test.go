@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"one-help/app"
+	"one-help/app/currencies"
 	"one-help/app/database"
 	"one-help/app/database/dbtesting"
 	"one-help/app/donations"
@@ -34,7 +35,7 @@ func TestPayments(t *testing.T) {
 		OrganizerId:  user.ID,
 		Title:        "Test",
 		Description:  "Test Description",
-		TargetAmount: 234.4,
+		TargetAmount: currencies.MinorUnits(23440),
 		StartDate:    time.Now(),
 		EndDate:      time.Time{},
 		Status:       fundraiseStatus,
@@ -44,7 +45,7 @@ func TestPayments(t *testing.T) {
 		ID:          uuid.New(),
 		UserId:      user.ID,
 		FundraiseId: fundraise.ID,
-		Amount:      100.0,
+		Amount:      currencies.Major(100),
 		CreatedAt:   time.Now(),
 	}
 
@@ -55,6 +56,7 @@ func TestPayments(t *testing.T) {
 		PaymentType:   paymentType,
 		TransactionId: "123456",
 		Confirmed:     false,
+		Status:        payments.StatusPending,
 	}
 
 	dbtesting.Run(t, database.Config{}, func(ctx context.Context, t *testing.T, db app.DB) {
@@ -86,6 +88,10 @@ func TestPayments(t *testing.T) {
 
 		t.Run("Update", func(t *testing.T) {
 			payment.Confirmed = true
+			payment.Status = payments.StatusConfirmed
+			payment.Reference = "pi_123456"
+			payment.CoversFee = true
+			payment.Charge(currencies.MinorUnits(10330), currencies.USD, payments.FeeSchedule{Percent: 2.9, Fixed: payments.FixedFees{currencies.USD: currencies.MinorUnits(30)}})
 
 			storedPayment, err := paymentsRepository.Get(ctx, donation.ID)
 			require.NoError(t, err)
@@ -99,6 +105,19 @@ func TestPayments(t *testing.T) {
 			assert.Equal(t, payment, storedPayment)
 		})
 
+		t.Run("GetByTransaction&GetByReference", func(t *testing.T) {
+			storedPayment, err := paymentsRepository.GetByTransaction(ctx, paymentType, payment.TransactionId)
+			require.NoError(t, err)
+			assert.Equal(t, payment, storedPayment)
+
+			storedPayment, err = paymentsRepository.GetByReference(ctx, paymentType, payment.Reference)
+			require.NoError(t, err)
+			assert.Equal(t, payment, storedPayment)
+
+			_, err = paymentsRepository.GetByReference(ctx, paymentType, "")
+			require.ErrorIs(t, err, payments.ErrNoPayment)
+		})
+
 		t.Run("List", func(t *testing.T) {
 			storedPayments, err := paymentsRepository.List(ctx)
 			require.NoError(t, err)
@@ -0,0 +1,298 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+
+	"one-help/app/currencies"
+	"one-help/app/fundraises"
+	"one-help/app/fundraises/payouts"
+	"one-help/app/ledger"
+)
+
+// ErrPayouts indicates that there was an error in the database.
+var ErrPayouts = errs.Class("payouts repository")
+
+// payoutsDB provides access to payouts db.
+//
+// architecture: Database
+type payoutsDB struct {
+	conn *sql.DB
+}
+
+// newPayoutsDB is a constructor for base payoutsDB.
+func newPayoutsDB(baseConn *sql.DB) payouts.DB {
+	return &payoutsDB{
+		conn: baseConn,
+	}
+}
+
+// payoutColumns lists selected payout columns in the scan order.
+const payoutColumns = `payout_id, fundraise_id, amount, currency, status, destination, requested_by, reviewed_by, review_reason,
+                       reference, failure_message, created_at, updated_at, iban`
+
+// reservedPayoutsQuery selects total of open payouts of the fundraise with id provided as $1.
+const reservedPayoutsQuery = `SELECT COALESCE(SUM(amount), 0) FROM payouts
+                              WHERE fundraise_id = $1 AND status IN ('PENDING_APPROVAL', 'PROCESSING')`
+
+// Create inserts payout into the database, ensuring that its amount does not exceed available balance of the fundraise.
+func (db *payoutsDB) Create(ctx context.Context, payout payouts.Payout) (err error) {
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return ErrPayouts.Wrap(err)
+	}
+
+	defer DeferCommitRollback(tx, &err)
+
+	// INFO: Fundraise row lock serializes concurrent payouts and transfers from the same fundraise.
+	var locked uuid.UUID
+	err = tx.QueryRowContext(ctx, `SELECT fundraise_id FROM fundraises WHERE fundraise_id = $1 FOR UPDATE`, payout.FundraiseID).Scan(&locked)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = fundraises.ErrNoFundraise
+		}
+
+		return ErrPayouts.Wrap(err)
+	}
+
+	available, err := availableBalance(ctx, tx, payout.FundraiseID)
+	if err != nil {
+		return ErrPayouts.Wrap(err)
+	}
+
+	if available.Cmp(payout.Amount) < 0 {
+		err = payouts.ErrInsufficientFunds
+		return ErrPayouts.Wrap(err)
+	}
+
+	query := `INSERT INTO payouts(` + payoutColumns + `)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
+	_, err = tx.ExecContext(ctx, query,
+		payout.ID,
+		payout.FundraiseID,
+		payout.Amount,
+		payout.Currency,
+		payout.Status,
+		payout.Destination,
+		payout.RequestedBy,
+		nullUUID(payout.ReviewedBy),
+		payout.ReviewReason,
+		payout.Reference,
+		payout.FailureMessage,
+		payout.CreatedAt,
+		payout.UpdatedAt,
+		payout.IBAN,
+	)
+	return ErrPayouts.Wrap(err)
+}
+
+// availableBalance returns funds of the fundraise held on its ledger account and not reserved by open payouts.
+func availableBalance(ctx context.Context, tx *sql.Tx, fundraiseID uuid.UUID) (currencies.Amount, error) {
+	var balance, reserved currencies.Amount
+	if err := tx.QueryRowContext(ctx, accountBalanceQuery, ledger.FundraiseBalance(fundraiseID)).Scan(&balance); err != nil {
+		return currencies.Zero, err
+	}
+
+	if err := tx.QueryRowContext(ctx, reservedPayoutsQuery, fundraiseID).Scan(&reserved); err != nil {
+		return currencies.Zero, err
+	}
+
+	return balance.Sub(reserved), nil
+}
+
+// Get returns payout by id.
+func (db *payoutsDB) Get(ctx context.Context, id uuid.UUID) (payouts.Payout, error) {
+	query := `SELECT ` + payoutColumns + ` FROM payouts WHERE payout_id = $1`
+
+	payout, err := scanPayout(db.conn.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return payouts.Payout{}, ErrPayouts.Wrap(payouts.ErrNoPayout)
+		}
+
+		return payouts.Payout{}, ErrPayouts.Wrap(err)
+	}
+
+	return payout, nil
+}
+
+// ListByFundraise returns payouts of the fundraise, the newest first.
+func (db *payoutsDB) ListByFundraise(ctx context.Context, fundraiseID uuid.UUID) ([]payouts.Payout, error) {
+	query := `SELECT ` + payoutColumns + ` FROM payouts WHERE fundraise_id = $1 ORDER BY created_at DESC`
+	return db.list(ctx, query, fundraiseID)
+}
+
+// ListByStatus returns payouts with provided status, the oldest first.
+func (db *payoutsDB) ListByStatus(ctx context.Context, status string) ([]payouts.Payout, error) {
+	query := `SELECT ` + payoutColumns + ` FROM payouts WHERE status = $1 ORDER BY created_at`
+	return db.list(ctx, query, status)
+}
+
+// Update updates payout in the database by id only if it still has the previous status, returns ErrStatusChanged otherwise.
+// Paid payout is posted to the ledger in the same transaction.
+func (db *payoutsDB) Update(ctx context.Context, payout payouts.Payout, previousStatus string) (err error) {
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return ErrPayouts.Wrap(err)
+	}
+
+	defer DeferCommitRollback(tx, &err)
+
+	query := `UPDATE payouts
+              SET status = $2, reviewed_by = $3, review_reason = $4, reference = $5, failure_message = $6, updated_at = $7
+              WHERE payout_id = $1 AND status = $8`
+	result, err := tx.ExecContext(ctx, query,
+		payout.ID,
+		payout.Status,
+		nullUUID(payout.ReviewedBy),
+		payout.ReviewReason,
+		payout.Reference,
+		payout.FailureMessage,
+		payout.UpdatedAt,
+		previousStatus,
+	)
+	if err != nil {
+		return ErrPayouts.Wrap(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return ErrPayouts.Wrap(err)
+	}
+	if affected == 0 {
+		var exists bool
+		if err = tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM payouts WHERE payout_id = $1)`, payout.ID).Scan(&exists); err != nil {
+			return ErrPayouts.Wrap(err)
+		}
+
+		err = payouts.ErrNoPayout
+		if exists {
+			err = payouts.ErrStatusChanged
+		}
+
+		return ErrPayouts.Wrap(err)
+	}
+
+	if payout.Status != payouts.StatusPaid {
+		return nil
+	}
+
+	posting := ledger.New(ledger.KindPayout, payout.ID.String(), payout.UpdatedAt)
+	posting.Move(
+		ledger.FundraiseBalance(payout.FundraiseID), currencies.NewMoney(payout.Amount, payout.Currency),
+		ledger.AccountPayouts, currencies.NewMoney(payout.Amount, payout.Currency),
+	)
+	_, err = postTransaction(ctx, tx, posting)
+	return ErrPayouts.Wrap(err)
+}
+
+// Balance returns balance of the fundraise with regard to its payouts.
+func (db *payoutsDB) Balance(ctx context.Context, fundraiseID uuid.UUID) (payouts.Balance, error) {
+	var balance payouts.Balance
+
+	query := `SELECT COALESCE(-SUM(e.amount) FILTER (WHERE t.kind <> $3), 0),
+                     COALESCE(SUM(e.amount) FILTER (WHERE t.kind = $3), 0),
+                     (` + reservedPayoutsQuery + `)
+              FROM ledger_entries e
+              INNER JOIN ledger_transactions t ON e.transaction_id = t.transaction_id
+              WHERE e.account = $2`
+	err := db.conn.QueryRowContext(ctx, query, fundraiseID, ledger.FundraiseBalance(fundraiseID), ledger.KindPayout).
+		Scan(&balance.Collected, &balance.PaidOut, &balance.Reserved)
+	if err != nil {
+		return payouts.Balance{}, ErrPayouts.Wrap(err)
+	}
+
+	balance.Available = balance.Collected.Sub(balance.PaidOut).Sub(balance.Reserved)
+
+	return balance, nil
+}
+
+// SaveBankDetails inserts or replaces bank details of the user.
+func (db *payoutsDB) SaveBankDetails(ctx context.Context, details payouts.BankDetails) error {
+	query := `INSERT INTO bank_details(user_id, holder_name, iban, bank_name, updated_at)
+              VALUES ($1, $2, $3, $4, $5)
+              ON CONFLICT (user_id) DO UPDATE
+              SET holder_name = EXCLUDED.holder_name, iban = EXCLUDED.iban, bank_name = EXCLUDED.bank_name, updated_at = EXCLUDED.updated_at`
+	_, err := db.conn.ExecContext(ctx, query, details.UserID, details.HolderName, details.IBAN, details.BankName, details.UpdatedAt)
+	return ErrPayouts.Wrap(err)
+}
+
+// GetBankDetails returns bank details of the user.
+func (db *payoutsDB) GetBankDetails(ctx context.Context, userID uuid.UUID) (payouts.BankDetails, error) {
+	var details payouts.BankDetails
+
+	query := `SELECT user_id, holder_name, iban, bank_name, updated_at FROM bank_details WHERE user_id = $1`
+	err := db.conn.QueryRowContext(ctx, query, userID).Scan(
+		&details.UserID,
+		&details.HolderName,
+		&details.IBAN,
+		&details.BankName,
+		&details.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return payouts.BankDetails{}, ErrPayouts.Wrap(payouts.ErrNoBankDetails)
+		}
+
+		return payouts.BankDetails{}, ErrPayouts.Wrap(err)
+	}
+
+	return details, nil
+}
+
+// list returns payouts selected by query.
+func (db *payoutsDB) list(ctx context.Context, query string, args ...any) (_ []payouts.Payout, err error) {
+	rows, err := db.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, ErrPayouts.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var list []payouts.Payout
+	for rows.Next() {
+		payout, err := scanPayout(rows)
+		if err != nil {
+			return nil, ErrPayouts.Wrap(err)
+		}
+
+		list = append(list, payout)
+	}
+
+	return list, ErrPayouts.Wrap(rows.Err())
+}
+
+// scanPayout scans payout columns from the row.
+func scanPayout(row interface{ Scan(dest ...any) error }) (payouts.Payout, error) {
+	var (
+		payout     payouts.Payout
+		reviewedBy uuid.NullUUID
+	)
+
+	err := row.Scan(
+		&payout.ID,
+		&payout.FundraiseID,
+		&payout.Amount,
+		&payout.Currency,
+		&payout.Status,
+		&payout.Destination,
+		&payout.RequestedBy,
+		&reviewedBy,
+		&payout.ReviewReason,
+		&payout.Reference,
+		&payout.FailureMessage,
+		&payout.CreatedAt,
+		&payout.UpdatedAt,
+		&payout.IBAN,
+	)
+	if err != nil {
+		return payouts.Payout{}, err
+	}
+
+	payout.ReviewedBy = reviewedBy.UUID
+
+	return payout, nil
+}
@@ -0,0 +1,182 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app"
+	"one-help/app/currencies"
+	"one-help/app/database"
+	"one-help/app/database/dbtesting"
+	"one-help/app/donations"
+	"one-help/app/fundraises"
+	"one-help/app/fundraises/payouts"
+	"one-help/app/fundraises/statuses"
+	"one-help/app/ledger"
+	"one-help/app/payments"
+	"one-help/app/users"
+)
+
+func TestPayouts(t *testing.T) {
+	user := users.User{
+		ID:        uuid.New(),
+		FirstName: "John",
+		LastName:  "Doe",
+	}
+
+	fundraise := fundraises.Fundraise{
+		ID:           uuid.New(),
+		OrganizerId:  user.ID,
+		Title:        "Test",
+		Description:  "Test Description",
+		TargetAmount: currencies.Major(1000),
+		Currency:     currencies.UAH,
+		StartDate:    time.Now(),
+		Status:       statuses.ActiveStatus,
+	}
+
+	donation := donations.Donation{
+		ID:           uuid.New(),
+		UserId:       user.ID,
+		FundraiseId:  fundraise.ID,
+		Amount:       currencies.Major(300),
+		Currency:     currencies.UAH,
+		ExchangeRate: 1,
+		CreatedAt:    time.Now(),
+	}
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	details := payouts.BankDetails{
+		UserID:     user.ID,
+		HolderName: "John Doe",
+		IBAN:       "UA213223130000026007233566001",
+		BankName:   "Bank",
+		UpdatedAt:  now,
+	}
+
+	payout := payouts.Payout{
+		ID:          uuid.New(),
+		FundraiseID: fundraise.ID,
+		Amount:      currencies.Major(100),
+		Currency:    currencies.UAH,
+		Status:      payouts.StatusPendingApproval,
+		Destination: details.MaskedIBAN(),
+		IBAN:        details.IBAN,
+		RequestedBy: user.ID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	dbtesting.Run(t, database.Config{}, func(ctx context.Context, t *testing.T, db app.DB) {
+		payoutsRepository := db.Payouts()
+
+		require.NoError(t, db.Users().Create(ctx, user))
+		require.NoError(t, db.Fundraises().Create(ctx, fundraise))
+		require.NoError(t, db.Donations().Create(ctx, donation))
+		require.NoError(t, db.Payments().Create(ctx, payments.Payment{
+			DonationId:    donation.ID,
+			PaymentType:   payments.TypeStripe,
+			TransactionId: "cs_test",
+			Confirmed:     true,
+			Status:        payments.StatusConfirmed,
+		}))
+
+		confirmation := ledger.New(ledger.KindConfirmation, donation.ID.String(), time.Now())
+		confirmation.Move(
+			ledger.DonorClearing(payments.TypeStripe), currencies.NewMoney(donation.Amount, donation.Currency),
+			ledger.FundraiseBalance(fundraise.ID), currencies.NewMoney(donation.Amount, fundraise.Currency),
+		)
+		_, err := db.Ledger().Post(ctx, confirmation)
+		require.NoError(t, err)
+
+		t.Run("BankDetails", func(t *testing.T) {
+			_, err := payoutsRepository.GetBankDetails(ctx, user.ID)
+			require.ErrorIs(t, err, payouts.ErrNoBankDetails)
+
+			require.NoError(t, payoutsRepository.SaveBankDetails(ctx, details))
+
+			details.BankName = "Another Bank"
+			require.NoError(t, payoutsRepository.SaveBankDetails(ctx, details))
+
+			stored, err := payoutsRepository.GetBankDetails(ctx, user.ID)
+			require.NoError(t, err)
+			assert.Equal(t, details.IBAN, stored.IBAN)
+			assert.Equal(t, details.BankName, stored.BankName)
+		})
+
+		t.Run("Create&Get", func(t *testing.T) {
+			require.NoError(t, payoutsRepository.Create(ctx, payout))
+
+			stored, err := payoutsRepository.Get(ctx, payout.ID)
+			require.NoError(t, err)
+			assert.Equal(t, payout.Amount, stored.Amount)
+			assert.Equal(t, payout.Destination, stored.Destination)
+			assert.Equal(t, payout.IBAN, stored.IBAN)
+			assert.Equal(t, uuid.Nil, stored.ReviewedBy)
+
+			_, err = payoutsRepository.Get(ctx, uuid.New())
+			require.ErrorIs(t, err, payouts.ErrNoPayout)
+		})
+
+		t.Run("Create(insufficient funds)", func(t *testing.T) {
+			overdrawn := payout
+			overdrawn.ID = uuid.New()
+			overdrawn.Amount = currencies.Major(201)
+
+			err := payoutsRepository.Create(ctx, overdrawn)
+			require.ErrorIs(t, err, payouts.ErrInsufficientFunds)
+
+			balance, err := payoutsRepository.Balance(ctx, fundraise.ID)
+			require.NoError(t, err)
+			assert.Equal(t, payouts.Balance{Collected: currencies.Major(300), Reserved: currencies.Major(100), Available: currencies.Major(200)}, balance)
+		})
+
+		t.Run("List", func(t *testing.T) {
+			list, err := payoutsRepository.ListByFundraise(ctx, fundraise.ID)
+			require.NoError(t, err)
+			require.Len(t, list, 1)
+
+			list, err = payoutsRepository.ListByStatus(ctx, payouts.StatusPendingApproval)
+			require.NoError(t, err)
+			require.Len(t, list, 1)
+			assert.Equal(t, payout.ID, list[0].ID)
+		})
+
+		t.Run("Update(paid)", func(t *testing.T) {
+			// INFO: payout is updated only from the expected status.
+			payout.Status = payouts.StatusProcessing
+			payout.ReviewedBy = user.ID
+			require.NoError(t, payoutsRepository.Update(ctx, payout, payouts.StatusPendingApproval))
+			err := payoutsRepository.Update(ctx, payout, payouts.StatusPendingApproval)
+			require.ErrorIs(t, err, payouts.ErrStatusChanged)
+			err = payoutsRepository.Update(ctx, payouts.Payout{ID: uuid.New()}, payouts.StatusPendingApproval)
+			require.ErrorIs(t, err, payouts.ErrNoPayout)
+
+			payout.Status = payouts.StatusPaid
+			payout.Reference = "po_test"
+			require.NoError(t, payoutsRepository.Update(ctx, payout, payouts.StatusProcessing))
+
+			balance, err := payoutsRepository.Balance(ctx, fundraise.ID)
+			require.NoError(t, err)
+			assert.Equal(t, payouts.Balance{Collected: currencies.Major(300), PaidOut: currencies.Major(100), Available: currencies.Major(200)}, balance)
+
+			// INFO: paid payout does not reduce collected funds of the fundraise.
+			filled, err := db.Fundraises().GetFilled(ctx, fundraise.ID)
+			require.NoError(t, err)
+			assert.Equal(t, currencies.Major(300), filled)
+
+			paidOut, err := db.Ledger().Balance(ctx, ledger.AccountPayouts, currencies.UAH)
+			require.NoError(t, err)
+			assert.Equal(t, currencies.Major(-100), paidOut)
+
+			violations, err := db.Ledger().Check(ctx)
+			require.NoError(t, err)
+			assert.Empty(t, violations)
+		})
+	})
+}
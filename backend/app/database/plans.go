@@ -0,0 +1,191 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+
+	"one-help/app/donations/plans"
+)
+
+// ErrPlans indicates that there was an error in the database.
+var ErrPlans = errs.Class("donation plans repository")
+
+// plansDB provides access to recurring donation plans db.
+//
+// architecture: Database
+type plansDB struct {
+	conn *sql.DB
+}
+
+// newPlansDB is a constructor for base plansDB.
+func newPlansDB(baseConn *sql.DB) plans.DB {
+	return &plansDB{
+		conn: baseConn,
+	}
+}
+
+// planColumns lists selected plan columns in the scan order.
+const planColumns = `plan_id, user_id, fundraise_id, organizer_id, amount, currency, interval, payment_type, transaction_id,
+                     subscription_id, status, failed_attempts, next_retry_at, created_at, updated_at`
+
+// Create inserts plan into the database.
+func (db *plansDB) Create(ctx context.Context, plan plans.Plan) error {
+	query := `INSERT INTO donation_plans(` + planColumns + `)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
+	_, err := db.conn.ExecContext(ctx, query,
+		plan.ID,
+		plan.UserID,
+		nullUUID(plan.FundraiseID),
+		nullUUID(plan.OrganizerID),
+		plan.Amount,
+		plan.Currency,
+		plan.Interval,
+		plan.PaymentType,
+		plan.TransactionID,
+		plan.SubscriptionID,
+		plan.Status,
+		plan.FailedAttempts,
+		nullTime(plan.NextRetryAt),
+		plan.CreatedAt,
+		plan.UpdatedAt,
+	)
+	return ErrPlans.Wrap(err)
+}
+
+// Get returns plan by id.
+func (db *plansDB) Get(ctx context.Context, id uuid.UUID) (plans.Plan, error) {
+	query := `SELECT ` + planColumns + ` FROM donation_plans WHERE plan_id = $1`
+	return db.get(ctx, query, id)
+}
+
+// GetBySubscription returns plan by provider's subscription id.
+func (db *plansDB) GetBySubscription(ctx context.Context, paymentType, subscriptionID string) (plans.Plan, error) {
+	if subscriptionID == "" {
+		return plans.Plan{}, ErrPlans.Wrap(plans.ErrNoPlan)
+	}
+
+	query := `SELECT ` + planColumns + ` FROM donation_plans WHERE payment_type = $1 AND subscription_id = $2`
+	return db.get(ctx, query, paymentType, subscriptionID)
+}
+
+// ListByUser returns plans of the donor, the newest first.
+func (db *plansDB) ListByUser(ctx context.Context, userID uuid.UUID) ([]plans.Plan, error) {
+	query := `SELECT ` + planColumns + ` FROM donation_plans WHERE user_id = $1 ORDER BY created_at DESC`
+	return db.list(ctx, query, userID)
+}
+
+// ListDue returns past due plans with retry scheduled before provided time.
+func (db *plansDB) ListDue(ctx context.Context, before time.Time) ([]plans.Plan, error) {
+	query := `SELECT ` + planColumns + ` FROM donation_plans
+              WHERE status = $1 AND next_retry_at <= $2
+              ORDER BY next_retry_at`
+	return db.list(ctx, query, plans.StatusPastDue, before)
+}
+
+// Update updates plan in the database by id.
+func (db *plansDB) Update(ctx context.Context, plan plans.Plan) error {
+	query := `UPDATE donation_plans
+              SET amount = $2, currency = $3, interval = $4, subscription_id = $5, status = $6, failed_attempts = $7,
+                  next_retry_at = $8, updated_at = $9
+              WHERE plan_id = $1`
+	result, err := db.conn.ExecContext(ctx, query,
+		plan.ID,
+		plan.Amount,
+		plan.Currency,
+		plan.Interval,
+		plan.SubscriptionID,
+		plan.Status,
+		plan.FailedAttempts,
+		nullTime(plan.NextRetryAt),
+		plan.UpdatedAt,
+	)
+	if err != nil {
+		return ErrPlans.Wrap(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return ErrPlans.Wrap(err)
+	}
+	if affected == 0 {
+		return ErrPlans.Wrap(plans.ErrNoPlan)
+	}
+
+	return nil
+}
+
+// get returns single plan selected by query.
+func (db *plansDB) get(ctx context.Context, query string, args ...any) (plans.Plan, error) {
+	plan, err := scanPlan(db.conn.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return plans.Plan{}, ErrPlans.Wrap(plans.ErrNoPlan)
+		}
+
+		return plans.Plan{}, ErrPlans.Wrap(err)
+	}
+
+	return plan, nil
+}
+
+// list returns plans selected by query.
+func (db *plansDB) list(ctx context.Context, query string, args ...any) (_ []plans.Plan, err error) {
+	rows, err := db.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, ErrPlans.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var list []plans.Plan
+	for rows.Next() {
+		plan, err := scanPlan(rows)
+		if err != nil {
+			return nil, ErrPlans.Wrap(err)
+		}
+
+		list = append(list, plan)
+	}
+
+	return list, ErrPlans.Wrap(rows.Err())
+}
+
+// scanPlan scans plan columns from the row.
+func scanPlan(row interface{ Scan(dest ...any) error }) (plans.Plan, error) {
+	var (
+		plan                     plans.Plan
+		fundraiseID, organizerID uuid.NullUUID
+		nextRetryAt              sql.NullTime
+	)
+
+	err := row.Scan(
+		&plan.ID,
+		&plan.UserID,
+		&fundraiseID,
+		&organizerID,
+		&plan.Amount,
+		&plan.Currency,
+		&plan.Interval,
+		&plan.PaymentType,
+		&plan.TransactionID,
+		&plan.SubscriptionID,
+		&plan.Status,
+		&plan.FailedAttempts,
+		&nextRetryAt,
+		&plan.CreatedAt,
+		&plan.UpdatedAt,
+	)
+	if err != nil {
+		return plans.Plan{}, err
+	}
+
+	plan.FundraiseID = fundraiseID.UUID
+	plan.OrganizerID = organizerID.UUID
+	plan.NextRetryAt = nextRetryAt.Time
+
+	return plan, nil
+}
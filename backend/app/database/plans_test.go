@@ -0,0 +1,108 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app"
+	"one-help/app/currencies"
+	"one-help/app/database"
+	"one-help/app/database/dbtesting"
+	"one-help/app/donations/plans"
+	"one-help/app/payments"
+	"one-help/app/users"
+)
+
+func TestPlans(t *testing.T) {
+	donor := users.User{
+		ID:        uuid.New(),
+		FirstName: "John",
+		LastName:  "Doe",
+	}
+	organizer := users.User{
+		ID:        uuid.New(),
+		FirstName: "Jane",
+		LastName:  "Doe",
+	}
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	plan := plans.Plan{
+		ID:            uuid.New(),
+		UserID:        donor.ID,
+		OrganizerID:   organizer.ID,
+		Amount:        currencies.Major(200),
+		Currency:      currencies.UAH,
+		Interval:      plans.IntervalMonth,
+		PaymentType:   payments.TypeStripe,
+		TransactionID: "cs_test",
+		Status:        plans.StatusPending,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	dbtesting.Run(t, database.Config{}, func(ctx context.Context, t *testing.T, db app.DB) {
+		plansRepository := db.DonationPlans()
+
+		t.Run("Create&Get", func(t *testing.T) {
+			require.NoError(t, db.Users().Create(ctx, donor))
+			require.NoError(t, db.Users().Create(ctx, organizer))
+			require.NoError(t, plansRepository.Create(ctx, plan))
+
+			storedPlan, err := plansRepository.Get(ctx, plan.ID)
+			require.NoError(t, err)
+			plansAreEqual(t, plan, storedPlan)
+		})
+
+		t.Run("Get(negative)", func(t *testing.T) {
+			_, err := plansRepository.Get(ctx, uuid.New())
+			require.ErrorIs(t, err, plans.ErrNoPlan)
+
+			_, err = plansRepository.GetBySubscription(ctx, payments.TypeStripe, "")
+			require.ErrorIs(t, err, plans.ErrNoPlan)
+		})
+
+		t.Run("Update&GetBySubscription", func(t *testing.T) {
+			plan.SubscriptionID = "sub_test"
+			plan.Fail(now)
+			require.NoError(t, plansRepository.Update(ctx, plan))
+
+			storedPlan, err := plansRepository.GetBySubscription(ctx, payments.TypeStripe, plan.SubscriptionID)
+			require.NoError(t, err)
+			plansAreEqual(t, plan, storedPlan)
+		})
+
+		t.Run("ListByUser&ListDue", func(t *testing.T) {
+			list, err := plansRepository.ListByUser(ctx, donor.ID)
+			require.NoError(t, err)
+			require.Len(t, list, 1)
+
+			list, err = plansRepository.ListDue(ctx, now)
+			require.NoError(t, err)
+			require.Len(t, list, 0)
+
+			list, err = plansRepository.ListDue(ctx, plan.NextRetryAt)
+			require.NoError(t, err)
+			require.Len(t, list, 1)
+			plansAreEqual(t, plan, list[0])
+		})
+	})
+}
+
+func plansAreEqual(t *testing.T, expected, actual plans.Plan) {
+	assert.Equal(t, expected.ID, actual.ID)
+	assert.Equal(t, expected.UserID, actual.UserID)
+	assert.Equal(t, expected.FundraiseID, actual.FundraiseID)
+	assert.Equal(t, expected.OrganizerID, actual.OrganizerID)
+	assert.Equal(t, expected.Amount, actual.Amount)
+	assert.Equal(t, expected.Currency, actual.Currency)
+	assert.Equal(t, expected.Interval, actual.Interval)
+	assert.Equal(t, expected.SubscriptionID, actual.SubscriptionID)
+	assert.Equal(t, expected.Status, actual.Status)
+	assert.Equal(t, expected.FailedAttempts, actual.FailedAttempts)
+	assert.True(t, expected.NextRetryAt.Equal(actual.NextRetryAt))
+}
@@ -0,0 +1,133 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+
+	"one-help/app/donations/receipts"
+)
+
+// ErrReceipts indicates that there was an error in the database.
+var ErrReceipts = errs.Class("receipts repository")
+
+// receiptsDB provides access to receipts db.
+//
+// architecture: Database
+type receiptsDB struct {
+	conn *sql.DB
+}
+
+// newReceiptsDB is a constructor for base receiptsDB.
+func newReceiptsDB(baseConn *sql.DB) receipts.DB {
+	return &receiptsDB{
+		conn: baseConn,
+	}
+}
+
+// receiptColumns lists selected receipt columns in the scan order.
+const receiptColumns = `receipt_id, donation_id, number, organizer_id, organizer_name, organizer_website, fundraise_id,
+                        fundraise_title, donor_name, amount, currency, payment_type, transaction_id, donated_at, issued_at`
+
+// Create assigns next organizer's number to the receipt and inserts it into the database.
+// Returns already issued receipt if the donation has one.
+func (db *receiptsDB) Create(ctx context.Context, receipt receipts.Receipt) (_ receipts.Receipt, err error) {
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return receipts.Receipt{}, ErrReceipts.Wrap(err)
+	}
+
+	defer DeferCommitRollback(tx, &err)
+
+	// INFO: locks organizer's counter, so numbers are assigned one by one without gaps.
+	query := `INSERT INTO receipt_counters(organizer_id, last_number)
+              VALUES ($1, 0)
+              ON CONFLICT (organizer_id) DO UPDATE SET last_number = receipt_counters.last_number
+              RETURNING last_number`
+	var lastNumber int64
+	if err = tx.QueryRowContext(ctx, query, receipt.OrganizerID).Scan(&lastNumber); err != nil {
+		return receipts.Receipt{}, ErrReceipts.Wrap(err)
+	}
+
+	query = `SELECT ` + receiptColumns + ` FROM receipts WHERE donation_id = $1`
+	existing, err := scanReceipt(tx.QueryRowContext(ctx, query, receipt.DonationID))
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return receipts.Receipt{}, ErrReceipts.Wrap(err)
+	}
+
+	receipt.Number = lastNumber + 1
+	query = `UPDATE receipt_counters SET last_number = $2 WHERE organizer_id = $1`
+	if _, err = tx.ExecContext(ctx, query, receipt.OrganizerID, receipt.Number); err != nil {
+		return receipts.Receipt{}, ErrReceipts.Wrap(err)
+	}
+
+	query = `INSERT INTO receipts(` + receiptColumns + `)
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
+	_, err = tx.ExecContext(ctx, query,
+		receipt.ID,
+		receipt.DonationID,
+		receipt.Number,
+		receipt.OrganizerID,
+		receipt.OrganizerName,
+		receipt.OrganizerWebsite,
+		receipt.FundraiseID,
+		receipt.FundraiseTitle,
+		receipt.DonorName,
+		receipt.Amount,
+		receipt.Currency,
+		receipt.PaymentType,
+		receipt.TransactionID,
+		receipt.DonatedAt,
+		receipt.IssuedAt,
+	)
+	if err != nil {
+		return receipts.Receipt{}, ErrReceipts.Wrap(err)
+	}
+
+	return receipt, nil
+}
+
+// GetByDonation returns receipt of the donation.
+func (db *receiptsDB) GetByDonation(ctx context.Context, donationID uuid.UUID) (receipts.Receipt, error) {
+	query := `SELECT ` + receiptColumns + ` FROM receipts WHERE donation_id = $1`
+
+	receipt, err := scanReceipt(db.conn.QueryRowContext(ctx, query, donationID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return receipts.Receipt{}, ErrReceipts.Wrap(receipts.ErrNoReceipt)
+		}
+
+		return receipts.Receipt{}, ErrReceipts.Wrap(err)
+	}
+
+	return receipt, nil
+}
+
+// scanReceipt scans receipt columns from the row.
+func scanReceipt(row interface{ Scan(dest ...any) error }) (receipts.Receipt, error) {
+	var receipt receipts.Receipt
+	err := row.Scan(
+		&receipt.ID,
+		&receipt.DonationID,
+		&receipt.Number,
+		&receipt.OrganizerID,
+		&receipt.OrganizerName,
+		&receipt.OrganizerWebsite,
+		&receipt.FundraiseID,
+		&receipt.FundraiseTitle,
+		&receipt.DonorName,
+		&receipt.Amount,
+		&receipt.Currency,
+		&receipt.PaymentType,
+		&receipt.TransactionID,
+		&receipt.DonatedAt,
+		&receipt.IssuedAt,
+	)
+	return receipt, err
+}
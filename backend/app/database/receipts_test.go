@@ -0,0 +1,138 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app"
+	"one-help/app/currencies"
+	"one-help/app/database"
+	"one-help/app/database/dbtesting"
+	"one-help/app/donations"
+	"one-help/app/donations/receipts"
+	"one-help/app/fundraises"
+	"one-help/app/fundraises/statuses"
+	"one-help/app/payments"
+	"one-help/app/users"
+)
+
+func TestReceipts(t *testing.T) {
+	user := users.User{
+		ID:        uuid.New(),
+		FirstName: "John",
+		LastName:  "Doe",
+	}
+
+	fundraise := fundraises.Fundraise{
+		ID:           uuid.New(),
+		OrganizerId:  user.ID,
+		Title:        "Test",
+		Description:  "Test Description",
+		TargetAmount: currencies.Major(1000),
+		Currency:     currencies.UAH,
+		StartDate:    time.Now(),
+		Status:       statuses.ActiveStatus,
+	}
+
+	donation1 := donations.Donation{
+		ID:          uuid.New(),
+		UserId:      user.ID,
+		FundraiseId: fundraise.ID,
+		Amount:      currencies.Major(100),
+		Currency:    currencies.UAH,
+		CreatedAt:   time.Now(),
+	}
+	donation2 := donation1
+	donation2.ID = uuid.New()
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	receipt := receipts.Receipt{
+		ID:             uuid.New(),
+		DonationID:     donation1.ID,
+		OrganizerID:    user.ID,
+		OrganizerName:  user.FullName(),
+		FundraiseID:    fundraise.ID,
+		FundraiseTitle: fundraise.Title,
+		DonorName:      user.FullName(),
+		Amount:         donation1.Amount,
+		Currency:       donation1.Currency,
+		PaymentType:    payments.TypeStripe,
+		TransactionID:  "cs_test_1",
+		DonatedAt:      now,
+		IssuedAt:       now,
+	}
+
+	dbtesting.Run(t, database.Config{}, func(ctx context.Context, t *testing.T, db app.DB) {
+		receiptsRepository := db.Receipts()
+
+		t.Run("Create&GetByDonation", func(t *testing.T) {
+			require.NoError(t, db.Users().Create(ctx, user))
+			require.NoError(t, db.Fundraises().Create(ctx, fundraise))
+			for i, donation := range []donations.Donation{donation1, donation2} {
+				require.NoError(t, db.Donations().Create(ctx, donation))
+				require.NoError(t, db.Payments().Create(ctx, payments.Payment{
+					DonationId:    donation.ID,
+					PaymentType:   payments.TypeStripe,
+					TransactionId: "cs_test_" + string(rune('1'+i)),
+					Confirmed:     true,
+				}))
+			}
+
+			created, err := receiptsRepository.Create(ctx, receipt)
+			require.NoError(t, err)
+			assert.EqualValues(t, 1, created.Number)
+
+			stored, err := receiptsRepository.GetByDonation(ctx, donation1.ID)
+			require.NoError(t, err)
+			receiptsAreEqual(t, created, stored)
+		})
+
+		t.Run("Create(idempotent)", func(t *testing.T) {
+			again := receipt
+			again.ID = uuid.New()
+
+			stored, err := receiptsRepository.Create(ctx, again)
+			require.NoError(t, err)
+			assert.Equal(t, receipt.ID, stored.ID)
+			assert.EqualValues(t, 1, stored.Number)
+		})
+
+		t.Run("Create(next number)", func(t *testing.T) {
+			next := receipt
+			next.ID = uuid.New()
+			next.DonationID = donation2.ID
+			next.TransactionID = "cs_test_2"
+
+			stored, err := receiptsRepository.Create(ctx, next)
+			require.NoError(t, err)
+			assert.EqualValues(t, 2, stored.Number)
+		})
+
+		t.Run("GetByDonation(negative)", func(t *testing.T) {
+			_, err := receiptsRepository.GetByDonation(ctx, uuid.New())
+			require.ErrorIs(t, err, receipts.ErrNoReceipt)
+		})
+	})
+}
+
+func receiptsAreEqual(t *testing.T, expected, actual receipts.Receipt) {
+	assert.Equal(t, expected.ID, actual.ID)
+	assert.Equal(t, expected.DonationID, actual.DonationID)
+	assert.Equal(t, expected.Number, actual.Number)
+	assert.Equal(t, expected.OrganizerID, actual.OrganizerID)
+	assert.Equal(t, expected.OrganizerName, actual.OrganizerName)
+	assert.Equal(t, expected.FundraiseID, actual.FundraiseID)
+	assert.Equal(t, expected.FundraiseTitle, actual.FundraiseTitle)
+	assert.Equal(t, expected.DonorName, actual.DonorName)
+	assert.Equal(t, expected.Amount, actual.Amount)
+	assert.Equal(t, expected.Currency, actual.Currency)
+	assert.Equal(t, expected.PaymentType, actual.PaymentType)
+	assert.Equal(t, expected.TransactionID, actual.TransactionID)
+	assert.True(t, expected.DonatedAt.Equal(actual.DonatedAt))
+	assert.True(t, expected.IssuedAt.Equal(actual.IssuedAt))
+}
@@ -0,0 +1,115 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"one-help/app/payments/reconciliation"
+)
+
+// ErrReconciliation indicates that there was an error in the database.
+var ErrReconciliation = errs.Class("reconciliation reports repository")
+
+// reconciliationDB provides access to reconciliation reports db.
+//
+// architecture: Database
+type reconciliationDB struct {
+	conn *sql.DB
+}
+
+// newReconciliationDB is a constructor for base reconciliationDB.
+func newReconciliationDB(baseConn *sql.DB) reconciliation.DB {
+	return &reconciliationDB{
+		conn: baseConn,
+	}
+}
+
+// Confirmed returns totals of confirmed donations created within the period, net of refunds.
+func (db *reconciliationDB) Confirmed(ctx context.Context, from, to time.Time) (_ []reconciliation.Report, err error) {
+	query := `SELECT p.payment_type, d.currency, COALESCE(SUM(d.amount - p.refunded_amount), 0)
+              FROM donations d
+              JOIN payments p ON p.donation_id = d.donation_id
+              WHERE p.confirmed AND p.status <> 'REFUNDED' AND d.created_at >= $1 AND d.created_at < $2
+              GROUP BY p.payment_type, d.currency
+              ORDER BY p.payment_type, d.currency`
+	rows, err := db.conn.QueryContext(ctx, query, from, to)
+	if err != nil {
+		return nil, ErrReconciliation.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var reports []reconciliation.Report
+	for rows.Next() {
+		report := reconciliation.Report{Date: from}
+		if err = rows.Scan(&report.PaymentType, &report.Currency, &report.Confirmed); err != nil {
+			return nil, ErrReconciliation.Wrap(err)
+		}
+
+		reports = append(reports, report)
+	}
+
+	return reports, ErrReconciliation.Wrap(rows.Err())
+}
+
+// Save inserts report or replaces the report of the same day, payment type and currency.
+func (db *reconciliationDB) Save(ctx context.Context, report reconciliation.Report) error {
+	query := `INSERT INTO reconciliation_reports(report_date, payment_type, currency, confirmed, collected, created_at)
+              VALUES ($1, $2, $3, $4, $5, $6)
+              ON CONFLICT (report_date, payment_type, currency)
+              DO UPDATE SET confirmed = EXCLUDED.confirmed, collected = EXCLUDED.collected, created_at = EXCLUDED.created_at`
+	_, err := db.conn.ExecContext(ctx, query,
+		report.Date,
+		report.PaymentType,
+		report.Currency,
+		report.Confirmed,
+		report.Collected,
+		report.CreatedAt,
+	)
+	return ErrReconciliation.Wrap(err)
+}
+
+// List returns reports of the day ordered by payment type and currency.
+func (db *reconciliationDB) List(ctx context.Context, date time.Time) (_ []reconciliation.Report, err error) {
+	query := `SELECT report_date, payment_type, currency, confirmed, collected, created_at
+              FROM reconciliation_reports
+              WHERE report_date = $1
+              ORDER BY payment_type, currency`
+	rows, err := db.conn.QueryContext(ctx, query, date)
+	if err != nil {
+		return nil, ErrReconciliation.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var reports []reconciliation.Report
+	for rows.Next() {
+		var report reconciliation.Report
+		err = rows.Scan(&report.Date, &report.PaymentType, &report.Currency, &report.Confirmed, &report.Collected, &report.CreatedAt)
+		if err != nil {
+			return nil, ErrReconciliation.Wrap(err)
+		}
+
+		reports = append(reports, report)
+	}
+
+	return reports, ErrReconciliation.Wrap(rows.Err())
+}
+
+// MarkReported records that the day is reported, even if it has no reports.
+func (db *reconciliationDB) MarkReported(ctx context.Context, date, reportedAt time.Time) error {
+	query := `INSERT INTO reconciliation_days(report_date, reported_at)
+              VALUES ($1, $2)
+              ON CONFLICT (report_date) DO UPDATE SET reported_at = EXCLUDED.reported_at`
+	_, err := db.conn.ExecContext(ctx, query, date, reportedAt)
+	return ErrReconciliation.Wrap(err)
+}
+
+// IsReported returns true if the day is reported.
+func (db *reconciliationDB) IsReported(ctx context.Context, date time.Time) (bool, error) {
+	var reported bool
+	query := `SELECT EXISTS(SELECT 1 FROM reconciliation_days WHERE report_date = $1)`
+	err := db.conn.QueryRowContext(ctx, query, date).Scan(&reported)
+	return reported, ErrReconciliation.Wrap(err)
+}
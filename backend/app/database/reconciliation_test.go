@@ -0,0 +1,145 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app"
+	"one-help/app/currencies"
+	"one-help/app/database"
+	"one-help/app/database/dbtesting"
+	"one-help/app/donations"
+	"one-help/app/fundraises"
+	"one-help/app/fundraises/statuses"
+	"one-help/app/payments"
+	"one-help/app/payments/reconciliation"
+	"one-help/app/users"
+)
+
+func TestReconciliation(t *testing.T) {
+	user := users.User{
+		ID:        uuid.New(),
+		FirstName: "John",
+		LastName:  "Doe",
+	}
+
+	fundraise := fundraises.Fundraise{
+		ID:           uuid.New(),
+		OrganizerId:  user.ID,
+		Title:        "Test",
+		Description:  "Test Description",
+		TargetAmount: currencies.Major(1000),
+		Currency:     currencies.UAH,
+		StartDate:    time.Now(),
+		Status:       statuses.ActiveStatus,
+	}
+
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	confirmed := donations.Donation{
+		ID:          uuid.New(),
+		UserId:      user.ID,
+		FundraiseId: fundraise.ID,
+		Amount:      currencies.Major(100),
+		Currency:    currencies.UAH,
+		CreatedAt:   day.Add(time.Hour),
+	}
+	pending := donations.Donation{
+		ID:          uuid.New(),
+		UserId:      user.ID,
+		FundraiseId: fundraise.ID,
+		Currency:    currencies.UAH,
+		CreatedAt:   day.Add(2 * time.Hour),
+	}
+
+	dbtesting.Run(t, database.Config{}, func(ctx context.Context, t *testing.T, db app.DB) {
+		reconciliationRepository := db.ReconciliationReports()
+
+		require.NoError(t, db.Users().Create(ctx, user))
+		require.NoError(t, db.Fundraises().Create(ctx, fundraise))
+		require.NoError(t, db.Donations().Create(ctx, confirmed))
+		require.NoError(t, db.Donations().Create(ctx, pending))
+		require.NoError(t, db.Payments().Create(ctx, payments.Payment{
+			DonationId:     confirmed.ID,
+			PaymentType:    payments.TypeStripe,
+			TransactionId:  "cs_confirmed",
+			Confirmed:      true,
+			Status:         payments.StatusPartiallyRefunded,
+			RefundedAmount: currencies.Major(30),
+		}))
+		require.NoError(t, db.Payments().Create(ctx, payments.Payment{
+			DonationId:    pending.ID,
+			PaymentType:   payments.TypeStripe,
+			TransactionId: "cs_pending",
+		}))
+
+		t.Run("ListPending", func(t *testing.T) {
+			list, err := db.Payments().ListPending(ctx, pending.CreatedAt)
+			require.NoError(t, err)
+			assert.Empty(t, list)
+
+			list, err = db.Payments().ListPending(ctx, pending.CreatedAt.Add(time.Minute))
+			require.NoError(t, err)
+			require.Len(t, list, 1)
+			assert.Equal(t, pending.ID, list[0].DonationId)
+		})
+
+		t.Run("Confirmed", func(t *testing.T) {
+			reports, err := reconciliationRepository.Confirmed(ctx, day, day.AddDate(0, 0, 1))
+			require.NoError(t, err)
+			require.Len(t, reports, 1)
+			assert.Equal(t, payments.TypeStripe, reports[0].PaymentType)
+			assert.Equal(t, currencies.UAH, reports[0].Currency)
+			assert.Equal(t, currencies.Major(70), reports[0].Confirmed)
+
+			reports, err = reconciliationRepository.Confirmed(ctx, day.AddDate(0, 0, 1), day.AddDate(0, 0, 2))
+			require.NoError(t, err)
+			assert.Empty(t, reports)
+		})
+
+		t.Run("Save&List", func(t *testing.T) {
+			report := reconciliation.Report{
+				Date:        day,
+				PaymentType: payments.TypeStripe,
+				Currency:    currencies.UAH,
+				Confirmed:   currencies.Major(70),
+				Collected:   currencies.Major(100),
+				CreatedAt:   time.Now().UTC(),
+			}
+			require.NoError(t, reconciliationRepository.Save(ctx, report))
+
+			report.Collected = currencies.Major(70)
+			require.NoError(t, reconciliationRepository.Save(ctx, report))
+
+			reports, err := reconciliationRepository.List(ctx, day)
+			require.NoError(t, err)
+			require.Len(t, reports, 1)
+			assert.True(t, reports[0].Date.Equal(day))
+			assert.Equal(t, currencies.Major(70), reports[0].Collected)
+			assert.True(t, reports[0].IsBalanced())
+		})
+
+		t.Run("MarkReported&IsReported", func(t *testing.T) {
+			// INFO: day without reports is recorded as reported.
+			empty := day.AddDate(0, 0, 1)
+			reported, err := reconciliationRepository.IsReported(ctx, empty)
+			require.NoError(t, err)
+			assert.False(t, reported)
+
+			require.NoError(t, reconciliationRepository.MarkReported(ctx, empty, time.Now().UTC()))
+			require.NoError(t, reconciliationRepository.MarkReported(ctx, empty, time.Now().UTC()))
+
+			reported, err = reconciliationRepository.IsReported(ctx, empty)
+			require.NoError(t, err)
+			assert.True(t, reported)
+
+			reports, err := reconciliationRepository.List(ctx, empty)
+			require.NoError(t, err)
+			assert.Empty(t, reports)
+		})
+	})
+}
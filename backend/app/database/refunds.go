@@ -0,0 +1,161 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+
+	"one-help/app/payments/refunds"
+)
+
+// ErrRefunds indicates that there was an error in the database.
+var ErrRefunds = errs.Class("refunds repository")
+
+// refundsDB provides access to refunds db.
+//
+// architecture: Database
+type refundsDB struct {
+	conn *sql.DB
+}
+
+// newRefundsDB is a constructor for base refundsDB.
+func newRefundsDB(baseConn *sql.DB) refunds.DB {
+	return &refundsDB{
+		conn: baseConn,
+	}
+}
+
+// refundColumns lists selected refund columns in the scan order.
+const refundColumns = `refund_id, donation_id, amount, currency, reason, status, requested_by, reviewed_by, review_reason,
+                       failure_message, created_at, updated_at`
+
+// Create inserts refund into the database.
+func (db *refundsDB) Create(ctx context.Context, refund refunds.Refund) error {
+	query := `INSERT INTO refunds(` + refundColumns + `)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+	_, err := db.conn.ExecContext(ctx, query,
+		refund.ID,
+		refund.DonationID,
+		refund.Amount,
+		refund.Currency,
+		refund.Reason,
+		refund.Status,
+		refund.RequestedBy,
+		nullUUID(refund.ReviewedBy),
+		refund.ReviewReason,
+		refund.FailureMessage,
+		refund.CreatedAt,
+		refund.UpdatedAt,
+	)
+	return ErrRefunds.Wrap(err)
+}
+
+// Get returns refund by id.
+func (db *refundsDB) Get(ctx context.Context, id uuid.UUID) (refunds.Refund, error) {
+	query := `SELECT ` + refundColumns + ` FROM refunds WHERE refund_id = $1`
+
+	refund, err := scanRefund(db.conn.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return refunds.Refund{}, ErrRefunds.Wrap(refunds.ErrNoRefund)
+		}
+
+		return refunds.Refund{}, ErrRefunds.Wrap(err)
+	}
+
+	return refund, nil
+}
+
+// ListByDonation returns refunds of the donation, the oldest first.
+func (db *refundsDB) ListByDonation(ctx context.Context, donationID uuid.UUID) ([]refunds.Refund, error) {
+	query := `SELECT ` + refundColumns + ` FROM refunds WHERE donation_id = $1 ORDER BY created_at`
+	return db.list(ctx, query, donationID)
+}
+
+// ListByStatus returns refunds with provided status, the oldest first.
+func (db *refundsDB) ListByStatus(ctx context.Context, status string) ([]refunds.Refund, error) {
+	query := `SELECT ` + refundColumns + ` FROM refunds WHERE status = $1 ORDER BY created_at`
+	return db.list(ctx, query, status)
+}
+
+// Update updates refund in the database by id.
+func (db *refundsDB) Update(ctx context.Context, refund refunds.Refund) error {
+	query := `UPDATE refunds
+              SET status = $2, reviewed_by = $3, review_reason = $4, failure_message = $5, updated_at = $6
+              WHERE refund_id = $1`
+	result, err := db.conn.ExecContext(ctx, query,
+		refund.ID,
+		refund.Status,
+		nullUUID(refund.ReviewedBy),
+		refund.ReviewReason,
+		refund.FailureMessage,
+		refund.UpdatedAt,
+	)
+	if err != nil {
+		return ErrRefunds.Wrap(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return ErrRefunds.Wrap(err)
+	}
+	if affected == 0 {
+		return ErrRefunds.Wrap(refunds.ErrNoRefund)
+	}
+
+	return nil
+}
+
+// list returns refunds selected by query.
+func (db *refundsDB) list(ctx context.Context, query string, args ...any) (_ []refunds.Refund, err error) {
+	rows, err := db.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, ErrRefunds.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var list []refunds.Refund
+	for rows.Next() {
+		refund, err := scanRefund(rows)
+		if err != nil {
+			return nil, ErrRefunds.Wrap(err)
+		}
+
+		list = append(list, refund)
+	}
+
+	return list, ErrRefunds.Wrap(rows.Err())
+}
+
+// scanRefund scans refund columns from the row.
+func scanRefund(row interface{ Scan(dest ...any) error }) (refunds.Refund, error) {
+	var (
+		refund     refunds.Refund
+		reviewedBy uuid.NullUUID
+	)
+
+	err := row.Scan(
+		&refund.ID,
+		&refund.DonationID,
+		&refund.Amount,
+		&refund.Currency,
+		&refund.Reason,
+		&refund.Status,
+		&refund.RequestedBy,
+		&reviewedBy,
+		&refund.ReviewReason,
+		&refund.FailureMessage,
+		&refund.CreatedAt,
+		&refund.UpdatedAt,
+	)
+	if err != nil {
+		return refunds.Refund{}, err
+	}
+
+	refund.ReviewedBy = reviewedBy.UUID
+
+	return refund, nil
+}
@@ -0,0 +1,134 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app"
+	"one-help/app/currencies"
+	"one-help/app/database"
+	"one-help/app/database/dbtesting"
+	"one-help/app/donations"
+	"one-help/app/fundraises"
+	"one-help/app/fundraises/statuses"
+	"one-help/app/payments"
+	"one-help/app/payments/refunds"
+	"one-help/app/users"
+)
+
+func TestRefunds(t *testing.T) {
+	user := users.User{
+		ID:        uuid.New(),
+		FirstName: "John",
+		LastName:  "Doe",
+	}
+	admin := users.User{
+		ID:        uuid.New(),
+		FirstName: "Jane",
+		LastName:  "Doe",
+	}
+
+	fundraise := fundraises.Fundraise{
+		ID:           uuid.New(),
+		OrganizerId:  user.ID,
+		Title:        "Test",
+		Description:  "Test Description",
+		TargetAmount: currencies.Major(1000),
+		Currency:     currencies.UAH,
+		StartDate:    time.Now(),
+		Status:       statuses.ActiveStatus,
+	}
+
+	donation := donations.Donation{
+		ID:          uuid.New(),
+		UserId:      user.ID,
+		FundraiseId: fundraise.ID,
+		Amount:      currencies.Major(100),
+		Currency:    currencies.UAH,
+		CreatedAt:   time.Now(),
+	}
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	refund := refunds.Refund{
+		ID:          uuid.New(),
+		DonationID:  donation.ID,
+		Amount:      currencies.Major(40),
+		Currency:    currencies.UAH,
+		Reason:      "duplicate donation",
+		Status:      refunds.StatusPendingApproval,
+		RequestedBy: user.ID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	dbtesting.Run(t, database.Config{}, func(ctx context.Context, t *testing.T, db app.DB) {
+		refundsRepository := db.Refunds()
+
+		t.Run("Create&Get", func(t *testing.T) {
+			require.NoError(t, db.Users().Create(ctx, user))
+			require.NoError(t, db.Users().Create(ctx, admin))
+			require.NoError(t, db.Fundraises().Create(ctx, fundraise))
+			require.NoError(t, db.Donations().Create(ctx, donation))
+			require.NoError(t, db.Payments().Create(ctx, payments.Payment{
+				DonationId:    donation.ID,
+				PaymentType:   payments.TypeStripe,
+				TransactionId: "cs_test",
+				Confirmed:     true,
+			}))
+			require.NoError(t, refundsRepository.Create(ctx, refund))
+
+			storedRefund, err := refundsRepository.Get(ctx, refund.ID)
+			require.NoError(t, err)
+			refundsAreEqual(t, refund, storedRefund)
+		})
+
+		t.Run("Get(negative)", func(t *testing.T) {
+			_, err := refundsRepository.Get(ctx, uuid.New())
+			require.ErrorIs(t, err, refunds.ErrNoRefund)
+
+			err = refundsRepository.Update(ctx, refunds.Refund{ID: uuid.New(), Status: refunds.StatusFailed})
+			require.ErrorIs(t, err, refunds.ErrNoRefund)
+		})
+
+		t.Run("ListByStatus", func(t *testing.T) {
+			list, err := refundsRepository.ListByStatus(ctx, refunds.StatusPendingApproval)
+			require.NoError(t, err)
+			require.Len(t, list, 1)
+			refundsAreEqual(t, refund, list[0])
+		})
+
+		t.Run("Update&ListByDonation", func(t *testing.T) {
+			refund.Status = refunds.StatusSucceeded
+			refund.ReviewedBy = admin.ID
+			refund.UpdatedAt = now.Add(time.Minute)
+			require.NoError(t, refundsRepository.Update(ctx, refund))
+
+			list, err := refundsRepository.ListByDonation(ctx, donation.ID)
+			require.NoError(t, err)
+			require.Len(t, list, 1)
+			refundsAreEqual(t, refund, list[0])
+
+			list, err = refundsRepository.ListByStatus(ctx, refunds.StatusPendingApproval)
+			require.NoError(t, err)
+			assert.Empty(t, list)
+		})
+	})
+}
+
+func refundsAreEqual(t *testing.T, expected, actual refunds.Refund) {
+	assert.Equal(t, expected.ID, actual.ID)
+	assert.Equal(t, expected.DonationID, actual.DonationID)
+	assert.Equal(t, expected.Amount, actual.Amount)
+	assert.Equal(t, expected.Currency, actual.Currency)
+	assert.Equal(t, expected.Reason, actual.Reason)
+	assert.Equal(t, expected.Status, actual.Status)
+	assert.Equal(t, expected.RequestedBy, actual.RequestedBy)
+	assert.Equal(t, expected.ReviewedBy, actual.ReviewedBy)
+	assert.True(t, expected.CreatedAt.Equal(actual.CreatedAt))
+	assert.True(t, expected.UpdatedAt.Equal(actual.UpdatedAt))
+}
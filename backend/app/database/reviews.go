@@ -0,0 +1,114 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+
+	"one-help/app/fundraises/reviews"
+)
+
+// ErrReviews indicates that there was an error in the database.
+var ErrReviews = errs.Class("reviews repository")
+
+// reviewsDB provides access to fundraise reviews db.
+//
+// architecture: Database
+type reviewsDB struct {
+	conn *sql.DB
+}
+
+// newReviewsDB is a constructor for base reviewsDB.
+func newReviewsDB(baseConn *sql.DB) reviews.DB {
+	return &reviewsDB{
+		conn: baseConn,
+	}
+}
+
+// Create records review and moves fundraise from fromStatus to toStatus in the same transaction.
+// Status is left untouched if toStatus is empty.
+func (db *reviewsDB) Create(ctx context.Context, review reviews.Review, fromStatus, toStatus string) error {
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return ErrReviews.Wrap(err)
+	}
+
+	defer DeferCommitRollback(tx, &err)
+
+	if toStatus != "" {
+		var result sql.Result
+		result, err = tx.ExecContext(ctx, `UPDATE fundraises SET status = $3 WHERE fundraise_id = $1 AND status = $2`,
+			review.FundraiseID, fromStatus, toStatus)
+		if err != nil {
+			return ErrReviews.Wrap(err)
+		}
+
+		var affected int64
+		if affected, err = result.RowsAffected(); err != nil {
+			return ErrReviews.Wrap(err)
+		}
+		if affected == 0 {
+			err = reviews.ErrStatusChanged
+			return ErrReviews.Wrap(err)
+		}
+	}
+
+	err = insertReview(ctx, tx, review)
+	return ErrReviews.Wrap(err)
+}
+
+// insertReview inserts review within provided database transaction.
+func insertReview(ctx context.Context, tx *sql.Tx, review reviews.Review) error {
+	query := `INSERT INTO fundraise_reviews(review_id, fundraise_id, actor_id, decision, reason, created_at)
+              VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err := tx.ExecContext(ctx, query,
+		review.ID,
+		review.FundraiseID,
+		review.ActorID,
+		review.Decision,
+		review.Reason,
+		review.CreatedAt,
+	)
+
+	return err
+}
+
+// List returns reviews of the fundraise ordered from the oldest to the newest.
+func (db *reviewsDB) List(ctx context.Context, fundraiseID uuid.UUID) (_ []reviews.Review, err error) {
+	query := `SELECT review_id, fundraise_id, actor_id, decision, reason, created_at
+              FROM fundraise_reviews
+              WHERE fundraise_id = $1
+              ORDER BY created_at`
+
+	rows, err := db.conn.QueryContext(ctx, query, fundraiseID)
+	if err != nil {
+		return nil, ErrReviews.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var reviewsList []reviews.Review
+	for rows.Next() {
+		var review reviews.Review
+		err = rows.Scan(
+			&review.ID,
+			&review.FundraiseID,
+			&review.ActorID,
+			&review.Decision,
+			&review.Reason,
+			&review.CreatedAt,
+		)
+		if err != nil {
+			return nil, ErrReviews.Wrap(err)
+		}
+
+		reviewsList = append(reviewsList, review)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, ErrReviews.Wrap(err)
+	}
+
+	return reviewsList, nil
+}
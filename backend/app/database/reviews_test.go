@@ -0,0 +1,81 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app"
+	"one-help/app/currencies"
+	"one-help/app/database"
+	"one-help/app/database/dbtesting"
+	"one-help/app/fundraises"
+	"one-help/app/fundraises/reviews"
+	"one-help/app/fundraises/statuses"
+	"one-help/app/users"
+)
+
+func TestReviews(t *testing.T) {
+	user := users.User{
+		ID:        uuid.New(),
+		FirstName: "John",
+		LastName:  "Doe",
+	}
+
+	fundraise := fundraises.Fundraise{
+		ID:           uuid.New(),
+		OrganizerId:  user.ID,
+		Title:        "Test",
+		Description:  "Test Description",
+		TargetAmount: currencies.Major(1000),
+		StartDate:    time.Now(),
+		Status:       statuses.PendingReviewStatus,
+	}
+
+	rejection := reviews.Review{
+		ID:          uuid.New(),
+		FundraiseID: fundraise.ID,
+		ActorID:     user.ID,
+		Decision:    reviews.DecisionRejected,
+		Reason:      "missing proof of need",
+		CreatedAt:   time.Now(),
+	}
+
+	dbtesting.Run(t, database.Config{}, func(ctx context.Context, t *testing.T, db app.DB) {
+		reviewsRepository := db.FundraiseReviews()
+
+		require.NoError(t, db.Users().Create(ctx, user))
+		require.NoError(t, db.Fundraises().Create(ctx, fundraise))
+
+		t.Run("Create", func(t *testing.T) {
+			require.NoError(t, reviewsRepository.Create(ctx, rejection, statuses.PendingReviewStatus, statuses.RejectedStatus))
+
+			storedFundraise, err := db.Fundraises().Get(ctx, fundraise.ID)
+			require.NoError(t, err)
+			assert.Equal(t, statuses.RejectedStatus, storedFundraise.Status)
+		})
+
+		t.Run("Create(status changed)", func(t *testing.T) {
+			approval := rejection
+			approval.ID = uuid.New()
+			approval.Decision = reviews.DecisionApproved
+
+			err := reviewsRepository.Create(ctx, approval, statuses.PendingReviewStatus, statuses.ActiveStatus)
+			require.Error(t, err)
+			require.ErrorIs(t, err, reviews.ErrStatusChanged)
+		})
+
+		t.Run("List", func(t *testing.T) {
+			list, err := reviewsRepository.List(ctx, fundraise.ID)
+			require.NoError(t, err)
+			require.Len(t, list, 1)
+			assert.Equal(t, rejection.ID, list[0].ID)
+			assert.Equal(t, rejection.Decision, list[0].Decision)
+			assert.Equal(t, rejection.Reason, list[0].Reason)
+		})
+	})
+}
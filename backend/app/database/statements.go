@@ -0,0 +1,195 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+
+	"one-help/app/payments/statements"
+)
+
+// ErrStatements indicates that there was an error in the database.
+var ErrStatements = errs.Class("bank statements repository")
+
+// statementsDB provides access to bank statements db.
+//
+// architecture: Database
+type statementsDB struct {
+	conn *sql.DB
+}
+
+// newStatementsDB is a constructor for base statementsDB.
+func newStatementsDB(baseConn *sql.DB) statements.DB {
+	return &statementsDB{
+		conn: baseConn,
+	}
+}
+
+// statementLineColumns lists selected statement line columns in the scan order.
+const statementLineColumns = `line_id, statement_id, fundraise_id, external_id, booked_at, amount, currency, reference, counterparty,
+                              status, donation_id, reviewed_by, updated_at`
+
+// Create inserts statement with its lines, lines of transactions imported before are skipped.
+// Returns number of inserted lines.
+func (db *statementsDB) Create(ctx context.Context, statement statements.Statement, lines []statements.Line) (inserted int, err error) {
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, ErrStatements.Wrap(err)
+	}
+
+	defer DeferCommitRollback(tx, &err)
+
+	query := `INSERT INTO bank_statements(statement_id, fundraise_id, format, imported_by, created_at)
+              VALUES ($1, $2, $3, $4, $5)`
+	_, err = tx.ExecContext(ctx, query, statement.ID, statement.FundraiseID, statement.Format, statement.ImportedBy, statement.CreatedAt)
+	if err != nil {
+		return 0, ErrStatements.Wrap(err)
+	}
+
+	query = `INSERT INTO statement_lines(` + statementLineColumns + `)
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+             ON CONFLICT (fundraise_id, external_id) DO NOTHING`
+	for _, line := range lines {
+		result, err := tx.ExecContext(ctx, query,
+			line.ID,
+			statement.ID,
+			statement.FundraiseID,
+			line.Transaction.ExternalID,
+			line.Transaction.BookedAt,
+			line.Transaction.Amount,
+			line.Transaction.Currency,
+			line.Transaction.Reference,
+			line.Transaction.Counterparty,
+			line.Status,
+			nullUUID(line.DonationID),
+			nullUUID(line.ReviewedBy),
+			line.UpdatedAt,
+		)
+		if err != nil {
+			return 0, ErrStatements.Wrap(err)
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return 0, ErrStatements.Wrap(err)
+		}
+
+		inserted += int(affected)
+	}
+
+	return inserted, nil
+}
+
+// GetLine returns statement line by id.
+func (db *statementsDB) GetLine(ctx context.Context, id uuid.UUID) (statements.Line, error) {
+	query := `SELECT ` + statementLineColumns + ` FROM statement_lines WHERE line_id = $1`
+
+	line, err := scanStatementLine(db.conn.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return statements.Line{}, ErrStatements.Wrap(statements.ErrNoLine)
+		}
+
+		return statements.Line{}, ErrStatements.Wrap(err)
+	}
+
+	return line, nil
+}
+
+// ListLines returns lines of the fundraise statements with provided status, all if empty, the latest booked first.
+func (db *statementsDB) ListLines(ctx context.Context, fundraiseID uuid.UUID, status string) (_ []statements.Line, err error) {
+	query := `SELECT ` + statementLineColumns + ` FROM statement_lines
+              WHERE fundraise_id = $1 AND ($2 = '' OR status = $2)
+              ORDER BY booked_at DESC, line_id`
+	rows, err := db.conn.QueryContext(ctx, query, fundraiseID, status)
+	if err != nil {
+		return nil, ErrStatements.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var lines []statements.Line
+	for rows.Next() {
+		line, err := scanStatementLine(rows)
+		if err != nil {
+			return nil, ErrStatements.Wrap(err)
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines, ErrStatements.Wrap(rows.Err())
+}
+
+// UpdateLine updates reconciliation state of the line only if it still has the previous status, returns ErrStatusChanged otherwise.
+func (db *statementsDB) UpdateLine(ctx context.Context, line statements.Line, previousStatus string) error {
+	query := `UPDATE statement_lines
+              SET status = $2, donation_id = $3, reviewed_by = $4, updated_at = $5
+              WHERE line_id = $1 AND status = $6`
+	result, err := db.conn.ExecContext(ctx, query,
+		line.ID,
+		line.Status,
+		nullUUID(line.DonationID),
+		nullUUID(line.ReviewedBy),
+		line.UpdatedAt,
+		previousStatus,
+	)
+	if err != nil {
+		return ErrStatements.Wrap(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return ErrStatements.Wrap(err)
+	}
+	if affected == 0 {
+		var exists bool
+		if err = db.conn.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM statement_lines WHERE line_id = $1)`, line.ID).Scan(&exists); err != nil {
+			return ErrStatements.Wrap(err)
+		}
+
+		err = statements.ErrNoLine
+		if exists {
+			err = statements.ErrStatusChanged
+		}
+
+		return ErrStatements.Wrap(err)
+	}
+
+	return nil
+}
+
+// scanStatementLine scans statement line columns from the row.
+func scanStatementLine(row interface{ Scan(dest ...any) error }) (statements.Line, error) {
+	var (
+		line       statements.Line
+		donationID uuid.NullUUID
+		reviewedBy uuid.NullUUID
+	)
+
+	err := row.Scan(
+		&line.ID,
+		&line.StatementID,
+		&line.FundraiseID,
+		&line.Transaction.ExternalID,
+		&line.Transaction.BookedAt,
+		&line.Transaction.Amount,
+		&line.Transaction.Currency,
+		&line.Transaction.Reference,
+		&line.Transaction.Counterparty,
+		&line.Status,
+		&donationID,
+		&reviewedBy,
+		&line.UpdatedAt,
+	)
+	if err != nil {
+		return statements.Line{}, err
+	}
+
+	line.DonationID = donationID.UUID
+	line.ReviewedBy = reviewedBy.UUID
+
+	return line, nil
+}
@@ -0,0 +1,121 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app"
+	"one-help/app/currencies"
+	"one-help/app/database"
+	"one-help/app/database/dbtesting"
+	"one-help/app/fundraises"
+	"one-help/app/fundraises/statuses"
+	"one-help/app/payments/statements"
+	"one-help/app/users"
+)
+
+func TestBankStatements(t *testing.T) {
+	organizer := users.User{ID: uuid.New(), FirstName: "John", LastName: "Doe"}
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	fundraise := fundraises.Fundraise{
+		ID:           uuid.New(),
+		OrganizerId:  organizer.ID,
+		Title:        "Test",
+		Description:  "Test Description",
+		TargetAmount: currencies.Major(1000),
+		StartDate:    now,
+		Status:       statuses.ActiveStatus,
+	}
+
+	statement := statements.Statement{
+		ID:          uuid.New(),
+		FundraiseID: fundraise.ID,
+		Format:      statements.FormatCAMT053,
+		ImportedBy:  organizer.ID,
+		CreatedAt:   now,
+	}
+
+	line := statements.Line{
+		ID: uuid.New(),
+		Transaction: statements.Transaction{
+			ExternalID:   "REF-1",
+			BookedAt:     now.Add(-time.Hour),
+			Amount:       currencies.Major(250),
+			Currency:     "UAH",
+			Reference:    "Donation",
+			Counterparty: "Jane Doe",
+		},
+		Status:    statements.StatusUnmatched,
+		UpdatedAt: now,
+	}
+
+	dbtesting.Run(t, database.Config{}, func(ctx context.Context, t *testing.T, db app.DB) {
+		statementsRepository := db.BankStatements()
+
+		require.NoError(t, db.Users().Create(ctx, organizer))
+		require.NoError(t, db.Fundraises().Create(ctx, fundraise))
+
+		t.Run("Create&GetLine", func(t *testing.T) {
+			inserted, err := statementsRepository.Create(ctx, statement, []statements.Line{line})
+			require.NoError(t, err)
+			assert.Equal(t, 1, inserted)
+
+			stored, err := statementsRepository.GetLine(ctx, line.ID)
+			require.NoError(t, err)
+			assert.Equal(t, statement.ID, stored.StatementID)
+			assert.Equal(t, fundraise.ID, stored.FundraiseID)
+			assert.Equal(t, line.Transaction.ExternalID, stored.Transaction.ExternalID)
+			assert.Equal(t, line.Transaction.Amount, stored.Transaction.Amount)
+			assert.Equal(t, statements.StatusUnmatched, stored.Status)
+			assert.Equal(t, uuid.Nil, stored.DonationID)
+		})
+
+		t.Run("Create(duplicate)", func(t *testing.T) {
+			repeated := statement
+			repeated.ID = uuid.New()
+			duplicate := line
+			duplicate.ID = uuid.New()
+
+			inserted, err := statementsRepository.Create(ctx, repeated, []statements.Line{duplicate})
+			require.NoError(t, err)
+			assert.Zero(t, inserted)
+		})
+
+		t.Run("UpdateLine&ListLines", func(t *testing.T) {
+			reviewed := line
+			reviewed.Status = statements.StatusIgnored
+			reviewed.ReviewedBy = organizer.ID
+			require.NoError(t, statementsRepository.UpdateLine(ctx, reviewed, statements.StatusUnmatched))
+
+			// INFO: line reviewed concurrently is not updated again.
+			err := statementsRepository.UpdateLine(ctx, reviewed, statements.StatusUnmatched)
+			require.ErrorIs(t, err, statements.ErrStatusChanged)
+
+			lines, err := statementsRepository.ListLines(ctx, fundraise.ID, statements.StatusUnmatched)
+			require.NoError(t, err)
+			assert.Empty(t, lines)
+
+			lines, err = statementsRepository.ListLines(ctx, fundraise.ID, "")
+			require.NoError(t, err)
+			require.Len(t, lines, 1)
+			assert.Equal(t, statements.StatusIgnored, lines[0].Status)
+			assert.Equal(t, organizer.ID, lines[0].ReviewedBy)
+		})
+
+		t.Run("GetLine(negative)", func(t *testing.T) {
+			_, err := statementsRepository.GetLine(ctx, uuid.New())
+			require.Error(t, err)
+			require.ErrorIs(t, err, statements.ErrNoLine)
+
+			err = statementsRepository.UpdateLine(ctx, statements.Line{ID: uuid.New(), Status: statements.StatusIgnored}, statements.StatusUnmatched)
+			require.ErrorIs(t, err, statements.ErrNoLine)
+		})
+	})
+}
@@ -0,0 +1,210 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+
+	"one-help/app/currencies"
+	"one-help/app/fundraises"
+	"one-help/app/fundraises/transfers"
+	"one-help/app/ledger"
+)
+
+// ErrTransfers indicates that there was an error in the database.
+var ErrTransfers = errs.Class("transfers repository")
+
+// transfersDB provides access to fundraise transfers db.
+//
+// architecture: Database
+type transfersDB struct {
+	conn *sql.DB
+}
+
+// newTransfersDB is a constructor for base transfersDB.
+func newTransfersDB(baseConn *sql.DB) transfers.DB {
+	return &transfersDB{
+		conn: baseConn,
+	}
+}
+
+// Create inserts transfer into the database, ensuring that source fundraise keeps at least minRemaining balance
+// and that the amount is not paid out or reserved by payouts.
+// Source fundraise status is changed to sourceStatus if it is not empty, and the transfer is posted to the ledger
+// in the same transaction.
+func (db *transfersDB) Create(ctx context.Context, transfer transfers.Transfer, minRemaining currencies.Amount, sourceStatus string) error {
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return ErrTransfers.Wrap(err)
+	}
+
+	defer DeferCommitRollback(tx, &err)
+
+	// INFO: Source fundraise row lock serializes concurrent transfers from the same fundraise.
+	var fromCurrency, toCurrency string
+	err = tx.QueryRowContext(ctx, `SELECT currency FROM fundraises WHERE fundraise_id = $1 FOR UPDATE`, transfer.FromFundraiseID).Scan(&fromCurrency)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = fundraises.ErrNoFundraise
+		}
+
+		return ErrTransfers.Wrap(err)
+	}
+
+	err = tx.QueryRowContext(ctx, `SELECT currency FROM fundraises WHERE fundraise_id = $1`, transfer.ToFundraiseID).Scan(&toCurrency)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = fundraises.ErrNoFundraise
+		}
+
+		return ErrTransfers.Wrap(err)
+	}
+
+	var collected currencies.Amount
+	if err = tx.QueryRowContext(ctx, fundraiseCollectedQuery, ledger.FundraiseBalance(transfer.FromFundraiseID)).Scan(&collected); err != nil {
+		return ErrTransfers.Wrap(err)
+	}
+
+	// INFO: funds already paid out or reserved by open payouts can not be transferred.
+	available, err := availableBalance(ctx, tx, transfer.FromFundraiseID)
+	if err != nil {
+		return ErrTransfers.Wrap(err)
+	}
+
+	if collected.Sub(transfer.Amount).Cmp(minRemaining) < 0 || available.Cmp(transfer.Amount) < 0 {
+		err = transfers.ErrInsufficientFunds
+		return ErrTransfers.Wrap(err)
+	}
+
+	query := `INSERT INTO fundraise_transfers(transfer_id, from_fundraise_id, to_fundraise_id, amount, exchange_rate, initiated_by, reason, created_at)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err = tx.ExecContext(ctx, query,
+		transfer.ID,
+		transfer.FromFundraiseID,
+		transfer.ToFundraiseID,
+		transfer.Amount,
+		transfer.ExchangeRate,
+		transfer.InitiatedBy,
+		transfer.Reason,
+		transfer.CreatedAt,
+	)
+	if err != nil {
+		return ErrTransfers.Wrap(err)
+	}
+
+	posting := ledger.New(ledger.KindTransfer, transfer.ID.String(), transfer.CreatedAt)
+	posting.Move(
+		ledger.FundraiseBalance(transfer.FromFundraiseID), currencies.NewMoney(transfer.Amount, fromCurrency),
+		ledger.FundraiseBalance(transfer.ToFundraiseID), currencies.NewMoney(transfer.ConvertedAmount(), toCurrency),
+	)
+	if _, err = postTransaction(ctx, tx, posting); err != nil {
+		return ErrTransfers.Wrap(err)
+	}
+
+	if sourceStatus != "" {
+		_, err = tx.ExecContext(ctx, `UPDATE fundraises SET status = $2 WHERE fundraise_id = $1`, transfer.FromFundraiseID, sourceStatus)
+		if err != nil {
+			return ErrTransfers.Wrap(err)
+		}
+	}
+
+	return nil
+}
+
+// Get returns transfer from the database by ID.
+func (db *transfersDB) Get(ctx context.Context, id uuid.UUID) (transfers.Transfer, error) {
+	var transfer transfers.Transfer
+
+	query := `SELECT transfer_id, from_fundraise_id, to_fundraise_id, amount, exchange_rate, initiated_by, reason, created_at
+              FROM fundraise_transfers
+              WHERE transfer_id = $1`
+
+	row := db.conn.QueryRowContext(ctx, query, id)
+	err := row.Scan(
+		&transfer.ID,
+		&transfer.FromFundraiseID,
+		&transfer.ToFundraiseID,
+		&transfer.Amount,
+		&transfer.ExchangeRate,
+		&transfer.InitiatedBy,
+		&transfer.Reason,
+		&transfer.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return transfers.Transfer{}, ErrTransfers.Wrap(transfers.ErrNoTransfer)
+		}
+
+		return transfer, ErrTransfers.Wrap(err)
+	}
+
+	return transfer, nil
+}
+
+// List returns transfers ordered from the newest to the oldest.
+func (db *transfersDB) List(ctx context.Context, params transfers.ListParams) (_ []transfers.Transfer, err error) {
+	var (
+		args       = make([]any, 0, 2)
+		conditions []string
+	)
+
+	query := `SELECT transfer_id, from_fundraise_id, to_fundraise_id, amount, exchange_rate, initiated_by, reason, created_at
+              FROM fundraise_transfers`
+
+	if params.FundraiseID != nil {
+		args = append(args, *params.FundraiseID)
+		conditions = append(conditions, fmt.Sprintf("(from_fundraise_id = $%d OR to_fundraise_id = $%d)", len(args), len(args)))
+	}
+
+	if params.DonorID != nil {
+		args = append(args, *params.DonorID)
+		conditions = append(conditions, fmt.Sprintf(`from_fundraise_id IN (
+                SELECT donations.fundraise_id
+                FROM donations
+                INNER JOIN payments ON donations.donation_id = payments.donation_id
+                WHERE donations.user_id = $%d AND payments.confirmed
+            )`, len(args)))
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY created_at DESC"
+
+	rows, err := db.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, ErrTransfers.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var transfersList []transfers.Transfer
+	for rows.Next() {
+		var transfer transfers.Transfer
+		err = rows.Scan(
+			&transfer.ID,
+			&transfer.FromFundraiseID,
+			&transfer.ToFundraiseID,
+			&transfer.Amount,
+			&transfer.ExchangeRate,
+			&transfer.InitiatedBy,
+			&transfer.Reason,
+			&transfer.CreatedAt,
+		)
+		if err != nil {
+			return nil, ErrTransfers.Wrap(err)
+		}
+
+		transfersList = append(transfersList, transfer)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, ErrTransfers.Wrap(err)
+	}
+
+	return transfersList, nil
+}
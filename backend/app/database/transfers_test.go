@@ -0,0 +1,144 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app"
+	"one-help/app/currencies"
+	"one-help/app/database"
+	"one-help/app/database/dbtesting"
+	"one-help/app/donations"
+	"one-help/app/fundraises"
+	"one-help/app/fundraises/statuses"
+	"one-help/app/fundraises/transfers"
+	"one-help/app/ledger"
+	"one-help/app/payments"
+	"one-help/app/users"
+)
+
+func TestTransfers(t *testing.T) {
+	user := users.User{
+		ID:        uuid.New(),
+		FirstName: "John",
+		LastName:  "Doe",
+	}
+
+	from := fundraises.Fundraise{
+		ID:           uuid.New(),
+		OrganizerId:  user.ID,
+		Title:        "From",
+		Description:  "Cancelled fundraise",
+		TargetAmount: currencies.Major(1000),
+		StartDate:    time.Now(),
+		Status:       statuses.CancelledStatus,
+	}
+
+	to := fundraises.Fundraise{
+		ID:           uuid.New(),
+		OrganizerId:  user.ID,
+		Title:        "To",
+		Description:  "Active fundraise",
+		TargetAmount: currencies.Major(1000),
+		StartDate:    time.Now(),
+		Status:       statuses.ActiveStatus,
+	}
+
+	donation := donations.Donation{
+		ID:          uuid.New(),
+		UserId:      user.ID,
+		FundraiseId: from.ID,
+		Amount:      currencies.Major(300),
+		CreatedAt:   time.Now(),
+	}
+
+	transfer := transfers.Transfer{
+		ID:              uuid.New(),
+		FromFundraiseID: from.ID,
+		ToFundraiseID:   to.ID,
+		Amount:          currencies.Major(300),
+		ExchangeRate:    1,
+		InitiatedBy:     user.ID,
+		Reason:          "fundraise cancelled",
+		CreatedAt:       time.Now(),
+	}
+
+	dbtesting.Run(t, database.Config{}, func(ctx context.Context, t *testing.T, db app.DB) {
+		transfersRepository := db.FundraiseTransfers()
+
+		require.NoError(t, db.Users().Create(ctx, user))
+		require.NoError(t, db.Fundraises().Create(ctx, from))
+		require.NoError(t, db.Fundraises().Create(ctx, to))
+		require.NoError(t, db.Donations().Create(ctx, donation))
+		require.NoError(t, db.Payments().Create(ctx, payments.Payment{
+			DonationId:    donation.ID,
+			PaymentType:   payments.TypeStripe,
+			TransactionId: "cs_test",
+			Confirmed:     true,
+		}))
+
+		confirmation := ledger.New(ledger.KindConfirmation, donation.ID.String(), time.Now())
+		confirmation.Move(
+			ledger.DonorClearing(payments.TypeStripe), currencies.NewMoney(donation.Amount, currencies.UAH),
+			ledger.FundraiseBalance(from.ID), currencies.NewMoney(donation.Amount, currencies.UAH),
+		)
+		_, err := db.Ledger().Post(ctx, confirmation)
+		require.NoError(t, err)
+
+		t.Run("Create(insufficient funds)", func(t *testing.T) {
+			overdrawn := transfer
+			overdrawn.ID = uuid.New()
+			overdrawn.Amount = currencies.Major(301)
+
+			err := transfersRepository.Create(ctx, overdrawn, currencies.Zero, statuses.TransferredStatus)
+			require.Error(t, err)
+			require.ErrorIs(t, err, transfers.ErrInsufficientFunds)
+		})
+
+		t.Run("Create&Get", func(t *testing.T) {
+			require.NoError(t, transfersRepository.Create(ctx, transfer, currencies.Zero, statuses.TransferredStatus))
+
+			storedTransfer, err := transfersRepository.Get(ctx, transfer.ID)
+			require.NoError(t, err)
+			assert.Equal(t, transfer.FromFundraiseID, storedTransfer.FromFundraiseID)
+			assert.Equal(t, transfer.ToFundraiseID, storedTransfer.ToFundraiseID)
+			assert.Equal(t, transfer.Amount, storedTransfer.Amount)
+
+			storedFrom, err := db.Fundraises().Get(ctx, from.ID)
+			require.NoError(t, err)
+			assert.Equal(t, statuses.TransferredStatus, storedFrom.Status)
+		})
+
+		t.Run("Filled", func(t *testing.T) {
+			filled, err := db.Fundraises().GetFilled(ctx, from.ID)
+			require.NoError(t, err)
+			assert.Equal(t, currencies.Zero, filled)
+
+			filled, err = db.Fundraises().GetFilled(ctx, to.ID)
+			require.NoError(t, err)
+			assert.Equal(t, currencies.Major(300), filled)
+		})
+
+		t.Run("List", func(t *testing.T) {
+			list, err := transfersRepository.List(ctx, transfers.ListParams{FundraiseID: &to.ID})
+			require.NoError(t, err)
+			require.Len(t, list, 1)
+
+			list, err = transfersRepository.List(ctx, transfers.ListParams{DonorID: &user.ID})
+			require.NoError(t, err)
+			require.Len(t, list, 1)
+			assert.Equal(t, transfer.ID, list[0].ID)
+		})
+
+		t.Run("Get(negative)", func(t *testing.T) {
+			_, err := transfersRepository.Get(ctx, uuid.New())
+			require.Error(t, err)
+			require.ErrorIs(t, err, transfers.ErrNoTransfer)
+		})
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/zeebo/errs"
 
 	"one-help/app/users"
+	"one-help/app/users/roles"
 )
 
 // ErrUsers indicates that there was an error in the database.
@@ -36,9 +37,13 @@ func (db *usersDB) Create(ctx context.Context, user users.User) error {
 
 	defer DeferCommitRollback(tx, &err)
 
-	query := `INSERT INTO users(user_id, first_name, last_name, website, file_name)
-              VALUES ($1, $2, $3, $4, $5)`
-	_, err = tx.ExecContext(ctx, query, user.ID, user.FirstName, user.LastName, user.Website, user.ImageUrl)
+	if user.Role == "" { // INFO: Fallback to default role.
+		user.Role = roles.UserRole
+	}
+
+	query := `INSERT INTO users(user_id, first_name, last_name, website, file_name, role, trusted, private)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err = tx.ExecContext(ctx, query, user.ID, user.FirstName, user.LastName, user.Website, user.ImageUrl, user.Role, user.Trusted, user.Private)
 	if err != nil {
 		return ErrUsers.Wrap(err)
 	}
@@ -64,11 +69,11 @@ func (db *usersDB) Get(ctx context.Context, id uuid.UUID) (users.User, error) {
 		postDepartment sql.NullString
 	)
 
-	query := `SELECT u.user_id, first_name, last_name, website, file_name, city, post, post_department
+	query := `SELECT u.user_id, first_name, last_name, website, file_name, role, trusted, private, city, post, post_department
               FROM users u LEFT JOIN delivery_addresses d ON u.user_id = d.user_id
               WHERE u.user_id = $1`
 	row := db.conn.QueryRowContext(ctx, query, id)
-	err := row.Scan(&user.ID, &user.FirstName, &user.LastName, &user.Website, &user.ImageUrl, &city, &post, &postDepartment)
+	err := row.Scan(&user.ID, &user.FirstName, &user.LastName, &user.Website, &user.ImageUrl, &user.Role, &user.Trusted, &user.Private, &city, &post, &postDepartment)
 	if err != nil {
 		if errs.Is(err, sql.ErrNoRows) {
 			return users.User{}, ErrUsers.Wrap(users.ErrNoUser)
@@ -94,9 +99,9 @@ func (db *usersDB) Update(ctx context.Context, user users.User) error {
 	defer DeferCommitRollback(tx, &err)
 
 	query := `UPDATE users
-              SET first_name = $2, last_name = $3, website = $4, file_name = $5
+              SET first_name = $2, last_name = $3, website = $4, file_name = $5, private = $6
               WHERE user_id = $1`
-	_, err = tx.ExecContext(ctx, query, user.ID, user.FirstName, user.LastName, user.Website, user.ImageUrl)
+	_, err = tx.ExecContext(ctx, query, user.ID, user.FirstName, user.LastName, user.Website, user.ImageUrl, user.Private)
 	if err != nil {
 		return ErrUsers.Wrap(err)
 	}
@@ -118,6 +123,24 @@ func (db *usersDB) Update(ctx context.Context, user users.User) error {
 	return nil
 }
 
+// SetTrusted updates user's trusted organizer flag.
+func (db *usersDB) SetTrusted(ctx context.Context, id uuid.UUID, trusted bool) error {
+	result, err := db.conn.ExecContext(ctx, `UPDATE users SET trusted = $2 WHERE user_id = $1`, id, trusted)
+	if err != nil {
+		return ErrUsers.Wrap(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return ErrUsers.Wrap(err)
+	}
+	if affected == 0 {
+		return ErrUsers.Wrap(users.ErrNoUser)
+	}
+
+	return nil
+}
+
 // Delete removes user from the database.
 func (db *usersDB) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM users WHERE user_id = $1`
@@ -159,7 +182,7 @@ func (db *usersDB) ListRaffleParticipants(ctx context.Context, raffleID uuid.UUI
 			WHERE r.raffle_id = $1
 		)
 		GROUP BY don.user_id
-		HAVING SUM(don.amount) >= (
+		HAVING SUM(don.amount * don.exchange_rate) >= (
 			SELECT r.minimum_donation
 			FROM raffles r
 			WHERE r.raffle_id = $1
@@ -12,6 +12,7 @@ import (
 	"one-help/app/database"
 	"one-help/app/database/dbtesting"
 	"one-help/app/users"
+	"one-help/app/users/roles"
 )
 
 func TestUsers(t *testing.T) {
@@ -21,6 +22,7 @@ func TestUsers(t *testing.T) {
 		LastName:  "Doe",
 		Website:   "https://example.com",
 		ImageUrl:  "john_doe.txt",
+		Role:      roles.UserRole,
 	}
 	dbtesting.Run(t, database.Config{}, func(ctx context.Context, t *testing.T, db app.DB) {
 		usersRepository := db.Users()
@@ -56,6 +58,20 @@ func TestUsers(t *testing.T) {
 			assert.Equal(t, user, storedUser)
 		})
 
+		t.Run("SetTrusted", func(t *testing.T) {
+			require.NoError(t, usersRepository.SetTrusted(ctx, user.ID, true))
+
+			storedUser, err := usersRepository.Get(ctx, user.ID)
+			require.NoError(t, err)
+			assert.True(t, storedUser.Trusted)
+
+			err = usersRepository.SetTrusted(ctx, uuid.New(), true)
+			require.Error(t, err)
+			require.ErrorIs(t, err, users.ErrNoUser)
+
+			user.Trusted = true
+		})
+
 		t.Run("Delete", func(t *testing.T) {
 			err := usersRepository.Delete(ctx, user.ID)
 			require.NoError(t, err)
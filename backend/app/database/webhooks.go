@@ -0,0 +1,70 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"one-help/app/payments/webhooks"
+)
+
+// ErrWebhooks indicates that there was an error in the database.
+var ErrWebhooks = errs.Class("webhook events repository")
+
+// webhooksDB provides access to webhook events db.
+//
+// architecture: Database
+type webhooksDB struct {
+	conn *sql.DB
+}
+
+// newWebhooksDB is a constructor for base webhooksDB.
+func newWebhooksDB(baseConn *sql.DB) webhooks.DB {
+	return &webhooksDB{
+		conn: baseConn,
+	}
+}
+
+// Store inserts event if it was not received before and returns the stored one.
+func (db *webhooksDB) Store(ctx context.Context, event webhooks.Event) (webhooks.Event, error) {
+	query := `INSERT INTO webhook_events(provider, event_id, type, payload, received_at)
+              VALUES ($1, $2, $3, $4, $5)
+              ON CONFLICT (provider, event_id) DO NOTHING`
+	_, err := db.conn.ExecContext(ctx, query, event.Provider, event.ID, event.Type, event.Payload, event.ReceivedAt)
+	if err != nil {
+		return webhooks.Event{}, ErrWebhooks.Wrap(err)
+	}
+
+	var (
+		stored      webhooks.Event
+		processedAt sql.NullTime
+	)
+
+	query = `SELECT provider, event_id, type, payload, received_at, processed_at
+             FROM webhook_events
+             WHERE provider = $1 AND event_id = $2`
+	err = db.conn.QueryRowContext(ctx, query, event.Provider, event.ID).Scan(
+		&stored.Provider,
+		&stored.ID,
+		&stored.Type,
+		&stored.Payload,
+		&stored.ReceivedAt,
+		&processedAt,
+	)
+	if err != nil {
+		return webhooks.Event{}, ErrWebhooks.Wrap(err)
+	}
+
+	stored.ProcessedAt = processedAt.Time
+
+	return stored, nil
+}
+
+// MarkProcessed sets event processing time.
+func (db *webhooksDB) MarkProcessed(ctx context.Context, provider, id string, processedAt time.Time) error {
+	query := `UPDATE webhook_events SET processed_at = $3 WHERE provider = $1 AND event_id = $2`
+	_, err := db.conn.ExecContext(ctx, query, provider, id, processedAt)
+	return ErrWebhooks.Wrap(err)
+}
@@ -0,0 +1,46 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app"
+	"one-help/app/database"
+	"one-help/app/database/dbtesting"
+	"one-help/app/payments"
+	"one-help/app/payments/webhooks"
+)
+
+func TestWebhooks(t *testing.T) {
+	event := webhooks.Event{
+		Provider:   payments.TypeStripe,
+		ID:         "evt_test",
+		Type:       "checkout.session.completed",
+		Payload:    []byte(`{"id": "evt_test"}`),
+		ReceivedAt: time.Now(),
+	}
+
+	dbtesting.Run(t, database.Config{}, func(ctx context.Context, t *testing.T, db app.DB) {
+		webhooksRepository := db.WebhookEvents()
+
+		t.Run("Store", func(t *testing.T) {
+			stored, err := webhooksRepository.Store(ctx, event)
+			require.NoError(t, err)
+			assert.Equal(t, event.ID, stored.ID)
+			assert.False(t, stored.IsProcessed())
+		})
+
+		t.Run("Store(duplicate)", func(t *testing.T) {
+			processedAt := time.Now()
+			require.NoError(t, webhooksRepository.MarkProcessed(ctx, event.Provider, event.ID, processedAt))
+
+			stored, err := webhooksRepository.Store(ctx, event)
+			require.NoError(t, err)
+			assert.True(t, stored.IsProcessed())
+		})
+	})
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/zeebo/errs"
+
+	"one-help/app/payments"
 )
 
 // ErrNoDonation indicates that donation does not exist.
@@ -16,10 +18,20 @@ var ErrNoDonation = errs.New("donation does not exist")
 type DB interface {
 	// Create inserts donation into the database.
 	Create(ctx context.Context, donation Donation) error
+	// CreateWithPayment inserts donation with its payment in one transaction.
+	CreateWithPayment(ctx context.Context, donation Donation, payment payments.Payment) error
 	// Get donation from the database.
 	Get(ctx context.Context, id uuid.UUID) (Donation, error)
 	// List returns all available donations.
 	List(ctx context.Context, listParams ListParams) ([]Donation, error)
+	// ListHistory returns donations of the donor with their payment state, the latest first.
+	ListHistory(ctx context.Context, params HistoryParams) ([]HistoryEntry, error)
+	// YearlyTotals returns sums of the donor's confirmed donations by year and currency, the latest year first.
+	YearlyTotals(ctx context.Context, params HistoryParams) ([]YearTotal, error)
+	// Contributions returns sums of the donor's confirmed donations by fundraise, the latest donated first.
+	Contributions(ctx context.Context, params HistoryParams) ([]Contribution, error)
+	// ListSupporters returns the latest confirmed donations of the fundraise with donors' names.
+	ListSupporters(ctx context.Context, fundraiseID uuid.UUID, limit int) ([]Supporter, error)
 	// Update updates donation in database by id.
 	Update(ctx context.Context, donation Donation) error
 	// Delete donation from the database.
@@ -4,6 +4,9 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"one-help/app/currencies"
+	"one-help/app/donations/tributes"
 )
 
 // Donation holds donate info.
@@ -11,8 +14,50 @@ type Donation struct {
 	ID          uuid.UUID
 	UserId      uuid.UUID
 	FundraiseId uuid.UUID
-	Amount      float64
-	CreatedAt   time.Time
+	Amount      currencies.Amount
+	Currency    string
+	// ExchangeRate is a snapshot of the rate from donation currency to fundraise currency.
+	ExchangeRate float64
+	RatedAt      time.Time
+	CreatedAt    time.Time
+	// RequestedAmount is the amount chosen by the donor, zero if default price was charged.
+	RequestedAmount currencies.Amount
+	PlanID          uuid.UUID // INFO: uuid.Nil for one-time donations.
+	// Anonymous hides donor's identity from the public, organizer still sees it.
+	Anonymous bool
+	Message   string // INFO: optional message of support, shown publicly.
+	// TeamCode attributes the donation to the team or referrer on leaderboards, empty when not provided.
+	TeamCode string
+	// Tribute dedicates the donation in memory or in honour of someone, empty for regular donations.
+	Tribute tributes.Tribute
+}
+
+// MaxMessageLength defines maximum length of the donor's message in symbols.
+const MaxMessageLength = 280
+
+// Supporter describes confirmed donation shown publicly with donor's name.
+type Supporter struct {
+	Donation  Donation
+	FirstName string
+	LastName  string
+	// Private is true if the donor hides their name from public lists.
+	Private bool
+}
+
+// Hide removes donor's identity of the anonymous donation or of the donor with private profile.
+func (s *Supporter) Hide() {
+	if !s.Donation.Anonymous && !s.Private {
+		return
+	}
+
+	s.Donation.UserId = uuid.Nil
+	s.FirstName = ""
+	s.LastName = ""
+}
+
+// ConvertedAmount returns donation amount in the fundraise currency.
+func (d *Donation) ConvertedAmount() currencies.Amount {
+	return d.Amount.Mul(d.ExchangeRate)
 }
 
 // ListParams holds the parameters for listing donations.
@@ -0,0 +1,48 @@
+package donations
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"one-help/app/currencies"
+)
+
+// HistoryParams holds the parameters for listing donations of the donor.
+type HistoryParams struct {
+	UserID      uuid.UUID
+	FundraiseID uuid.UUID // INFO: uuid.Nil for all fundraises.
+	From        time.Time // INFO: zero for no lower bound.
+	To          time.Time // INFO: exclusive, zero for no upper bound.
+	// Limit and Page are used for listing only, totals include all donations matching the filters.
+	Limit int
+	Page  int
+}
+
+// HistoryEntry describes donation of the donor with its payment state.
+type HistoryEntry struct {
+	Donation        Donation
+	FundraiseTitle  string
+	FundraiseStatus string
+	PaymentType     string
+	PaymentStatus   string // INFO: empty if checkout was not started.
+	RefundedAmount  currencies.Amount
+}
+
+// YearTotal is the sum of the donor's confirmed donations of the year in the currency, less refunds.
+type YearTotal struct {
+	Year     int
+	Currency string
+	Amount   currencies.Amount
+}
+
+// Contribution summarizes the donor's confirmed donations to the fundraise, less refunds.
+type Contribution struct {
+	FundraiseID     uuid.UUID
+	FundraiseTitle  string
+	FundraiseStatus string
+	Currency        string            // INFO: the fundraise currency.
+	Amount          currencies.Amount // INFO: in the fundraise currency.
+	Donations       int
+	LastDonatedAt   time.Time
+}
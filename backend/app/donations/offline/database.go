@@ -0,0 +1,26 @@
+package offline
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+)
+
+// ErrNoRecord indicates that offline donation record does not exist.
+var ErrNoRecord = errs.New("offline donation record does not exist")
+
+// DB exposes access to offline donations db.
+//
+// architecture: DB
+type DB interface {
+	// Create inserts offline donation record into the database.
+	Create(ctx context.Context, record Record) error
+	// Get returns offline donation record by donation id.
+	Get(ctx context.Context, donationID uuid.UUID) (Record, error)
+	// ListByFundraise returns offline donations of the fundraise with their records, the latest received first.
+	ListByFundraise(ctx context.Context, fundraiseID uuid.UUID) ([]Entry, error)
+	// Verify marks offline donations as found in the imported bank statement, keeping the time of the first verification.
+	Verify(ctx context.Context, donationIDs []uuid.UUID, at time.Time) error
+}
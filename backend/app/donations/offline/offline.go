@@ -0,0 +1,35 @@
+package offline
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"one-help/app/donations"
+)
+
+// MaxNotesLength defines maximum length of the organizer's notes in symbols.
+const MaxNotesLength = 1000
+
+// Record describes donation received outside of payment providers and recorded by the organizer.
+type Record struct {
+	DonationID uuid.UUID
+	RecordedBy uuid.UUID
+	// EvidenceURL links to the proof of the donation, e.g. bank statement or receipt scan.
+	EvidenceURL string
+	Notes       string // INFO: visible to organizer and moderators only.
+	// ReceivedAt is the time the donation was actually received by the organizer.
+	ReceivedAt time.Time
+	// VerifiedAt is the time the donation was found in the imported bank statement, zero while it is self-reported only.
+	VerifiedAt time.Time
+	CreatedAt  time.Time
+}
+
+// Entry describes offline donation with its record.
+type Entry struct {
+	Donation    donations.Donation
+	PaymentType string
+	// Reference is the payment identifier provided by the organizer, e.g. bank transfer reference.
+	Reference string
+	Record    Record
+}
@@ -0,0 +1,32 @@
+package plans
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+)
+
+var (
+	// ErrNoPlan indicates that recurring donation plan does not exist.
+	ErrNoPlan = errs.New("recurring donation plan does not exist")
+)
+
+// DB exposes access to recurring donation plans db.
+//
+// architecture: DB
+type DB interface {
+	// Create inserts plan into the database.
+	Create(ctx context.Context, plan Plan) error
+	// Get returns plan by id.
+	Get(ctx context.Context, id uuid.UUID) (Plan, error)
+	// GetBySubscription returns plan by provider's subscription id.
+	GetBySubscription(ctx context.Context, paymentType, subscriptionID string) (Plan, error)
+	// ListByUser returns plans of the donor, the newest first.
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]Plan, error)
+	// ListDue returns past due plans with retry scheduled before provided time.
+	ListDue(ctx context.Context, before time.Time) ([]Plan, error)
+	// Update updates plan in the database by id.
+	Update(ctx context.Context, plan Plan) error
+}
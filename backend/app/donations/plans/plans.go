@@ -0,0 +1,115 @@
+package plans
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"one-help/app/currencies"
+)
+
+const (
+	// IntervalMonth defines monthly charges.
+	IntervalMonth string = "MONTH"
+	// IntervalYear defines yearly charges.
+	IntervalYear string = "YEAR"
+)
+
+const (
+	// StatusPending defines plan awaiting donor's checkout.
+	StatusPending string = "PENDING"
+	// StatusActive defines plan charged on schedule.
+	StatusActive string = "ACTIVE"
+	// StatusPaused defines plan temporarily not charged by donor's request.
+	StatusPaused string = "PAUSED"
+	// StatusPastDue defines plan with failed charge, awaiting retry.
+	StatusPastDue string = "PAST_DUE"
+	// StatusCanceled defines plan stopped permanently, by donor or after exhausted retries.
+	StatusCanceled string = "CANCELED"
+)
+
+// DunningSchedule defines delays of retries after consecutive failed charges.
+// Plan is canceled when charge fails once more after the last retry.
+var DunningSchedule = []time.Duration{24 * time.Hour, 3 * 24 * time.Hour, 7 * 24 * time.Hour}
+
+// Plan describes donor's recurring donation backed by provider's subscription.
+// Plan supports either a single fundraise or all fundraises of the organizer.
+type Plan struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	FundraiseID uuid.UUID // INFO: uuid.Nil for organizer plans.
+	OrganizerID uuid.UUID // INFO: uuid.Nil for fundraise plans.
+	Amount      currencies.Amount
+	Currency    string
+	Interval    string
+	PaymentType string
+	// TransactionID is provider's checkout session identifier.
+	TransactionID string
+	// SubscriptionID is provider's subscription identifier, assigned after checkout.
+	SubscriptionID string
+	Status         string
+	// FailedAttempts counts consecutive failed charges.
+	FailedAttempts int
+	// NextRetryAt is time of the next retry of failed charge, zero if none is scheduled.
+	NextRetryAt time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// IsIntervalSupported returns true if plans can be charged with the interval.
+func IsIntervalSupported(interval string) bool {
+	return interval == IntervalMonth || interval == IntervalYear
+}
+
+// IsClosed returns true if plan can not be changed anymore.
+func (p *Plan) IsClosed() bool {
+	return p.Status == StatusCanceled
+}
+
+// Fail records failed charge and schedules the next retry.
+// Returns false if retries are exhausted and plan must be canceled.
+func (p *Plan) Fail(now time.Time) bool {
+	p.FailedAttempts++
+	if p.FailedAttempts > len(DunningSchedule) {
+		p.Status = StatusCanceled
+		p.NextRetryAt = time.Time{}
+		return false
+	}
+
+	p.Status = StatusPastDue
+	p.NextRetryAt = now.Add(DunningSchedule[p.FailedAttempts-1])
+
+	return true
+}
+
+// Charged resets dunning state after successful charge.
+func (p *Plan) Charged() {
+	p.Status = StatusActive
+	p.FailedAttempts = 0
+	p.NextRetryAt = time.Time{}
+}
+
+// CreateParams defines values needed to create recurring donation plan.
+// Exactly one of FundraiseID and OrganizerID must be provided.
+type CreateParams struct {
+	UserID      uuid.UUID
+	FundraiseID uuid.UUID
+	OrganizerID uuid.UUID
+	Amount      currencies.Amount
+	Currency    string // INFO: fundraise or default currency is used when empty.
+	Interval    string // INFO: monthly when empty.
+	PaymentType string // INFO: Stripe is used when empty.
+}
+
+// CreateResult defines recurring donation plan creation result values.
+type CreateResult struct {
+	Plan       Plan
+	PaymentURL string
+}
+
+// ChangeParams defines values needed to change recurring donation plan.
+type ChangeParams struct {
+	PlanID uuid.UUID
+	UserID uuid.UUID
+	Amount currencies.Amount
+}
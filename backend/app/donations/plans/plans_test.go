@@ -0,0 +1,30 @@
+package plans_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"one-help/app/donations/plans"
+)
+
+func TestPlanDunning(t *testing.T) {
+	now := time.Now()
+	plan := plans.Plan{Status: plans.StatusActive}
+
+	for i, delay := range plans.DunningSchedule {
+		assert.True(t, plan.Fail(now))
+		assert.Equal(t, plans.StatusPastDue, plan.Status)
+		assert.Equal(t, i+1, plan.FailedAttempts)
+		assert.Equal(t, now.Add(delay), plan.NextRetryAt)
+	}
+
+	assert.False(t, plan.Fail(now))
+	assert.Equal(t, plans.StatusCanceled, plan.Status)
+	assert.True(t, plan.NextRetryAt.IsZero())
+
+	plan.Charged()
+	assert.Equal(t, plans.StatusActive, plan.Status)
+	assert.Zero(t, plan.FailedAttempts)
+}
@@ -0,0 +1,544 @@
+package plans
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+
+	"one-help/app/currencies"
+	"one-help/app/fundraises"
+	"one-help/app/fundraises/statuses"
+	"one-help/app/payments"
+	"one-help/app/users"
+	"one-help/internal/logger"
+)
+
+var (
+	// Error wraps errors from plans service that indicates about internal errors.
+	Error = errs.Class("plans service")
+	// ParamsError wraps errors from plans service that indicates about invalid or malformed parameters' data.
+	ParamsError = errs.Class("plans service: params")
+	// ErrNotPlanOwner indicates that caller is not the donor of the recurring donation plan.
+	ErrNotPlanOwner = errs.New("caller is not the plan donor")
+)
+
+// DunningInterval defines how often past due plans are checked for scheduled retries.
+const DunningInterval = time.Hour
+
+// Service handles recurring donation plans related logic.
+//
+// architecture: Service
+type Service struct {
+	logger logger.Logger
+
+	plans      DB
+	fundraises fundraises.DB
+	payments   payments.DB
+	users      users.DB
+
+	providers *payments.Providers
+	// fundraisesService records charges of the plans as confirmed donations.
+	fundraisesService *fundraises.Service
+}
+
+// NewService is a constructor for plans service.
+func NewService(
+	logger logger.Logger,
+	plans DB,
+	fundraisesDB fundraises.DB,
+	payments payments.DB,
+	users users.DB,
+	providers *payments.Providers,
+	fundraisesService *fundraises.Service,
+) *Service {
+	return &Service{
+		logger:            logger,
+		plans:             plans,
+		fundraises:        fundraisesDB,
+		payments:          payments,
+		users:             users,
+		providers:         providers,
+		fundraisesService: fundraisesService,
+	}
+}
+
+// Create registers recurring donation plan and provides subscription checkout url.
+// Plan becomes active when provider confirms the subscription.
+func (service *Service) Create(ctx context.Context, params CreateParams) (result CreateResult, err error) {
+	if (params.FundraiseID == uuid.Nil) == (params.OrganizerID == uuid.Nil) {
+		return result, ParamsError.New("either fundraise or organizer must be provided")
+	}
+	if params.Interval == "" {
+		params.Interval = IntervalMonth
+	}
+	if !IsIntervalSupported(params.Interval) {
+		return result, ParamsError.New("interval %q is not supported", params.Interval)
+	}
+	if !params.Amount.IsPositive() {
+		return result, ParamsError.New("amount must be positive")
+	}
+
+	description := "Monthly donation"
+	if params.Interval == IntervalYear {
+		description = "Yearly donation"
+	}
+
+	if params.FundraiseID != uuid.Nil {
+		fundraise, err := service.fundraises.Get(ctx, params.FundraiseID)
+		if err != nil {
+			return result, Error.Wrap(err)
+		}
+		if fundraise.Status != statuses.ActiveStatus {
+			return result, ParamsError.Wrap(fundraises.ErrNotApproved)
+		}
+
+		if params.Currency == "" {
+			params.Currency = fundraise.Currency
+		}
+		if err = service.validateAmount(ctx, fundraise, params.Amount, params.Currency); err != nil {
+			return result, err
+		}
+
+		description += ": " + fundraise.Title
+	} else {
+		organizer, err := service.users.Get(ctx, params.OrganizerID)
+		if err != nil {
+			return result, Error.Wrap(err)
+		}
+
+		if params.Currency == "" {
+			params.Currency = currencies.Default
+		}
+		if params.Amount.Cmp(payments.MaxAmount) > 0 {
+			return result, ParamsError.New("amount must not exceed %s", payments.MaxAmount)
+		}
+
+		description += ": " + organizer.FirstName + " " + organizer.LastName
+	}
+
+	if !currencies.IsSupported(params.Currency) {
+		return result, ParamsError.New("currency %q is not supported", params.Currency)
+	}
+
+	if params.PaymentType == "" {
+		params.PaymentType = payments.TypeStripe
+	}
+	provider, err := service.providers.Subscriptions(params.PaymentType)
+	if err != nil {
+		return result, ParamsError.Wrap(err)
+	}
+
+	now := time.Now().UTC()
+	plan := Plan{
+		ID:          uuid.New(),
+		UserID:      params.UserID,
+		FundraiseID: params.FundraiseID,
+		OrganizerID: params.OrganizerID,
+		Amount:      params.Amount,
+		Currency:    params.Currency,
+		Interval:    params.Interval,
+		PaymentType: provider.Type(),
+		Status:      StatusPending,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	session, err := provider.CreateSubscription(ctx, payments.SubscriptionParams{
+		Reference:    plan.ID.String(),
+		RedirectPath: "/plans/checkout/" + plan.ID.String(),
+		Amount:       currencies.NewMoney(plan.Amount, plan.Currency),
+		Interval:     plan.Interval,
+		Description:  description,
+	})
+	if err != nil {
+		if errors.Is(err, payments.ErrUnsupportedCurrency) {
+			return result, ParamsError.Wrap(err)
+		}
+
+		return result, Error.Wrap(err)
+	}
+
+	plan.TransactionID = session.TransactionID
+	if err = service.plans.Create(ctx, plan); err != nil {
+		return result, Error.Wrap(err)
+	}
+
+	result.Plan = plan
+	result.PaymentURL = session.URL
+
+	return result, nil
+}
+
+// List returns recurring donation plans of the donor.
+func (service *Service) List(ctx context.Context, userID uuid.UUID) ([]Plan, error) {
+	list, err := service.plans.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return list, nil
+}
+
+// Get returns recurring donation plan of the donor.
+func (service *Service) Get(ctx context.Context, planID, callerID uuid.UUID) (Plan, error) {
+	plan, err := service.plans.Get(ctx, planID)
+	if err != nil {
+		return plan, Error.Wrap(err)
+	}
+
+	if plan.UserID != callerID {
+		return Plan{}, ParamsError.Wrap(ErrNotPlanOwner)
+	}
+
+	return plan, nil
+}
+
+// Pause stops charges of active plan until it is resumed.
+func (service *Service) Pause(ctx context.Context, planID, callerID uuid.UUID) (Plan, error) {
+	return service.update(ctx, planID, callerID, func(plan *Plan, provider payments.SubscriptionProvider) error {
+		if plan.Status != StatusActive {
+			return ParamsError.New("only active plans can be paused")
+		}
+
+		plan.Status = StatusPaused
+		return provider.PauseSubscription(ctx, plan.SubscriptionID, true)
+	})
+}
+
+// Resume resumes charges of paused plan.
+func (service *Service) Resume(ctx context.Context, planID, callerID uuid.UUID) (Plan, error) {
+	return service.update(ctx, planID, callerID, func(plan *Plan, provider payments.SubscriptionProvider) error {
+		if plan.Status != StatusPaused {
+			return ParamsError.New("only paused plans can be resumed")
+		}
+
+		plan.Status = StatusActive
+		return provider.PauseSubscription(ctx, plan.SubscriptionID, false)
+	})
+}
+
+// Change changes amount of the next charges of the plan.
+func (service *Service) Change(ctx context.Context, params ChangeParams) (Plan, error) {
+	return service.update(ctx, params.PlanID, params.UserID, func(plan *Plan, provider payments.SubscriptionProvider) error {
+		switch {
+		case plan.SubscriptionID == "":
+			return ParamsError.New("plan checkout is not finished yet")
+		case !params.Amount.IsPositive():
+			return ParamsError.New("amount must be positive")
+		case params.Amount.Cmp(payments.MaxAmount) > 0:
+			return ParamsError.New("amount must not exceed %s", payments.MaxAmount)
+		}
+
+		if plan.FundraiseID != uuid.Nil {
+			fundraise, err := service.fundraises.Get(ctx, plan.FundraiseID)
+			if err != nil {
+				return Error.Wrap(err)
+			}
+
+			if err = service.validateAmount(ctx, fundraise, params.Amount, plan.Currency); err != nil {
+				return err
+			}
+		}
+
+		plan.Amount = params.Amount
+		return provider.UpdateSubscription(ctx, plan.SubscriptionID, params.Amount)
+	})
+}
+
+// Cancel stops charges of the plan permanently.
+func (service *Service) Cancel(ctx context.Context, planID, callerID uuid.UUID) (Plan, error) {
+	return service.update(ctx, planID, callerID, func(plan *Plan, provider payments.SubscriptionProvider) error {
+		plan.Status = StatusCanceled
+		plan.NextRetryAt = time.Time{}
+		if plan.SubscriptionID == "" {
+			return nil
+		}
+
+		return provider.CancelSubscription(ctx, plan.SubscriptionID)
+	})
+}
+
+// RunDunning retries failed charges of past due plans on schedule until context is canceled.
+func (service *Service) RunDunning(ctx context.Context) error {
+	ticker := time.NewTicker(DunningInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := service.RetryDue(ctx); err != nil {
+			service.logger.Error("failed to retry due plans", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// RetryDue retries failed charges of plans with due retry time.
+// Retry result is delivered by provider webhook, failed retry request counts as failed charge.
+func (service *Service) RetryDue(ctx context.Context) error {
+	now := time.Now().UTC()
+
+	due, err := service.plans.ListDue(ctx, now)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	var errlist errs.Group
+	for _, plan := range due {
+		provider, err := service.providers.Subscriptions(plan.PaymentType)
+		if err != nil {
+			errlist.Add(err)
+			continue
+		}
+
+		plan.NextRetryAt = time.Time{}
+		if err = provider.RetrySubscription(ctx, plan.SubscriptionID); err != nil {
+			service.logger.ErrorF("failed to retry charge of plan %s", err, plan.ID)
+			if !plan.Fail(now) {
+				errlist.Add(provider.CancelSubscription(ctx, plan.SubscriptionID))
+			}
+		}
+
+		plan.UpdatedAt = now
+		errlist.Add(service.plans.Update(ctx, plan))
+	}
+
+	return Error.Wrap(errlist.Err())
+}
+
+// HandleEvent applies subscription event of the payment provider to its plan, events of other kinds are ignored.
+func (service *Service) HandleEvent(ctx context.Context, paymentType string, event payments.Event) error {
+	switch event.Kind {
+	case payments.EventSubscriptionStarted:
+		return service.startSubscription(ctx, paymentType, event)
+	case payments.EventSubscriptionCharged:
+		return service.chargeSubscription(ctx, paymentType, event)
+	case payments.EventSubscriptionFailed:
+		return service.failSubscription(ctx, paymentType, event)
+	case payments.EventSubscriptionCanceled:
+		return service.cancelSubscription(ctx, paymentType, event)
+	default:
+		return nil
+	}
+}
+
+// update applies donor's change to the plan and its subscription.
+func (service *Service) update(ctx context.Context, planID, callerID uuid.UUID, update func(plan *Plan, provider payments.SubscriptionProvider) error) (Plan, error) {
+	plan, err := service.Get(ctx, planID, callerID)
+	if err != nil {
+		return plan, err
+	}
+
+	if plan.IsClosed() {
+		return plan, ParamsError.New("plan is canceled")
+	}
+
+	provider, err := service.providers.Subscriptions(plan.PaymentType)
+	if err != nil {
+		return plan, Error.Wrap(err)
+	}
+
+	if err = update(&plan, provider); err != nil {
+		if ParamsError.Has(err) || Error.Has(err) {
+			return plan, err
+		}
+
+		return plan, Error.Wrap(err)
+	}
+
+	plan.UpdatedAt = time.Now().UTC()
+	if err = service.plans.Update(ctx, plan); err != nil {
+		return plan, Error.Wrap(err)
+	}
+
+	return plan, nil
+}
+
+// validateAmount checks amount of the plan charges against donation limits of the fundraise.
+func (service *Service) validateAmount(ctx context.Context, fundraise fundraises.Fundraise, amount currencies.Amount, currency string) error {
+	err := service.fundraisesService.ValidateAmount(ctx, fundraise, amount, currency)
+	if fundraises.ParamsError.Has(err) {
+		return ParamsError.Wrap(errors.Unwrap(err))
+	}
+
+	return Error.Wrap(err)
+}
+
+// startSubscription activates pending plan after donor's checkout.
+func (service *Service) startSubscription(ctx context.Context, paymentType string, event payments.Event) error {
+	plan, err := service.eventPlan(ctx, paymentType, event)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	plan.SubscriptionID = event.SubscriptionID
+	if plan.Status == StatusPending {
+		plan.Status = StatusActive
+	}
+	plan.UpdatedAt = time.Now().UTC()
+
+	return Error.Wrap(service.plans.Update(ctx, plan))
+}
+
+// chargeSubscription records recurring charge as a confirmed donation.
+// Charges of plans without active fundraise to support are refunded and plan is stopped.
+func (service *Service) chargeSubscription(ctx context.Context, paymentType string, event payments.Event) error {
+	plan, err := service.eventPlan(ctx, paymentType, event)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	plan.SubscriptionID = event.SubscriptionID
+	plan.UpdatedAt = time.Now().UTC()
+
+	fundraise, err := service.planFundraise(ctx, plan)
+	if err != nil {
+		if !errors.Is(err, fundraises.ErrNoFundraise) {
+			return Error.Wrap(err)
+		}
+
+		service.logger.WarnF("plan %s has no active fundraise to support, refunding charge %s", plan.ID, event.TransactionID)
+		provider, err := service.providers.Subscriptions(paymentType)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+
+		refunded := payments.Payment{PaymentType: paymentType, TransactionId: event.TransactionID, Reference: event.PaymentReference}
+		if err = provider.Refund(ctx, refunded, event.Amount.Amount); err != nil {
+			return Error.Wrap(err)
+		}
+
+		// INFO: organizer may start a new fundraise later, while finished fundraise will not accept donations anymore.
+		plan.Status = StatusPaused
+		err = provider.PauseSubscription(ctx, plan.SubscriptionID, true)
+		if plan.FundraiseID != uuid.Nil {
+			plan.Status = StatusCanceled
+			err = provider.CancelSubscription(ctx, plan.SubscriptionID)
+		}
+		if err != nil {
+			return Error.Wrap(err)
+		}
+
+		return Error.Wrap(service.plans.Update(ctx, plan))
+	}
+
+	amount := event.Amount
+	if amount.Currency == "" {
+		amount.Currency = plan.Currency
+	}
+
+	// INFO: invoice may be redelivered with a different event id, it is recorded once by its transaction id.
+	err = service.fundraisesService.RecordCharge(ctx, fundraises.ChargeParams{
+		Fundraise:       fundraise,
+		UserID:          plan.UserID,
+		PlanID:          plan.ID,
+		PaymentType:     paymentType,
+		TransactionID:   event.TransactionID,
+		Reference:       event.PaymentReference,
+		Amount:          amount,
+		RequestedAmount: plan.Amount,
+	})
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if plan.Status != StatusPaused && plan.Status != StatusCanceled {
+		plan.Charged()
+	}
+
+	return Error.Wrap(service.plans.Update(ctx, plan))
+}
+
+// failSubscription records failed recurring charge and schedules its retry, plan is canceled when retries are exhausted.
+func (service *Service) failSubscription(ctx context.Context, paymentType string, event payments.Event) error {
+	plan, err := service.eventPlan(ctx, paymentType, event)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if plan.IsClosed() {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	plan.SubscriptionID = event.SubscriptionID
+	plan.UpdatedAt = now
+	if !plan.Fail(now) {
+		service.logger.InfoF("canceling plan %s after %d failed charges", plan.ID, plan.FailedAttempts)
+
+		provider, err := service.providers.Subscriptions(paymentType)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+
+		if err = provider.CancelSubscription(ctx, plan.SubscriptionID); err != nil {
+			return Error.Wrap(err)
+		}
+	}
+
+	return Error.Wrap(service.plans.Update(ctx, plan))
+}
+
+// cancelSubscription cancels plan of the subscription canceled on provider's side.
+func (service *Service) cancelSubscription(ctx context.Context, paymentType string, event payments.Event) error {
+	plan, err := service.eventPlan(ctx, paymentType, event)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if plan.IsClosed() {
+		return nil
+	}
+
+	plan.Status = StatusCanceled
+	plan.NextRetryAt = time.Time{}
+	plan.UpdatedAt = time.Now().UTC()
+
+	return Error.Wrap(service.plans.Update(ctx, plan))
+}
+
+// eventPlan finds plan of the event by plan reference or by provider's subscription id.
+func (service *Service) eventPlan(ctx context.Context, paymentType string, event payments.Event) (Plan, error) {
+	if planID, err := uuid.Parse(event.Reference); err == nil {
+		return service.plans.Get(ctx, planID)
+	}
+
+	return service.plans.GetBySubscription(ctx, paymentType, event.SubscriptionID)
+}
+
+// planFundraise returns fundraise supported by the plan charge, the oldest active one for organizer plans.
+func (service *Service) planFundraise(ctx context.Context, plan Plan) (fundraises.Fundraise, error) {
+	if plan.FundraiseID != uuid.Nil {
+		fundraise, err := service.fundraises.Get(ctx, plan.FundraiseID)
+		if err != nil {
+			return fundraise, err
+		}
+
+		if fundraise.Status != statuses.ActiveStatus {
+			return fundraise, fundraises.ErrNoFundraise
+		}
+
+		return fundraise, nil
+	}
+
+	list, err := service.fundraises.List(ctx, fundraises.ListParams{
+		OrganizerID: &plan.OrganizerID,
+		Statuses:    []string{statuses.ActiveStatus},
+		OldestFirst: true,
+		Limit:       1,
+	})
+	if err != nil {
+		return fundraises.Fundraise{}, err
+	}
+	if len(list) == 0 {
+		return fundraises.Fundraise{}, fundraises.ErrNoFundraise
+	}
+
+	return list[0], nil
+}
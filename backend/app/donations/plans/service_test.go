@@ -0,0 +1,91 @@
+package plans_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app"
+	"one-help/app/currencies"
+	"one-help/app/database"
+	"one-help/app/database/dbtesting"
+	"one-help/app/donations/plans"
+	"one-help/app/fundraises"
+	"one-help/app/fundraises/fundraisestesting"
+	notificationfake "one-help/app/notifications/fake"
+	"one-help/app/payments"
+	"one-help/app/payments/fake"
+	"one-help/app/users"
+	"one-help/internal/logger/zaplog"
+)
+
+func TestPlans(t *testing.T) {
+	organizer := users.User{
+		ID:        uuid.New(),
+		FirstName: "John",
+		LastName:  "Doe",
+	}
+	donor := users.User{
+		ID:        uuid.New(),
+		FirstName: "Jane",
+		LastName:  "Doe",
+	}
+
+	provider := fake.NewProvider(payments.TypeStripe)
+
+	dbtesting.Run(t, database.Config{MigrationsPath: "../../database/migrations"}, func(ctx context.Context, t *testing.T, db app.DB) {
+		require.NoError(t, db.Users().Create(ctx, organizer))
+		require.NoError(t, db.Users().SetTrusted(ctx, organizer.ID, true))
+		require.NoError(t, db.Users().Create(ctx, donor))
+
+		providers := payments.NewProviders(provider)
+		fundraisesService := fundraisestesting.NewService(db, providers, notificationfake.NewSender())
+		service := plans.NewService(zaplog.NewLog(), db.DonationPlans(), db.Fundraises(), db.Payments(), db.Users(), providers, fundraisesService)
+
+		fundraise, err := fundraisesService.Create(ctx, fundraises.CreateParams{
+			OrganizerId:  organizer.ID,
+			Title:        "Test",
+			Description:  "Test Description",
+			TargetAmount: currencies.Major(1000),
+			Currency:     currencies.UAH,
+		})
+		require.NoError(t, err)
+
+		result, err := service.Create(ctx, plans.CreateParams{
+			UserID:      donor.ID,
+			FundraiseID: fundraise.ID,
+			Amount:      currencies.Major(100),
+		})
+		require.NoError(t, err)
+		subscriptionID := strings.TrimPrefix(result.PaymentURL, fake.CheckoutURL)
+
+		t.Run("started", func(t *testing.T) {
+			require.NoError(t, service.HandleEvent(ctx, payments.TypeStripe, provider.StartSubscription(subscriptionID)))
+
+			plan, err := service.Get(ctx, result.Plan.ID, donor.ID)
+			require.NoError(t, err)
+			assert.Equal(t, plans.StatusActive, plan.Status)
+		})
+
+		t.Run("charged", func(t *testing.T) {
+			charged := provider.ChargeSubscription(subscriptionID, true)
+			require.NoError(t, service.HandleEvent(ctx, payments.TypeStripe, charged))
+
+			// INFO: invoice redelivered with a different event id is recorded once.
+			charged.ID = uuid.NewString()
+			require.NoError(t, service.HandleEvent(ctx, payments.TypeStripe, charged))
+
+			filled, err := fundraisesService.Filled(ctx, fundraise.ID)
+			require.NoError(t, err)
+			assert.Equal(t, currencies.Major(100), filled)
+
+			violations, err := db.Ledger().Check(ctx)
+			require.NoError(t, err)
+			assert.Empty(t, violations)
+		})
+	})
+}
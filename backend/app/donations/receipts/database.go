@@ -0,0 +1,22 @@
+package receipts
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+)
+
+// ErrNoReceipt indicates that receipt does not exist.
+var ErrNoReceipt = errs.New("receipt does not exist")
+
+// DB exposes access to receipts db.
+//
+// architecture: DB
+type DB interface {
+	// Create assigns next organizer's number to the receipt and inserts it into the database.
+	// Returns already issued receipt if the donation has one.
+	Create(ctx context.Context, receipt Receipt) (Receipt, error)
+	// GetByDonation returns receipt of the donation.
+	GetByDonation(ctx context.Context, donationID uuid.UUID) (Receipt, error)
+}
@@ -0,0 +1,38 @@
+package receipts
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"one-help/app/currencies"
+)
+
+// Receipt describes numbered document confirming the donation for donor's accounting.
+// Receipt holds a snapshot of the details at the moment of issue, so it is rendered the same way every time.
+type Receipt struct {
+	ID         uuid.UUID
+	DonationID uuid.UUID
+	// Number is sequential within the organizer's receipts, starting from 1.
+	Number           int64
+	OrganizerID      uuid.UUID
+	OrganizerName    string
+	OrganizerWebsite string
+	FundraiseID      uuid.UUID
+	FundraiseTitle   string
+	DonorName        string // INFO: empty for anonymous donations.
+	Amount           currencies.Amount
+	Currency         string
+	PaymentType      string
+	TransactionID    string
+	DonatedAt        time.Time
+	IssuedAt         time.Time
+}
+
+// Code returns receipt number prefixed with the organizer, unique across all receipts.
+func (r *Receipt) Code() string {
+	prefix := strings.ToUpper(strings.ReplaceAll(r.OrganizerID.String(), "-", "")[:8])
+	return fmt.Sprintf("%s-%06d", prefix, r.Number)
+}
@@ -0,0 +1,42 @@
+package receipts_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app/currencies"
+	"one-help/app/donations/receipts"
+)
+
+func TestReceipt(t *testing.T) {
+	receipt := receipts.Receipt{
+		ID:             uuid.New(),
+		DonationID:     uuid.New(),
+		Number:         42,
+		OrganizerID:    uuid.MustParse("0a1b2c3d-0000-0000-0000-000000000000"),
+		OrganizerName:  "Іван Франко",
+		FundraiseTitle: "Drones for the brigade, a rather long title that must be wrapped onto the next line of the receipt",
+		Amount:         currencies.MinorUnits(15050),
+		Currency:       "UAH",
+		PaymentType:    "LIQPAY",
+		TransactionID:  "liqpay-123",
+		DonatedAt:      time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+		IssuedAt:       time.Date(2026, 3, 1, 12, 1, 0, 0, time.UTC),
+	}
+
+	assert.Equal(t, "0A1B2C3D-000042", receipt.Code())
+
+	document, err := receipts.Render(receipt)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(document, []byte("%PDF-")))
+
+	// INFO: regenerated receipt is identical.
+	again, err := receipts.Render(receipt)
+	require.NoError(t, err)
+	assert.Equal(t, document, again)
+}
@@ -0,0 +1,92 @@
+package receipts
+
+import (
+	"github.com/zeebo/errs"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+
+	"one-help/internal/pdf"
+)
+
+// ErrRender indicates that there was an error while rendering receipt document.
+var ErrRender = errs.Class("receipt render")
+
+const (
+	// margin is the distance from the page edges to the content in points.
+	margin = 56.0
+	// labelWidth is the width of the details labels column in points.
+	labelWidth = 150.0
+)
+
+// Render returns receipt as PDF document.
+func Render(receipt Receipt) ([]byte, error) {
+	doc := pdf.New()
+	regular, err := doc.AddFont("Regular", goregular.TTF)
+	if err != nil {
+		return nil, ErrRender.Wrap(err)
+	}
+	bold, err := doc.AddFont("Bold", gobold.TTF)
+	if err != nil {
+		return nil, ErrRender.Wrap(err)
+	}
+
+	y := pdf.PageHeight - margin - 20
+	if err = doc.Text(bold, 20, margin, y, "Donation receipt № "+receipt.Code()); err != nil {
+		return nil, ErrRender.Wrap(err)
+	}
+	y -= 20
+	if err = doc.Text(regular, 10, margin, y, "Issued "+receipt.IssuedAt.UTC().Format("02.01.2006")); err != nil {
+		return nil, ErrRender.Wrap(err)
+	}
+	y -= 14
+	doc.Line(margin, y, pdf.PageWidth-margin, y, 0.75)
+
+	donor := receipt.DonorName
+	if donor == "" {
+		donor = "Anonymous donor"
+	}
+
+	rows := [][2]string{
+		{"Organizer", receipt.OrganizerName},
+		{"Organizer website", receipt.OrganizerWebsite},
+		{"Fundraise", receipt.FundraiseTitle},
+		{"Donor", donor},
+		{"Amount", receipt.Amount.String() + " " + receipt.Currency},
+		{"Date", receipt.DonatedAt.UTC().Format("02.01.2006 15:04 UTC")},
+		{"Payment method", receipt.PaymentType},
+		{"Transaction ID", receipt.TransactionID},
+	}
+
+	y -= 30
+	for _, row := range rows {
+		if row[1] == "" {
+			continue
+		}
+
+		if err = doc.Text(bold, 11, margin, y, row[0]); err != nil {
+			return nil, ErrRender.Wrap(err)
+		}
+
+		lines, err := regular.Wrap(11, pdf.PageWidth-2*margin-labelWidth, row[1])
+		if err != nil {
+			return nil, ErrRender.Wrap(err)
+		}
+		for _, line := range lines {
+			if err = doc.Text(regular, 11, margin+labelWidth, y, line); err != nil {
+				return nil, ErrRender.Wrap(err)
+			}
+			y -= 16
+		}
+		y -= 6
+	}
+
+	y -= 8
+	doc.Line(margin, y, pdf.PageWidth-margin, y, 0.75)
+	y -= 20
+	if err = doc.Text(regular, 9, margin, y, "This receipt confirms the donation received by the organizer through one-help."); err != nil {
+		return nil, ErrRender.Wrap(err)
+	}
+
+	data, err := doc.Bytes()
+	return data, ErrRender.Wrap(err)
+}
@@ -0,0 +1,100 @@
+package tributes
+
+import (
+	"strings"
+
+	"github.com/zeebo/errs"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/goregular"
+
+	"one-help/internal/pdf"
+)
+
+// ErrRender indicates that there was an error while rendering dedication card.
+var ErrRender = errs.Class("dedication card render")
+
+const (
+	// margin is the distance from the page edges to the card frame in points.
+	margin = 56.0
+	// padding is the distance from the card frame to the content in points.
+	padding = 36.0
+)
+
+// Render returns dedication card as PDF document, text is centered within the frame.
+func Render(card Card) ([]byte, error) {
+	doc := pdf.New()
+	regular, err := doc.AddFont("Regular", goregular.TTF)
+	if err != nil {
+		return nil, ErrRender.Wrap(err)
+	}
+	bold, err := doc.AddFont("Bold", gobold.TTF)
+	if err != nil {
+		return nil, ErrRender.Wrap(err)
+	}
+	italic, err := doc.AddFont("Italic", goitalic.TTF)
+	if err != nil {
+		return nil, ErrRender.Wrap(err)
+	}
+
+	top, bottom := pdf.PageHeight-margin, pdf.PageHeight/2-margin
+	left, right := margin, pdf.PageWidth-margin
+	doc.Line(left, top, right, top, 1.5)
+	doc.Line(right, top, right, bottom, 1.5)
+	doc.Line(right, bottom, left, bottom, 1.5)
+	doc.Line(left, bottom, left, top, 1.5)
+
+	width := right - left - 2*padding
+	// centered draws wrapped text centered between the frame sides, returns baseline of the next line.
+	centered := func(font *pdf.Font, size, y float64, text string) (float64, error) {
+		lines, err := font.Wrap(size, width, text)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, line := range lines {
+			lineWidth, err := font.Width(size, line)
+			if err != nil {
+				return 0, err
+			}
+			if err = doc.Text(font, size, (pdf.PageWidth-lineWidth)/2, y, line); err != nil {
+				return 0, err
+			}
+			y -= size * 1.4
+		}
+
+		return y, nil
+	}
+
+	title := "In honour of"
+	if card.Tribute.Type == TypeInMemory {
+		title = "In loving memory of"
+	}
+
+	y := top - padding - 20
+	if y, err = centered(italic, 18, y, title); err != nil {
+		return nil, ErrRender.Wrap(err)
+	}
+	y -= 6
+	if y, err = centered(bold, 26, y, card.Tribute.HonoreeName); err != nil {
+		return nil, ErrRender.Wrap(err)
+	}
+
+	y -= 10
+	doc.Line(pdf.PageWidth/2-60, y, pdf.PageWidth/2+60, y, 0.75)
+	y -= 30
+
+	for _, paragraph := range strings.Split(card.Message(), "\n\n") {
+		if y, err = centered(regular, 12, y, paragraph); err != nil {
+			return nil, ErrRender.Wrap(err)
+		}
+		y -= 8
+	}
+
+	if _, err = centered(regular, 9, bottom+padding/2, "Sent on behalf of the donor by one-help."); err != nil {
+		return nil, ErrRender.Wrap(err)
+	}
+
+	data, err := doc.Bytes()
+	return data, ErrRender.Wrap(err)
+}
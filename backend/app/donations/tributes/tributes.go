@@ -0,0 +1,123 @@
+package tributes
+
+import (
+	"net/mail"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/zeebo/errs"
+)
+
+// ErrInvalidTribute indicates that tribute of the donation is malformed.
+var ErrInvalidTribute = errs.Class("invalid tribute")
+
+const (
+	// TypeInMemory defines donation made in memory of the honoree.
+	TypeInMemory string = "IN_MEMORY"
+	// TypeInHonour defines donation made in honour of the honoree.
+	TypeInHonour string = "IN_HONOUR"
+)
+
+// MaxNameLength defines maximum length of the honoree and recipient names in symbols.
+const MaxNameLength = 100
+
+// Tribute dedicates the donation to the honoree, optionally notifying the recipient, e.g. the honoree or their family.
+type Tribute struct {
+	Type        string // INFO: empty for donations without tribute.
+	HonoreeName string
+	// RecipientName addresses the dedication card, optional.
+	RecipientName string
+	// RecipientEmail is where the dedication card is sent on confirmation, nobody is notified when empty.
+	RecipientEmail string
+}
+
+// IsEmpty returns true if donation is not dedicated to anyone.
+func (t *Tribute) IsEmpty() bool {
+	return t.Type == ""
+}
+
+// Notifies returns true if dedication card should be sent to the recipient.
+func (t *Tribute) Notifies() bool {
+	return !t.IsEmpty() && t.RecipientEmail != ""
+}
+
+// Dedication returns the phrase dedicating the donation to the honoree.
+func (t *Tribute) Dedication() string {
+	if t.Type == TypeInMemory {
+		return "in memory of " + t.HonoreeName
+	}
+
+	return "in honour of " + t.HonoreeName
+}
+
+// Parse trims tribute values and checks them, tribute without type must have no other values.
+func Parse(tribute Tribute) (Tribute, error) {
+	tribute.Type = strings.ToUpper(strings.TrimSpace(tribute.Type))
+	tribute.HonoreeName = strings.TrimSpace(tribute.HonoreeName)
+	tribute.RecipientName = strings.TrimSpace(tribute.RecipientName)
+	tribute.RecipientEmail = strings.TrimSpace(tribute.RecipientEmail)
+
+	switch tribute.Type {
+	case "":
+		if tribute != (Tribute{}) {
+			return Tribute{}, ErrInvalidTribute.New("tribute type is required")
+		}
+
+		return tribute, nil
+	case TypeInMemory, TypeInHonour:
+	default:
+		return Tribute{}, ErrInvalidTribute.New("tribute type must be %q or %q", TypeInMemory, TypeInHonour)
+	}
+
+	switch {
+	case tribute.HonoreeName == "":
+		return Tribute{}, ErrInvalidTribute.New("honoree name is required")
+	case utf8.RuneCountInString(tribute.HonoreeName) > MaxNameLength:
+		return Tribute{}, ErrInvalidTribute.New("honoree name must not exceed %d symbols", MaxNameLength)
+	case utf8.RuneCountInString(tribute.RecipientName) > MaxNameLength:
+		return Tribute{}, ErrInvalidTribute.New("recipient name must not exceed %d symbols", MaxNameLength)
+	case tribute.RecipientName != "" && tribute.RecipientEmail == "":
+		return Tribute{}, ErrInvalidTribute.New("recipient email is required to notify the recipient")
+	}
+
+	if tribute.RecipientEmail != "" {
+		address, err := mail.ParseAddress(tribute.RecipientEmail)
+		if err != nil || address.Address != tribute.RecipientEmail {
+			return Tribute{}, ErrInvalidTribute.New("recipient email is invalid")
+		}
+	}
+
+	return tribute, nil
+}
+
+// Card describes dedication card sent to the tribute recipient when the donation is confirmed.
+// NOTE: amount is not shown, card only tells that the donation was made.
+type Card struct {
+	Tribute        Tribute
+	DonorName      string // INFO: empty for anonymous donations.
+	FundraiseTitle string
+	DonatedAt      time.Time
+}
+
+// Subject returns subject of the notification the card is sent with.
+func (c *Card) Subject() string {
+	return "A donation was made " + c.Tribute.Dedication()
+}
+
+// Message returns text of the card.
+func (c *Card) Message() string {
+	donor := c.DonorName
+	if donor == "" {
+		donor = "Someone who wished to remain anonymous"
+	}
+
+	var message strings.Builder
+	if c.Tribute.RecipientName != "" {
+		message.WriteString("Dear " + c.Tribute.RecipientName + ",\n\n")
+	}
+	message.WriteString(donor + " made a donation " + c.Tribute.Dedication() + " to the fundraise \"" + c.FundraiseTitle + "\"")
+	message.WriteString(" on " + c.DonatedAt.UTC().Format("02.01.2006") + ".")
+
+	return message.String()
+}
@@ -0,0 +1,67 @@
+package tributes_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app/donations/tributes"
+)
+
+func TestParse(t *testing.T) {
+	tribute, err := tributes.Parse(tributes.Tribute{
+		Type:           " in_memory ",
+		HonoreeName:    " Тарас Шевченко ",
+		RecipientEmail: "family@example.com",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, tributes.TypeInMemory, tribute.Type)
+	assert.Equal(t, "Тарас Шевченко", tribute.HonoreeName)
+	assert.True(t, tribute.Notifies())
+
+	tribute, err = tributes.Parse(tributes.Tribute{})
+	require.NoError(t, err)
+	assert.True(t, tribute.IsEmpty())
+	assert.False(t, tribute.Notifies())
+
+	for _, invalid := range []tributes.Tribute{
+		{HonoreeName: "John"},
+		{Type: "IN_SUPPORT", HonoreeName: "John"},
+		{Type: tributes.TypeInHonour},
+		{Type: tributes.TypeInHonour, HonoreeName: "John", RecipientName: "Jane"},
+		{Type: tributes.TypeInHonour, HonoreeName: "John", RecipientEmail: "Jane <jane@example.com>"},
+		{Type: tributes.TypeInHonour, HonoreeName: "John", RecipientEmail: "not an email"},
+	} {
+		_, err = tributes.Parse(invalid)
+		assert.True(t, tributes.ErrInvalidTribute.Has(err), invalid)
+	}
+}
+
+func TestCard(t *testing.T) {
+	card := tributes.Card{
+		Tribute: tributes.Tribute{
+			Type:           tributes.TypeInMemory,
+			HonoreeName:    "Тарас Шевченко",
+			RecipientName:  "Shevchenko family",
+			RecipientEmail: "family@example.com",
+		},
+		FundraiseTitle: "Drones for the brigade, a rather long title that must be wrapped onto the next line of the card",
+		DonatedAt:      time.Date(2026, 3, 9, 12, 0, 0, 0, time.UTC),
+	}
+
+	assert.Equal(t, "A donation was made in memory of Тарас Шевченко", card.Subject())
+	assert.Equal(t, "Dear Shevchenko family,\n\nSomeone who wished to remain anonymous made a donation in memory of Тарас Шевченко"+
+		" to the fundraise \"Drones for the brigade, a rather long title that must be wrapped onto the next line of the card\" on 09.03.2026.", card.Message())
+
+	document, err := tributes.Render(card)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(document, []byte("%PDF-")))
+
+	// INFO: regenerated card is identical.
+	again, err := tributes.Render(card)
+	require.NoError(t, err)
+	assert.Equal(t, document, again)
+}
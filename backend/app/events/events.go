@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"one-help/app/currencies"
 )
 
 // Event describes event entity.
@@ -15,7 +17,7 @@ type Event struct {
 	EndDate         time.Time
 	Format          string
 	MaxParticipants int
-	MinimumDonation float64
+	MinimumDonation currencies.Amount
 	Address         string
 	Status          string
 	FundraiseId     uuid.UUID
@@ -32,7 +34,7 @@ type CreateParams struct {
 	EndDate         time.Time
 	Format          string
 	MaxParticipants int
-	MinimumDonation float64
+	MinimumDonation currencies.Amount
 	Address         string
 	FundraiseId     uuid.UUID
 	ImageUrl        string
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"one-help/app/currencies"
 	"one-help/app/donations"
 
 	"one-help/app/events/statuses"
@@ -58,7 +59,7 @@ func (service *Service) Create(ctx context.Context, params CreateParams) (*Event
 		return nil, ParamsError.New("format is required")
 	case params.MaxParticipants < 0:
 		return nil, ParamsError.New("max participants must be positive or 0 for unlimited")
-	case params.MinimumDonation < 0:
+	case params.MinimumDonation.Sign() < 0:
 		return nil, ParamsError.New("minimum donation must be positive or 0 for no minimum")
 	case params.Address == "":
 		return nil, ParamsError.New("address is required")
@@ -147,7 +148,7 @@ func (service *Service) Enroll(ctx context.Context, eventID, userID uuid.UUID) (
 		}
 	}
 
-	if event.MinimumDonation > 0 {
+	if event.MinimumDonation.IsPositive() {
 		donations, err := service.donations.List(ctx, donations.ListParams{
 			UserID:      &userID,
 			FundraiseID: &event.FundraiseId})
@@ -155,11 +156,11 @@ func (service *Service) Enroll(ctx context.Context, eventID, userID uuid.UUID) (
 		if err != nil {
 			return nil, Error.Wrap(err)
 		}
-		var total float64
+		var total currencies.Amount
 		for _, donation := range donations {
-			total += donation.Amount
+			total = total.Add(donation.ConvertedAmount())
 		}
-		if total < event.MinimumDonation {
+		if total.Cmp(event.MinimumDonation) < 0 {
 			return nil, ParamsError.New("donation is less than minimum")
 		}
 	}
@@ -0,0 +1,87 @@
+package analytics
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"one-help/app/currencies"
+)
+
+// Granularity defines time series bucket size.
+type Granularity string
+
+const (
+	// Hour groups donations by hours.
+	Hour Granularity = "hour"
+	// Day groups donations by days.
+	Day Granularity = "day"
+)
+
+// MaxHourlyRange defines the longest period hourly time series is built for.
+const MaxHourlyRange = 7 * 24 * time.Hour
+
+// Params defines period the analytics is computed for.
+type Params struct {
+	FundraiseID uuid.UUID
+	From        time.Time
+	To          time.Time
+}
+
+// Point is a single time series bucket. Amounts are converted to the fundraise currency.
+type Point struct {
+	Time      time.Time
+	Donations int
+	Donors    int
+	Amount    currencies.Amount
+	// Cumulative is the total confirmed amount collected up to the end of the bucket within the period.
+	Cumulative currencies.Amount
+}
+
+// Summary holds aggregated donations statistics of the fundraise.
+type Summary struct {
+	// Clicks is the number of registered donate-link clicks, each of them creates a pending donation.
+	Clicks int
+	// Confirmed includes self-reported donations, they count towards the totals as well.
+	Confirmed    int
+	UniqueDonors int
+	RepeatDonors int
+	Total        currencies.Amount
+	Average      currencies.Amount
+	Median       currencies.Amount
+	// Matched is the total added by sponsors' matching pledges, it is not included into the donations total.
+	Matched currencies.Amount
+	// SelfReportedDonations is the number of offline donations recorded by the organizer without provider's confirmation.
+	SelfReportedDonations int
+	SelfReported          currencies.Amount
+	// VerifiedDonations is the number of self-reported donations found in imported bank statements.
+	VerifiedDonations int
+	Verified          currencies.Amount
+}
+
+// RepeatDonorRatio returns part of donors that donated more than once.
+func (summary Summary) RepeatDonorRatio() float64 {
+	if summary.UniqueDonors == 0 {
+		return 0
+	}
+
+	return float64(summary.RepeatDonors) / float64(summary.UniqueDonors)
+}
+
+// Conversion returns part of donate-link clicks that ended up with confirmed payment.
+func (summary Summary) Conversion() float64 {
+	if summary.Clicks == 0 {
+		return 0
+	}
+
+	// INFO: self-reported donations are recorded without donate-link clicks.
+	return float64(summary.Confirmed-summary.SelfReportedDonations) / float64(summary.Clicks)
+}
+
+// Report is the fundraise analytics for the period.
+type Report struct {
+	Params  Params
+	Summary Summary
+	Daily   []Point
+	Hourly  []Point
+}
@@ -0,0 +1,15 @@
+package analytics
+
+import (
+	"context"
+)
+
+// DB exposes access to fundraise analytics computed from donations and payments.
+//
+// architecture: DB
+type DB interface {
+	// Summary returns aggregated donations statistics for the period.
+	Summary(ctx context.Context, params Params) (Summary, error)
+	// TimeSeries returns confirmed donations grouped into buckets of provided granularity, including empty ones.
+	TimeSeries(ctx context.Context, params Params, granularity Granularity) ([]Point, error)
+}
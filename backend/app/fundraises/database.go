@@ -5,6 +5,9 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/zeebo/errs"
+
+	"one-help/app/currencies"
+	"one-help/app/fundraises/reviews"
 )
 
 var (
@@ -18,6 +21,8 @@ var (
 type DB interface {
 	// Create inserts fundraise into the database.
 	Create(ctx context.Context, fundraise Fundraise) error
+	// CreateWithReview inserts fundraise with its first review in the same transaction.
+	CreateWithReview(ctx context.Context, fundraise Fundraise, review reviews.Review) error
 	// Get fundraise from the database.
 	Get(ctx context.Context, id uuid.UUID) (Fundraise, error)
 	// List returns all available fundraises.
@@ -27,12 +32,18 @@ type DB interface {
 	// Delete fundraise from the database.
 	Delete(ctx context.Context, id uuid.UUID) error
 	// GetFilled returns collected funds on the provided fundraise.
-	GetFilled(ctx context.Context, id uuid.UUID) (float64, error)
+	GetFilled(ctx context.Context, id uuid.UUID) (currencies.Amount, error)
 }
 
 // ListParams defines params for list method.
 type ListParams struct {
 	OrganizerID *uuid.UUID
+	// Statuses lists only fundraises in one of the statuses, if not empty.
+	Statuses []string
+	// ExcludeStatuses skips fundraises in any of the statuses.
+	ExcludeStatuses []string
+	// OldestFirst orders fundraises by start date ascending, used for review queue.
+	OldestFirst bool
 	Limit       int
 	Page        int
 }
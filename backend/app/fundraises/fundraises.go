@@ -1,9 +1,13 @@
 package fundraises
 
 import (
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
+
+	"one-help/app/currencies"
+	"one-help/app/donations/tributes"
 )
 
 // Fundraise describes fundraise entity.
@@ -12,11 +16,16 @@ type Fundraise struct {
 	OrganizerId  uuid.UUID
 	Title        string
 	Description  string
-	TargetAmount float64
-	StartDate    time.Time
-	EndDate      time.Time
-	Status       string
-	ImageUrl     string
+	TargetAmount currencies.Amount
+	Currency     string
+	// MinDonation is the smallest donation accepted, in the fundraise currency, zero means no limit.
+	MinDonation currencies.Amount
+	// Presets are suggested donation amounts in the fundraise currency.
+	Presets   []currencies.Amount
+	StartDate time.Time
+	EndDate   time.Time
+	Status    string
+	ImageUrl  string
 }
 
 // IsEndDateSet returns true if end date is not null.
@@ -24,12 +33,20 @@ func (f *Fundraise) IsEndDateSet() bool {
 	return f.EndDate != time.Time{}
 }
 
+// IsApproved returns true if fundraise has passed moderation review.
+func (f *Fundraise) IsApproved() bool {
+	return !slices.Contains(unreviewedStatuses, f.Status)
+}
+
 // CreateParams defines needed params to create a new fundraise.
 type CreateParams struct {
 	OrganizerId  uuid.UUID
 	Title        string
 	Description  string
-	TargetAmount float64
+	TargetAmount currencies.Amount
+	Currency     string
+	MinDonation  currencies.Amount
+	Presets      []currencies.Amount
 	EndDate      time.Time
 	ImageUrl     string
 }
@@ -38,9 +55,84 @@ type CreateParams struct {
 type RegisterDonateParams struct {
 	FundraiseID uuid.UUID
 	UserID      uuid.UUID
+	Currency    string // INFO: fundraise currency is used when empty.
+	// Amount chosen by the donor in the donation currency, zero falls back to the configured default price.
+	Amount currencies.Amount
+	// PaymentType selects payment provider, Stripe is used when empty.
+	PaymentType string
+	// Anonymous hides donor's identity from the public.
+	Anonymous bool
+	Message   string
+	// CoverFee adds the estimated processing fee to the charged amount, so that the fundraise receives the whole Amount.
+	CoverFee bool
+	// TeamCode attributes the donation to the team or referrer on leaderboards, optional.
+	TeamCode string
+	// Tribute dedicates the donation to the honoree, its recipient gets dedication card on confirmation, optional.
+	Tribute tributes.Tribute
 }
 
 // RegisterDonateResult defines donate register result values.
 type RegisterDonateResult struct {
 	PaymentURL string
+	// Amount is the amount the donor is charged, zero for the default price.
+	Amount currencies.Amount
+	// Fee is the estimated processing fee covered by the donor.
+	Fee currencies.Amount
+}
+
+// TransferParams defines values needed to transfer collected funds to another fundraise.
+type TransferParams struct {
+	FromFundraiseID uuid.UUID
+	ToFundraiseID   uuid.UUID
+	CallerID        uuid.UUID
+	Amount          currencies.Amount // INFO: zero amount transfers whole available balance.
+	Reason          string
+}
+
+// ReviewParams defines values needed to approve or reject fundraise pending review.
+type ReviewParams struct {
+	FundraiseID uuid.UUID
+	CallerID    uuid.UUID
+	Reason      string // INFO: required for rejection.
+}
+
+// ResubmitParams defines organizer's fixes of the rejected fundraise, empty values keep the current ones.
+type ResubmitParams struct {
+	FundraiseID  uuid.UUID
+	CallerID     uuid.UUID
+	Title        string
+	Description  string
+	TargetAmount currencies.Amount
+	EndDate      time.Time
+	ImageUrl     string
+	Comment      string
+}
+
+// OfflineDonationParams defines values needed to record donation received outside of payment providers.
+type OfflineDonationParams struct {
+	FundraiseID uuid.UUID
+	CallerID    uuid.UUID
+	DonorID     uuid.UUID // INFO: nil uuid for donation of unknown or unregistered donor.
+	PaymentType string
+	Amount      currencies.Amount
+	Currency    string
+	ReceivedAt  time.Time
+	// Reference is the optional identifier of the payment, e.g. bank transfer reference.
+	Reference   string
+	EvidenceURL string
+	Notes       string
+	Message     string
+}
+
+// ChargeParams defines values of the recurring donation charge confirmed by payment provider.
+type ChargeParams struct {
+	Fundraise     Fundraise
+	UserID        uuid.UUID
+	PlanID        uuid.UUID
+	PaymentType   string
+	TransactionID string
+	Reference     string // INFO: provider's payment reference.
+	Amount        currencies.Money
+	// RequestedAmount is the amount of the plan at the moment of the charge.
+	RequestedAmount currencies.Amount
 }
@@ -0,0 +1,57 @@
+// Package fundraisestesting provides fundraises service and donation fixtures for the tests of services built on top of it.
+package fundraisestesting
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"one-help/app"
+	"one-help/app/currencies"
+	"one-help/app/fundraises"
+	"one-help/app/notifications"
+	"one-help/app/payments"
+	"one-help/app/payments/fake"
+	"one-help/internal/logger/zaplog"
+)
+
+// NewService returns fundraises service on top of the database, converting amounts with identity exchange rates.
+func NewService(db app.DB, providers *payments.Providers, notifier notifications.Sender) *fundraises.Service {
+	return fundraises.NewService(
+		zaplog.NewLog(),
+		db.Fundraises(),
+		db.Donations(),
+		db.Receipts(),
+		db.Payments(),
+		db.ReconciliationReports(),
+		db.FundraiseTransfers(),
+		db.FundraiseAnalytics(),
+		db.FundraiseReviews(),
+		db.Matching(),
+		db.Ledger(),
+		db.OfflineDonations(),
+		db.Leaderboards(),
+		db.Notifications(),
+		db.Users(),
+		providers,
+		notifier,
+		currencies.IdentityProvider{},
+	)
+}
+
+// Donate registers donation with the fake provider, pays it and applies the completion event.
+// Returns the completion event, its reference is the donation id.
+func Donate(ctx context.Context, t *testing.T, service *fundraises.Service, provider *fake.Provider, params fundraises.RegisterDonateParams) payments.Event {
+	t.Helper()
+
+	params.PaymentType = provider.Type()
+	result, err := service.RegisterDonate(ctx, params)
+	require.NoError(t, err)
+
+	paid := provider.Pay(strings.TrimPrefix(result.PaymentURL, fake.CheckoutURL))
+	require.NoError(t, service.HandleEvent(ctx, provider.Type(), paid))
+
+	return paid
+}
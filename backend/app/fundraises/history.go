@@ -0,0 +1,49 @@
+package fundraises
+
+import (
+	"context"
+
+	"one-help/app/donations"
+)
+
+// DonationHistory holds donations of the donor with totals of all donations matching the filters.
+type DonationHistory struct {
+	Donations     []donations.HistoryEntry
+	YearlyTotals  []donations.YearTotal
+	Contributions []donations.Contribution
+}
+
+// DonationHistory returns page of the donor's donations with their payment state,
+// confirmed donations totals by year and contributions to every fundraise.
+func (service *Service) DonationHistory(ctx context.Context, params donations.HistoryParams) (DonationHistory, error) {
+	switch {
+	case params.Limit <= 0:
+		return DonationHistory{}, ParamsError.New("limit must be positive")
+	case params.Page <= 0:
+		return DonationHistory{}, ParamsError.New("page must be positive")
+	case !params.From.IsZero() && !params.To.IsZero() && !params.From.Before(params.To):
+		return DonationHistory{}, ParamsError.New("from must be before to")
+	}
+
+	var (
+		history DonationHistory
+		err     error
+	)
+
+	history.Donations, err = service.donations.ListHistory(ctx, params)
+	if err != nil {
+		return DonationHistory{}, Error.Wrap(err)
+	}
+
+	history.YearlyTotals, err = service.donations.YearlyTotals(ctx, params)
+	if err != nil {
+		return DonationHistory{}, Error.Wrap(err)
+	}
+
+	history.Contributions, err = service.donations.Contributions(ctx, params)
+	if err != nil {
+		return DonationHistory{}, Error.Wrap(err)
+	}
+
+	return history, nil
+}
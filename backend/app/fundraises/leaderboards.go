@@ -0,0 +1,98 @@
+package fundraises
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"one-help/app/currencies"
+	"one-help/app/donations"
+	"one-help/app/fundraises/leaderboards"
+	"one-help/app/payments"
+)
+
+// MaxLeaderboardEntries limits number of entries shown on the leaderboard.
+const MaxLeaderboardEntries = 100
+
+// Leaderboard returns top donors or teams of the fundraise, or of the whole platform when fundraise id is nil,
+// for the month or for all time. Totals of the platform are in the default currency.
+// Anonymous donations are counted only towards teams, donors with private profiles are not shown.
+func (service *Service) Leaderboard(ctx context.Context, params leaderboards.Params) (leaderboards.Board, error) {
+	board := leaderboards.Board{Params: params, Currency: currencies.Default}
+
+	switch board.Kind {
+	case "":
+		board.Kind = leaderboards.KindDonors
+	case leaderboards.KindDonors, leaderboards.KindTeams:
+	default:
+		return leaderboards.Board{}, ParamsError.New("leaderboard kind must be %q or %q", leaderboards.KindDonors, leaderboards.KindTeams)
+	}
+
+	period, err := leaderboards.ParsePeriod(board.Period)
+	if err != nil {
+		return leaderboards.Board{}, ParamsError.Wrap(err)
+	}
+	board.Period = period
+
+	if board.Limit <= 0 || board.Limit > MaxLeaderboardEntries {
+		board.Limit = MaxLeaderboardEntries
+	}
+
+	if board.FundraiseID != uuid.Nil {
+		fundraise, err := service.fundraises.Get(ctx, board.FundraiseID)
+		if err != nil {
+			return leaderboards.Board{}, Error.Wrap(err)
+		}
+
+		if slices.Contains(unreviewedStatuses, fundraise.Status) {
+			return leaderboards.Board{}, Error.Wrap(ErrNoFundraise)
+		}
+
+		board.Currency = fundraise.Currency
+	}
+
+	entries, err := service.leaderboards.List(ctx, board.Params)
+	if err != nil {
+		return leaderboards.Board{}, Error.Wrap(err)
+	}
+
+	leaderboards.Rank(entries)
+	board.Entries = entries
+
+	return board, nil
+}
+
+// countDonation adds provider-confirmed donation to leaderboards of its fundraise and of the platform.
+// NOTE: self-reported donations are not counted, as they are not confirmed by providers.
+func (service *Service) countDonation(ctx context.Context, donation donations.Donation) error {
+	// INFO: Rate snapshot to the default currency is taken once, so that platform totals never change afterwards.
+	rate, err := service.rate(ctx, donation.Currency, currencies.Default)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	contribution := leaderboards.Contribution{
+		DonationID:   donation.ID,
+		FundraiseID:  donation.FundraiseId,
+		DonorID:      donation.UserId,
+		TeamCode:     donation.TeamCode,
+		Amount:       donation.Amount,
+		ExchangeRate: donation.ExchangeRate,
+		PlatformRate: rate.Value,
+		DonatedAt:    donation.CreatedAt,
+	}
+	if donation.Anonymous {
+		contribution.DonorID = uuid.Nil
+	}
+
+	return Error.Wrap(service.leaderboards.Record(ctx, contribution))
+}
+
+// uncountRefund reduces leaderboard totals of the donation by its refunded amount.
+// NOTE: failures do not affect the refund, totals are only used for leaderboards.
+func (service *Service) uncountRefund(ctx context.Context, donation donations.Donation, payment payments.Payment) {
+	if err := service.leaderboards.Refund(ctx, donation.ID, payment.RefundedAmount); err != nil {
+		service.logger.ErrorF("could not update leaderboards of refunded donation %s", err, donation.ID)
+	}
+}
@@ -0,0 +1,24 @@
+package leaderboards
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"one-help/app/currencies"
+)
+
+// DB exposes access to leaderboards db.
+// Totals are cached per leaderboard and updated incrementally by recorded contributions.
+//
+// architecture: DB
+type DB interface {
+	// Record adds confirmed donation to leaderboard totals, donation is counted only once.
+	Record(ctx context.Context, contribution Contribution) error
+	// Refund sets total refunded amount of the counted donation and reduces leaderboard totals accordingly.
+	// Donations not counted towards leaderboards are ignored.
+	Refund(ctx context.Context, donationID uuid.UUID, refunded currencies.Amount) error
+	// List returns top entries of the leaderboard with positive totals, the largest first.
+	// Donors with private profiles are skipped.
+	List(ctx context.Context, params Params) ([]Entry, error)
+}
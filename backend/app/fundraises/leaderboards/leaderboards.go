@@ -0,0 +1,136 @@
+package leaderboards
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+
+	"one-help/app/currencies"
+)
+
+var (
+	// ErrInvalidTeamCode indicates that team code has unsupported format.
+	ErrInvalidTeamCode = errs.New("team code must be 2 to 32 latin letters, digits, dashes or underscores")
+	// ErrInvalidPeriod indicates that leaderboard period is neither all-time nor a month.
+	ErrInvalidPeriod = errs.New("period must be %q or a month in YYYY-MM format", PeriodAllTime)
+)
+
+const (
+	// KindDonors ranks donors by their confirmed donations.
+	KindDonors = "donors"
+	// KindTeams ranks teams by confirmed donations carrying their code.
+	KindTeams = "teams"
+)
+
+// PeriodAllTime defines period of all-time leaderboards.
+const PeriodAllTime = "all"
+
+// monthLayout defines format of monthly leaderboard periods.
+const monthLayout = "2006-01"
+
+// teamCodeRegExp defines allowed format of team codes.
+var teamCodeRegExp = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{1,31}$`)
+
+// ParseTeamCode returns normalized team code, codes are case-insensitive.
+// Empty code is allowed and means that donation carries no code.
+func ParseTeamCode(code string) (string, error) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if code == "" {
+		return "", nil
+	}
+
+	if !teamCodeRegExp.MatchString(code) {
+		return "", ErrInvalidTeamCode
+	}
+
+	return code, nil
+}
+
+// MonthPeriod returns period of the monthly leaderboard the time belongs to.
+func MonthPeriod(at time.Time) string {
+	return at.UTC().Format(monthLayout)
+}
+
+// ParsePeriod returns normalized leaderboard period, empty period means all time.
+func ParsePeriod(period string) (string, error) {
+	if period == "" || period == PeriodAllTime {
+		return PeriodAllTime, nil
+	}
+
+	month, err := time.Parse(monthLayout, period)
+	if err != nil {
+		return "", ErrInvalidPeriod
+	}
+
+	return MonthPeriod(month), nil
+}
+
+// Contribution describes confirmed donation counted towards leaderboards.
+// Totals of the fundraise are kept in the fundraise currency, platform-wide ones - in the default currency.
+type Contribution struct {
+	DonationID  uuid.UUID
+	FundraiseID uuid.UUID
+	DonorID     uuid.UUID         // INFO: uuid.Nil for anonymous donations, counted only towards team leaderboards.
+	TeamCode    string            // INFO: empty when donation carries no code.
+	Amount      currencies.Amount // INFO: in the donation currency.
+	Refunded    currencies.Amount
+	// ExchangeRate is a snapshot of the rate from donation currency to fundraise currency.
+	ExchangeRate float64
+	// PlatformRate is a snapshot of the rate from donation currency to the default currency.
+	PlatformRate float64
+	DonatedAt    time.Time
+}
+
+// Net returns not refunded amount of the contribution in the donation currency.
+func (c *Contribution) Net() currencies.Amount {
+	return c.Amount.Sub(c.Refunded).Max(currencies.Zero)
+}
+
+// FundraiseAmount returns amount counted towards leaderboards of the fundraise.
+func (c *Contribution) FundraiseAmount() currencies.Amount {
+	return c.Net().Mul(c.ExchangeRate)
+}
+
+// PlatformAmount returns amount counted towards platform-wide leaderboards.
+func (c *Contribution) PlatformAmount() currencies.Amount {
+	return c.Net().Mul(c.PlatformRate)
+}
+
+// Params defines leaderboard to list.
+type Params struct {
+	FundraiseID uuid.UUID // INFO: uuid.Nil for platform-wide leaderboard.
+	Kind        string
+	Period      string // INFO: PeriodAllTime or month returned by MonthPeriod.
+	Limit       int
+}
+
+// Entry describes donor or team position on the leaderboard.
+type Entry struct {
+	Rank      int
+	DonorID   uuid.UUID // INFO: set on donors leaderboards.
+	FirstName string
+	LastName  string
+	TeamCode  string // INFO: set on teams leaderboards.
+	Amount    currencies.Amount
+	Donations int
+}
+
+// Board describes ranked leaderboard.
+type Board struct {
+	Params
+	Currency string
+	Entries  []Entry
+}
+
+// Rank assigns ranks to entries sorted by amount, entries with equal amounts share the rank.
+func Rank(entries []Entry) {
+	for i := range entries {
+		entries[i].Rank = i + 1
+		if i > 0 && entries[i].Amount == entries[i-1].Amount {
+			entries[i].Rank = entries[i-1].Rank
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package leaderboards_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app/currencies"
+	"one-help/app/fundraises/leaderboards"
+)
+
+func TestParseTeamCode(t *testing.T) {
+	code, err := leaderboards.ParseTeamCode("  Kyiv-Runners ")
+	require.NoError(t, err)
+	assert.Equal(t, "kyiv-runners", code)
+
+	code, err = leaderboards.ParseTeamCode("")
+	require.NoError(t, err)
+	assert.Empty(t, code)
+
+	for _, invalid := range []string{"a", "-team", "team code", "команда", "a23456789012345678901234567890123"} {
+		_, err = leaderboards.ParseTeamCode(invalid)
+		assert.ErrorIs(t, err, leaderboards.ErrInvalidTeamCode, invalid)
+	}
+}
+
+func TestParsePeriod(t *testing.T) {
+	period, err := leaderboards.ParsePeriod("")
+	require.NoError(t, err)
+	assert.Equal(t, leaderboards.PeriodAllTime, period)
+
+	period, err = leaderboards.ParsePeriod("2026-10")
+	require.NoError(t, err)
+	assert.Equal(t, leaderboards.MonthPeriod(time.Date(2026, time.October, 31, 23, 0, 0, 0, time.UTC)), period)
+
+	_, err = leaderboards.ParsePeriod("2026-13")
+	assert.ErrorIs(t, err, leaderboards.ErrInvalidPeriod)
+}
+
+func TestContribution(t *testing.T) {
+	contribution := leaderboards.Contribution{
+		Amount:       currencies.Major(10),
+		Refunded:     currencies.Major(4),
+		ExchangeRate: 40,
+		PlatformRate: 41.5,
+	}
+
+	assert.Equal(t, currencies.Major(6), contribution.Net())
+	assert.Equal(t, currencies.Major(240), contribution.FundraiseAmount())
+	assert.Equal(t, currencies.Major(249), contribution.PlatformAmount())
+
+	contribution.Refunded = currencies.Major(11)
+	assert.Equal(t, currencies.Zero, contribution.FundraiseAmount())
+}
+
+func TestRank(t *testing.T) {
+	entries := []leaderboards.Entry{
+		{Amount: currencies.Major(300)},
+		{Amount: currencies.Major(200)},
+		{Amount: currencies.Major(200)},
+		{Amount: currencies.Major(100)},
+	}
+
+	leaderboards.Rank(entries)
+	for i, rank := range []int{1, 2, 2, 4} {
+		assert.Equal(t, rank, entries[i].Rank)
+	}
+}
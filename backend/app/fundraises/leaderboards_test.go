@@ -0,0 +1,115 @@
+package fundraises_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app"
+	"one-help/app/currencies"
+	"one-help/app/database"
+	"one-help/app/database/dbtesting"
+	"one-help/app/fundraises"
+	"one-help/app/fundraises/fundraisestesting"
+	"one-help/app/fundraises/leaderboards"
+	notificationfake "one-help/app/notifications/fake"
+	"one-help/app/payments"
+	"one-help/app/payments/fake"
+	"one-help/app/users"
+)
+
+func TestLeaderboards(t *testing.T) {
+	organizer := users.User{
+		ID:        uuid.New(),
+		FirstName: "John",
+		LastName:  "Doe",
+	}
+	donor := users.User{
+		ID:        uuid.New(),
+		FirstName: "Jane",
+		LastName:  "Doe",
+	}
+
+	provider := fake.NewProvider(payments.TypeStripe)
+
+	dbtesting.Run(t, database.Config{MigrationsPath: "../database/migrations"}, func(ctx context.Context, t *testing.T, db app.DB) {
+		require.NoError(t, db.Users().Create(ctx, organizer))
+		require.NoError(t, db.Users().SetTrusted(ctx, organizer.ID, true))
+		require.NoError(t, db.Users().Create(ctx, donor))
+
+		service := fundraisestesting.NewService(db, payments.NewProviders(provider), notificationfake.NewSender())
+
+		fundraise, err := service.Create(ctx, fundraises.CreateParams{
+			OrganizerId:  organizer.ID,
+			Title:        "Test",
+			Description:  "Test Description",
+			TargetAmount: currencies.Major(1000),
+			Currency:     currencies.UAH,
+		})
+		require.NoError(t, err)
+
+		t.Run("invalid team code", func(t *testing.T) {
+			_, err := service.RegisterDonate(ctx, fundraises.RegisterDonateParams{
+				FundraiseID: fundraise.ID,
+				UserID:      donor.ID,
+				Amount:      currencies.Major(100),
+				TeamCode:    "runners team",
+			})
+			require.ErrorIs(t, err, leaderboards.ErrInvalidTeamCode)
+		})
+
+		fundraisestesting.Donate(ctx, t, service, provider, fundraises.RegisterDonateParams{
+			FundraiseID: fundraise.ID,
+			UserID:      donor.ID,
+			Amount:      currencies.Major(100),
+			TeamCode:    "Runners",
+		})
+
+		t.Run("teams", func(t *testing.T) {
+			board, err := service.Leaderboard(ctx, leaderboards.Params{FundraiseID: fundraise.ID, Kind: leaderboards.KindTeams})
+			require.NoError(t, err)
+			assert.Equal(t, currencies.UAH, board.Currency)
+			assert.Equal(t, leaderboards.PeriodAllTime, board.Period)
+			require.Len(t, board.Entries, 1)
+			assert.Equal(t, "runners", board.Entries[0].TeamCode)
+			assert.Equal(t, currencies.Major(100), board.Entries[0].Amount)
+			assert.Equal(t, 1, board.Entries[0].Rank)
+		})
+
+		t.Run("platform", func(t *testing.T) {
+			board, err := service.Leaderboard(ctx, leaderboards.Params{Period: leaderboards.MonthPeriod(time.Now())})
+			require.NoError(t, err)
+			require.NotEmpty(t, board.Entries)
+			assert.Equal(t, donor.ID, board.Entries[0].DonorID)
+		})
+
+		t.Run("private donor", func(t *testing.T) {
+			// INFO: private donors are not shown by name.
+			private := donor
+			private.Private = true
+			require.NoError(t, db.Users().Update(ctx, private))
+
+			board, err := service.Leaderboard(ctx, leaderboards.Params{FundraiseID: fundraise.ID})
+			require.NoError(t, err)
+			assert.Empty(t, board.Entries)
+
+			supporters, err := service.RecentSupporters(ctx, fundraise.ID, 1)
+			require.NoError(t, err)
+			require.Len(t, supporters, 1)
+			assert.False(t, supporters[0].Donation.Anonymous)
+			assert.Equal(t, uuid.Nil, supporters[0].Donation.UserId)
+			assert.Empty(t, supporters[0].FirstName)
+		})
+
+		t.Run("invalid params", func(t *testing.T) {
+			_, err := service.Leaderboard(ctx, leaderboards.Params{Kind: "sponsors"})
+			require.True(t, fundraises.ParamsError.Has(err))
+			_, err = service.Leaderboard(ctx, leaderboards.Params{Period: "october"})
+			require.ErrorIs(t, err, leaderboards.ErrInvalidPeriod)
+		})
+	})
+}
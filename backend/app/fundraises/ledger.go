@@ -0,0 +1,63 @@
+package fundraises
+
+import (
+	"context"
+	"time"
+
+	"one-help/app/currencies"
+	"one-help/app/donations"
+	"one-help/app/ledger"
+	"one-help/app/payments"
+)
+
+// postConfirmation posts net amount of the confirmed payment from donor clearing account to the fundraise balance,
+// and its processing fee to the provider's fees account.
+// Posting is idempotent, so it is safe to repeat it when confirmation is retried, returns false if it was posted before.
+func (service *Service) postConfirmation(ctx context.Context, donation donations.Donation, fundraise Fundraise, payment payments.Payment) (bool, error) {
+	transaction := ledger.New(ledger.KindConfirmation, donation.ID.String(), time.Now().UTC())
+	transaction.Move(
+		ledger.DonorClearing(payment.PaymentType), currencies.NewMoney(payment.NetAmount, donation.Currency),
+		ledger.FundraiseBalance(fundraise.ID), currencies.NewMoney(payment.NetAmount.Mul(donation.ExchangeRate), fundraise.Currency),
+	)
+	if payment.FeeAmount.IsPositive() {
+		transaction.Move(
+			ledger.DonorClearing(payment.PaymentType), currencies.NewMoney(payment.FeeAmount, donation.Currency),
+			ledger.Fees(payment.PaymentType), currencies.NewMoney(payment.FeeAmount, donation.Currency),
+		)
+	}
+
+	posted, err := service.ledger.Post(ctx, transaction)
+	return posted, Error.Wrap(err)
+}
+
+// postRefund posts refunded amount of the payment, in the donation currency, to refunds account.
+// Refunds are taken from the fundraise balance up to the net amount of the payment, the rest returns processing fee
+// kept by the provider and is taken from its kept fees account, so the fundraise never returns more than it received.
+// Payment holds amount refunded before this refund. Reference identifies the refund, so that the same refund is posted once.
+func (service *Service) postRefund(ctx context.Context, reference string, donation donations.Donation, payment payments.Payment, amount currencies.Amount) error {
+	fundraise, err := service.fundraises.Get(ctx, donation.FundraiseId)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	refunded := payment.RefundedAmount.Add(amount)
+	net := refunded.Min(payment.NetAmount).Sub(payment.RefundedAmount.Min(payment.NetAmount))
+	fee := amount.Sub(net)
+
+	transaction := ledger.New(ledger.KindRefund, reference, time.Now().UTC())
+	if net.IsPositive() {
+		transaction.Move(
+			ledger.FundraiseBalance(fundraise.ID), currencies.NewMoney(net.Mul(donation.ExchangeRate), fundraise.Currency),
+			ledger.Refunds(payment.PaymentType), currencies.NewMoney(net, donation.Currency),
+		)
+	}
+	if fee.IsPositive() {
+		transaction.Move(
+			ledger.KeptFees(payment.PaymentType), currencies.NewMoney(fee, donation.Currency),
+			ledger.Refunds(payment.PaymentType), currencies.NewMoney(fee, donation.Currency),
+		)
+	}
+
+	_, err = service.ledger.Post(ctx, transaction)
+	return Error.Wrap(err)
+}
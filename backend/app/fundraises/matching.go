@@ -0,0 +1,151 @@
+package fundraises
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+
+	"one-help/app/currencies"
+	"one-help/app/donations"
+	"one-help/app/fundraises/matching"
+)
+
+const (
+	// MaxMatchRatio limits matched amount per unit of the donation.
+	MaxMatchRatio = 10
+	// MatchRetryAge defines age of the confirmed donation after which its failed matching is not retried.
+	MatchRetryAge = 24 * time.Hour
+)
+
+// PledgeParams defines needed params to attach matching pledge to the fundraise.
+type PledgeParams struct {
+	FundraiseID uuid.UUID
+	CallerID    uuid.UUID
+	SponsorName string
+	Ratio       float64
+	Cap         currencies.Amount // INFO: in the fundraise currency.
+	StartsAt    time.Time         // INFO: zero to start immediately.
+	EndsAt      time.Time
+}
+
+// CreatePledge attaches sponsor's matching pledge to the fundraise, allowed only to the fundraise organizer.
+func (service *Service) CreatePledge(ctx context.Context, params PledgeParams) (matching.Pledge, error) {
+	now := time.Now().UTC()
+	if params.StartsAt.IsZero() {
+		params.StartsAt = now
+	}
+
+	switch {
+	case params.SponsorName == "":
+		return matching.Pledge{}, ParamsError.New("sponsor name is required")
+	case params.Ratio <= 0 || params.Ratio > MaxMatchRatio:
+		return matching.Pledge{}, ParamsError.New("ratio must be positive and not exceed %d", MaxMatchRatio)
+	case !params.Cap.IsPositive():
+		return matching.Pledge{}, ParamsError.New("cap must be positive")
+	case !params.EndsAt.After(params.StartsAt) || !params.EndsAt.After(now):
+		return matching.Pledge{}, ParamsError.New("pledge must end in the future, after it starts")
+	}
+
+	fundraise, err := service.fundraises.Get(ctx, params.FundraiseID)
+	if err != nil {
+		return matching.Pledge{}, Error.Wrap(err)
+	}
+
+	if fundraise.OrganizerId != params.CallerID {
+		return matching.Pledge{}, ParamsError.Wrap(ErrNotOrganizer)
+	}
+
+	pledge := matching.Pledge{
+		ID:          uuid.New(),
+		FundraiseID: fundraise.ID,
+		SponsorName: params.SponsorName,
+		Ratio:       params.Ratio,
+		Cap:         params.Cap,
+		StartsAt:    params.StartsAt.UTC(),
+		EndsAt:      params.EndsAt.UTC(),
+		CreatedBy:   params.CallerID,
+		CreatedAt:   now,
+	}
+	if err = service.matching.CreatePledge(ctx, pledge); err != nil {
+		return matching.Pledge{}, Error.Wrap(err)
+	}
+
+	return pledge, nil
+}
+
+// Pledges returns matching pledges of the fundraise, the oldest first.
+func (service *Service) Pledges(ctx context.Context, fundraiseID uuid.UUID) ([]matching.Pledge, error) {
+	pledges, err := service.matching.ListPledges(ctx, fundraiseID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return pledges, nil
+}
+
+// matchDonation applies pledges of the fundraise active at the donation time to the confirmed donation.
+// Matches are kept apart from the donations and do not count towards the collected funds.
+func (service *Service) matchDonation(ctx context.Context, donation donations.Donation) error {
+	pledges, err := service.matching.ListPledges(ctx, donation.FundraiseId)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	var errlist errs.Group
+	for _, pledge := range pledges {
+		if !pledge.IsActive(donation.CreatedAt) {
+			continue
+		}
+
+		amount := pledge.MatchAmount(donation.ConvertedAmount())
+		if !amount.IsPositive() {
+			continue
+		}
+
+		_, err = service.matching.Match(ctx, matching.Match{
+			PledgeID:   pledge.ID,
+			DonationID: donation.ID,
+			Amount:     amount,
+			CreatedAt:  time.Now().UTC(),
+		})
+		errlist.Add(err)
+	}
+
+	return Error.Wrap(errlist.Err())
+}
+
+// releaseMatches returns budget matched to the refunded part of the donation to its pledges.
+// Refunded is the total refunded amount of the donation, so repeated release with the same amount does nothing.
+func (service *Service) releaseMatches(ctx context.Context, donation donations.Donation, refunded currencies.Amount) error {
+	if !donation.Amount.IsPositive() {
+		return nil
+	}
+
+	share := min(refunded.Float64()/donation.Amount.Float64(), 1)
+	_, err := service.matching.Release(ctx, donation.ID, share)
+	return Error.Wrap(err)
+}
+
+// retryMatches applies pledges to confirmed donations created since provided time, which were not matched on
+// confirmation. Matching is idempotent, so donations matched concurrently are not matched twice.
+func (service *Service) retryMatches(ctx context.Context, since time.Time) error {
+	unmatched, err := service.matching.ListUnmatched(ctx, since)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	var errlist errs.Group
+	for _, donationID := range unmatched {
+		donation, err := service.donations.Get(ctx, donationID)
+		if err != nil {
+			errlist.Add(err)
+			continue
+		}
+
+		errlist.Add(service.matchDonation(ctx, donation))
+	}
+
+	return Error.Wrap(errlist.Err())
+}
@@ -0,0 +1,35 @@
+package matching
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+
+	"one-help/app/currencies"
+)
+
+// ErrNoPledge indicates that matching pledge does not exist.
+var ErrNoPledge = errs.New("matching pledge does not exist")
+
+// DB exposes access to matching pledges db.
+//
+// architecture: DB
+type DB interface {
+	// CreatePledge inserts pledge into the database.
+	CreatePledge(ctx context.Context, pledge Pledge) error
+	// ListPledges returns pledges of the fundraise, the oldest first.
+	ListPledges(ctx context.Context, fundraiseID uuid.UUID) ([]Pledge, error)
+	// Match inserts match of the donation, reducing its amount to the remaining budget of the pledge, and returns it.
+	// Returns stored match if the donation was already matched by the pledge, zero amount match is not stored.
+	Match(ctx context.Context, match Match) (Match, error)
+	// Release returns provided share of the donation matches to the budgets of their pledges and returns released amount.
+	// Share is the total refunded part of the donation, so repeated release of the same share releases nothing.
+	Release(ctx context.Context, donationID uuid.UUID, share float64) (currencies.Amount, error)
+	// ListUnmatched returns confirmed not refunded donations created since provided time, which were not matched
+	// by pledges active at their time and having remaining budget.
+	ListUnmatched(ctx context.Context, since time.Time) ([]uuid.UUID, error)
+	// Matched returns total of the fundraise matches created within the period, excluding released amounts.
+	Matched(ctx context.Context, fundraiseID uuid.UUID, from, to time.Time) (currencies.Amount, error)
+}
@@ -0,0 +1,69 @@
+package matching
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"one-help/app/currencies"
+)
+
+// Pledge describes sponsor's commitment to match donations to the fundraise within the time window, up to the cap.
+// Refund of the matched donation returns its refunded share of the match to the budget.
+type Pledge struct {
+	ID          uuid.UUID
+	FundraiseID uuid.UUID
+	SponsorName string
+	// Ratio is the matched amount per unit of the donation, e.g. 1 doubles every donation.
+	Ratio     float64
+	Cap       currencies.Amount // INFO: in the fundraise currency.
+	Matched   currencies.Amount // INFO: in the fundraise currency.
+	StartsAt  time.Time
+	EndsAt    time.Time // INFO: exclusive.
+	CreatedBy uuid.UUID
+	CreatedAt time.Time
+}
+
+// IsActive returns true if donations made at provided time are matched.
+func (p *Pledge) IsActive(at time.Time) bool {
+	return !at.Before(p.StartsAt) && at.Before(p.EndsAt)
+}
+
+// Remaining returns not yet used budget of the pledge.
+func (p *Pledge) Remaining() currencies.Amount {
+	return p.Cap.Sub(p.Matched).Max(currencies.Zero)
+}
+
+// MatchAmount returns amount the pledge adds to the donation converted to the fundraise currency, limited by remaining budget.
+func (p *Pledge) MatchAmount(donation currencies.Amount) currencies.Amount {
+	return donation.Mul(p.Ratio).Min(p.Remaining())
+}
+
+// Match describes sponsor's addition to the confirmed donation.
+type Match struct {
+	PledgeID   uuid.UUID
+	DonationID uuid.UUID
+	Amount     currencies.Amount // INFO: in the fundraise currency.
+	Released   currencies.Amount // INFO: part of the amount returned to the pledge on refund.
+	CreatedAt  time.Time
+}
+
+// Budget summarizes matching pledges of the fundraise.
+type Budget struct {
+	Matched currencies.Amount
+	// Remaining is the budget of the pledges not expired at the moment.
+	Remaining currencies.Amount
+}
+
+// NewBudget summarizes pledges at provided time.
+func NewBudget(pledges []Pledge, at time.Time) Budget {
+	var budget Budget
+	for _, pledge := range pledges {
+		budget.Matched = budget.Matched.Add(pledge.Matched)
+		if at.Before(pledge.EndsAt) {
+			budget.Remaining = budget.Remaining.Add(pledge.Remaining())
+		}
+	}
+
+	return budget
+}
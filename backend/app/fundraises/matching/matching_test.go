@@ -0,0 +1,38 @@
+package matching_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"one-help/app/currencies"
+	"one-help/app/fundraises/matching"
+)
+
+func TestPledge(t *testing.T) {
+	now := time.Now()
+	pledge := matching.Pledge{
+		Ratio:    2,
+		Cap:      currencies.Major(100),
+		Matched:  currencies.Major(70),
+		StartsAt: now,
+		EndsAt:   now.Add(time.Hour),
+	}
+
+	assert.True(t, pledge.IsActive(now))
+	assert.False(t, pledge.IsActive(now.Add(-time.Second)))
+	assert.False(t, pledge.IsActive(pledge.EndsAt))
+
+	assert.Equal(t, currencies.Major(30), pledge.Remaining())
+	assert.Equal(t, currencies.MinorUnits(2020), pledge.MatchAmount(currencies.MinorUnits(1010)))
+	assert.Equal(t, currencies.Major(30), pledge.MatchAmount(currencies.Major(50)))
+
+	expired := pledge
+	expired.Matched = currencies.Major(10)
+	expired.EndsAt = now
+
+	budget := matching.NewBudget([]matching.Pledge{pledge, expired}, now)
+	assert.Equal(t, currencies.Major(80), budget.Matched)
+	assert.Equal(t, currencies.Major(30), budget.Remaining)
+}
@@ -0,0 +1,143 @@
+package fundraises_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app"
+	"one-help/app/currencies"
+	"one-help/app/database"
+	"one-help/app/database/dbtesting"
+	"one-help/app/fundraises"
+	"one-help/app/fundraises/fundraisestesting"
+	notificationfake "one-help/app/notifications/fake"
+	"one-help/app/payments"
+	"one-help/app/payments/fake"
+	"one-help/app/users"
+)
+
+func TestMatching(t *testing.T) {
+	organizer := users.User{
+		ID:        uuid.New(),
+		FirstName: "John",
+		LastName:  "Doe",
+	}
+	donor := users.User{
+		ID:        uuid.New(),
+		FirstName: "Jane",
+		LastName:  "Doe",
+	}
+
+	provider := fake.NewProvider(payments.TypeStripe)
+
+	dbtesting.Run(t, database.Config{MigrationsPath: "../database/migrations"}, func(ctx context.Context, t *testing.T, db app.DB) {
+		require.NoError(t, db.Users().Create(ctx, organizer))
+		require.NoError(t, db.Users().SetTrusted(ctx, organizer.ID, true))
+		require.NoError(t, db.Users().Create(ctx, donor))
+
+		service := fundraisestesting.NewService(db, payments.NewProviders(provider), notificationfake.NewSender())
+
+		fundraise, err := service.Create(ctx, fundraises.CreateParams{
+			OrganizerId:  organizer.ID,
+			Title:        "Test",
+			Description:  "Test Description",
+			TargetAmount: currencies.Major(1000),
+			Currency:     currencies.UAH,
+		})
+		require.NoError(t, err)
+
+		t.Run("pledge", func(t *testing.T) {
+			_, err := service.CreatePledge(ctx, fundraises.PledgeParams{
+				FundraiseID: fundraise.ID,
+				CallerID:    donor.ID,
+				SponsorName: "Sponsor",
+				Ratio:       1,
+				Cap:         currencies.Major(50),
+				EndsAt:      time.Now().Add(time.Hour),
+			})
+			require.ErrorIs(t, err, fundraises.ErrNotOrganizer)
+
+			_, err = service.CreatePledge(ctx, fundraises.PledgeParams{
+				FundraiseID: fundraise.ID,
+				CallerID:    organizer.ID,
+				SponsorName: "Sponsor",
+				Ratio:       1,
+				Cap:         currencies.Major(50),
+				StartsAt:    time.Now().Add(-time.Hour),
+				EndsAt:      time.Now().Add(time.Hour),
+			})
+			require.NoError(t, err)
+		})
+
+		paid := fundraisestesting.Donate(ctx, t, service, provider, fundraises.RegisterDonateParams{
+			FundraiseID: fundraise.ID,
+			UserID:      donor.ID,
+			Amount:      currencies.Major(100),
+		})
+
+		t.Run("matched", func(t *testing.T) {
+			pledges, err := service.Pledges(ctx, fundraise.ID)
+			require.NoError(t, err)
+			require.Len(t, pledges, 1)
+			assert.Equal(t, currencies.Major(50), pledges[0].Matched)
+
+			// INFO: matches are not counted as collected funds.
+			filled, err := service.Filled(ctx, fundraise.ID)
+			require.NoError(t, err)
+			assert.Equal(t, currencies.Major(100), filled)
+		})
+
+		refunded := func(amount currencies.Amount) payments.Event {
+			return payments.Event{
+				ID:               uuid.NewString(),
+				Kind:             payments.EventRefunded,
+				Reference:        paid.Reference,
+				PaymentReference: paid.PaymentReference,
+				Amount:           currencies.NewMoney(currencies.Major(100), currencies.UAH),
+				Refunded:         amount,
+			}
+		}
+
+		t.Run("refunded", func(t *testing.T) {
+			require.NoError(t, service.HandleEvent(ctx, payments.TypeStripe, refunded(currencies.Major(40))))
+
+			filled, err := service.Filled(ctx, fundraise.ID)
+			require.NoError(t, err)
+			assert.Equal(t, currencies.Major(60), filled)
+
+			// INFO: refunded share of the donation returns its match to the pledge.
+			pledges, err := service.Pledges(ctx, fundraise.ID)
+			require.NoError(t, err)
+			assert.Equal(t, currencies.Major(30), pledges[0].Matched)
+		})
+
+		t.Run("fully refunded", func(t *testing.T) {
+			// INFO: refund reported again by another event is not posted twice.
+			for range 2 {
+				require.NoError(t, service.HandleEvent(ctx, payments.TypeStripe, refunded(currencies.Major(100))))
+			}
+
+			filled, err := service.Filled(ctx, fundraise.ID)
+			require.NoError(t, err)
+			assert.Equal(t, currencies.Zero, filled)
+
+			payment, err := db.Payments().Get(ctx, uuid.MustParse(paid.Reference))
+			require.NoError(t, err)
+			assert.Equal(t, payments.StatusRefunded, payment.Status)
+			assert.Equal(t, currencies.Major(100), payment.RefundedAmount)
+
+			violations, err := db.Ledger().Check(ctx)
+			require.NoError(t, err)
+			assert.Empty(t, violations)
+
+			pledges, err := service.Pledges(ctx, fundraise.ID)
+			require.NoError(t, err)
+			assert.Equal(t, currencies.Zero, pledges[0].Matched)
+		})
+	})
+}
@@ -0,0 +1,71 @@
+package fundraises
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"one-help/app/notifications"
+)
+
+const (
+	// NotificationsInterval defines how often unsent notifications are sent again.
+	NotificationsInterval = 5 * time.Minute
+	// StaleNotificationAge defines age of the pending notification after which its sending is considered interrupted.
+	StaleNotificationAge = 5 * time.Minute
+)
+
+// RunNotifications resends failed and interrupted notifications on schedule until context is canceled.
+func (service *Service) RunNotifications(ctx context.Context) error {
+	ticker := time.NewTicker(NotificationsInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := service.ResendNotifications(ctx); err != nil {
+			service.logger.Error("failed to resend notifications", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// ResendNotifications sends again failed notifications and pending ones left unsent, until they are attempted
+// maximum number of times.
+func (service *Service) ResendNotifications(ctx context.Context) error {
+	unsent, err := service.notifications.ListUnsent(ctx, time.Now().UTC().Add(-StaleNotificationAge))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	var errlist errs.Group
+	for _, notification := range unsent {
+		errlist.Add(service.notify(ctx, notification))
+	}
+
+	return Error.Wrap(errlist.Err())
+}
+
+// notify sends recorded notification and stores whether it was accepted by the sender.
+func (service *Service) notify(ctx context.Context, notification notifications.Notification) error {
+	notification.Attempts++
+	if err := service.notifier.Send(ctx, notification); err != nil {
+		notification.Status = notifications.StatusFailed
+		notification.FailureMessage = err.Error()
+		if notification.Attempts >= notifications.MaxAttempts {
+			service.logger.ErrorF("giving up notification %s after %d attempts", err, notification.ID, notification.Attempts)
+		}
+
+		return Error.Wrap(errs.Combine(err, service.notifications.Update(ctx, notification)))
+	}
+
+	notification.Status = notifications.StatusSent
+	notification.FailureMessage = ""
+	notification.SentAt = time.Now().UTC()
+
+	return Error.Wrap(service.notifications.Update(ctx, notification))
+}
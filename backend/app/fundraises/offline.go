@@ -0,0 +1,168 @@
+package fundraises
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"one-help/app/currencies"
+	"one-help/app/donations"
+	"one-help/app/donations/offline"
+	"one-help/app/payments"
+)
+
+// RecordOfflineDonation records donation received by the organizer outside of payment providers, e.g. in cash.
+// Such donation is confirmed on creation and counts towards fundraise totals, but is flagged as self-reported.
+func (service *Service) RecordOfflineDonation(ctx context.Context, params OfflineDonationParams) (offline.Entry, error) {
+	params.Notes = strings.TrimSpace(params.Notes)
+	params.Message = strings.TrimSpace(params.Message)
+
+	switch {
+	case !payments.IsSelfReported(params.PaymentType):
+		return offline.Entry{}, ParamsError.New("payment type must be one of %s", strings.Join(payments.SelfReportedTypes, ", "))
+	case !params.Amount.IsPositive():
+		return offline.Entry{}, ParamsError.New("amount must be positive")
+	case params.Amount.Cmp(payments.MaxAmount) > 0:
+		return offline.Entry{}, ParamsError.New("amount must not exceed %s", payments.MaxAmount)
+	case params.ReceivedAt.IsZero():
+		return offline.Entry{}, ParamsError.New("received at is required")
+	case params.ReceivedAt.After(time.Now()):
+		return offline.Entry{}, ParamsError.New("received at must not be in the future")
+	case utf8.RuneCountInString(params.Notes) > offline.MaxNotesLength:
+		return offline.Entry{}, ParamsError.New("notes must not exceed %d symbols", offline.MaxNotesLength)
+	}
+
+	if err := validateEvidenceURL(params.EvidenceURL); err != nil {
+		return offline.Entry{}, err
+	}
+
+	if err := validateMessage(params.Message); err != nil {
+		return offline.Entry{}, err
+	}
+
+	fundraise, err := service.fundraises.Get(ctx, params.FundraiseID)
+	if err != nil {
+		return offline.Entry{}, Error.Wrap(err)
+	}
+
+	if fundraise.OrganizerId != params.CallerID {
+		return offline.Entry{}, ErrNotOrganizer
+	}
+
+	if slices.Contains(unreviewedStatuses, fundraise.Status) {
+		return offline.Entry{}, ParamsError.Wrap(ErrNotApproved)
+	}
+
+	if params.Currency == "" {
+		params.Currency = fundraise.Currency
+	}
+	if !currencies.IsSupported(params.Currency) {
+		return offline.Entry{}, ParamsError.New("currency %q is not supported", params.Currency)
+	}
+
+	if params.DonorID != uuid.Nil {
+		if _, err = service.users.Get(ctx, params.DonorID); err != nil {
+			return offline.Entry{}, ParamsError.New("donor does not exist")
+		}
+	}
+
+	rate, err := service.rate(ctx, params.Currency, fundraise.Currency)
+	if err != nil {
+		return offline.Entry{}, Error.Wrap(err)
+	}
+
+	now := time.Now().UTC()
+	donation := donations.Donation{
+		ID:           uuid.New(),
+		UserId:       params.DonorID,
+		FundraiseId:  fundraise.ID,
+		Amount:       params.Amount,
+		Currency:     params.Currency,
+		ExchangeRate: rate.Value,
+		RatedAt:      rate.Date,
+		CreatedAt:    params.ReceivedAt.UTC(),
+		// INFO: Donation of unknown donor is never attributed publicly.
+		Anonymous: params.DonorID == uuid.Nil,
+		Message:   params.Message,
+	}
+	if err = service.donations.Create(ctx, donation); err != nil {
+		return offline.Entry{}, Error.Wrap(err)
+	}
+
+	payment := payments.Payment{
+		DonationId:    donation.ID,
+		PaymentType:   params.PaymentType,
+		TransactionId: donation.ID.String(),
+		Confirmed:     true,
+		Status:        payments.StatusConfirmed,
+		Reference:     params.Reference,
+	}
+	payment.Charge(donation.Amount, donation.Currency, payments.FeeSchedule{}) // INFO: fees of offline donations are unknown.
+
+	if _, err = service.postConfirmation(ctx, donation, fundraise, payment); err != nil {
+		return offline.Entry{}, Error.Wrap(err)
+	}
+
+	if err = service.payments.Create(ctx, payment); err != nil {
+		return offline.Entry{}, Error.Wrap(err)
+	}
+
+	record := offline.Record{
+		DonationID:  donation.ID,
+		RecordedBy:  params.CallerID,
+		EvidenceURL: params.EvidenceURL,
+		Notes:       params.Notes,
+		ReceivedAt:  donation.CreatedAt,
+		CreatedAt:   now,
+	}
+	if err = service.offline.Create(ctx, record); err != nil {
+		return offline.Entry{}, Error.Wrap(err)
+	}
+
+	// NOTE: self-reported donations are not matched by sponsors' pledges, receipt is issued only to the linked donor.
+	if donation.UserId != uuid.Nil {
+		if _, err = service.issueReceipt(ctx, donation, fundraise, payment); err != nil {
+			service.logger.ErrorF("could not issue receipt for donation %s", err, donation.ID)
+		}
+	}
+
+	return offline.Entry{Donation: donation, PaymentType: payment.PaymentType, Reference: payment.Reference, Record: record}, nil
+}
+
+// OfflineDonations returns offline donations of the fundraise with their evidence, allowed only to the organizer.
+func (service *Service) OfflineDonations(ctx context.Context, fundraiseID, callerID uuid.UUID) ([]offline.Entry, error) {
+	fundraise, err := service.fundraises.Get(ctx, fundraiseID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if fundraise.OrganizerId != callerID {
+		return nil, ErrNotOrganizer
+	}
+
+	entries, err := service.offline.ListByFundraise(ctx, fundraise.ID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return entries, nil
+}
+
+// validateEvidenceURL checks that optional evidence link is an absolute http(s) URL.
+func validateEvidenceURL(evidenceURL string) error {
+	if evidenceURL == "" {
+		return nil
+	}
+
+	parsed, err := url.Parse(evidenceURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return ParamsError.New("evidence url must be an absolute http(s) url")
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package fundraises_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app"
+	"one-help/app/currencies"
+	"one-help/app/database"
+	"one-help/app/database/dbtesting"
+	"one-help/app/fundraises"
+	"one-help/app/fundraises/fundraisestesting"
+	notificationfake "one-help/app/notifications/fake"
+	"one-help/app/payments"
+	"one-help/app/payments/fake"
+	"one-help/app/users"
+)
+
+func TestOfflineDonations(t *testing.T) {
+	organizer := users.User{
+		ID:        uuid.New(),
+		FirstName: "John",
+		LastName:  "Doe",
+	}
+	donor := users.User{
+		ID:        uuid.New(),
+		FirstName: "Jane",
+		LastName:  "Doe",
+	}
+
+	provider := fake.NewProvider(payments.TypeStripe)
+
+	dbtesting.Run(t, database.Config{MigrationsPath: "../database/migrations"}, func(ctx context.Context, t *testing.T, db app.DB) {
+		require.NoError(t, db.Users().Create(ctx, organizer))
+		require.NoError(t, db.Users().SetTrusted(ctx, organizer.ID, true))
+		require.NoError(t, db.Users().Create(ctx, donor))
+
+		service := fundraisestesting.NewService(db, payments.NewProviders(provider), notificationfake.NewSender())
+
+		fundraise, err := service.Create(ctx, fundraises.CreateParams{
+			OrganizerId:  organizer.ID,
+			Title:        "Test",
+			Description:  "Test Description",
+			TargetAmount: currencies.Major(1000),
+			Currency:     currencies.UAH,
+		})
+		require.NoError(t, err)
+
+		params := fundraises.OfflineDonationParams{
+			FundraiseID: fundraise.ID,
+			CallerID:    organizer.ID,
+			PaymentType: payments.TypeCash,
+			Amount:      currencies.Major(50),
+			ReceivedAt:  time.Now().Add(-time.Hour),
+			EvidenceURL: "https://example.com/receipt.jpg",
+			Notes:       "collected at the charity fair",
+		}
+
+		t.Run("invalid", func(t *testing.T) {
+			invalid := params
+			invalid.CallerID = donor.ID
+			_, err := service.RecordOfflineDonation(ctx, invalid)
+			require.ErrorIs(t, err, fundraises.ErrNotOrganizer)
+
+			invalid = params
+			invalid.PaymentType = payments.TypeStripe
+			_, err = service.RecordOfflineDonation(ctx, invalid)
+			require.True(t, fundraises.ParamsError.Has(err))
+
+			invalid = params
+			invalid.ReceivedAt = time.Now().Add(time.Hour)
+			_, err = service.RecordOfflineDonation(ctx, invalid)
+			require.True(t, fundraises.ParamsError.Has(err))
+		})
+
+		t.Run("recorded", func(t *testing.T) {
+			entry, err := service.RecordOfflineDonation(ctx, params)
+			require.NoError(t, err)
+			assert.True(t, entry.Donation.Anonymous)
+			assert.Equal(t, uuid.Nil, entry.Donation.UserId)
+
+			filled, err := service.Filled(ctx, fundraise.ID)
+			require.NoError(t, err)
+			assert.Equal(t, currencies.Major(50), filled)
+
+			_, _, err = service.Receipt(ctx, entry.Donation.ID, organizer.ID)
+			require.True(t, fundraises.ParamsError.Has(err))
+
+			list, err := service.OfflineDonations(ctx, fundraise.ID, organizer.ID)
+			require.NoError(t, err)
+			require.Len(t, list, 1)
+			assert.Equal(t, payments.TypeCash, list[0].PaymentType)
+			assert.Equal(t, params.Notes, list[0].Record.Notes)
+
+			_, err = service.OfflineDonations(ctx, fundraise.ID, donor.ID)
+			require.ErrorIs(t, err, fundraises.ErrNotOrganizer)
+		})
+	})
+}
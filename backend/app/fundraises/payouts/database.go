@@ -0,0 +1,43 @@
+package payouts
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+)
+
+var (
+	// ErrNoPayout indicates that payout does not exist.
+	ErrNoPayout = errs.New("payout does not exist")
+	// ErrNoBankDetails indicates that user has not provided bank details.
+	ErrNoBankDetails = errs.New("bank details are not provided")
+	// ErrInsufficientFunds indicates that payout amount exceeds available balance of the fundraise.
+	ErrInsufficientFunds = errs.New("insufficient funds on the fundraise")
+	// ErrStatusChanged indicates that payout status was changed concurrently, e.g. by another administrator.
+	ErrStatusChanged = errs.New("payout status was changed")
+)
+
+// DB exposes access to payouts db.
+//
+// architecture: DB
+type DB interface {
+	// Create inserts payout into the database, ensuring that its amount does not exceed available balance of the fundraise.
+	Create(ctx context.Context, payout Payout) error
+	// Get returns payout by id.
+	Get(ctx context.Context, id uuid.UUID) (Payout, error)
+	// ListByFundraise returns payouts of the fundraise, the newest first.
+	ListByFundraise(ctx context.Context, fundraiseID uuid.UUID) ([]Payout, error)
+	// ListByStatus returns payouts with provided status, the oldest first.
+	ListByStatus(ctx context.Context, status string) ([]Payout, error)
+	// Update updates payout in the database by id only if it still has the previous status, returns ErrStatusChanged otherwise.
+	// Paid payout is posted to the ledger in the same transaction.
+	Update(ctx context.Context, payout Payout, previousStatus string) error
+	// Balance returns balance of the fundraise with regard to its payouts.
+	Balance(ctx context.Context, fundraiseID uuid.UUID) (Balance, error)
+
+	// SaveBankDetails inserts or replaces bank details of the user.
+	SaveBankDetails(ctx context.Context, details BankDetails) error
+	// GetBankDetails returns bank details of the user.
+	GetBankDetails(ctx context.Context, userID uuid.UUID) (BankDetails, error)
+}
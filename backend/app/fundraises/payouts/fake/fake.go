@@ -0,0 +1,73 @@
+// Package fake provides in-memory payout provider, so payouts run locally and in tests without bank integration.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+
+	"one-help/app/fundraises/payouts"
+)
+
+// Error is an error wrapper that notifies that error was produced by fake payout provider.
+var Error = errs.Class("fake payout provider")
+
+// ensures that Provider implements payouts.Provider.
+var _ payouts.Provider = (*Provider)(nil)
+
+// Provider is an in-memory payout provider that records sent payouts.
+type Provider struct {
+	mu      sync.Mutex
+	sent    map[uuid.UUID]payouts.Payout
+	sends   int
+	failure error
+}
+
+// NewProvider is a constructor for fake payout provider.
+func NewProvider() *Provider {
+	return &Provider{
+		sent: make(map[uuid.UUID]payouts.Payout),
+	}
+}
+
+// Send records payout and returns its reference, or fails with the error set by Fail.
+func (p *Provider) Send(ctx context.Context, payout payouts.Payout, details payouts.BankDetails) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.failure != nil {
+		return "", Error.Wrap(p.failure)
+	}
+
+	p.sent[payout.ID] = payout
+	p.sends++
+
+	return "po_" + payout.ID.String(), nil
+}
+
+// Fail makes following payouts fail with provided error, nil error makes them succeed again.
+func (p *Provider) Fail(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.failure = err
+}
+
+// Sent returns sent payout by id.
+func (p *Provider) Sent(id uuid.UUID) (payouts.Payout, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	payout, ok := p.sent[id]
+	return payout, ok
+}
+
+// Sends returns number of payouts sent, including repeated sends of the same payout.
+func (p *Provider) Sends() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.sends
+}
@@ -0,0 +1,147 @@
+package payouts
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+
+	"one-help/app/currencies"
+)
+
+// ErrInvalidIBAN indicates that bank account number is not a valid IBAN.
+var ErrInvalidIBAN = errs.New("invalid IBAN")
+
+const (
+	// StatusPendingApproval defines payout requested by organizer and awaiting administrator's approval.
+	StatusPendingApproval string = "PENDING_APPROVAL"
+	// StatusProcessing defines approved payout sent to the payout provider.
+	StatusProcessing string = "PROCESSING"
+	// StatusPaid defines payout accepted by the payout provider, its amount left the fundraise balance.
+	StatusPaid string = "PAID"
+	// StatusFailed defines payout declined by the payout provider.
+	StatusFailed string = "FAILED"
+	// StatusRejected defines payout rejected by administrator.
+	StatusRejected string = "REJECTED"
+)
+
+// Payout describes withdrawal of collected funds from the fundraise to the organizer's bank account.
+type Payout struct {
+	ID          uuid.UUID
+	FundraiseID uuid.UUID
+	Amount      currencies.Amount // INFO: in the fundraise currency.
+	Currency    string
+	Status      string
+	// Destination is the masked bank account number the payout is sent to.
+	Destination string
+	// IBAN is the bank account number at the moment of request, payout is not sent if bank details were changed since.
+	IBAN        string
+	RequestedBy uuid.UUID
+	ReviewedBy  uuid.UUID // INFO: nil uuid until reviewed.
+	// ReviewReason explains administrator's decision.
+	ReviewReason string
+	// Reference is the payout provider's reference of the transfer.
+	Reference      string
+	FailureMessage string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// IsOpen returns true if payout is not finished yet, its amount is reserved from the available balance.
+func (p *Payout) IsOpen() bool {
+	return p.Status == StatusPendingApproval || p.Status == StatusProcessing
+}
+
+// Balance describes funds of the fundraise with regard to its payouts, in the fundraise currency.
+type Balance struct {
+	// Collected is the total of confirmed donations and transfers, not reduced by payouts.
+	Collected currencies.Amount
+	PaidOut   currencies.Amount
+	// Reserved is the total of open payouts.
+	Reserved  currencies.Amount
+	Available currencies.Amount
+}
+
+// BankDetails describes bank account of the user that payouts are sent to.
+type BankDetails struct {
+	UserID     uuid.UUID
+	HolderName string
+	IBAN       string
+	BankName   string
+	UpdatedAt  time.Time
+}
+
+// NormalizeIBAN removes spaces from IBAN and converts it to upper case.
+func NormalizeIBAN(iban string) string {
+	return strings.ToUpper(strings.ReplaceAll(iban, " ", ""))
+}
+
+// ValidateIBAN returns ErrInvalidIBAN if normalized IBAN has invalid format or check digits.
+func ValidateIBAN(iban string) error {
+	if len(iban) < 15 || len(iban) > 34 {
+		return ErrInvalidIBAN
+	}
+
+	// INFO: country code and check digits are moved to the end, letters are replaced with numbers from 10 to 35.
+	var remainder int
+	for _, r := range iban[4:] + iban[:4] {
+		switch {
+		case r >= '0' && r <= '9':
+			remainder = (remainder*10 + int(r-'0')) % 97
+		case r >= 'A' && r <= 'Z':
+			remainder = (remainder*100 + int(r-'A') + 10) % 97
+		default:
+			return ErrInvalidIBAN
+		}
+	}
+
+	if !isLetter(iban[0]) || !isLetter(iban[1]) || remainder != 1 {
+		return ErrInvalidIBAN
+	}
+
+	return nil
+}
+
+// isLetter returns true if byte is upper case latin letter.
+func isLetter(b byte) bool {
+	return b >= 'A' && b <= 'Z'
+}
+
+// MaskedIBAN returns IBAN with only country code and last four digits visible.
+func (d *BankDetails) MaskedIBAN() string {
+	if len(d.IBAN) < 8 {
+		return strings.Repeat("*", len(d.IBAN))
+	}
+
+	return d.IBAN[:2] + strings.Repeat("*", len(d.IBAN)-6) + d.IBAN[len(d.IBAN)-4:]
+}
+
+// Provider sends payouts to bank accounts.
+type Provider interface {
+	// Send transfers payout amount to the bank account and returns provider's reference of the transfer.
+	Send(ctx context.Context, payout Payout, details BankDetails) (string, error)
+}
+
+// RequestParams defines values needed to request payout of the fundraise funds.
+type RequestParams struct {
+	FundraiseID uuid.UUID
+	CallerID    uuid.UUID
+	Amount      currencies.Amount // INFO: zero requests whole available balance.
+}
+
+// ReviewParams defines values needed to approve or reject payout pending approval.
+type ReviewParams struct {
+	PayoutID uuid.UUID
+	CallerID uuid.UUID
+	Reason   string // INFO: required for rejection.
+}
+
+// BankDetailsParams defines values needed to save user's bank details.
+type BankDetailsParams struct {
+	UserID     uuid.UUID
+	HolderName string
+	IBAN       string
+	BankName   string
+}
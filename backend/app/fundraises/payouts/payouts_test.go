@@ -0,0 +1,24 @@
+package payouts_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app/fundraises/payouts"
+)
+
+func TestBankDetails(t *testing.T) {
+	iban := payouts.NormalizeIBAN("ua21 3223 1300 0002 6007 2335 6600 1")
+	assert.Equal(t, "UA213223130000026007233566001", iban)
+	require.NoError(t, payouts.ValidateIBAN(iban))
+	require.NoError(t, payouts.ValidateIBAN("DE89370400440532013000"))
+
+	require.ErrorIs(t, payouts.ValidateIBAN("UA213223130000026007233566002"), payouts.ErrInvalidIBAN)
+	require.ErrorIs(t, payouts.ValidateIBAN("UA21-3223"), payouts.ErrInvalidIBAN)
+	require.ErrorIs(t, payouts.ValidateIBAN("0021322313000002600723356600"), payouts.ErrInvalidIBAN)
+
+	details := payouts.BankDetails{IBAN: iban}
+	assert.Equal(t, "UA***********************6001", details.MaskedIBAN())
+}
@@ -0,0 +1,293 @@
+package payouts
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+
+	"one-help/app/fundraises"
+	"one-help/app/users"
+	"one-help/internal/logger"
+)
+
+var (
+	// Error wraps errors from payouts service that indicates about internal errors.
+	Error = errs.Class("payouts service")
+	// ParamsError wraps errors from payouts service that indicates about invalid or malformed parameters' data.
+	ParamsError = errs.Class("payouts service: params")
+)
+
+// Service handles payouts related logic.
+//
+// architecture: Service
+type Service struct {
+	logger logger.Logger
+
+	payouts    DB
+	fundraises fundraises.DB
+	users      users.DB
+
+	provider Provider
+}
+
+// NewService is a constructor for payouts service.
+func NewService(logger logger.Logger, payouts DB, fundraises fundraises.DB, users users.DB, provider Provider) *Service {
+	return &Service{
+		logger:     logger,
+		payouts:    payouts,
+		fundraises: fundraises,
+		users:      users,
+		provider:   provider,
+	}
+}
+
+// SaveBankDetails validates and stores bank account of the user that payouts are sent to.
+func (service *Service) SaveBankDetails(ctx context.Context, params BankDetailsParams) (BankDetails, error) {
+	details := BankDetails{
+		UserID:     params.UserID,
+		HolderName: params.HolderName,
+		IBAN:       NormalizeIBAN(params.IBAN),
+		BankName:   params.BankName,
+		UpdatedAt:  time.Now().UTC(),
+	}
+
+	if details.HolderName == "" {
+		return BankDetails{}, ParamsError.New("holder name is required")
+	}
+
+	if err := ValidateIBAN(details.IBAN); err != nil {
+		return BankDetails{}, ParamsError.Wrap(err)
+	}
+
+	if err := service.payouts.SaveBankDetails(ctx, details); err != nil {
+		return BankDetails{}, Error.Wrap(err)
+	}
+
+	return details, nil
+}
+
+// BankDetails returns bank account of the user.
+func (service *Service) BankDetails(ctx context.Context, userID uuid.UUID) (BankDetails, error) {
+	details, err := service.payouts.GetBankDetails(ctx, userID)
+	if err != nil {
+		return BankDetails{}, Error.Wrap(err)
+	}
+
+	return details, nil
+}
+
+// Request requests withdrawal of the fundraise funds to the organizer's bank account, allowed only to the organizer.
+// Requested amount is reserved from the available balance until administrator approves or rejects the payout.
+func (service *Service) Request(ctx context.Context, params RequestParams) (Payout, error) {
+	if params.Amount.Sign() < 0 {
+		return Payout{}, ParamsError.New("amount must be positive")
+	}
+
+	fundraise, err := service.fundraises.Get(ctx, params.FundraiseID)
+	if err != nil {
+		return Payout{}, Error.Wrap(err)
+	}
+
+	if fundraise.OrganizerId != params.CallerID {
+		return Payout{}, ParamsError.Wrap(fundraises.ErrNotOrganizer)
+	}
+
+	if !fundraise.IsApproved() {
+		return Payout{}, ParamsError.New("fundraise is not approved")
+	}
+
+	details, err := service.payouts.GetBankDetails(ctx, params.CallerID)
+	if err != nil {
+		if errors.Is(err, ErrNoBankDetails) {
+			return Payout{}, ParamsError.Wrap(ErrNoBankDetails)
+		}
+
+		return Payout{}, Error.Wrap(err)
+	}
+
+	amount := params.Amount
+	if amount.IsZero() {
+		balance, err := service.payouts.Balance(ctx, fundraise.ID)
+		if err != nil {
+			return Payout{}, Error.Wrap(err)
+		}
+
+		amount = balance.Available
+	}
+	if !amount.IsPositive() {
+		return Payout{}, ParamsError.Wrap(ErrInsufficientFunds)
+	}
+
+	now := time.Now().UTC()
+	payout := Payout{
+		ID:          uuid.New(),
+		FundraiseID: fundraise.ID,
+		Amount:      amount,
+		Currency:    fundraise.Currency,
+		Status:      StatusPendingApproval,
+		Destination: details.MaskedIBAN(),
+		IBAN:        details.IBAN,
+		RequestedBy: params.CallerID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err = service.payouts.Create(ctx, payout); err != nil {
+		if errors.Is(err, ErrInsufficientFunds) {
+			return Payout{}, ParamsError.Wrap(ErrInsufficientFunds)
+		}
+
+		return Payout{}, Error.Wrap(err)
+	}
+
+	return payout, nil
+}
+
+// List returns balance and payouts of the fundraise, allowed to the organizer and administrators.
+func (service *Service) List(ctx context.Context, fundraiseID, callerID uuid.UUID) (Balance, []Payout, error) {
+	fundraise, err := service.fundraises.Get(ctx, fundraiseID)
+	if err != nil {
+		return Balance{}, nil, Error.Wrap(err)
+	}
+
+	if fundraise.OrganizerId != callerID {
+		if err = service.ensureAdmin(ctx, callerID); err != nil {
+			return Balance{}, nil, err
+		}
+	}
+
+	balance, err := service.payouts.Balance(ctx, fundraise.ID)
+	if err != nil {
+		return Balance{}, nil, Error.Wrap(err)
+	}
+
+	list, err := service.payouts.ListByFundraise(ctx, fundraise.ID)
+	if err != nil {
+		return Balance{}, nil, Error.Wrap(err)
+	}
+
+	return balance, list, nil
+}
+
+// Queue returns payouts awaiting approval, the oldest first, allowed only to administrators.
+func (service *Service) Queue(ctx context.Context, callerID uuid.UUID) ([]Payout, error) {
+	if err := service.ensureAdmin(ctx, callerID); err != nil {
+		return nil, err
+	}
+
+	list, err := service.payouts.ListByStatus(ctx, StatusPendingApproval)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return list, nil
+}
+
+// Approve sends payout pending approval to the organizer's bank account through the payout provider.
+// Paid payout leaves the fundraise balance, failed one releases its reserved amount.
+// Payout is marked as processing before it is sent, so that concurrent approvals send it only once.
+func (service *Service) Approve(ctx context.Context, params ReviewParams) (Payout, error) {
+	payout, err := service.pending(ctx, params.PayoutID, params.CallerID)
+	if err != nil {
+		return payout, err
+	}
+
+	details, err := service.payouts.GetBankDetails(ctx, payout.RequestedBy)
+	if err != nil {
+		return payout, Error.Wrap(err)
+	}
+
+	if details.IBAN != payout.IBAN {
+		return payout, ParamsError.New("bank details were changed after the payout was requested")
+	}
+
+	payout.Status = StatusProcessing
+	payout.ReviewedBy = params.CallerID
+	payout.ReviewReason = params.Reason
+	payout.UpdatedAt = time.Now().UTC()
+	if err = service.payouts.Update(ctx, payout, StatusPendingApproval); err != nil {
+		return payout, wrapUpdate(err)
+	}
+
+	reference, err := service.provider.Send(ctx, payout, details)
+	payout.UpdatedAt = time.Now().UTC()
+	if err != nil {
+		payout.Status = StatusFailed
+		payout.FailureMessage = err.Error()
+		return payout, Error.Wrap(errs.Combine(err, service.payouts.Update(ctx, payout, StatusProcessing)))
+	}
+
+	payout.Status = StatusPaid
+	payout.Reference = reference
+	if err = service.payouts.Update(ctx, payout, StatusProcessing); err != nil {
+		return payout, Error.Wrap(err)
+	}
+
+	return payout, nil
+}
+
+// Reject declines payout pending approval, reason is required.
+func (service *Service) Reject(ctx context.Context, params ReviewParams) (Payout, error) {
+	if params.Reason == "" {
+		return Payout{}, ParamsError.New("reason is required")
+	}
+
+	payout, err := service.pending(ctx, params.PayoutID, params.CallerID)
+	if err != nil {
+		return payout, err
+	}
+
+	payout.Status = StatusRejected
+	payout.ReviewedBy = params.CallerID
+	payout.ReviewReason = params.Reason
+	payout.UpdatedAt = time.Now().UTC()
+	if err = service.payouts.Update(ctx, payout, StatusPendingApproval); err != nil {
+		return payout, wrapUpdate(err)
+	}
+
+	return payout, nil
+}
+
+// pending returns payout awaiting administrator's decision.
+func (service *Service) pending(ctx context.Context, payoutID, callerID uuid.UUID) (Payout, error) {
+	if err := service.ensureAdmin(ctx, callerID); err != nil {
+		return Payout{}, err
+	}
+
+	payout, err := service.payouts.Get(ctx, payoutID)
+	if err != nil {
+		return payout, Error.Wrap(err)
+	}
+
+	if payout.Status != StatusPendingApproval {
+		return payout, ParamsError.New("payout is not pending approval")
+	}
+
+	return payout, nil
+}
+
+// ensureAdmin checks that caller is the platform administrator.
+func (service *Service) ensureAdmin(ctx context.Context, callerID uuid.UUID) error {
+	caller, err := service.users.Get(ctx, callerID)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if !caller.IsAdmin() {
+		return ParamsError.Wrap(fundraises.ErrNotAdmin)
+	}
+
+	return nil
+}
+
+// wrapUpdate wraps error of the payout review, payout reviewed concurrently by another administrator is params error.
+func wrapUpdate(err error) error {
+	if errors.Is(err, ErrStatusChanged) {
+		return ParamsError.Wrap(ErrStatusChanged)
+	}
+
+	return Error.Wrap(err)
+}
@@ -0,0 +1,186 @@
+package payouts_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app"
+	"one-help/app/currencies"
+	"one-help/app/database"
+	"one-help/app/database/dbtesting"
+	"one-help/app/fundraises"
+	"one-help/app/fundraises/fundraisestesting"
+	"one-help/app/fundraises/payouts"
+	payoutfake "one-help/app/fundraises/payouts/fake"
+	notificationfake "one-help/app/notifications/fake"
+	"one-help/app/payments"
+	"one-help/app/payments/fake"
+	"one-help/app/users"
+	"one-help/app/users/roles"
+	"one-help/internal/logger/zaplog"
+)
+
+func TestPayouts(t *testing.T) {
+	organizer := users.User{
+		ID:        uuid.New(),
+		FirstName: "John",
+		LastName:  "Doe",
+	}
+	donor := users.User{
+		ID:        uuid.New(),
+		FirstName: "Jane",
+		LastName:  "Doe",
+	}
+	admin := users.User{
+		ID:        uuid.New(),
+		FirstName: "Admin",
+		LastName:  "Doe",
+		Role:      roles.AdminRole,
+	}
+
+	provider := fake.NewProvider(payments.TypeStripe)
+	payoutProvider := payoutfake.NewProvider()
+
+	dbtesting.Run(t, database.Config{MigrationsPath: "../../database/migrations"}, func(ctx context.Context, t *testing.T, db app.DB) {
+		require.NoError(t, db.Users().Create(ctx, organizer))
+		require.NoError(t, db.Users().SetTrusted(ctx, organizer.ID, true))
+		require.NoError(t, db.Users().Create(ctx, donor))
+		require.NoError(t, db.Users().Create(ctx, admin))
+
+		fundraisesService := fundraisestesting.NewService(db, payments.NewProviders(provider), notificationfake.NewSender())
+		service := payouts.NewService(zaplog.NewLog(), db.Payouts(), db.Fundraises(), db.Users(), payoutProvider)
+
+		fundraise, err := fundraisesService.Create(ctx, fundraises.CreateParams{
+			OrganizerId:  organizer.ID,
+			Title:        "Test",
+			Description:  "Test Description",
+			TargetAmount: currencies.Major(1000),
+			Currency:     currencies.UAH,
+		})
+		require.NoError(t, err)
+
+		fundraisestesting.Donate(ctx, t, fundraisesService, provider, fundraises.RegisterDonateParams{
+			FundraiseID: fundraise.ID,
+			UserID:      donor.ID,
+			Amount:      currencies.Major(100),
+		})
+
+		bankDetails := payouts.BankDetailsParams{
+			UserID:     organizer.ID,
+			HolderName: "John Doe",
+			IBAN:       "UA21 3223 1300 0002 6007 2335 6600 1",
+		}
+
+		t.Run("bank details", func(t *testing.T) {
+			_, err := service.Request(ctx, payouts.RequestParams{FundraiseID: fundraise.ID, CallerID: organizer.ID})
+			require.ErrorIs(t, err, payouts.ErrNoBankDetails)
+
+			_, err = service.SaveBankDetails(ctx, payouts.BankDetailsParams{UserID: organizer.ID, HolderName: "John Doe", IBAN: "UA00"})
+			require.True(t, payouts.ParamsError.Has(err))
+		})
+
+		t.Run("request", func(t *testing.T) {
+			details, err := service.SaveBankDetails(ctx, bankDetails)
+			require.NoError(t, err)
+
+			_, err = service.Request(ctx, payouts.RequestParams{FundraiseID: fundraise.ID, CallerID: donor.ID})
+			require.ErrorIs(t, err, fundraises.ErrNotOrganizer)
+
+			payout, err := service.Request(ctx, payouts.RequestParams{FundraiseID: fundraise.ID, CallerID: organizer.ID, Amount: currencies.Major(30)})
+			require.NoError(t, err)
+			assert.Equal(t, payouts.StatusPendingApproval, payout.Status)
+			assert.Equal(t, details.MaskedIBAN(), payout.Destination)
+
+			_, err = service.Request(ctx, payouts.RequestParams{FundraiseID: fundraise.ID, CallerID: organizer.ID, Amount: currencies.Major(71)})
+			require.ErrorIs(t, err, payouts.ErrInsufficientFunds)
+
+			balance, _, err := service.List(ctx, fundraise.ID, organizer.ID)
+			require.NoError(t, err)
+			assert.Equal(t, currencies.Major(30), balance.Reserved)
+			assert.Equal(t, currencies.Major(70), balance.Available)
+
+			_, err = service.Approve(ctx, payouts.ReviewParams{PayoutID: payout.ID, CallerID: organizer.ID})
+			require.ErrorIs(t, err, fundraises.ErrNotAdmin)
+
+			// INFO: account swapped after the request is detected even when its masked number is the same.
+			swapped, err := service.SaveBankDetails(ctx, payouts.BankDetailsParams{
+				UserID:     organizer.ID,
+				HolderName: "John Doe",
+				IBAN:       "UA63 3223 1300 0002 6007 9999 9600 1",
+			})
+			require.NoError(t, err)
+			require.Equal(t, details.MaskedIBAN(), swapped.MaskedIBAN())
+
+			sends := payoutProvider.Sends()
+			_, err = service.Approve(ctx, payouts.ReviewParams{PayoutID: payout.ID, CallerID: admin.ID})
+			require.True(t, payouts.ParamsError.Has(err))
+			assert.Equal(t, sends, payoutProvider.Sends())
+
+			_, err = service.SaveBankDetails(ctx, bankDetails)
+			require.NoError(t, err)
+
+			payoutProvider.Fail(errors.New("bank is unavailable"))
+			payout, err = service.Approve(ctx, payouts.ReviewParams{PayoutID: payout.ID, CallerID: admin.ID})
+			require.Error(t, err)
+			assert.Equal(t, payouts.StatusFailed, payout.Status)
+			payoutProvider.Fail(nil)
+
+			payout, err = service.Request(ctx, payouts.RequestParams{FundraiseID: fundraise.ID, CallerID: organizer.ID})
+			require.NoError(t, err)
+			assert.Equal(t, currencies.Major(100), payout.Amount)
+
+			payout, err = service.Reject(ctx, payouts.ReviewParams{PayoutID: payout.ID, CallerID: admin.ID, Reason: "documents needed"})
+			require.NoError(t, err)
+			assert.Equal(t, payouts.StatusRejected, payout.Status)
+
+			// INFO: failed and rejected payouts release their amounts.
+			balance, list, err := service.List(ctx, fundraise.ID, organizer.ID)
+			require.NoError(t, err)
+			assert.Len(t, list, 2)
+			assert.Equal(t, payouts.Balance{Collected: currencies.Major(100), Available: currencies.Major(100)}, balance)
+		})
+
+		t.Run("paid out", func(t *testing.T) {
+			_, err := service.SaveBankDetails(ctx, bankDetails)
+			require.NoError(t, err)
+
+			payout, err := service.Request(ctx, payouts.RequestParams{FundraiseID: fundraise.ID, CallerID: organizer.ID})
+			require.NoError(t, err)
+
+			// INFO: concurrent approvals send the payout only once.
+			sends := payoutProvider.Sends()
+			approvals := make([]error, 2)
+			var wg sync.WaitGroup
+			for i := range approvals {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, approvals[i] = service.Approve(ctx, payouts.ReviewParams{PayoutID: payout.ID, CallerID: admin.ID})
+				}()
+			}
+			wg.Wait()
+
+			assert.Equal(t, sends+1, payoutProvider.Sends())
+			var approved int
+			for _, err := range approvals {
+				if err == nil {
+					approved++
+					continue
+				}
+				assert.True(t, payouts.ParamsError.Has(err))
+			}
+			assert.Equal(t, 1, approved)
+
+			balance, _, err := service.List(ctx, fundraise.ID, organizer.ID)
+			require.NoError(t, err)
+			assert.Equal(t, payout.Amount, balance.PaidOut)
+			assert.Equal(t, currencies.Zero, balance.Available)
+		})
+	})
+}
@@ -0,0 +1,99 @@
+package fundraises
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+
+	"one-help/app/donations"
+	"one-help/app/donations/receipts"
+	"one-help/app/payments"
+)
+
+// ErrNotDonor indicates that caller is neither the donor nor the organizer of the fundraise.
+var ErrNotDonor = errs.New("caller is not the donor")
+
+// Receipt returns receipt of the confirmed donation with its PDF document, allowed to the donor and the fundraise organizer.
+// Receipt is issued on the first request if it was not issued on confirmation, later requests return the same receipt.
+func (service *Service) Receipt(ctx context.Context, donationID, callerID uuid.UUID) (receipts.Receipt, []byte, error) {
+	donation, err := service.donations.Get(ctx, donationID)
+	if err != nil {
+		return receipts.Receipt{}, nil, Error.Wrap(err)
+	}
+
+	fundraise, err := service.fundraises.Get(ctx, donation.FundraiseId)
+	if err != nil {
+		return receipts.Receipt{}, nil, Error.Wrap(err)
+	}
+
+	if donation.UserId != callerID && fundraise.OrganizerId != callerID {
+		return receipts.Receipt{}, nil, ErrNotDonor
+	}
+
+	receipt, err := service.receipts.GetByDonation(ctx, donation.ID)
+	if err != nil {
+		if !errors.Is(err, receipts.ErrNoReceipt) {
+			return receipts.Receipt{}, nil, Error.Wrap(err)
+		}
+
+		payment, err := service.payments.Get(ctx, donation.ID)
+		if err != nil {
+			return receipts.Receipt{}, nil, Error.Wrap(err)
+		}
+
+		if !payment.Confirmed {
+			return receipts.Receipt{}, nil, ParamsError.New("receipt is issued only for confirmed donation")
+		}
+
+		if donation.UserId == uuid.Nil {
+			return receipts.Receipt{}, nil, ParamsError.New("receipt is issued only for donation with linked donor")
+		}
+
+		receipt, err = service.issueReceipt(ctx, donation, fundraise, payment)
+		if err != nil {
+			return receipts.Receipt{}, nil, err
+		}
+	}
+
+	document, err := receipts.Render(receipt)
+	if err != nil {
+		return receipts.Receipt{}, nil, Error.Wrap(err)
+	}
+
+	return receipt, document, nil
+}
+
+// issueReceipt assigns the next organizer's receipt number to the confirmed donation, receipt is issued only once.
+func (service *Service) issueReceipt(ctx context.Context, donation donations.Donation, fundraise Fundraise, payment payments.Payment) (receipts.Receipt, error) {
+	organizer, err := service.users.Get(ctx, fundraise.OrganizerId)
+	if err != nil {
+		return receipts.Receipt{}, Error.Wrap(err)
+	}
+
+	donor, err := service.users.Get(ctx, donation.UserId)
+	if err != nil {
+		return receipts.Receipt{}, Error.Wrap(err)
+	}
+
+	receipt, err := service.receipts.Create(ctx, receipts.Receipt{
+		ID:               uuid.New(),
+		DonationID:       donation.ID,
+		OrganizerID:      organizer.ID,
+		OrganizerName:    organizer.FullName(),
+		OrganizerWebsite: organizer.Website,
+		FundraiseID:      fundraise.ID,
+		FundraiseTitle:   fundraise.Title,
+		DonorName:        donor.FullName(),
+		Amount:           donation.Amount,
+		Currency:         donation.Currency,
+		PaymentType:      payment.PaymentType,
+		TransactionID:    payment.TransactionId,
+		DonatedAt:        donation.CreatedAt,
+		IssuedAt:         time.Now().UTC(),
+	})
+
+	return receipt, Error.Wrap(err)
+}
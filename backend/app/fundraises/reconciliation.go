@@ -0,0 +1,202 @@
+package fundraises
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+
+	"one-help/app/payments"
+	"one-help/app/payments/reconciliation"
+)
+
+const (
+	// ReconciliationInterval defines how often stale pending payments are checked with providers.
+	ReconciliationInterval = 15 * time.Minute
+	// StalePaymentAge defines age of the pending payment after which its webhook is considered missed.
+	StalePaymentAge = time.Hour
+	// AbandonedPaymentAge defines age of the pending payment after which checkout session unknown to provider is considered abandoned.
+	AbandonedPaymentAge = 24 * time.Hour
+)
+
+// RunReconciliation settles stale pending payments on schedule and reports previous day reconciliation
+// once a day, until context is canceled.
+func (service *Service) RunReconciliation(ctx context.Context) error {
+	ticker := time.NewTicker(ReconciliationInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := service.ReconcilePayments(ctx); err != nil {
+			service.logger.Error("failed to reconcile pending payments", err)
+		}
+
+		yesterday := time.Now().UTC().AddDate(0, 0, -1)
+		reported, err := service.reconciliation.IsReported(ctx, day(yesterday))
+		if err != nil {
+			service.logger.Error("failed to check reconciliation of the day", err)
+		} else if !reported {
+			if _, err = service.ReportReconciliation(ctx, yesterday); err != nil {
+				service.logger.Error("failed to report reconciliation", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// ReconcilePayments queries providers for pending payments with missed webhooks, confirms the paid ones and
+// cancels expired checkouts and abandoned ones the provider does not know, so abandoned donations stop waiting for confirmation.
+// Other errors of the provider leave payments pending until the next run.
+// Recent confirmed donations not matched on confirmation are matched again.
+func (service *Service) ReconcilePayments(ctx context.Context) error {
+	now := time.Now().UTC()
+
+	pending, err := service.payments.ListPending(ctx, now.Add(-StalePaymentAge))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	var errlist errs.Group
+	for _, payment := range pending {
+		errlist.Add(service.reconcilePayment(ctx, payment, now))
+	}
+
+	errlist.Add(service.retryMatches(ctx, now.Add(-MatchRetryAge)))
+
+	return Error.Wrap(errlist.Err())
+}
+
+// reconcilePayment settles single pending payment by provider's session status.
+func (service *Service) reconcilePayment(ctx context.Context, payment payments.Payment, now time.Time) error {
+	donation, err := service.donations.Get(ctx, payment.DonationId)
+	if err != nil {
+		return err
+	}
+	abandoned := donation.CreatedAt.Before(now.Add(-AbandonedPaymentAge))
+
+	provider, err := service.providers.Get(payment.PaymentType)
+	if err != nil {
+		return err
+	}
+
+	status, err := provider.Status(ctx, payment.TransactionId)
+	if err != nil {
+		if !abandoned || !errors.Is(err, payments.ErrSessionNotFound) {
+			return err
+		}
+
+		// INFO: session unknown to provider is never paid.
+		status = payments.SessionStatus{Expired: true}
+	}
+
+	switch {
+	case status.Paid:
+		service.logger.InfoF("confirming payment of donation %s with missed webhook", donation.ID)
+		return service.completeCheckout(ctx, payment.PaymentType, payments.Event{
+			TransactionID:    payment.TransactionId,
+			PaymentReference: status.Reference,
+			Paid:             true,
+			Amount:           status.Amount,
+		})
+	case status.Expired:
+		service.logger.InfoF("canceling expired payment of donation %s", donation.ID)
+		payment.Status = payments.StatusCanceled
+		return service.payments.Update(ctx, payment)
+	default:
+		return nil
+	}
+}
+
+// ReportReconciliation compares totals of confirmed donations created within the UTC day with amounts
+// collected by each provider, stores the reports and marks the day reported. Mismatches are logged.
+func (service *Service) ReportReconciliation(ctx context.Context, date time.Time) ([]reconciliation.Report, error) {
+	from := day(date)
+	to := from.AddDate(0, 0, 1)
+	now := time.Now().UTC()
+
+	confirmed, err := service.reconciliation.Confirmed(ctx, from, to)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	var reports []reconciliation.Report
+	for _, paymentType := range service.providers.Types() {
+		totals := make(map[string]*reconciliation.Report)
+		for _, report := range confirmed {
+			if report.PaymentType == paymentType {
+				totals[report.Currency] = &report
+			}
+		}
+
+		provider, err := service.providers.Balances(paymentType)
+		if err != nil {
+			if errors.Is(err, payments.ErrBalancesUnsupported) {
+				service.logger.DebugF("%s does not report balances, skipping reconciliation", paymentType)
+				continue
+			}
+
+			return nil, Error.Wrap(err)
+		}
+
+		collected, err := provider.Collected(ctx, from, to)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+
+		for currency, amount := range collected {
+			report, ok := totals[currency]
+			if !ok {
+				report = &reconciliation.Report{Date: from, PaymentType: paymentType, Currency: currency}
+				totals[currency] = report
+			}
+
+			report.Collected = amount
+		}
+
+		for _, report := range totals {
+			report.CreatedAt = now
+			if err = service.reconciliation.Save(ctx, *report); err != nil {
+				return nil, Error.Wrap(err)
+			}
+
+			if !report.IsBalanced() {
+				service.logger.WarnF("%s %s totals of %s differ: confirmed %s, collected %s",
+					paymentType, report.Currency, from.Format(time.DateOnly), report.Confirmed, report.Collected)
+			}
+
+			reports = append(reports, *report)
+		}
+	}
+
+	// INFO: day without any totals is recorded as well, so it is not reported again.
+	if err = service.reconciliation.MarkReported(ctx, from, now); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return reports, nil
+}
+
+// ReconciliationReports returns stored reconciliation reports of the UTC day, allowed only to administrators.
+func (service *Service) ReconciliationReports(ctx context.Context, date time.Time, callerID uuid.UUID) ([]reconciliation.Report, error) {
+	if err := service.ensureAdmin(ctx, callerID); err != nil {
+		return nil, err
+	}
+
+	reports, err := service.reconciliation.List(ctx, day(date))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return reports, nil
+}
+
+// day returns start of the UTC day of the time.
+func day(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
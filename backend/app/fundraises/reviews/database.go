@@ -0,0 +1,24 @@
+package reviews
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+)
+
+var (
+	// ErrStatusChanged indicates that fundraise status has been changed concurrently.
+	ErrStatusChanged = errs.New("fundraise status has been changed")
+)
+
+// DB exposes access to fundraise reviews db.
+//
+// architecture: DB
+type DB interface {
+	// Create records review and moves fundraise from fromStatus to toStatus in the same transaction.
+	// Status is left untouched if toStatus is empty.
+	Create(ctx context.Context, review Review, fromStatus, toStatus string) error
+	// List returns reviews of the fundraise ordered from the oldest to the newest.
+	List(ctx context.Context, fundraiseID uuid.UUID) ([]Review, error)
+}
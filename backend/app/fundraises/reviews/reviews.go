@@ -0,0 +1,28 @@
+package reviews
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DecisionSubmitted records fundraise submission for review.
+	DecisionSubmitted string = "SUBMITTED"
+	// DecisionResubmitted records fundraise resubmission after rejection.
+	DecisionResubmitted string = "RESUBMITTED"
+	// DecisionApproved records fundraise approval, by moderator or automatically for trusted organizers.
+	DecisionApproved string = "APPROVED"
+	// DecisionRejected records fundraise rejection by moderator.
+	DecisionRejected string = "REJECTED"
+)
+
+// Review describes a single moderation decision on the fundraise.
+type Review struct {
+	ID          uuid.UUID
+	FundraiseID uuid.UUID
+	ActorID     uuid.UUID // INFO: organizer for submissions, moderator for decisions.
+	Decision    string
+	Reason      string
+	CreatedAt   time.Time
+}
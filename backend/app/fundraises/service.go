@@ -2,16 +2,33 @@ package fundraises
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"github.com/zeebo/errs"
 
+	"one-help/app/currencies"
 	"one-help/app/donations"
+	"one-help/app/donations/offline"
+	"one-help/app/donations/receipts"
+	"one-help/app/donations/tributes"
+	"one-help/app/fundraises/analytics"
+	"one-help/app/fundraises/leaderboards"
+	"one-help/app/fundraises/matching"
+	"one-help/app/fundraises/reviews"
 	"one-help/app/fundraises/statuses"
+	"one-help/app/fundraises/transfers"
+	"one-help/app/ledger"
+	"one-help/app/notifications"
 	"one-help/app/payments"
-	"one-help/app/stripe"
+	"one-help/app/payments/reconciliation"
+	"one-help/app/users"
 	"one-help/internal/logger"
+	"one-help/internal/profanity"
 )
 
 var (
@@ -19,29 +36,93 @@ var (
 	Error = errs.Class("fundraises service")
 	// ParamsError wraps errors from fundraises service that indicates about invalid or malformed parameters' data.
 	ParamsError = errs.Class("fundraises service: params")
+	// ErrNotOrganizer indicates that caller is not the organizer of the fundraise.
+	ErrNotOrganizer = errs.New("caller is not the fundraise organizer")
+	// ErrNotModerator indicates that caller is not allowed to review fundraises.
+	ErrNotModerator = errs.New("caller is not a moderator")
+	// ErrNotAdmin indicates that caller is not the platform administrator.
+	ErrNotAdmin = errs.New("caller is not an administrator")
+	// ErrAmountMismatch indicates that charged amount differs from the amount chosen by the donor.
+	ErrAmountMismatch = errs.New("charged amount does not match requested amount")
+	// ErrNotApproved indicates that fundraise has not passed moderation review and can not accept donations.
+	ErrNotApproved = errs.New("fundraise is not approved by moderators")
 )
 
+// MaxPresets limits number of suggested donation amounts of the fundraise.
+const MaxPresets = 6
+
+// MaxSupporters limits number of recent supporters shown publicly.
+const MaxSupporters = 50
+
+// unreviewedStatuses are statuses of fundraises hidden from the public and closed for donations.
+var unreviewedStatuses = []string{statuses.PendingReviewStatus, statuses.RejectedStatus}
+
 // Service handles fundraises related logic.
 //
 // architecture: Service
 type Service struct {
 	logger logger.Logger
 
-	fundraises DB
-	donations  donations.DB
-	payments   payments.DB
+	fundraises     DB
+	donations      donations.DB
+	receipts       receipts.DB
+	payments       payments.DB
+	reconciliation reconciliation.DB
+	transfers      transfers.DB
+	analytics      analytics.DB
+	reviews        reviews.DB
+	matching       matching.DB
+	ledger         ledger.DB
+	offline        offline.DB
+	leaderboards   leaderboards.DB
+	notifications  notifications.DB
+	users          users.DB
 
-	charger *stripe.Charger
+	providers *payments.Providers
+	notifier  notifications.Sender
+	rates     currencies.RateProvider
 }
 
 // NewService is a constructor for fundraises service.
-func NewService(logger logger.Logger, fundraises DB, donations donations.DB, payments payments.DB, charger *stripe.Charger) *Service {
+func NewService(
+	logger logger.Logger,
+	fundraises DB,
+	donations donations.DB,
+	receipts receipts.DB,
+	payments payments.DB,
+	reconciliation reconciliation.DB,
+	transfers transfers.DB,
+	analytics analytics.DB,
+	reviews reviews.DB,
+	matching matching.DB,
+	ledger ledger.DB,
+	offline offline.DB,
+	leaderboards leaderboards.DB,
+	notifications notifications.DB,
+	users users.DB,
+	providers *payments.Providers,
+	notifier notifications.Sender,
+	rates currencies.RateProvider,
+) *Service {
 	return &Service{
-		logger:     logger,
-		fundraises: fundraises,
-		donations:  donations,
-		payments:   payments,
-		charger:    charger,
+		logger:         logger,
+		fundraises:     fundraises,
+		donations:      donations,
+		receipts:       receipts,
+		payments:       payments,
+		reconciliation: reconciliation,
+		transfers:      transfers,
+		analytics:      analytics,
+		reviews:        reviews,
+		matching:       matching,
+		ledger:         ledger,
+		offline:        offline,
+		leaderboards:   leaderboards,
+		notifications:  notifications,
+		users:          users,
+		providers:      providers,
+		notifier:       notifier,
+		rates:          rates,
 	}
 }
 
@@ -52,27 +133,72 @@ func (service *Service) Create(ctx context.Context, params CreateParams) (*Fundr
 		return nil, ParamsError.New("title is required")
 	case params.Description == "":
 		return nil, ParamsError.New("description is required")
-	case params.TargetAmount <= 0.:
+	case !params.TargetAmount.IsPositive():
 		return nil, ParamsError.New("target amount must be positive")
 	}
 
+	if params.Currency == "" {
+		params.Currency = currencies.Default
+	}
+	if !currencies.IsSupported(params.Currency) {
+		return nil, ParamsError.New("currency %q is not supported", params.Currency)
+	}
+
+	switch {
+	case params.MinDonation.Sign() < 0:
+		return nil, ParamsError.New("min donation must not be negative")
+	case params.MinDonation.Cmp(payments.MaxAmount) > 0:
+		return nil, ParamsError.New("min donation must not exceed %s", payments.MaxAmount)
+	case len(params.Presets) > MaxPresets:
+		return nil, ParamsError.New("at most %d donation presets are allowed", MaxPresets)
+	}
+
+	presets := slices.Clone(params.Presets)
+	slices.SortFunc(presets, currencies.Amount.Cmp)
+	presets = slices.Compact(presets)
+	for _, preset := range presets {
+		if !preset.IsPositive() || preset.Cmp(params.MinDonation) < 0 || preset.Cmp(payments.MaxAmount) > 0 {
+			return nil, ParamsError.New("donation preset %s must be between min donation and %s", preset, payments.MaxAmount)
+		}
+	}
+
 	fundraise := &Fundraise{
 		ID:           uuid.New(),
 		OrganizerId:  params.OrganizerId,
 		Title:        params.Title,
 		Description:  params.Description,
 		TargetAmount: params.TargetAmount,
+		Currency:     params.Currency,
+		MinDonation:  params.MinDonation,
+		Presets:      presets,
 		StartDate:    time.Now().UTC(),
 		EndDate:      params.EndDate,
-		Status:       statuses.ActiveStatus,
+		Status:       statuses.PendingReviewStatus,
 		ImageUrl:     params.ImageUrl,
 	}
 
-	err := service.fundraises.Create(ctx, *fundraise)
+	organizer, err := service.users.Get(ctx, params.OrganizerId)
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
 
+	review := reviews.Review{
+		ID:          uuid.New(),
+		FundraiseID: fundraise.ID,
+		ActorID:     organizer.ID,
+		Decision:    reviews.DecisionSubmitted,
+		CreatedAt:   fundraise.StartDate,
+	}
+	if organizer.SkipsReview() {
+		fundraise.Status = statuses.ActiveStatus
+		review.Decision = reviews.DecisionApproved
+		review.Reason = "trusted organizer, review skipped"
+	}
+
+	if err = service.fundraises.CreateWithReview(ctx, *fundraise, review); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
 	return fundraise, nil
 }
 
@@ -86,6 +212,32 @@ func (service *Service) Get(ctx context.Context, id uuid.UUID) (*Fundraise, erro
 	return &fundraise, nil
 }
 
+// GetVisible returns Fundraise by id if it is visible to the caller, nil caller id defines anonymous caller.
+// Fundraises under review are visible only to their organizers and moderators, others get ErrNoFundraise.
+func (service *Service) GetVisible(ctx context.Context, id, callerID uuid.UUID) (*Fundraise, error) {
+	fundraise, err := service.fundraises.Get(ctx, id)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if !slices.Contains(unreviewedStatuses, fundraise.Status) || fundraise.OrganizerId == callerID {
+		return &fundraise, nil
+	}
+
+	if callerID != uuid.Nil {
+		caller, err := service.users.Get(ctx, callerID)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+
+		if caller.IsModerator() {
+			return &fundraise, nil
+		}
+	}
+
+	return nil, Error.Wrap(ErrNoFundraise)
+}
+
 // List returns list of fundraises.
 func (service *Service) List(ctx context.Context, limit, page int, creatorID *uuid.UUID) ([]Fundraise, error) {
 	switch {
@@ -95,11 +247,16 @@ func (service *Service) List(ctx context.Context, limit, page int, creatorID *uu
 		return nil, ParamsError.New("page must be positive")
 	}
 
-	list, err := service.fundraises.List(ctx, ListParams{
+	params := ListParams{
 		OrganizerID: creatorID,
 		Limit:       limit,
 		Page:        page,
-	})
+	}
+	if creatorID == nil { // INFO: fundraises under review are visible only to their organizers and moderators.
+		params.ExcludeStatuses = unreviewedStatuses
+	}
+
+	list, err := service.fundraises.List(ctx, params)
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
@@ -108,10 +265,10 @@ func (service *Service) List(ctx context.Context, limit, page int, creatorID *uu
 }
 
 // Filled returns funded amount on fundraise by id.
-func (service *Service) Filled(ctx context.Context, id uuid.UUID) (float64, error) {
+func (service *Service) Filled(ctx context.Context, id uuid.UUID) (currencies.Amount, error) {
 	filled, err := service.fundraises.GetFilled(ctx, id)
 	if err != nil {
-		return 0, Error.Wrap(err)
+		return currencies.Zero, Error.Wrap(err)
 	}
 
 	return filled, nil
@@ -119,37 +276,144 @@ func (service *Service) Filled(ctx context.Context, id uuid.UUID) (float64, erro
 
 // RegisterDonate register new donate values, provides payment url.
 func (service *Service) RegisterDonate(ctx context.Context, params RegisterDonateParams) (result RegisterDonateResult, err error) {
+	fundraise, err := service.fundraises.Get(ctx, params.FundraiseID)
+	if err != nil {
+		return result, Error.Wrap(err)
+	}
+
+	if slices.Contains(unreviewedStatuses, fundraise.Status) {
+		return result, ParamsError.Wrap(ErrNotApproved)
+	}
+
+	if params.Currency == "" {
+		params.Currency = fundraise.Currency
+	}
+	if !currencies.IsSupported(params.Currency) {
+		return result, ParamsError.New("currency %q is not supported", params.Currency)
+	}
+
+	if err = service.ValidateAmount(ctx, fundraise, params.Amount, params.Currency); err != nil {
+		return result, err
+	}
+
+	params.Message = strings.TrimSpace(params.Message)
+	if err = validateMessage(params.Message); err != nil {
+		return result, err
+	}
+
+	if params.TeamCode, err = leaderboards.ParseTeamCode(params.TeamCode); err != nil {
+		return result, ParamsError.Wrap(err)
+	}
+
+	if params.Tribute, err = tributes.Parse(params.Tribute); err != nil {
+		return result, ParamsError.Wrap(err)
+	}
+
+	if params.PaymentType == "" {
+		params.PaymentType = payments.TypeStripe
+	}
+	provider, err := service.providers.Get(params.PaymentType)
+	if err != nil {
+		return result, ParamsError.Wrap(err)
+	}
+
+	charge := params.Amount
+	if params.CoverFee {
+		if params.Amount.IsZero() {
+			return result, ParamsError.New("amount is required to cover the fee")
+		}
+
+		charge = service.providers.Fees(provider.Type()).Gross(params.Amount, params.Currency)
+		if charge.Cmp(payments.MaxAmount) > 0 {
+			return result, ParamsError.New("amount with the fee must not exceed %s", payments.MaxAmount)
+		}
+	}
+
 	donation := donations.Donation{
-		ID:          uuid.New(),
-		UserId:      params.UserID,
-		FundraiseId: params.FundraiseID,
-		Amount:      0,
-		CreatedAt:   time.Now().UTC(),
+		ID:              uuid.New(),
+		UserId:          params.UserID,
+		FundraiseId:     params.FundraiseID,
+		Amount:          currencies.Zero,
+		Currency:        params.Currency,
+		ExchangeRate:    1,
+		CreatedAt:       time.Now().UTC(),
+		RequestedAmount: params.Amount,
+		Anonymous:       params.Anonymous,
+		Message:         params.Message,
+		TeamCode:        params.TeamCode,
+		Tribute:         params.Tribute,
 	}
 	err = service.donations.Create(ctx, donation)
 	if err != nil {
 		return result, Error.Wrap(err)
 	}
 
-	var paymentID string
-	result.PaymentURL, paymentID, err = service.charger.SetupChargeSessionURL("/fundraises/donations/" + donation.ID.String())
+	session, err := provider.CreateSession(ctx, payments.SessionParams{
+		Reference:    donation.ID.String(),
+		RedirectPath: "/fundraises/donations/" + donation.ID.String(),
+		Amount:       currencies.NewMoney(charge, donation.Currency),
+		Description:  fundraise.Title,
+	})
 	if err != nil {
+		if errors.Is(err, payments.ErrUnsupportedCurrency) || errors.Is(err, payments.ErrAmountRequired) {
+			return result, ParamsError.Wrap(errs.Combine(service.donations.Delete(ctx, donation.ID), err))
+		}
+
 		return result, Error.Wrap(err)
 	}
 
-	err = service.payments.Create(ctx, payments.Payment{
+	result.PaymentURL = session.URL
+	payment := payments.Payment{
 		DonationId:    donation.ID,
-		PaymentType:   payments.TypeStripe,
-		TransactionId: paymentID,
+		PaymentType:   provider.Type(),
+		TransactionId: session.TransactionID,
 		Confirmed:     false,
-	})
-	if err != nil {
+		Status:        payments.StatusPending,
+		CoversFee:     params.CoverFee,
+	}
+	if params.CoverFee { // INFO: expected charge, amounts are recorded again on confirmation.
+		payment.GrossAmount = charge
+		payment.FeeAmount = charge.Sub(params.Amount)
+		payment.NetAmount = params.Amount
+	}
+	if err = service.payments.Create(ctx, payment); err != nil {
 		return result, Error.Wrap(err)
 	}
 
+	result.Amount = charge
+	result.Fee = payment.FeeAmount
+
 	return result, nil
 }
 
+// RecentSupporters returns the latest confirmed donations of the fundraise with identities of anonymous and private
+// donors hidden.
+func (service *Service) RecentSupporters(ctx context.Context, fundraiseID uuid.UUID, limit int) ([]donations.Supporter, error) {
+	fundraise, err := service.fundraises.Get(ctx, fundraiseID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if slices.Contains(unreviewedStatuses, fundraise.Status) {
+		return nil, Error.Wrap(ErrNoFundraise)
+	}
+
+	if limit <= 0 || limit > MaxSupporters {
+		limit = MaxSupporters
+	}
+
+	supporters, err := service.donations.ListSupporters(ctx, fundraise.ID, limit)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	for i := range supporters {
+		supporters[i].Hide()
+	}
+
+	return supporters, nil
+}
+
 // GetDonation returns donation by id.
 func (service *Service) GetDonation(ctx context.Context, donationID uuid.UUID) (donation donations.Donation, err error) {
 	donation, err = service.donations.Get(ctx, donationID)
@@ -160,39 +424,715 @@ func (service *Service) GetDonation(ctx context.Context, donationID uuid.UUID) (
 	return donation, nil
 }
 
-// ConfirmDonation finishes donation processes.
-func (service *Service) ConfirmDonation(ctx context.Context, donation donations.Donation) error {
-	payment, err := service.payments.Get(ctx, donation.ID)
+// DonationStatus returns payment status of the donation, used on donor's return from checkout.
+// Status is changed only by verified provider webhooks, so it may still be pending on return.
+func (service *Service) DonationStatus(ctx context.Context, donationID uuid.UUID) (string, error) {
+	payment, err := service.payments.Get(ctx, donationID)
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+
+	return payment.Status, nil
+}
+
+// HandleEvent applies checkout event of the payment provider to the donation payment, events of other kinds are ignored.
+func (service *Service) HandleEvent(ctx context.Context, paymentType string, event payments.Event) error {
+	switch event.Kind {
+	case payments.EventCompleted:
+		return service.completeCheckout(ctx, paymentType, event)
+	case payments.EventExpired:
+		return service.failPayment(ctx, paymentType, event, payments.StatusCanceled)
+	case payments.EventFailed:
+		return service.failPayment(ctx, paymentType, event, payments.StatusFailed)
+	case payments.EventRefunded:
+		return service.refundPayment(ctx, paymentType, event)
+	default:
+		return nil
+	}
+}
+
+// completeCheckout confirms pending payment of the paid checkout session.
+// Sessions paid with delayed methods are confirmed on a later event.
+func (service *Service) completeCheckout(ctx context.Context, paymentType string, event payments.Event) error {
+	payment, err := service.payments.GetByTransaction(ctx, paymentType, event.TransactionID)
 	if err != nil {
 		return Error.Wrap(err)
 	}
 
-	paid, funded, err := service.charger.GetSessionPaymentData(payment.TransactionId)
+	if payment.IsFinal() {
+		return nil
+	}
+
+	payment.Reference = event.PaymentReference
+	if !event.Paid {
+		service.logger.WarnF("received unpaid session: %s, for donation: %s", event.TransactionID, payment.DonationId)
+		return Error.Wrap(service.payments.Update(ctx, payment))
+	}
+
+	donation, err := service.donations.Get(ctx, payment.DonationId)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if donation.RequestedAmount.IsPositive() {
+		expected := currencies.NewMoney(donation.RequestedAmount, donation.Currency)
+		if payment.CoversFee { // INFO: donor was charged the requested amount with the estimated fee on top.
+			expected.Amount = payment.GrossAmount
+		}
+		charged := event.Amount
+		if charged.Currency == "" { // INFO: event without currency is charged in the currency of the session.
+			charged.Currency = donation.Currency
+		}
+		if !charged.Equal(expected) {
+			service.logger.ErrorF("session %s charged %s instead of %s for donation %s", ErrAmountMismatch,
+				event.TransactionID, charged, expected, donation.ID)
+			payment.Status = payments.StatusFailed
+			return Error.Wrap(service.payments.Update(ctx, payment))
+		}
+	}
+
+	if event.Amount.Currency != "" {
+		donation.Currency = event.Amount.Currency
+	}
+
+	fundraise, err := service.fundraises.Get(ctx, donation.FundraiseId)
 	if err != nil {
 		return Error.Wrap(err)
 	}
 
-	if !paid {
-		service.logger.WarnF("receiver unpaid session: %s, for donation: %s in ConfirmDonation", payment.TransactionId, donation.ID.String())
+	// INFO: Rate snapshot is taken at the moment of the confirmation and never changes afterwards.
+	rate, err := service.rate(ctx, donation.Currency, fundraise.Currency)
+	if err != nil {
+		return Error.Wrap(err)
 	}
 
-	donation.Amount = funded
+	donation.Amount = event.Amount.Amount
+	donation.ExchangeRate = rate.Value
+	donation.RatedAt = rate.Date
 	payment.Confirmed = true
+	payment.Status = payments.StatusConfirmed
+	payment.Charge(donation.Amount, donation.Currency, service.providers.Fees(paymentType))
 
 	err = service.donations.Update(ctx, donation)
 	if err != nil {
 		return Error.Wrap(err)
 	}
 
-	err = service.payments.Update(ctx, payment)
+	// INFO: funds are posted before the payment becomes final, so that retried confirmation posts them if it failed.
+	if _, err = service.postConfirmation(ctx, donation, fundraise, payment); err != nil {
+		return Error.Wrap(err)
+	}
+
+	if err = service.payments.Update(ctx, payment); err != nil {
+		return Error.Wrap(err)
+	}
+
+	service.donationConfirmed(ctx, donation, fundraise, payment)
+
+	return nil
+}
+
+// donationConfirmed issues receipt of the confirmed donation, applies matching pledges to it, counts it on leaderboards
+// and sends dedication card to the tribute recipient.
+// NOTE: failures do not affect the confirmation, receipt is issued later on the first download and matching is retried
+// by reconciliation.
+func (service *Service) donationConfirmed(ctx context.Context, donation donations.Donation, fundraise Fundraise, payment payments.Payment) {
+	if _, err := service.issueReceipt(ctx, donation, fundraise, payment); err != nil {
+		service.logger.ErrorF("could not issue receipt for donation %s", err, donation.ID)
+	}
+
+	if err := service.matchDonation(ctx, donation); err != nil {
+		service.logger.ErrorF("could not match donation %s", err, donation.ID)
+	}
+
+	if err := service.countDonation(ctx, donation); err != nil {
+		service.logger.ErrorF("could not count donation %s on leaderboards", err, donation.ID)
+	}
+
+	if err := service.sendTributeCard(ctx, donation, fundraise); err != nil {
+		service.logger.ErrorF("could not send dedication card of donation %s", err, donation.ID)
+	}
+}
+
+// RecordCharge records recurring charge of the plan as a confirmed donation to the fundraise.
+// Donation is created together with its payment, which identifies the charge by provider's transaction id, so repeated
+// charge is recorded once and only completes posting to the ledger if it failed before.
+func (service *Service) RecordCharge(ctx context.Context, params ChargeParams) error {
+	payment, err := service.payments.GetByTransaction(ctx, params.PaymentType, params.TransactionID)
+	switch {
+	case err == nil:
+		donation, err := service.donations.Get(ctx, payment.DonationId)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+
+		return service.confirmCharge(ctx, donation, params.Fundraise, payment)
+	case !errors.Is(err, payments.ErrNoPayment):
+		return Error.Wrap(err)
+	}
+
+	// INFO: Rate snapshot is taken at the moment of the charge and never changes afterwards.
+	rate, err := service.rate(ctx, params.Amount.Currency, params.Fundraise.Currency)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	donation := donations.Donation{
+		ID:              uuid.New(),
+		UserId:          params.UserID,
+		FundraiseId:     params.Fundraise.ID,
+		Amount:          params.Amount.Amount,
+		Currency:        params.Amount.Currency,
+		ExchangeRate:    rate.Value,
+		RatedAt:         rate.Date,
+		CreatedAt:       time.Now().UTC(),
+		RequestedAmount: params.RequestedAmount,
+		PlanID:          params.PlanID,
+	}
+
+	payment = payments.Payment{
+		DonationId:    donation.ID,
+		PaymentType:   params.PaymentType,
+		TransactionId: params.TransactionID,
+		Confirmed:     true,
+		Status:        payments.StatusConfirmed,
+		Reference:     params.Reference,
+	}
+	payment.Charge(donation.Amount, donation.Currency, service.providers.Fees(params.PaymentType))
+
+	if err = service.donations.CreateWithPayment(ctx, donation, payment); err != nil {
+		return Error.Wrap(err)
+	}
+
+	return service.confirmCharge(ctx, donation, params.Fundraise, payment)
+}
+
+// confirmCharge posts recorded charge to the ledger, confirmation side effects follow only the first posting.
+func (service *Service) confirmCharge(ctx context.Context, donation donations.Donation, fundraise Fundraise, payment payments.Payment) error {
+	posted, err := service.postConfirmation(ctx, donation, fundraise, payment)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if posted {
+		service.donationConfirmed(ctx, donation, fundraise, payment)
+	}
+
+	return nil
+}
+
+// RecordRefund records refund of the donation accepted by the payment provider.
+// Reference identifies the refund, so that the same refund is posted once.
+func (service *Service) RecordRefund(ctx context.Context, reference string, donation donations.Donation, payment payments.Payment, amount currencies.Amount) error {
+	if err := service.postRefund(ctx, reference, donation, payment, amount); err != nil {
+		return Error.Wrap(err)
+	}
+
+	return service.applyRefund(ctx, donation, payment, payment.RefundedAmount.Add(amount))
+}
+
+// failPayment marks pending payment with provided final status.
+func (service *Service) failPayment(ctx context.Context, paymentType string, event payments.Event, status string) error {
+	payment, err := service.eventPayment(ctx, paymentType, event)
+	if err != nil {
+		if errors.Is(err, payments.ErrNoPayment) {
+			service.logger.WarnF("no payment found for %s event %s", paymentType, event.ID)
+			return nil
+		}
+
+		return Error.Wrap(err)
+	}
+
+	if payment.IsFinal() {
+		return nil
+	}
+
+	if event.FailureMessage != "" {
+		service.logger.InfoF("payment of donation %s failed: %s", payment.DonationId, event.FailureMessage)
+	}
+
+	payment.Status = status
+	return Error.Wrap(service.payments.Update(ctx, payment))
+}
+
+// refundPayment records refunded amount of confirmed payment and releases its share of matches, fully refunded payment
+// stops counting towards totals.
+func (service *Service) refundPayment(ctx context.Context, paymentType string, event payments.Event) error {
+	payment, err := service.eventPayment(ctx, paymentType, event)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	// INFO: fully refunded payment is not confirmed anymore, its redelivered refund is applied again.
+	if !payment.Confirmed && payment.Status != payments.StatusRefunded {
+		service.logger.WarnF("refund of unconfirmed payment of donation %s", payment.DonationId)
+		return nil
+	}
+
+	donation, err := service.donations.Get(ctx, payment.DonationId)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	// INFO: event carries total refunded amount, only its part not yet recorded by refunds of the platform is posted.
+	refunded := event.Refunded.Min(donation.Amount)
+	if delta := refunded.Sub(payment.RefundedAmount); delta.IsPositive() {
+		if err = service.postRefund(ctx, paymentType+":"+event.ID, donation, payment, delta); err != nil {
+			return Error.Wrap(err)
+		}
+	}
+
+	// INFO: release is repeated on redelivered event, so it completes if it failed before.
+	return service.applyRefund(ctx, donation, payment, refunded)
+}
+
+// applyRefund releases share of matches of the refunded amount and records it on the payment, so that it stops counting
+// towards fundraise totals. Fully refunded payment is not confirmed anymore.
+func (service *Service) applyRefund(ctx context.Context, donation donations.Donation, payment payments.Payment, refunded currencies.Amount) error {
+	if err := service.releaseMatches(ctx, donation, refunded); err != nil {
+		return Error.Wrap(err)
+	}
+
+	payment.RefundedAmount = refunded
+	payment.Status = payments.StatusPartiallyRefunded
+	if refunded.Cmp(donation.Amount) >= 0 {
+		payment.Status = payments.StatusRefunded
+		payment.Confirmed = false
+	}
+
+	if err := service.payments.Update(ctx, payment); err != nil {
+		return Error.Wrap(err)
+	}
+
+	service.uncountRefund(ctx, donation, payment)
+
+	return nil
+}
+
+// eventPayment finds payment of the event by donation reference or by provider's payment reference.
+func (service *Service) eventPayment(ctx context.Context, paymentType string, event payments.Event) (payments.Payment, error) {
+	if donationID, err := uuid.Parse(event.Reference); err == nil {
+		return service.payments.Get(ctx, donationID)
+	}
+
+	if event.TransactionID != "" {
+		return service.payments.GetByTransaction(ctx, paymentType, event.TransactionID)
+	}
+
+	return service.payments.GetByReference(ctx, paymentType, event.PaymentReference)
+}
+
+// Transfer moves collected funds of overfunded or cancelled fundraise to another active fundraise.
+// Overfunded fundraise can transfer only the amount above its target, cancelled one - whole balance,
+// after which it is marked as transferred.
+func (service *Service) Transfer(ctx context.Context, params TransferParams) (*transfers.Transfer, error) {
+	switch {
+	case params.FromFundraiseID == params.ToFundraiseID:
+		return nil, ParamsError.New("funds can not be transferred to the same fundraise")
+	case params.Amount.Sign() < 0:
+		return nil, ParamsError.New("amount must be positive")
+	case params.Reason == "":
+		return nil, ParamsError.New("reason is required")
+	}
+
+	from, err := service.fundraises.Get(ctx, params.FromFundraiseID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if from.OrganizerId != params.CallerID {
+		return nil, ParamsError.Wrap(ErrNotOrganizer)
+	}
+
+	to, err := service.fundraises.Get(ctx, params.ToFundraiseID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if to.Status != statuses.ActiveStatus {
+		return nil, ParamsError.New("funds can be transferred only to active fundraise")
+	}
+
+	filled, err := service.fundraises.GetFilled(ctx, from.ID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	var minRemaining currencies.Amount
+	switch {
+	case from.Status == statuses.CancelledStatus:
+		minRemaining = currencies.Zero
+	case from.Status != statuses.TransferredStatus && filled.Cmp(from.TargetAmount) > 0:
+		minRemaining = from.TargetAmount
+	default:
+		return nil, ParamsError.New("only overfunded or cancelled fundraise can transfer funds")
+	}
+
+	available := filled.Sub(minRemaining)
+	amount := params.Amount
+	if amount.IsZero() {
+		amount = available
+	}
+
+	switch {
+	case !amount.IsPositive():
+		return nil, ParamsError.New("fundraise has no funds to transfer")
+	case amount.Cmp(available) > 0:
+		return nil, ParamsError.Wrap(transfers.ErrInsufficientFunds)
+	}
+
+	var sourceStatus string
+	if from.Status == statuses.CancelledStatus && amount.Cmp(available) >= 0 {
+		sourceStatus = statuses.TransferredStatus
+	}
+
+	rate, err := service.rate(ctx, from.Currency, to.Currency)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	transfer := &transfers.Transfer{
+		ID:              uuid.New(),
+		FromFundraiseID: from.ID,
+		ToFundraiseID:   to.ID,
+		Amount:          amount,
+		ExchangeRate:    rate.Value,
+		InitiatedBy:     params.CallerID,
+		Reason:          params.Reason,
+		CreatedAt:       time.Now().UTC(),
+	}
+
+	err = service.transfers.Create(ctx, *transfer, minRemaining, sourceStatus)
+	if err != nil {
+		if errors.Is(err, transfers.ErrInsufficientFunds) {
+			return nil, ParamsError.Wrap(transfers.ErrInsufficientFunds)
+		}
+
+		return nil, Error.Wrap(err)
+	}
+
+	return transfer, nil
+}
+
+// ListTransfers returns incoming and outgoing transfers of the fundraise.
+func (service *Service) ListTransfers(ctx context.Context, fundraiseID uuid.UUID) ([]transfers.Transfer, error) {
+	list, err := service.transfers.List(ctx, transfers.ListParams{FundraiseID: &fundraiseID})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return list, nil
+}
+
+// ListDonorTransfers returns transfers of funds out of fundraises the user has donated to.
+func (service *Service) ListDonorTransfers(ctx context.Context, userID uuid.UUID) ([]transfers.Transfer, error) {
+	list, err := service.transfers.List(ctx, transfers.ListParams{DonorID: &userID})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return list, nil
+}
+
+// ListDonations returns donations of the fundraise, allowed only to the fundraise organizer.
+func (service *Service) ListDonations(ctx context.Context, fundraiseID, callerID uuid.UUID) ([]donations.Donation, error) {
+	fundraise, err := service.fundraises.Get(ctx, fundraiseID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if fundraise.OrganizerId != callerID {
+		return nil, ParamsError.Wrap(ErrNotOrganizer)
+	}
+
+	list, err := service.donations.List(ctx, donations.ListParams{FundraiseID: &fundraise.ID})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return list, nil
+}
+
+// Analytics returns donations analytics of the fundraise for the period, allowed only to the fundraise organizer.
+// Period defaults to the whole fundraise lifetime, hourly series covers at most the last analytics.MaxHourlyRange of it.
+func (service *Service) Analytics(ctx context.Context, params analytics.Params, callerID uuid.UUID) (report analytics.Report, err error) {
+	fundraise, err := service.fundraises.Get(ctx, params.FundraiseID)
+	if err != nil {
+		return report, Error.Wrap(err)
+	}
+
+	if fundraise.OrganizerId != callerID {
+		return report, ParamsError.Wrap(ErrNotOrganizer)
+	}
+
+	if params.From.IsZero() {
+		params.From = fundraise.StartDate
+	}
+	if params.To.IsZero() {
+		params.To = time.Now().UTC()
+	}
+	if !params.From.Before(params.To) {
+		return report, ParamsError.New("period start must be before its end")
+	}
+
+	report.Params = params
+	report.Summary, err = service.analytics.Summary(ctx, params)
+	if err != nil {
+		return report, Error.Wrap(err)
+	}
+
+	report.Summary.Matched, err = service.matching.Matched(ctx, params.FundraiseID, params.From, params.To)
+	if err != nil {
+		return report, Error.Wrap(err)
+	}
+
+	report.Daily, err = service.analytics.TimeSeries(ctx, params, analytics.Day)
+	if err != nil {
+		return report, Error.Wrap(err)
+	}
+
+	hourly := params
+	if hourly.To.Sub(hourly.From) > analytics.MaxHourlyRange {
+		hourly.From = hourly.To.Add(-analytics.MaxHourlyRange)
+	}
+
+	report.Hourly, err = service.analytics.TimeSeries(ctx, hourly, analytics.Hour)
+	if err != nil {
+		return report, Error.Wrap(err)
+	}
+
+	return report, nil
+}
+
+// ReviewQueue returns fundraises awaiting moderation review, the oldest first. Allowed only to moderators.
+func (service *Service) ReviewQueue(ctx context.Context, callerID uuid.UUID, limit, page int) ([]Fundraise, error) {
+	switch {
+	case limit <= 0:
+		return nil, ParamsError.New("limit must be positive")
+	case page <= 0:
+		return nil, ParamsError.New("page must be positive")
+	}
+
+	if err := service.ensureModerator(ctx, callerID); err != nil {
+		return nil, err
+	}
+
+	list, err := service.fundraises.List(ctx, ListParams{
+		Statuses:    []string{statuses.PendingReviewStatus},
+		OldestFirst: true,
+		Limit:       limit,
+		Page:        page,
+	})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return list, nil
+}
+
+// Approve activates fundraise pending review, allowing it to accept donations.
+func (service *Service) Approve(ctx context.Context, params ReviewParams) error {
+	return service.decide(ctx, params, reviews.DecisionApproved, statuses.ActiveStatus)
+}
+
+// Reject rejects fundraise pending review with the reason, organizer may fix and resubmit it.
+func (service *Service) Reject(ctx context.Context, params ReviewParams) error {
+	if params.Reason == "" {
+		return ParamsError.New("rejection reason is required")
+	}
+
+	return service.decide(ctx, params, reviews.DecisionRejected, statuses.RejectedStatus)
+}
+
+// decide records moderator's decision on the fundraise pending review and moves it to the status.
+func (service *Service) decide(ctx context.Context, params ReviewParams, decision, status string) error {
+	if err := service.ensureModerator(ctx, params.CallerID); err != nil {
+		return err
+	}
+
+	fundraise, err := service.fundraises.Get(ctx, params.FundraiseID)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if fundraise.Status != statuses.PendingReviewStatus {
+		return ParamsError.New("fundraise is not pending review")
+	}
+
+	review := reviews.Review{
+		ID:          uuid.New(),
+		FundraiseID: fundraise.ID,
+		ActorID:     params.CallerID,
+		Decision:    decision,
+		Reason:      params.Reason,
+		CreatedAt:   time.Now().UTC(),
+	}
+	if err = service.reviews.Create(ctx, review, statuses.PendingReviewStatus, status); err != nil {
+		if errors.Is(err, reviews.ErrStatusChanged) {
+			return ParamsError.Wrap(reviews.ErrStatusChanged)
+		}
+
+		return Error.Wrap(err)
+	}
+
+	return nil
+}
+
+// Resubmit applies organizer's fixes to the rejected fundraise and sends it back to the review queue.
+func (service *Service) Resubmit(ctx context.Context, params ResubmitParams) (*Fundraise, error) {
+	fundraise, err := service.fundraises.Get(ctx, params.FundraiseID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if fundraise.OrganizerId != params.CallerID {
+		return nil, ParamsError.Wrap(ErrNotOrganizer)
+	}
+
+	if fundraise.Status != statuses.RejectedStatus {
+		return nil, ParamsError.New("only rejected fundraise can be resubmitted")
+	}
+
+	if params.TargetAmount.Sign() < 0 {
+		return nil, ParamsError.New("target amount must be positive")
+	}
+
+	if params.Title != "" {
+		fundraise.Title = params.Title
+	}
+	if params.Description != "" {
+		fundraise.Description = params.Description
+	}
+	if params.TargetAmount.IsPositive() {
+		fundraise.TargetAmount = params.TargetAmount
+	}
+	if !params.EndDate.IsZero() {
+		fundraise.EndDate = params.EndDate
+	}
+	if params.ImageUrl != "" {
+		fundraise.ImageUrl = params.ImageUrl
+	}
+
+	if err = service.fundraises.Update(ctx, fundraise); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	review := reviews.Review{
+		ID:          uuid.New(),
+		FundraiseID: fundraise.ID,
+		ActorID:     params.CallerID,
+		Decision:    reviews.DecisionResubmitted,
+		Reason:      params.Comment,
+		CreatedAt:   time.Now().UTC(),
+	}
+	if err = service.reviews.Create(ctx, review, statuses.RejectedStatus, statuses.PendingReviewStatus); err != nil {
+		if errors.Is(err, reviews.ErrStatusChanged) {
+			return nil, ParamsError.Wrap(reviews.ErrStatusChanged)
+		}
+
+		return nil, Error.Wrap(err)
+	}
+
+	fundraise.Status = statuses.PendingReviewStatus
+	return &fundraise, nil
+}
+
+// ListReviews returns moderation history of the fundraise, allowed to its organizer and moderators.
+func (service *Service) ListReviews(ctx context.Context, fundraiseID, callerID uuid.UUID) ([]reviews.Review, error) {
+	fundraise, err := service.fundraises.Get(ctx, fundraiseID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if fundraise.OrganizerId != callerID {
+		if err = service.ensureModerator(ctx, callerID); err != nil {
+			return nil, err
+		}
+	}
+
+	list, err := service.reviews.List(ctx, fundraise.ID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return list, nil
+}
+
+// ensureModerator returns ErrNotModerator if caller is not allowed to review fundraises.
+func (service *Service) ensureModerator(ctx context.Context, callerID uuid.UUID) error {
+	caller, err := service.users.Get(ctx, callerID)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if !caller.IsModerator() {
+		return ParamsError.Wrap(ErrNotModerator)
+	}
+
+	return nil
+}
+
+// ensureAdmin checks that caller is the platform administrator.
+func (service *Service) ensureAdmin(ctx context.Context, callerID uuid.UUID) error {
+	caller, err := service.users.Get(ctx, callerID)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if !caller.IsAdmin() {
+		return ParamsError.Wrap(ErrNotAdmin)
+	}
+
+	return nil
+}
+
+// validateMessage checks length and wording of the donor's message.
+func validateMessage(message string) error {
+	switch {
+	case utf8.RuneCountInString(message) > donations.MaxMessageLength:
+		return ParamsError.New("message must not exceed %d symbols", donations.MaxMessageLength)
+	case profanity.Contains(message):
+		return ParamsError.New("message contains inappropriate language")
+	}
+
+	return nil
+}
+
+// ValidateAmount checks donor-chosen amount against the fundraise minimum, converted to the donation currency.
+// Zero amount means the default price, only the rate to the donation currency is checked for it.
+func (service *Service) ValidateAmount(ctx context.Context, fundraise Fundraise, amount currencies.Amount, currency string) error {
+	switch {
+	case amount.Sign() < 0:
+		return ParamsError.New("amount must be positive")
+	case amount.Cmp(payments.MaxAmount) > 0:
+		return ParamsError.New("amount must not exceed %s", payments.MaxAmount)
+	}
+
+	// INFO: donation is converted to the fundraise currency on confirmation, so the rate has to be known upfront.
+	rate, err := service.rate(ctx, fundraise.Currency, currency)
 	if err != nil {
+		if errors.Is(err, currencies.ErrNoRate) {
+			return ParamsError.New("donations in %s are not accepted by the fundraise", currency)
+		}
 		return Error.Wrap(err)
 	}
 
+	if amount.IsZero() || fundraise.MinDonation.IsZero() {
+		return nil
+	}
+
+	if minimum := rate.Convert(fundraise.MinDonation); amount.Cmp(minimum) < 0 {
+		return ParamsError.New("amount must be at least %s %s", minimum, currency)
+	}
+
 	return nil
 }
 
-// CancelDonation removes canceled donation data.
-func (service *Service) CancelDonation(ctx context.Context, donation donations.Donation) error {
-	return Error.Wrap(service.donations.Delete(ctx, donation.ID))
+// rate returns exchange rate between currencies, skipping provider call for the same currency.
+func (service *Service) rate(ctx context.Context, from, to string) (currencies.Rate, error) {
+	if from == to {
+		return currencies.Identity(from, time.Now().UTC()), nil
+	}
+
+	return service.rates.Rate(ctx, from, to)
 }
@@ -0,0 +1,220 @@
+package fundraises_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app"
+	"one-help/app/currencies"
+	"one-help/app/database"
+	"one-help/app/database/dbtesting"
+	"one-help/app/donations"
+	"one-help/app/fundraises"
+	"one-help/app/fundraises/fundraisestesting"
+	"one-help/app/fundraises/statuses"
+	"one-help/app/ledger"
+	notificationfake "one-help/app/notifications/fake"
+	"one-help/app/payments"
+	"one-help/app/payments/fake"
+	"one-help/app/users"
+	"one-help/app/users/roles"
+)
+
+func TestDonations(t *testing.T) {
+	organizer := users.User{
+		ID:        uuid.New(),
+		FirstName: "John",
+		LastName:  "Doe",
+	}
+	donor := users.User{
+		ID:        uuid.New(),
+		FirstName: "Jane",
+		LastName:  "Doe",
+	}
+	admin := users.User{
+		ID:        uuid.New(),
+		FirstName: "Admin",
+		LastName:  "Doe",
+		Role:      roles.AdminRole,
+	}
+
+	provider := fake.NewProvider(payments.TypeStripe)
+
+	dbtesting.Run(t, database.Config{MigrationsPath: "../database/migrations"}, func(ctx context.Context, t *testing.T, db app.DB) {
+		require.NoError(t, db.Users().Create(ctx, organizer))
+		require.NoError(t, db.Users().SetTrusted(ctx, organizer.ID, true))
+		require.NoError(t, db.Users().Create(ctx, donor))
+		require.NoError(t, db.Users().Create(ctx, admin))
+
+		service := fundraisestesting.NewService(db, payments.NewProviders(provider), notificationfake.NewSender())
+
+		fundraise, err := service.Create(ctx, fundraises.CreateParams{
+			OrganizerId:  organizer.ID,
+			Title:        "Test",
+			Description:  "Test Description",
+			TargetAmount: currencies.Major(1000),
+			Currency:     currencies.UAH,
+		})
+		require.NoError(t, err)
+
+		result, err := service.RegisterDonate(ctx, fundraises.RegisterDonateParams{
+			FundraiseID: fundraise.ID,
+			UserID:      donor.ID,
+			Amount:      currencies.Major(100),
+			Anonymous:   true,
+			Message:     "Stay strong!",
+		})
+		require.NoError(t, err)
+		transactionID := strings.TrimPrefix(result.PaymentURL, fake.CheckoutURL)
+
+		t.Run("unknown provider", func(t *testing.T) {
+			_, err := service.RegisterDonate(ctx, fundraises.RegisterDonateParams{
+				FundraiseID: fundraise.ID,
+				UserID:      donor.ID,
+				Amount:      currencies.Major(100),
+				PaymentType: payments.TypeLiqPay,
+			})
+			require.ErrorIs(t, err, payments.ErrUnknownProvider)
+		})
+
+		t.Run("inappropriate message", func(t *testing.T) {
+			_, err := service.RegisterDonate(ctx, fundraises.RegisterDonateParams{
+				FundraiseID: fundraise.ID,
+				UserID:      donor.ID,
+				Amount:      currencies.Major(100),
+				Message:     "what the fuck",
+			})
+			require.True(t, fundraises.ParamsError.Has(err))
+		})
+
+		paid := provider.Pay(transactionID)
+
+		t.Run("completed", func(t *testing.T) {
+			// INFO: repeated completion of confirmed payment is ignored.
+			for range 2 {
+				require.NoError(t, service.HandleEvent(ctx, payments.TypeStripe, paid))
+
+				filled, err := service.Filled(ctx, fundraise.ID)
+				require.NoError(t, err)
+				assert.Equal(t, currencies.Major(100), filled)
+			}
+		})
+
+		t.Run("supporters", func(t *testing.T) {
+			supporters, err := service.RecentSupporters(ctx, fundraise.ID, 0)
+			require.NoError(t, err)
+			require.Len(t, supporters, 1)
+			assert.Equal(t, uuid.Nil, supporters[0].Donation.UserId)
+			assert.Empty(t, supporters[0].FirstName)
+			assert.Equal(t, "Stay strong!", supporters[0].Donation.Message)
+
+			list, err := service.ListDonations(ctx, fundraise.ID, organizer.ID)
+			require.NoError(t, err)
+			require.Len(t, list, 1)
+			assert.Equal(t, donor.ID, list[0].UserId)
+		})
+
+		t.Run("visibility", func(t *testing.T) {
+			pending, err := service.Create(ctx, fundraises.CreateParams{
+				OrganizerId:  donor.ID,
+				Title:        "Pending",
+				Description:  "Pending Description",
+				TargetAmount: currencies.Major(100),
+			})
+			require.NoError(t, err)
+			require.Equal(t, statuses.PendingReviewStatus, pending.Status)
+
+			// INFO: fundraise under review is visible only to its organizer and moderators.
+			_, err = service.GetVisible(ctx, pending.ID, uuid.Nil)
+			require.ErrorIs(t, err, fundraises.ErrNoFundraise)
+			_, err = service.GetVisible(ctx, pending.ID, organizer.ID)
+			require.ErrorIs(t, err, fundraises.ErrNoFundraise)
+
+			_, err = service.GetVisible(ctx, pending.ID, donor.ID)
+			require.NoError(t, err)
+			_, err = service.GetVisible(ctx, pending.ID, admin.ID)
+			require.NoError(t, err)
+			_, err = service.GetVisible(ctx, fundraise.ID, uuid.Nil)
+			require.NoError(t, err)
+		})
+
+		t.Run("receipt", func(t *testing.T) {
+			donationID := uuid.MustParse(paid.Reference)
+
+			receipt, document, err := service.Receipt(ctx, donationID, donor.ID)
+			require.NoError(t, err)
+			assert.EqualValues(t, 1, receipt.Number)
+			assert.Equal(t, transactionID, receipt.TransactionID)
+			assert.NotEmpty(t, document)
+
+			// INFO: organizer downloads the same receipt.
+			again, _, err := service.Receipt(ctx, donationID, organizer.ID)
+			require.NoError(t, err)
+			assert.Equal(t, receipt.ID, again.ID)
+
+			_, _, err = service.Receipt(ctx, donationID, uuid.New())
+			require.ErrorIs(t, err, fundraises.ErrNotDonor)
+		})
+
+		t.Run("history", func(t *testing.T) {
+			history, err := service.DonationHistory(ctx, donations.HistoryParams{UserID: donor.ID, Limit: 10, Page: 1})
+			require.NoError(t, err)
+			require.NotEmpty(t, history.Donations)
+			require.Len(t, history.YearlyTotals, 1)
+			assert.Equal(t, currencies.Major(100), history.YearlyTotals[0].Amount)
+			require.Len(t, history.Contributions, 1)
+			assert.Equal(t, fundraise.ID, history.Contributions[0].FundraiseID)
+
+			_, err = service.DonationHistory(ctx, donations.HistoryParams{UserID: donor.ID, Limit: 0, Page: 1})
+			require.True(t, fundraises.ParamsError.Has(err))
+		})
+
+		t.Run("cover fee", func(t *testing.T) {
+			provider.SetFees(payments.FeeSchedule{Percent: 2.9})
+			defer provider.SetFees(payments.FeeSchedule{})
+
+			_, err := service.RegisterDonate(ctx, fundraises.RegisterDonateParams{
+				FundraiseID: fundraise.ID,
+				UserID:      donor.ID,
+				CoverFee:    true,
+			})
+			require.True(t, fundraises.ParamsError.Has(err))
+
+			result, err := service.RegisterDonate(ctx, fundraises.RegisterDonateParams{
+				FundraiseID: fundraise.ID,
+				UserID:      donor.ID,
+				Amount:      currencies.Major(100),
+				CoverFee:    true,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, currencies.MinorUnits(10299), result.Amount)
+			assert.Equal(t, currencies.MinorUnits(299), result.Fee)
+
+			filled, err := service.Filled(ctx, fundraise.ID)
+			require.NoError(t, err)
+
+			paid := provider.Pay(strings.TrimPrefix(result.PaymentURL, fake.CheckoutURL))
+			require.NoError(t, service.HandleEvent(ctx, payments.TypeStripe, paid))
+
+			// INFO: progress counts net amount, the fee is credited to the provider fees account.
+			covered, err := service.Filled(ctx, fundraise.ID)
+			require.NoError(t, err)
+			assert.Equal(t, filled.Add(currencies.Major(100)), covered)
+
+			fees, err := db.Ledger().Balance(ctx, ledger.Fees(payments.TypeStripe), currencies.UAH)
+			require.NoError(t, err)
+			assert.Equal(t, currencies.MinorUnits(-299), fees)
+		})
+
+		t.Run("ledger", func(t *testing.T) {
+			violations, err := db.Ledger().Check(ctx)
+			require.NoError(t, err)
+			assert.Empty(t, violations)
+		})
+	})
+}
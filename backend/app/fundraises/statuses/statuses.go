@@ -11,4 +11,8 @@ const (
 	CancelledStatus string = "CANCELLED"
 	// TransferredStatus defines transferred status.
 	TransferredStatus string = "TRANSFERRED"
+	// PendingReviewStatus defines status of the fundraise awaiting moderator's approval.
+	PendingReviewStatus string = "PENDING_REVIEW"
+	// RejectedStatus defines status of the fundraise rejected by moderator.
+	RejectedStatus string = "REJECTED"
 )
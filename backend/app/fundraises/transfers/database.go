@@ -0,0 +1,40 @@
+package transfers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+
+	"one-help/app/currencies"
+)
+
+var (
+	// ErrNoTransfer indicates that transfer does not exist.
+	ErrNoTransfer = errs.New("transfer does not exist")
+	// ErrInsufficientFunds indicates that source fundraise balance does not cover the transfer.
+	ErrInsufficientFunds = errs.New("insufficient funds on the fundraise")
+)
+
+// DB exposes access to fundraise transfers db.
+//
+// architecture: DB
+type DB interface {
+	// Create inserts transfer into the database, ensuring that source fundraise keeps at least minRemaining balance
+	// and that the amount is not paid out or reserved by payouts.
+	// Source fundraise status is changed to sourceStatus if it is not empty, and the transfer is posted to the ledger
+	// in the same transaction.
+	Create(ctx context.Context, transfer Transfer, minRemaining currencies.Amount, sourceStatus string) error
+	// Get transfer from the database.
+	Get(ctx context.Context, id uuid.UUID) (Transfer, error)
+	// List returns transfers ordered from the newest to the oldest.
+	List(ctx context.Context, params ListParams) ([]Transfer, error)
+}
+
+// ListParams defines params for list method.
+type ListParams struct {
+	// FundraiseID lists incoming and outgoing transfers of the fundraise.
+	FundraiseID *uuid.UUID
+	// DonorID lists transfers out of fundraises the user has confirmed donations to.
+	DonorID *uuid.UUID
+}
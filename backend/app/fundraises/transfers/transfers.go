@@ -0,0 +1,27 @@
+package transfers
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"one-help/app/currencies"
+)
+
+// Transfer describes movement of collected funds from one fundraise to another.
+type Transfer struct {
+	ID              uuid.UUID
+	FromFundraiseID uuid.UUID
+	ToFundraiseID   uuid.UUID
+	Amount          currencies.Amount // INFO: in the source fundraise currency.
+	// ExchangeRate is a snapshot of the rate from source to target fundraise currency.
+	ExchangeRate float64
+	InitiatedBy  uuid.UUID
+	Reason       string
+	CreatedAt    time.Time
+}
+
+// ConvertedAmount returns transferred amount in the target fundraise currency.
+func (t *Transfer) ConvertedAmount() currencies.Amount {
+	return t.Amount.Mul(t.ExchangeRate)
+}
@@ -0,0 +1,64 @@
+package fundraises
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"one-help/app/donations"
+	"one-help/app/donations/tributes"
+	"one-help/app/notifications"
+)
+
+// sendTributeCard sends dedication card of the confirmed donation to its tribute recipient, card is sent only once.
+// NOTE: failed card is not sent again on repeated confirmation, it is resent by RunNotifications.
+func (service *Service) sendTributeCard(ctx context.Context, donation donations.Donation, fundraise Fundraise) error {
+	if !donation.Tribute.Notifies() {
+		return nil
+	}
+
+	card := tributes.Card{
+		Tribute:        donation.Tribute,
+		FundraiseTitle: fundraise.Title,
+		DonatedAt:      donation.CreatedAt,
+	}
+	if !donation.Anonymous && donation.UserId != uuid.Nil {
+		donor, err := service.users.Get(ctx, donation.UserId)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+
+		card.DonorName = donor.FullName()
+	}
+
+	document, err := tributes.Render(card)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	notification := notifications.Notification{
+		ID:        uuid.New(),
+		Key:       "tribute:" + donation.ID.String(),
+		Recipient: donation.Tribute.RecipientEmail,
+		Subject:   card.Subject(),
+		Body:      card.Message(),
+		Attachment: notifications.Attachment{
+			Name:        "dedication-card.pdf",
+			ContentType: "application/pdf",
+			Data:        document,
+		},
+		Status:    notifications.StatusPending,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	created, err := service.notifications.Create(ctx, notification)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if !created {
+		return nil
+	}
+
+	return service.notify(ctx, notification)
+}
@@ -0,0 +1,134 @@
+package fundraises_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app"
+	"one-help/app/currencies"
+	"one-help/app/database"
+	"one-help/app/database/dbtesting"
+	"one-help/app/donations/tributes"
+	"one-help/app/fundraises"
+	"one-help/app/fundraises/fundraisestesting"
+	"one-help/app/notifications"
+	notificationfake "one-help/app/notifications/fake"
+	"one-help/app/payments"
+	"one-help/app/payments/fake"
+	"one-help/app/users"
+)
+
+func TestTributes(t *testing.T) {
+	organizer := users.User{
+		ID:        uuid.New(),
+		FirstName: "John",
+		LastName:  "Doe",
+	}
+	donor := users.User{
+		ID:        uuid.New(),
+		FirstName: "Jane",
+		LastName:  "Doe",
+	}
+
+	provider := fake.NewProvider(payments.TypeStripe)
+	notifier := notificationfake.NewSender()
+
+	dbtesting.Run(t, database.Config{MigrationsPath: "../database/migrations"}, func(ctx context.Context, t *testing.T, db app.DB) {
+		require.NoError(t, db.Users().Create(ctx, organizer))
+		require.NoError(t, db.Users().SetTrusted(ctx, organizer.ID, true))
+		require.NoError(t, db.Users().Create(ctx, donor))
+
+		service := fundraisestesting.NewService(db, payments.NewProviders(provider), notifier)
+
+		fundraise, err := service.Create(ctx, fundraises.CreateParams{
+			OrganizerId:  organizer.ID,
+			Title:        "Test",
+			Description:  "Test Description",
+			TargetAmount: currencies.Major(1000),
+			Currency:     currencies.UAH,
+		})
+		require.NoError(t, err)
+
+		t.Run("sent", func(t *testing.T) {
+			_, err := service.RegisterDonate(ctx, fundraises.RegisterDonateParams{
+				FundraiseID: fundraise.ID,
+				UserID:      donor.ID,
+				Amount:      currencies.Major(100),
+				Tribute:     tributes.Tribute{Type: tributes.TypeInMemory, RecipientEmail: "family@example.com"},
+			})
+			require.True(t, fundraises.ParamsError.Has(err))
+
+			result, err := service.RegisterDonate(ctx, fundraises.RegisterDonateParams{
+				FundraiseID: fundraise.ID,
+				UserID:      donor.ID,
+				Amount:      currencies.Major(100),
+				Tribute: tributes.Tribute{
+					Type:           tributes.TypeInMemory,
+					HonoreeName:    "Taras",
+					RecipientEmail: "family@example.com",
+				},
+			})
+			require.NoError(t, err)
+
+			paid := provider.Pay(strings.TrimPrefix(result.PaymentURL, fake.CheckoutURL))
+			require.NoError(t, service.HandleEvent(ctx, payments.TypeStripe, paid))
+			// INFO: repeated confirmation does not send the card again.
+			require.NoError(t, service.HandleEvent(ctx, payments.TypeStripe, paid))
+
+			notification, err := db.Notifications().GetByKey(ctx, "tribute:"+paid.Reference)
+			require.NoError(t, err)
+			assert.Equal(t, notifications.StatusSent, notification.Status)
+			assert.Equal(t, "family@example.com", notification.Recipient)
+			assert.Equal(t, "A donation was made in memory of Taras", notification.Subject)
+			assert.Contains(t, notification.Body, donor.FullName())
+
+			sent, ok := notifier.Sent(notification.ID)
+			require.True(t, ok)
+			assert.True(t, bytes.HasPrefix(sent.Attachment.Data, []byte("%PDF-")))
+		})
+
+		t.Run("resent", func(t *testing.T) {
+			// INFO: failed card does not fail the confirmation and is resent later.
+			notifier.Fail(errors.New("mailbox unavailable"))
+			paid := fundraisestesting.Donate(ctx, t, service, provider, fundraises.RegisterDonateParams{
+				FundraiseID: fundraise.ID,
+				UserID:      donor.ID,
+				Amount:      currencies.Major(100),
+				Tribute: tributes.Tribute{
+					Type:           tributes.TypeInHonour,
+					HonoreeName:    "Olena",
+					RecipientEmail: "olena@example.com",
+				},
+			})
+
+			notification, err := db.Notifications().GetByKey(ctx, "tribute:"+paid.Reference)
+			require.NoError(t, err)
+			assert.Equal(t, notifications.StatusFailed, notification.Status)
+			assert.Equal(t, 1, notification.Attempts)
+
+			require.Error(t, service.ResendNotifications(ctx))
+			notifier.Fail(nil)
+			require.NoError(t, service.ResendNotifications(ctx))
+
+			notification, err = db.Notifications().GetByKey(ctx, "tribute:"+paid.Reference)
+			require.NoError(t, err)
+			assert.Equal(t, notifications.StatusSent, notification.Status)
+			assert.Equal(t, 3, notification.Attempts)
+			_, ok := notifier.Sent(notification.ID)
+			assert.True(t, ok)
+
+			// INFO: sent notification is not resent.
+			unsent, err := db.Notifications().ListUnsent(ctx, time.Now().UTC())
+			require.NoError(t, err)
+			assert.Empty(t, unsent)
+		})
+	})
+}
@@ -0,0 +1,27 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+)
+
+// ErrNoRecord indicates that idempotency record does not exist.
+var ErrNoRecord = errs.New("idempotency record does not exist")
+
+// DB exposes access to idempotency records db.
+//
+// architecture: DB
+type DB interface {
+	// Reserve inserts record if the user has no record with the key or it has expired, returns true in that case.
+	// Otherwise, returns the stored record and false.
+	Reserve(ctx context.Context, record Record) (Record, bool, error)
+	// Complete stores the response of the record.
+	Complete(ctx context.Context, record Record) error
+	// Delete deletes record of the user by key.
+	Delete(ctx context.Context, userID uuid.UUID, key string) error
+	// DeleteExpired deletes records expired before provided time.
+	DeleteExpired(ctx context.Context, before time.Time) error
+}
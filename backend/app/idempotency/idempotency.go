@@ -0,0 +1,149 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+
+	"one-help/internal/logger"
+)
+
+var (
+	// Error wraps errors from idempotency service that indicates about internal errors.
+	Error = errs.Class("idempotency service")
+	// ParamsError wraps errors from idempotency service that indicates about invalid or malformed parameters' data.
+	ParamsError = errs.Class("idempotency service: params")
+	// ErrKeyReused indicates that idempotency key was already used for a different request.
+	ErrKeyReused = errs.New("idempotency key was already used for a different request")
+	// ErrInProgress indicates that request with the same idempotency key is still being processed.
+	ErrInProgress = errs.New("request with the same idempotency key is in progress")
+)
+
+// MaxKeyLength defines maximum length of the idempotency key.
+const MaxKeyLength = 255
+
+// CleanupInterval defines how often expired keys are removed.
+const CleanupInterval = time.Hour
+
+// Config holds configurable values for idempotency keys.
+type Config struct {
+	// Window is the time the first response is replayed for repeated requests with the same key.
+	Window time.Duration `env:"WINDOW" envDefault:"24h"`
+	// MaxBodySize limits size of the request body read to fingerprint the request, in bytes.
+	// It must fit the largest accepted request, e.g. imported bank statement.
+	MaxBodySize int64 `env:"MAX_BODY_SIZE" envDefault:"5242880"`
+}
+
+// Record describes request made with idempotency key and its stored response.
+type Record struct {
+	UserID uuid.UUID
+	Key    string
+	// Fingerprint identifies the request the key was first used for.
+	Fingerprint string
+	Completed   bool
+	Status      int
+	ContentType string
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// Fingerprint returns fingerprint of the request by its method, path and body.
+func Fingerprint(method, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method + " " + path + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Service handles idempotency keys related logic.
+//
+// architecture: Service
+type Service struct {
+	logger logger.Logger
+	config Config
+
+	records DB
+}
+
+// NewService is a constructor for idempotency service.
+func NewService(logger logger.Logger, config Config, records DB) *Service {
+	return &Service{
+		logger:  logger,
+		config:  config,
+		records: records,
+	}
+}
+
+// MaxBodySize returns limit of the request body size in bytes.
+func (service *Service) MaxBodySize() int64 {
+	return service.config.MaxBodySize
+}
+
+// Begin reserves the key of the user for the request. Returns completed record with the response
+// to replay if the same request was already made with the key, and not completed record if request should be processed.
+func (service *Service) Begin(ctx context.Context, userID uuid.UUID, key, fingerprint string) (Record, error) {
+	if len(key) > MaxKeyLength {
+		return Record{}, ParamsError.New("idempotency key must not exceed %d symbols", MaxKeyLength)
+	}
+
+	now := time.Now().UTC()
+	record, reserved, err := service.records.Reserve(ctx, Record{
+		UserID:      userID,
+		Key:         key,
+		Fingerprint: fingerprint,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(service.config.Window),
+	})
+	if err != nil {
+		return Record{}, Error.Wrap(err)
+	}
+
+	switch {
+	case reserved:
+		return record, nil
+	case record.Fingerprint != fingerprint:
+		return Record{}, ErrKeyReused
+	case !record.Completed:
+		return Record{}, ErrInProgress
+	default:
+		return record, nil
+	}
+}
+
+// Finish stores the response of the request to replay it for repeated requests.
+func (service *Service) Finish(ctx context.Context, record Record, status int, contentType string, body []byte) error {
+	record.Completed = true
+	record.Status = status
+	record.ContentType = contentType
+	record.Body = body
+
+	return Error.Wrap(service.records.Complete(ctx, record))
+}
+
+// Release frees the key of not completed request, so the request can be retried with the same key.
+func (service *Service) Release(ctx context.Context, record Record) error {
+	return Error.Wrap(service.records.Delete(ctx, record.UserID, record.Key))
+}
+
+// RunCleanup removes expired keys on schedule until context is canceled.
+func (service *Service) RunCleanup(ctx context.Context) error {
+	ticker := time.NewTicker(CleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := service.records.DeleteExpired(ctx, time.Now().UTC()); err != nil {
+			service.logger.Error("failed to delete expired idempotency keys", Error.Wrap(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
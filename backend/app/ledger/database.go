@@ -0,0 +1,21 @@
+package ledger
+
+import (
+	"context"
+
+	"one-help/app/currencies"
+)
+
+// DB exposes access to ledger db.
+//
+// architecture: DB
+type DB interface {
+	// Post appends balanced transaction to the ledger. Returns false without posting if the transaction
+	// of the same kind and reference was already posted.
+	Post(ctx context.Context, transaction Transaction) (bool, error)
+	// Balance returns debit balance of the account in provided currency.
+	Balance(ctx context.Context, account, currency string) (currencies.Amount, error)
+	// Check verifies that every transaction and currency balances and that fundraise accounts
+	// match donations, refunds, transfers and payouts they were posted for. Returns found violations.
+	Check(ctx context.Context) ([]Violation, error)
+}
@@ -0,0 +1,129 @@
+// Package ledger records every movement of donated funds as balanced double-entry transactions.
+package ledger
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+
+	"one-help/app/currencies"
+)
+
+// ErrUnbalanced indicates that transaction entries do not sum up to zero in some currency.
+var ErrUnbalanced = errs.New("ledger transaction is unbalanced")
+
+// Kinds of ledger transactions, each kind is posted once per reference.
+const (
+	// KindConfirmation records confirmed donation, referenced by donation id.
+	KindConfirmation = "confirmation"
+	// KindRefund records refund of the donation, referenced by refund id or by payment type and webhook event id.
+	KindRefund = "refund"
+	// KindTransfer records transfer of funds between fundraises, referenced by transfer id.
+	KindTransfer = "transfer"
+	// KindPayout records withdrawal of fundraise funds, referenced by payout id.
+	KindPayout = "payout"
+)
+
+const (
+	// AccountExchange balances conversions between currencies within transaction.
+	AccountExchange = "exchange"
+	// AccountPayouts accumulates funds paid out of fundraises.
+	AccountPayouts = "payouts"
+)
+
+// DonorClearing returns account of funds received from donors and held by the payment provider.
+func DonorClearing(paymentType string) string {
+	return "donor_clearing:" + paymentType
+}
+
+// Fees returns account of fees charged by the payment provider.
+func Fees(paymentType string) string {
+	return "fees:" + paymentType
+}
+
+// KeptFees returns account of processing fees the payment provider kept on refunded payments, which were returned
+// to donors at the platform's expense.
+func KeptFees(paymentType string) string {
+	return "kept_fees:" + paymentType
+}
+
+// Refunds returns account of funds returned to donors through the payment provider.
+func Refunds(paymentType string) string {
+	return "refunds:" + paymentType
+}
+
+// FundraiseBalance returns account of funds collected by the fundraise.
+func FundraiseBalance(fundraiseID uuid.UUID) string {
+	return "fundraise:" + fundraiseID.String()
+}
+
+// Entry is a single posting to the account. Positive amount debits the account, negative - credits it.
+type Entry struct {
+	Account  string
+	Currency string
+	Amount   currencies.Amount
+}
+
+// Transaction is a set of entries that are posted together and sum up to zero in every currency.
+type Transaction struct {
+	ID        uuid.UUID
+	Kind      string
+	Reference string
+	CreatedAt time.Time
+	Entries   []Entry
+}
+
+// New is a constructor for transaction without entries.
+func New(kind, reference string, createdAt time.Time) Transaction {
+	return Transaction{
+		ID:        uuid.New(),
+		Kind:      kind,
+		Reference: reference,
+		CreatedAt: createdAt,
+	}
+}
+
+// Move debits one account and credits another, money of each side is in its own currency.
+// Exchange account entries are added when currencies differ, so that every currency stays balanced.
+func (t *Transaction) Move(debit string, debitMoney currencies.Money, credit string, creditMoney currencies.Money) {
+	t.Entries = append(t.Entries,
+		Entry{Account: debit, Currency: debitMoney.Currency, Amount: debitMoney.Amount},
+		Entry{Account: credit, Currency: creditMoney.Currency, Amount: creditMoney.Amount.Neg()},
+	)
+
+	if debitMoney.Currency != creditMoney.Currency {
+		t.Entries = append(t.Entries,
+			Entry{Account: AccountExchange, Currency: debitMoney.Currency, Amount: debitMoney.Amount.Neg()},
+			Entry{Account: AccountExchange, Currency: creditMoney.Currency, Amount: creditMoney.Amount},
+		)
+	}
+}
+
+// Validate returns ErrUnbalanced if the transaction has no entries or they do not sum up to zero in some currency.
+func (t *Transaction) Validate() error {
+	if len(t.Entries) == 0 {
+		return ErrUnbalanced
+	}
+
+	sums := make(map[string]currencies.Amount)
+	for _, entry := range t.Entries {
+		sums[entry.Currency] = sums[entry.Currency].Add(entry.Amount)
+	}
+
+	for _, sum := range sums {
+		if !sum.IsZero() {
+			return ErrUnbalanced
+		}
+	}
+
+	return nil
+}
+
+// Violation describes broken invariant of the ledger.
+type Violation struct {
+	// Subject is an account or transaction the violation relates to.
+	Subject  string
+	Currency string
+	Message  string
+}
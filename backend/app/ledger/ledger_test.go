@@ -0,0 +1,50 @@
+package ledger_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app/currencies"
+	"one-help/app/ledger"
+)
+
+func TestTransaction(t *testing.T) {
+	fundraiseID := uuid.New()
+
+	t.Run("same currency", func(t *testing.T) {
+		transaction := ledger.New(ledger.KindConfirmation, uuid.NewString(), time.Now())
+		transaction.Move("clearing", currencies.NewMoney(currencies.Major(100), currencies.UAH), ledger.FundraiseBalance(fundraiseID), currencies.NewMoney(currencies.Major(100), currencies.UAH))
+
+		require.NoError(t, transaction.Validate())
+		assert.Equal(t, []ledger.Entry{
+			{Account: "clearing", Currency: currencies.UAH, Amount: currencies.Major(100)},
+			{Account: ledger.FundraiseBalance(fundraiseID), Currency: currencies.UAH, Amount: currencies.Major(-100)},
+		}, transaction.Entries)
+	})
+
+	t.Run("exchange", func(t *testing.T) {
+		transaction := ledger.New(ledger.KindConfirmation, uuid.NewString(), time.Now())
+		transaction.Move(
+			"clearing", currencies.NewMoney(currencies.MinorUnits(1010), currencies.USD),
+			ledger.FundraiseBalance(fundraiseID), currencies.NewMoney(currencies.MinorUnits(1010).Mul(41.37), currencies.UAH),
+		)
+
+		require.NoError(t, transaction.Validate())
+		assert.Len(t, transaction.Entries, 4)
+	})
+
+	t.Run("unbalanced", func(t *testing.T) {
+		transaction := ledger.New(ledger.KindPayout, uuid.NewString(), time.Now())
+		require.ErrorIs(t, transaction.Validate(), ledger.ErrUnbalanced)
+
+		transaction.Entries = []ledger.Entry{
+			{Account: ledger.FundraiseBalance(fundraiseID), Currency: currencies.UAH, Amount: currencies.Major(100)},
+			{Account: ledger.AccountPayouts, Currency: currencies.USD, Amount: currencies.Major(-100)},
+		}
+		require.ErrorIs(t, transaction.Validate(), ledger.ErrUnbalanced)
+	})
+}
@@ -0,0 +1,330 @@
+package liqpay
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"one-help/app/currencies"
+	"one-help/app/payments"
+	"one-help/internal/logger"
+)
+
+// Error is an error wrapper that notifies that error was produced by LiqPay client.
+var Error = errs.Class("liqpay client")
+
+// ensures that Client implements payments.BalanceProvider and payments.FeeProvider.
+var (
+	_ payments.BalanceProvider = (*Client)(nil)
+	_ payments.FeeProvider     = (*Client)(nil)
+)
+
+// apiVersion is a version of LiqPay API.
+const apiVersion = 3
+
+// Payment statuses sent by LiqPay.
+const (
+	statusSuccess  = "success"
+	statusSandbox  = "sandbox"
+	statusFailure  = "failure"
+	statusError    = "error"
+	statusReversed = "reversed"
+)
+
+// errCodePaymentNotFound is an error code LiqPay returns for the order it has no payment of.
+const errCodePaymentNotFound = "payment_not_found"
+
+// Config holds configurable values for LiqPay client. LiqPay is disabled if keys are not configured.
+type Config struct {
+	PublicKey      string `env:"PUBLIC_KEY" envDefault:""`
+	PrivateKey     string `env:"PRIVATE_KEY" envDefault:""`
+	RedirectDomain string `env:"REDIRECT_DOMAIN" envDefault:""` // INFO: Ex.: localhost:port/api/v0
+	APIURL         string `env:"API_URL" envDefault:"https://www.liqpay.ua"`
+	// Sandbox accepts test payments made with sandbox keys as paid, must be disabled in production.
+	Sandbox bool `env:"SANDBOX" envDefault:"false"`
+
+	Fees payments.FeeSchedule `envPrefix:"FEE_"`
+}
+
+// IsConfigured returns true if LiqPay keys are provided.
+func (config Config) IsConfigured() bool {
+	return config.PublicKey != "" && config.PrivateKey != ""
+}
+
+// Client is a LiqPay acquiring client.
+type Client struct {
+	log    logger.Logger
+	config Config
+	http   *http.Client
+}
+
+// NewClient is a constructor for LiqPay client.
+func NewClient(log logger.Logger, config Config) *Client {
+	return &Client{
+		log:    log,
+		config: config,
+		http:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// request describes LiqPay API request, encoded into the data field.
+type request struct {
+	Version     int     `json:"version"`
+	PublicKey   string  `json:"public_key"`
+	Action      string  `json:"action"`
+	OrderID     string  `json:"order_id"`
+	Amount      float64 `json:"amount,omitempty"` // INFO: float, so that it is omitted from requests without amount.
+	Currency    string  `json:"currency,omitempty"`
+	Description string  `json:"description,omitempty"`
+	ResultURL   string  `json:"result_url,omitempty"`
+	ServerURL   string  `json:"server_url,omitempty"`
+	DateFrom    int64   `json:"date_from,omitempty"` // INFO: unix milliseconds.
+	DateTo      int64   `json:"date_to,omitempty"`   // INFO: unix milliseconds.
+}
+
+// response describes LiqPay API response and callback data.
+type response struct {
+	Result         string            `json:"result"`
+	Action         string            `json:"action"`
+	Status         string            `json:"status"`
+	PaymentID      int64             `json:"payment_id"`
+	OrderID        string            `json:"order_id"`
+	Amount         currencies.Amount `json:"amount"`
+	Currency       string            `json:"currency"`
+	RefundAmount   currencies.Amount `json:"refund_amount"`
+	ErrCode        string            `json:"err_code"`
+	ErrDescription string            `json:"err_description"`
+	// Data lists payments of the reports request.
+	Data []response `json:"data"`
+}
+
+// Type returns payment type of the provider.
+func (c *Client) Type() string {
+	return payments.TypeLiqPay
+}
+
+// Fees returns configured processing fee schedule of LiqPay.
+func (c *Client) Fees() payments.FeeSchedule {
+	return c.config.Fees
+}
+
+// CreateSession provides checkout url of the payment, order is identified by the donation reference.
+func (c *Client) CreateSession(ctx context.Context, params payments.SessionParams) (payments.Session, error) {
+	if !params.Amount.Amount.IsPositive() {
+		return payments.Session{}, Error.Wrap(payments.ErrAmountRequired)
+	}
+	if !supportsCurrency(params.Amount.Currency) {
+		return payments.Session{}, Error.Wrap(payments.ErrUnsupportedCurrency)
+	}
+
+	data, signature, err := c.sign(request{
+		Action:      "pay",
+		OrderID:     params.Reference,
+		Amount:      params.Amount.Amount.Float64(),
+		Currency:    params.Amount.Currency,
+		Description: params.Description,
+		ResultURL:   c.config.RedirectDomain + params.RedirectPath,
+		ServerURL:   c.config.RedirectDomain + "/webhooks/liqpay",
+	})
+	if err != nil {
+		return payments.Session{}, Error.Wrap(err)
+	}
+
+	query := url.Values{"data": {data}, "signature": {signature}}
+
+	return payments.Session{
+		URL:           c.config.APIURL + "/api/3/checkout?" + query.Encode(),
+		TransactionID: params.Reference,
+	}, nil
+}
+
+// Status queries LiqPay for the state of the order payment.
+func (c *Client) Status(ctx context.Context, transactionID string) (payments.SessionStatus, error) {
+	resp, err := c.do(ctx, request{Action: "status", OrderID: transactionID})
+	if err != nil {
+		if resp.ErrCode == errCodePaymentNotFound { // INFO: order is unknown until donor pays on the checkout page.
+			return payments.SessionStatus{}, Error.Wrap(payments.ErrSessionNotFound)
+		}
+
+		return payments.SessionStatus{}, err
+	}
+
+	status := payments.SessionStatus{
+		Paid:    c.isPaid(resp.Status),
+		Amount:  currencies.NewMoney(resp.Amount, resp.Currency),
+		Expired: resp.Status == statusFailure || resp.Status == statusError,
+	}
+	if resp.PaymentID != 0 {
+		status.Reference = strconv.FormatInt(resp.PaymentID, 10)
+	}
+
+	return status, nil
+}
+
+// Refund returns amount of the order payment to the donor.
+func (c *Client) Refund(ctx context.Context, payment payments.Payment, amount currencies.Amount) error {
+	_, err := c.do(ctx, request{Action: "refund", OrderID: payment.TransactionId, Amount: amount.Float64()})
+	return err
+}
+
+// Collected returns amounts of successful payments made within the period, net of their refunds.
+func (c *Client) Collected(ctx context.Context, from, to time.Time) (map[string]currencies.Amount, error) {
+	resp, err := c.do(ctx, request{Action: "reports", DateFrom: from.UnixMilli(), DateTo: to.UnixMilli()})
+	if err != nil {
+		return nil, err
+	}
+
+	collected := make(map[string]currencies.Amount)
+	for _, payment := range resp.Data {
+		if c.isPaid(payment.Status) {
+			collected[payment.Currency] = collected[payment.Currency].Add(payment.Amount.Sub(payment.RefundAmount))
+		}
+	}
+
+	return collected, nil
+}
+
+// ParseWebhook verifies signature of LiqPay callback form and parses the payment state.
+// LiqPay has no event identifiers, so event is identified by payment id and status.
+func (c *Client) ParseWebhook(payload []byte, header http.Header) (payments.Event, error) {
+	form, err := url.ParseQuery(string(payload))
+	if err != nil {
+		return payments.Event{}, Error.Wrap(err)
+	}
+
+	data := form.Get("data")
+	if !c.verify(data, form.Get("signature")) {
+		return payments.Event{}, Error.Wrap(payments.ErrInvalidSignature)
+	}
+
+	var resp response
+	if err = decode(data, &resp); err != nil {
+		return payments.Event{}, Error.Wrap(err)
+	}
+
+	event := payments.Event{
+		ID:               strconv.FormatInt(resp.PaymentID, 10) + ":" + resp.Status,
+		Type:             resp.Status,
+		Kind:             payments.EventIgnored,
+		Payload:          payload,
+		Reference:        resp.OrderID,
+		TransactionID:    resp.OrderID,
+		PaymentReference: strconv.FormatInt(resp.PaymentID, 10),
+		Amount:           currencies.NewMoney(resp.Amount, resp.Currency),
+		FailureMessage:   resp.ErrDescription,
+	}
+
+	switch {
+	case c.isPaid(resp.Status):
+		event.Kind = payments.EventCompleted
+		event.Paid = true
+	case resp.Status == statusFailure || resp.Status == statusError:
+		event.Kind = payments.EventFailed
+	case resp.Status == statusReversed:
+		event.Kind = payments.EventRefunded
+		event.Refunded = resp.RefundAmount
+		if event.Refunded.IsZero() {
+			event.Refunded = resp.Amount
+		}
+	}
+
+	return event, nil
+}
+
+// isPaid returns true if payment with provided status is paid, sandbox payments are paid only in sandbox mode.
+func (c *Client) isPaid(status string) bool {
+	return status == statusSuccess || (c.config.Sandbox && status == statusSandbox)
+}
+
+// do sends signed request to LiqPay API.
+func (c *Client) do(ctx context.Context, req request) (resp response, err error) {
+	data, signature, err := c.sign(req)
+	if err != nil {
+		return resp, Error.Wrap(err)
+	}
+
+	form := url.Values{"data": {data}, "signature": {signature}}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.APIURL+"/api/request", strings.NewReader(form.Encode()))
+	if err != nil {
+		return resp, Error.Wrap(err)
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	httpResp, err := c.http.Do(httpReq)
+	if err != nil {
+		c.log.Error("error sending liqpay request", Error.Wrap(err))
+		return resp, Error.Wrap(err)
+	}
+	defer func() {
+		err = errs.Combine(err, Error.Wrap(httpResp.Body.Close()))
+	}()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return resp, Error.New("unexpected status code %d", httpResp.StatusCode)
+	}
+
+	if err = json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return resp, Error.Wrap(err)
+	}
+
+	if resp.Result == statusError {
+		return resp, Error.New("%s: %s", resp.ErrCode, resp.ErrDescription)
+	}
+
+	return resp, nil
+}
+
+// sign encodes request into data field and signs it with the private key.
+func (c *Client) sign(req request) (data, signature string, err error) {
+	req.Version = apiVersion
+	req.PublicKey = c.config.PublicKey
+
+	raw, err := json.Marshal(req)
+	if err != nil {
+		return "", "", err
+	}
+
+	data = base64.StdEncoding.EncodeToString(raw)
+
+	return data, c.signature(data), nil
+}
+
+// verify checks that data is signed with the private key.
+func (c *Client) verify(data, signature string) bool {
+	return data != "" && subtle.ConstantTimeCompare([]byte(c.signature(data)), []byte(signature)) == 1
+}
+
+// signature returns base64 encoded sha1 of the data wrapped with the private key, as defined by LiqPay.
+func (c *Client) signature(data string) string {
+	hash := sha1.Sum([]byte(c.config.PrivateKey + data + c.config.PrivateKey))
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+// decode decodes base64 encoded json data.
+func decode(data string, v any) error {
+	raw, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(raw, v)
+}
+
+// supportsCurrency returns true if LiqPay accepts payments in the currency.
+func supportsCurrency(code string) bool {
+	switch code {
+	case currencies.UAH, currencies.USD, currencies.EUR:
+		return true
+	default:
+		return false
+	}
+}
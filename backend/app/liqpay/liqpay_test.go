@@ -0,0 +1,194 @@
+package liqpay_test
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/caarlos0/env/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app/currencies"
+	"one-help/app/liqpay"
+	"one-help/app/payments"
+	"one-help/internal/logger/zaplog"
+)
+
+const privateKey = "private_test"
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		data := r.PostForm.Get("data")
+		require.Equal(t, sign(data), r.PostForm.Get("signature"))
+
+		var req map[string]any
+		raw, err := base64.StdEncoding.DecodeString(data)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(raw, &req))
+
+		switch req["action"] {
+		case "status":
+			switch req["order_id"] {
+			case "sandbox":
+				_, _ = w.Write([]byte(`{"result": "ok", "status": "sandbox", "payment_id": 43, "amount": 5, "currency": "USD"}`))
+				return
+			case "abandoned":
+				_, _ = w.Write([]byte(`{"result": "error", "status": "error", "err_code": "payment_not_found"}`))
+				return
+			case "unavailable":
+				_, _ = w.Write([]byte(`{"result": "error", "status": "error", "err_code": "limit"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"result": "ok", "status": "success", "payment_id": 42, "amount": 150.5, "currency": "UAH"}`))
+		case "refund":
+			_, _ = w.Write([]byte(`{"result": "ok", "status": "reversed"}`))
+		case "reports":
+			_, _ = w.Write([]byte(`{"result": "success", "data": [
+				{"status": "success", "amount": 150.5, "currency": "UAH", "refund_amount": 50},
+				{"status": "success", "amount": 10, "currency": "USD"},
+				{"status": "failure", "amount": 20, "currency": "USD"},
+				{"status": "sandbox", "amount": 5, "currency": "USD"}
+			]}`))
+		default:
+			_, _ = w.Write([]byte(`{"result": "error", "err_code": "invalid_action"}`))
+		}
+	}))
+	defer server.Close()
+
+	config := liqpay.Config{
+		PublicKey:      "public_test",
+		PrivateKey:     privateKey,
+		RedirectDomain: "http://localhost:8080/api/v0",
+		APIURL:         server.URL,
+	}
+	client := liqpay.NewClient(zaplog.NewLog(), config)
+
+	t.Run("CreateSession", func(t *testing.T) {
+		session, err := client.CreateSession(ctx, payments.SessionParams{
+			Reference:    "donation",
+			RedirectPath: "/fundraises/donations/donation",
+			Amount:       currencies.NewMoney(currencies.MinorUnits(15050), currencies.UAH),
+			Description:  "Donation",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "donation", session.TransactionID)
+
+		checkoutURL, err := url.Parse(session.URL)
+		require.NoError(t, err)
+		assert.Equal(t, "/api/3/checkout", checkoutURL.Path)
+		assert.Equal(t, sign(checkoutURL.Query().Get("data")), checkoutURL.Query().Get("signature"))
+	})
+
+	t.Run("CreateSession(negative)", func(t *testing.T) {
+		_, err := client.CreateSession(ctx, payments.SessionParams{Reference: "donation", Amount: currencies.NewMoney(currencies.Zero, currencies.UAH)})
+		require.ErrorIs(t, err, payments.ErrAmountRequired)
+
+		_, err = client.CreateSession(ctx, payments.SessionParams{Reference: "donation", Amount: currencies.NewMoney(currencies.Major(10), currencies.PLN)})
+		require.ErrorIs(t, err, payments.ErrUnsupportedCurrency)
+	})
+
+	t.Run("Status", func(t *testing.T) {
+		status, err := client.Status(ctx, "donation")
+		require.NoError(t, err)
+		assert.True(t, status.Paid)
+		assert.Equal(t, currencies.NewMoney(currencies.MinorUnits(15050), currencies.UAH), status.Amount)
+		assert.Equal(t, "42", status.Reference)
+
+		_, err = client.Status(ctx, "abandoned")
+		require.ErrorIs(t, err, payments.ErrSessionNotFound)
+
+		// INFO: other errors do not mean the order was never paid.
+		_, err = client.Status(ctx, "unavailable")
+		require.Error(t, err)
+		require.NotErrorIs(t, err, payments.ErrSessionNotFound)
+	})
+
+	t.Run("Refund", func(t *testing.T) {
+		require.NoError(t, client.Refund(ctx, payments.Payment{TransactionId: "donation"}, currencies.Major(50)))
+	})
+
+	t.Run("Collected", func(t *testing.T) {
+		collected, err := client.Collected(ctx, time.Now().Add(-24*time.Hour), time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, map[string]currencies.Amount{"UAH": currencies.MinorUnits(10050), "USD": currencies.Major(10)}, collected)
+	})
+
+	t.Run("ParseWebhook", func(t *testing.T) {
+		data := base64.StdEncoding.EncodeToString([]byte(
+			`{"action": "pay", "status": "reversed", "payment_id": 42, "order_id": "donation", "amount": 150.5, "currency": "UAH", "refund_amount": 50}`,
+		))
+
+		payload := []byte(url.Values{"data": {data}, "signature": {sign(data)}}.Encode())
+		event, err := client.ParseWebhook(payload, http.Header{})
+		require.NoError(t, err)
+		assert.Equal(t, "42:reversed", event.ID)
+		assert.Equal(t, payments.EventRefunded, event.Kind)
+		assert.Equal(t, "donation", event.Reference)
+		assert.Equal(t, "42", event.PaymentReference)
+		assert.Equal(t, currencies.Major(50), event.Refunded)
+
+		payload = []byte(url.Values{"data": {data}, "signature": {"invalid"}}.Encode())
+		_, err = client.ParseWebhook(payload, http.Header{})
+		require.ErrorIs(t, err, payments.ErrInvalidSignature)
+	})
+
+	t.Run("Sandbox", func(t *testing.T) {
+		config.Sandbox = true
+		sandboxClient := liqpay.NewClient(zaplog.NewLog(), config)
+
+		data := base64.StdEncoding.EncodeToString([]byte(
+			`{"action": "pay", "status": "sandbox", "payment_id": 43, "order_id": "sandbox", "amount": 5, "currency": "USD"}`,
+		))
+		payload := []byte(url.Values{"data": {data}, "signature": {sign(data)}}.Encode())
+
+		// INFO: sandbox payments are not paid unless sandbox mode is enabled.
+		status, err := client.Status(ctx, "sandbox")
+		require.NoError(t, err)
+		assert.False(t, status.Paid)
+
+		event, err := client.ParseWebhook(payload, http.Header{})
+		require.NoError(t, err)
+		assert.Equal(t, payments.EventIgnored, event.Kind)
+		assert.False(t, event.Paid)
+
+		status, err = sandboxClient.Status(ctx, "sandbox")
+		require.NoError(t, err)
+		assert.True(t, status.Paid)
+
+		event, err = sandboxClient.ParseWebhook(payload, http.Header{})
+		require.NoError(t, err)
+		assert.Equal(t, payments.EventCompleted, event.Kind)
+		assert.True(t, event.Paid)
+
+		collected, err := sandboxClient.Collected(ctx, time.Now().Add(-24*time.Hour), time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, map[string]currencies.Amount{"UAH": currencies.MinorUnits(10050), "USD": currencies.Major(15)}, collected)
+	})
+}
+
+// sign returns LiqPay signature of the data.
+func sign(data string) string {
+	hash := sha1.Sum([]byte(privateKey + data + privateKey))
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+func TestConfig(t *testing.T) {
+	var config liqpay.Config
+	err := env.Parse(&config, env.Options{Environment: map[string]string{}, RequiredIfNoDef: true})
+	require.NoError(t, err)
+	assert.False(t, config.Sandbox)
+	assert.False(t, config.IsConfigured())
+
+	config.PublicKey, config.PrivateKey = "public_test", privateKey
+	assert.True(t, config.IsConfigured())
+}
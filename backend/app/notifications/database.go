@@ -0,0 +1,26 @@
+package notifications
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+)
+
+// ErrNoNotification indicates that notification does not exist.
+var ErrNoNotification = errs.New("notification does not exist")
+
+// DB exposes access to notifications db.
+//
+// architecture: DB
+type DB interface {
+	// Create inserts notification if there is no notification with its key, returns true in that case.
+	Create(ctx context.Context, notification Notification) (bool, error)
+	// GetByKey returns notification by its key.
+	GetByKey(ctx context.Context, key string) (Notification, error)
+	// ListUnsent returns failed notifications and pending notifications created before provided time,
+	// which were attempted less than maximum number of times, the oldest first.
+	ListUnsent(ctx context.Context, pendingBefore time.Time) ([]Notification, error)
+	// Update updates status and attempts of the notification.
+	Update(ctx context.Context, notification Notification) error
+}
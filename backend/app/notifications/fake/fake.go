@@ -0,0 +1,63 @@
+// Package fake provides in-memory notification sender, so notifications run locally and in tests without email provider.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+
+	"one-help/app/notifications"
+)
+
+// Error is an error wrapper that notifies that error was produced by fake notification sender.
+var Error = errs.Class("fake notification sender")
+
+// ensures that Sender implements notifications.Sender.
+var _ notifications.Sender = (*Sender)(nil)
+
+// Sender is an in-memory notification sender that records sent notifications.
+type Sender struct {
+	mu      sync.Mutex
+	sent    map[uuid.UUID]notifications.Notification
+	failure error
+}
+
+// NewSender is a constructor for fake notification sender.
+func NewSender() *Sender {
+	return &Sender{
+		sent: make(map[uuid.UUID]notifications.Notification),
+	}
+}
+
+// Send records notification, or fails with the error set by Fail.
+func (s *Sender) Send(ctx context.Context, notification notifications.Notification) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failure != nil {
+		return Error.Wrap(s.failure)
+	}
+
+	s.sent[notification.ID] = notification
+
+	return nil
+}
+
+// Fail makes following notifications fail with provided error, nil error makes them succeed again.
+func (s *Sender) Fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failure = err
+}
+
+// Sent returns sent notification by id.
+func (s *Sender) Sent(id uuid.UUID) (notifications.Notification, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	notification, ok := s.sent[id]
+	return notification, ok
+}
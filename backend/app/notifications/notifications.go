@@ -0,0 +1,49 @@
+package notifications
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// StatusPending defines notification recorded but not sent yet.
+	StatusPending string = "PENDING"
+	// StatusSent defines notification accepted by the sender.
+	StatusSent string = "SENT"
+	// StatusFailed defines notification declined by the sender.
+	StatusFailed string = "FAILED"
+)
+
+// MaxAttempts limits number of times the notification is sent before it is left failed.
+const MaxAttempts = 5
+
+// Notification describes message sent to the recipient by email.
+type Notification struct {
+	ID uuid.UUID
+	// Key identifies the event the notification is sent for, so that it is sent only once.
+	Key            string
+	Recipient      string // INFO: email address.
+	Subject        string
+	Body           string
+	Attachment     Attachment
+	Status         string
+	FailureMessage string
+	Attempts       int
+	CreatedAt      time.Time
+	SentAt         time.Time
+}
+
+// Attachment is a file attached to the notification, empty when the notification has none.
+type Attachment struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// Sender delivers notifications to their recipients.
+type Sender interface {
+	// Send delivers the notification, returns error if it was not accepted.
+	Send(ctx context.Context, notification Notification) error
+}
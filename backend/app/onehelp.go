@@ -1,15 +1,32 @@
 package app
 
 import (
+	"one-help/app/comments"
 	"one-help/app/donations"
+	"one-help/app/donations/offline"
+	"one-help/app/donations/plans"
+	"one-help/app/donations/receipts"
 	"one-help/app/events"
 	eventformats "one-help/app/events/formats"
 	eventparticipants "one-help/app/events/participants"
 	eventstatuses "one-help/app/events/statuses"
 	"one-help/app/fundraises"
+	fundraiseanalytics "one-help/app/fundraises/analytics"
+	"one-help/app/fundraises/leaderboards"
+	"one-help/app/fundraises/matching"
+	"one-help/app/fundraises/payouts"
+	fundraisereviews "one-help/app/fundraises/reviews"
 	fundraisestatuses "one-help/app/fundraises/statuses"
+	fundraisetransfers "one-help/app/fundraises/transfers"
+	"one-help/app/idempotency"
+	"one-help/app/ledger"
+	"one-help/app/notifications"
 	"one-help/app/payments"
+	"one-help/app/payments/reconciliation"
+	"one-help/app/payments/refunds"
+	"one-help/app/payments/statements"
 	paymenttypes "one-help/app/payments/types"
+	"one-help/app/payments/webhooks"
 	"one-help/app/posts"
 	"one-help/app/raffles"
 	"one-help/app/users"
@@ -38,6 +55,15 @@ type DB interface {
 	// Fundraises provides access to fundraises DB.
 	Fundraises() fundraises.DB
 
+	// FundraiseTransfers provides access to fundraise transfers DB.
+	FundraiseTransfers() fundraisetransfers.DB
+
+	// FundraiseAnalytics provides access to fundraise analytics DB.
+	FundraiseAnalytics() fundraiseanalytics.DB
+
+	// FundraiseReviews provides access to fundraise reviews DB.
+	FundraiseReviews() fundraisereviews.DB
+
 	// Events provides access to events DB.
 	Events() events.DB
 
@@ -59,9 +85,51 @@ type DB interface {
 	// Payments provides access to payments DB.
 	Payments() payments.DB
 
+	// DonationPlans provides access to recurring donation plans DB.
+	DonationPlans() plans.DB
+
+	// WebhookEvents provides access to payment webhook events DB.
+	WebhookEvents() webhooks.DB
+
+	// Refunds provides access to donation refunds DB.
+	Refunds() refunds.DB
+
+	// ReconciliationReports provides access to payment reconciliation reports DB.
+	ReconciliationReports() reconciliation.DB
+
+	// Receipts provides access to donation receipts DB.
+	Receipts() receipts.DB
+
+	// IdempotencyKeys provides access to idempotency keys DB.
+	IdempotencyKeys() idempotency.DB
+
+	// Matching provides access to fundraise matching pledges DB.
+	Matching() matching.DB
+
+	// Ledger provides access to ledger DB.
+	Ledger() ledger.DB
+
+	// Payouts provides access to fundraise payouts DB.
+	Payouts() payouts.DB
+
+	// OfflineDonations provides access to offline donations DB.
+	OfflineDonations() offline.DB
+
+	// BankStatements provides access to imported bank statements DB.
+	BankStatements() statements.DB
+
+	// Leaderboards provides access to donor and team leaderboards DB.
+	Leaderboards() leaderboards.DB
+
+	// Notifications provides access to notifications DB.
+	Notifications() notifications.DB
+
 	// Raffles provides access to raffles DB.
 	Raffles() raffles.DB
 
+	// Comments provides access to comments DB.
+	Comments() comments.DB
+
 	// ExecuteMigrations applies migrations for the database.
 	ExecuteMigrations(migrationsPath string, isUp bool) (err error)
 }
@@ -0,0 +1,47 @@
+package payments
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"one-help/app/currencies"
+)
+
+// ErrBalancesUnsupported indicates that provider does not report collected amounts.
+var ErrBalancesUnsupported = errs.New("payment provider does not report balances")
+
+// BalanceProvider is a payment provider able to report collected amounts for reconciliation.
+type BalanceProvider interface {
+	Provider
+	// Collected returns amounts of payments charged within the period, net of their refunds, by currency.
+	Collected(ctx context.Context, from, to time.Time) (map[string]currencies.Amount, error)
+}
+
+// Balances returns provider of the payment type that reports collected amounts.
+func (p *Providers) Balances(paymentType string) (BalanceProvider, error) {
+	provider, err := p.Get(paymentType)
+	if err != nil {
+		return nil, err
+	}
+
+	balances, ok := provider.(BalanceProvider)
+	if !ok {
+		return nil, ErrBalancesUnsupported
+	}
+
+	return balances, nil
+}
+
+// Types returns payment types of registered providers in alphabetical order.
+func (p *Providers) Types() []string {
+	types := make([]string, 0, len(p.providers))
+	for paymentType := range p.providers {
+		types = append(types, paymentType)
+	}
+	sort.Strings(types)
+
+	return types
+}
@@ -2,6 +2,7 @@ package payments
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/zeebo/errs"
@@ -20,6 +21,12 @@ type DB interface {
 	Create(ctx context.Context, payment Payment) error
 	// Get payment from the database.
 	Get(ctx context.Context, id uuid.UUID) (Payment, error)
+	// GetByTransaction returns payment by provider's checkout transaction id.
+	GetByTransaction(ctx context.Context, paymentType, transactionID string) (Payment, error)
+	// GetByReference returns payment by provider's payment reference.
+	GetByReference(ctx context.Context, paymentType, reference string) (Payment, error)
+	// ListPending returns pending payments of donations created before provided time.
+	ListPending(ctx context.Context, before time.Time) ([]Payment, error)
 	// List returns all available payments.
 	List(ctx context.Context) ([]Payment, error)
 	// Update updates payment in database by id.
@@ -0,0 +1,337 @@
+// Package fake provides in-memory payment provider, so payment flows run in tests without network.
+package fake
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+
+	"one-help/app/currencies"
+	"one-help/app/payments"
+)
+
+// Error is an error wrapper that notifies that error was produced by fake provider.
+var Error = errs.Class("fake payment provider")
+
+// ensures that Provider implements payments.SubscriptionProvider, payments.BalanceProvider and payments.FeeProvider.
+var (
+	_ payments.SubscriptionProvider = (*Provider)(nil)
+	_ payments.BalanceProvider      = (*Provider)(nil)
+	_ payments.FeeProvider          = (*Provider)(nil)
+)
+
+// CheckoutURL is a prefix of fake checkout urls, followed by transaction id.
+const CheckoutURL = "https://checkout.fake/"
+
+// SignatureHeader is a header of fake webhook payload signature.
+const SignatureHeader = "X-Fake-Signature"
+
+// Provider is an in-memory payment provider that signs its webhooks with random secret.
+type Provider struct {
+	paymentType string
+	secret      []byte
+
+	mu       sync.Mutex
+	sessions map[string]payments.SessionParams
+	statuses map[string]payments.SessionStatus
+	refunds  map[string]currencies.Amount
+	paidAt   map[string]time.Time
+	fees     payments.FeeSchedule
+
+	subscriptions map[string]*Subscription
+}
+
+// Subscription describes state of fake subscription.
+type Subscription struct {
+	Params   payments.SubscriptionParams
+	Paused   bool
+	Canceled bool
+	Retries  int
+}
+
+// NewProvider is a constructor for fake provider of the payment type.
+func NewProvider(paymentType string) *Provider {
+	return &Provider{
+		paymentType: paymentType,
+		secret:      []byte(uuid.NewString()),
+		sessions:    make(map[string]payments.SessionParams),
+		statuses:    make(map[string]payments.SessionStatus),
+		refunds:     make(map[string]currencies.Amount),
+		paidAt:      make(map[string]time.Time),
+
+		subscriptions: make(map[string]*Subscription),
+	}
+}
+
+// Type returns payment type of the provider.
+func (p *Provider) Type() string {
+	return p.paymentType
+}
+
+// SetFees sets processing fee schedule of the provider.
+func (p *Provider) SetFees(fees payments.FeeSchedule) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.fees = fees
+}
+
+// Fees returns processing fee schedule set by SetFees.
+func (p *Provider) Fees() payments.FeeSchedule {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.fees
+}
+
+// CreateSession stores session params and returns fake checkout url.
+func (p *Provider) CreateSession(ctx context.Context, params payments.SessionParams) (payments.Session, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	transactionID := "fake_" + uuid.NewString()
+	p.sessions[transactionID] = params
+	p.statuses[transactionID] = payments.SessionStatus{Amount: params.Amount}
+
+	return payments.Session{URL: CheckoutURL + transactionID, TransactionID: transactionID}, nil
+}
+
+// Status returns session status set by Pay.
+func (p *Provider) Status(ctx context.Context, transactionID string) (payments.SessionStatus, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	status, ok := p.statuses[transactionID]
+	if !ok {
+		return payments.SessionStatus{}, Error.Wrap(payments.ErrSessionNotFound)
+	}
+
+	return status, nil
+}
+
+// Refund records refunded amount of the payment.
+func (p *Provider) Refund(ctx context.Context, payment payments.Payment, amount currencies.Amount) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.refunds[payment.Reference] = p.refunds[payment.Reference].Add(amount)
+	return nil
+}
+
+// ParseWebhook verifies signature of the payload created by Webhook.
+func (p *Provider) ParseWebhook(payload []byte, header http.Header) (payments.Event, error) {
+	if !hmac.Equal([]byte(p.sign(payload)), []byte(header.Get(SignatureHeader))) {
+		return payments.Event{}, Error.Wrap(payments.ErrInvalidSignature)
+	}
+
+	var event payments.Event
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return payments.Event{}, Error.Wrap(err)
+	}
+
+	event.Payload = payload
+
+	return event, nil
+}
+
+// Pay marks session as paid with the session amount, returning its completion event.
+func (p *Provider) Pay(transactionID string) payments.Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	params := p.sessions[transactionID]
+	status := payments.SessionStatus{
+		Paid:      true,
+		Amount:    params.Amount,
+		Reference: "pay_" + transactionID,
+	}
+	p.statuses[transactionID] = status
+	p.paidAt[transactionID] = time.Now()
+
+	return payments.Event{
+		ID:               uuid.NewString(),
+		Type:             string(payments.EventCompleted),
+		Kind:             payments.EventCompleted,
+		Reference:        params.Reference,
+		TransactionID:    transactionID,
+		PaymentReference: status.Reference,
+		Paid:             true,
+		Amount:           status.Amount,
+	}
+}
+
+// Expire marks unpaid session as expired.
+func (p *Provider) Expire(transactionID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	status := p.statuses[transactionID]
+	status.Expired = true
+	p.statuses[transactionID] = status
+}
+
+// Collected returns amounts of sessions paid within the period, net of their refunds.
+func (p *Provider) Collected(ctx context.Context, from, to time.Time) (map[string]currencies.Amount, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	collected := make(map[string]currencies.Amount)
+	for transactionID, paidAt := range p.paidAt {
+		if paidAt.Before(from) || !paidAt.Before(to) {
+			continue
+		}
+
+		status := p.statuses[transactionID]
+		currency := status.Amount.Currency
+		collected[currency] = collected[currency].Add(status.Amount.Amount.Sub(p.refunds[status.Reference]))
+	}
+
+	return collected, nil
+}
+
+// Refunded returns total amount refunded for the payment reference.
+func (p *Provider) Refunded(reference string) currencies.Amount {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.refunds[reference]
+}
+
+// Webhook encodes and signs the event, as it would be delivered by the provider.
+func (p *Provider) Webhook(event payments.Event) (payload []byte, header http.Header, err error) {
+	event.Payload = nil
+	payload, err = json.Marshal(event)
+	if err != nil {
+		return nil, nil, Error.Wrap(err)
+	}
+
+	return payload, http.Header{SignatureHeader: {p.sign(payload)}}, nil
+}
+
+// sign returns hex encoded HMAC-SHA256 of the payload.
+func (p *Provider) sign(payload []byte) string {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// CreateSubscription stores subscription params and returns fake checkout url.
+func (p *Provider) CreateSubscription(ctx context.Context, params payments.SubscriptionParams) (payments.Session, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	transactionID := "fake_" + uuid.NewString()
+	p.subscriptions[transactionID] = &Subscription{Params: params}
+
+	return payments.Session{URL: CheckoutURL + transactionID, TransactionID: transactionID}, nil
+}
+
+// UpdateSubscription changes subscription amount.
+func (p *Provider) UpdateSubscription(ctx context.Context, subscriptionID string, amount currencies.Amount) error {
+	return p.updateSubscription(subscriptionID, func(subscription *Subscription) {
+		subscription.Params.Amount.Amount = amount
+	})
+}
+
+// PauseSubscription changes subscription pause state.
+func (p *Provider) PauseSubscription(ctx context.Context, subscriptionID string, paused bool) error {
+	return p.updateSubscription(subscriptionID, func(subscription *Subscription) {
+		subscription.Paused = paused
+	})
+}
+
+// CancelSubscription marks subscription as canceled.
+func (p *Provider) CancelSubscription(ctx context.Context, subscriptionID string) error {
+	return p.updateSubscription(subscriptionID, func(subscription *Subscription) {
+		subscription.Canceled = true
+	})
+}
+
+// RetrySubscription counts retries of the subscription.
+func (p *Provider) RetrySubscription(ctx context.Context, subscriptionID string) error {
+	return p.updateSubscription(subscriptionID, func(subscription *Subscription) {
+		subscription.Retries++
+	})
+}
+
+// Subscription returns state of the subscription.
+func (p *Provider) Subscription(subscriptionID string) (Subscription, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	subscription, ok := p.subscriptions[subscriptionID]
+	if !ok {
+		return Subscription{}, false
+	}
+
+	return *subscription, true
+}
+
+// StartSubscription returns started event of the subscription created with checkout transaction id.
+// Subscription id equals to the transaction id.
+func (p *Provider) StartSubscription(transactionID string) payments.Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	params := p.subscriptions[transactionID].Params
+
+	return payments.Event{
+		ID:             uuid.NewString(),
+		Type:           string(payments.EventSubscriptionStarted),
+		Kind:           payments.EventSubscriptionStarted,
+		Reference:      params.Reference,
+		TransactionID:  transactionID,
+		SubscriptionID: transactionID,
+		Paid:           true,
+		Amount:         params.Amount,
+	}
+}
+
+// ChargeSubscription returns charged or failed event of the subscription's recurring charge.
+func (p *Provider) ChargeSubscription(subscriptionID string, paid bool) payments.Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	params := p.subscriptions[subscriptionID].Params
+	kind := payments.EventSubscriptionFailed
+	if paid {
+		kind = payments.EventSubscriptionCharged
+	}
+
+	invoiceID := "fake_invoice_" + uuid.NewString()
+
+	return payments.Event{
+		ID:               uuid.NewString(),
+		Type:             string(kind),
+		Kind:             kind,
+		Reference:        params.Reference,
+		TransactionID:    invoiceID,
+		SubscriptionID:   subscriptionID,
+		PaymentReference: "pay_" + invoiceID,
+		Paid:             paid,
+		Amount:           params.Amount,
+	}
+}
+
+// updateSubscription applies update to existing subscription.
+func (p *Provider) updateSubscription(subscriptionID string, update func(subscription *Subscription)) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	subscription, ok := p.subscriptions[subscriptionID]
+	if !ok {
+		return Error.New("subscription %s does not exist", subscriptionID)
+	}
+
+	update(subscription)
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package fake_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app/currencies"
+	"one-help/app/payments"
+	"one-help/app/payments/fake"
+)
+
+func TestProvider(t *testing.T) {
+	ctx := context.Background()
+	provider := fake.NewProvider(payments.TypeStripe)
+	providers := payments.NewProviders(provider)
+
+	registered, err := providers.Get(payments.TypeStripe)
+	require.NoError(t, err)
+	assert.Equal(t, provider, registered)
+
+	_, err = providers.Get(payments.TypeLiqPay)
+	require.ErrorIs(t, err, payments.ErrUnknownProvider)
+
+	session, err := provider.CreateSession(ctx, payments.SessionParams{Reference: "donation", Amount: currencies.NewMoney(currencies.Major(100), currencies.UAH)})
+	require.NoError(t, err)
+
+	status, err := provider.Status(ctx, session.TransactionID)
+	require.NoError(t, err)
+	assert.False(t, status.Paid)
+
+	_, err = provider.Status(ctx, "unknown")
+	require.ErrorIs(t, err, payments.ErrSessionNotFound)
+
+	payload, header, err := provider.Webhook(provider.Pay(session.TransactionID))
+	require.NoError(t, err)
+
+	event, err := provider.ParseWebhook(payload, header)
+	require.NoError(t, err)
+	assert.Equal(t, payments.EventCompleted, event.Kind)
+	assert.Equal(t, "donation", event.Reference)
+	assert.Equal(t, currencies.NewMoney(currencies.Major(100), currencies.UAH), event.Amount)
+
+	_, err = provider.ParseWebhook(payload, http.Header{})
+	require.ErrorIs(t, err, payments.ErrInvalidSignature)
+
+	status, err = provider.Status(ctx, session.TransactionID)
+	require.NoError(t, err)
+	assert.True(t, status.Paid)
+
+	require.NoError(t, provider.Refund(ctx, payments.Payment{Reference: status.Reference}, currencies.Major(40)))
+	assert.Equal(t, currencies.Major(40), provider.Refunded(status.Reference))
+}
@@ -0,0 +1,87 @@
+package payments
+
+import (
+	"math"
+	"strings"
+
+	"github.com/zeebo/errs"
+
+	"one-help/app/currencies"
+)
+
+// FeeSchedule describes processing fee of the payment provider: percent of the charged amount plus fixed fee.
+// Zero schedule is used if fees are not configured.
+type FeeSchedule struct {
+	Percent float64   `env:"PERCENT" envDefault:"0"`
+	Fixed   FixedFees `env:"FIXED" envDefault:""`
+}
+
+// FixedFees holds fixed fee by currency, configured as "USD:0.30,EUR:0.25".
+type FixedFees map[string]currencies.Amount
+
+// UnmarshalText decodes fixed fees from comma separated currency and amount pairs.
+func (fees *FixedFees) UnmarshalText(text []byte) error {
+	parsed := make(FixedFees)
+	if strings.TrimSpace(string(text)) == "" {
+		*fees = parsed
+		return nil
+	}
+
+	for _, pair := range strings.Split(string(text), ",") {
+		currency, value, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok {
+			return errs.New("fixed fee %q must be currency and amount separated by colon", pair)
+		}
+
+		amount, err := currencies.ParseAmount(value)
+		if err != nil {
+			return err
+		}
+
+		parsed[strings.ToUpper(currency)] = amount
+	}
+
+	*fees = parsed
+	return nil
+}
+
+// FeeProvider is implemented by payment providers with configured fee schedule.
+type FeeProvider interface {
+	// Fees returns processing fee schedule of the provider.
+	Fees() FeeSchedule
+}
+
+// Fee returns estimated fee the provider takes from the charged amount.
+func (s FeeSchedule) Fee(gross currencies.Amount, currency string) currencies.Amount {
+	return currencies.MinorUnits(s.fee(gross.MinorUnits(), currency))
+}
+
+// Gross returns amount to charge, so that net amount remains after the provider takes its fee.
+func (s FeeSchedule) Gross(net currencies.Amount, currency string) currencies.Amount {
+	units := net.MinorUnits()
+	if units <= 0 || s.Percent >= 100 {
+		return net
+	}
+
+	gross := int64(math.Ceil(float64(units+s.Fixed[currency].MinorUnits()) / (1 - s.Percent/100)))
+
+	// INFO: rounding of the percent fee may shift the smallest sufficient charge by a minor unit.
+	for gross-s.fee(gross, currency) < units {
+		gross++
+	}
+	for gross-1-s.fee(gross-1, currency) >= units {
+		gross--
+	}
+
+	return currencies.MinorUnits(gross)
+}
+
+// fee returns fee of the charged amount in minor units, it never exceeds the amount.
+func (s FeeSchedule) fee(charged int64, currency string) int64 {
+	if charged <= 0 {
+		return 0
+	}
+
+	fee := int64(math.Round(float64(charged)*s.Percent/100)) + s.Fixed[currency].MinorUnits()
+	return min(fee, charged)
+}
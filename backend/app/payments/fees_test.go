@@ -0,0 +1,74 @@
+package payments_test
+
+import (
+	"testing"
+
+	"github.com/caarlos0/env/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app/currencies"
+	"one-help/app/payments"
+)
+
+func TestFeeSchedule(t *testing.T) {
+	fees := payments.FeeSchedule{Percent: 2.9, Fixed: payments.FixedFees{currencies.USD: currencies.MinorUnits(30)}}
+
+	t.Run("config", func(t *testing.T) {
+		var config struct {
+			Fees payments.FeeSchedule `envPrefix:"FEE_"`
+		}
+		err := env.Parse(&config, env.Options{Environment: map[string]string{
+			"FEE_PERCENT": "2.9",
+			"FEE_FIXED":   "usd:0.30, EUR:0.25",
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, payments.FeeSchedule{
+			Percent: 2.9,
+			Fixed:   payments.FixedFees{currencies.USD: currencies.MinorUnits(30), currencies.EUR: currencies.MinorUnits(25)},
+		}, config.Fees)
+	})
+
+	t.Run("config defaults", func(t *testing.T) {
+		var config struct {
+			Fees payments.FeeSchedule `envPrefix:"FEE_"`
+		}
+		err := env.Parse(&config, env.Options{Environment: map[string]string{}, RequiredIfNoDef: true})
+		require.NoError(t, err)
+		assert.Zero(t, config.Fees.Percent)
+		assert.True(t, config.Fees.Fee(currencies.Major(100), currencies.USD).IsZero())
+	})
+
+	t.Run("fee", func(t *testing.T) {
+		assert.Equal(t, currencies.MinorUnits(320), fees.Fee(currencies.Major(100), currencies.USD))
+		assert.Equal(t, currencies.MinorUnits(290), fees.Fee(currencies.Major(100), currencies.EUR))
+		assert.Equal(t, currencies.MinorUnits(20), fees.Fee(currencies.MinorUnits(20), currencies.USD))
+		assert.True(t, fees.Fee(currencies.Zero, currencies.USD).IsZero())
+		assert.True(t, payments.FeeSchedule{}.Fee(currencies.Major(100), currencies.USD).IsZero())
+	})
+
+	t.Run("gross", func(t *testing.T) {
+		for _, units := range []int64{1, 100, 1000, 9999, 10000, 123456} {
+			net := currencies.MinorUnits(units)
+
+			// INFO: gross is the smallest charge that leaves at least net after the fee.
+			gross := fees.Gross(net, currencies.USD)
+			assert.GreaterOrEqual(t, gross.Sub(fees.Fee(gross, currencies.USD)).Cmp(net), 0, "net %s", net)
+
+			less := gross.Sub(currencies.MinorUnits(1))
+			assert.Negative(t, less.Sub(fees.Fee(less, currencies.USD)).Cmp(net), "net %s", net)
+		}
+
+		assert.Equal(t, currencies.MinorUnits(10330), fees.Gross(currencies.Major(100), currencies.USD))
+		assert.Equal(t, currencies.Major(100), payments.FeeSchedule{}.Gross(currencies.Major(100), currencies.USD))
+		assert.True(t, fees.Gross(currencies.Zero, currencies.USD).IsZero())
+	})
+
+	t.Run("charge", func(t *testing.T) {
+		var payment payments.Payment
+		payment.Charge(currencies.MinorUnits(10330), currencies.USD, fees)
+		assert.Equal(t, currencies.MinorUnits(10330), payment.GrossAmount)
+		assert.Equal(t, currencies.MinorUnits(330), payment.FeeAmount)
+		assert.Equal(t, currencies.Major(100), payment.NetAmount)
+	})
+}
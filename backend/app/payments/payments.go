@@ -1,16 +1,79 @@
 package payments
 
 import (
+	"slices"
+
 	"github.com/google/uuid"
+
+	"one-help/app/currencies"
+)
+
+const (
+	// TypeStripe defines stripe payment type.
+	TypeStripe string = "STRIPE"
+	// TypeLiqPay defines LiqPay payment type.
+	TypeLiqPay string = "LIQPAY"
+	// TypeBankTransfer defines self-reported donation received by bank transfer.
+	TypeBankTransfer string = "BANK_TRANSFER"
+	// TypeCash defines self-reported donation received in cash, e.g. at events.
+	TypeCash string = "CASH"
+	// TypeMonobankJar defines self-reported donation collected by Monobank jar.
+	TypeMonobankJar string = "MONOBANK_JAR"
 )
 
-// TypeStripe defines stripe payment type.
-const TypeStripe string = "STRIPE"
+// SelfReportedTypes lists payment types of donations recorded by organizers without provider's confirmation.
+var SelfReportedTypes = []string{TypeBankTransfer, TypeCash, TypeMonobankJar}
+
+// IsSelfReported returns true if donations of the payment type are recorded by organizers, not confirmed by provider.
+func IsSelfReported(paymentType string) bool {
+	return slices.Contains(SelfReportedTypes, paymentType)
+}
+
+const (
+	// StatusPending defines payment awaiting provider's confirmation.
+	StatusPending string = "PENDING"
+	// StatusConfirmed defines successfully charged payment.
+	StatusConfirmed string = "CONFIRMED"
+	// StatusFailed defines payment declined by provider.
+	StatusFailed string = "FAILED"
+	// StatusCanceled defines payment abandoned by donor.
+	StatusCanceled string = "CANCELED"
+	// StatusPartiallyRefunded defines confirmed payment with part of the amount returned to donor.
+	StatusPartiallyRefunded string = "PARTIALLY_REFUNDED"
+	// StatusRefunded defines payment fully returned to donor.
+	StatusRefunded string = "REFUNDED"
+)
 
 // Payment holds payment info.
 type Payment struct {
 	DonationId    uuid.UUID
 	PaymentType   string
 	TransactionId string
-	Confirmed     bool
+	// Confirmed is true while donation counts towards fundraise totals.
+	Confirmed bool
+	Status    string
+	// Reference is provider's payment identifier, assigned after checkout, e.g. Stripe payment intent.
+	Reference string
+	// RefundedAmount is the total amount returned to the donor, equal to the donation amount once fully refunded.
+	RefundedAmount currencies.Amount
+	// GrossAmount is the amount charged from the donor, including the fee covered by the donor.
+	GrossAmount currencies.Amount
+	// FeeAmount is the processing fee estimated by the provider's fee schedule.
+	FeeAmount currencies.Amount
+	// NetAmount is the amount the fundraise receives, it counts towards fundraise progress.
+	NetAmount currencies.Amount
+	// CoversFee is true if the donor opted to cover the processing fee.
+	CoversFee bool
+}
+
+// Charge records amounts of the charge in the donation currency with the fee estimated by the schedule.
+func (p *Payment) Charge(gross currencies.Amount, currency string, fees FeeSchedule) {
+	p.GrossAmount = gross
+	p.FeeAmount = fees.Fee(gross, currency)
+	p.NetAmount = gross.Sub(p.FeeAmount)
+}
+
+// IsFinal returns true if payment will not be confirmed anymore.
+func (p *Payment) IsFinal() bool {
+	return p.Status != StatusPending
 }
@@ -0,0 +1,164 @@
+package payments
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/zeebo/errs"
+
+	"one-help/app/currencies"
+)
+
+var (
+	// ErrUnknownProvider indicates that no provider is registered for the payment type.
+	ErrUnknownProvider = errs.New("payment provider is not registered")
+	// ErrInvalidSignature indicates that webhook payload is not signed by the provider.
+	ErrInvalidSignature = errs.New("invalid webhook signature")
+	// ErrSessionNotFound indicates that provider does not know the checkout session, so it was never paid.
+	ErrSessionNotFound = errs.New("checkout session does not exist")
+	// ErrUnsupportedCurrency indicates that provider can not charge in requested currency.
+	ErrUnsupportedCurrency = errs.New("currency is not supported by payment provider")
+	// ErrAmountRequired indicates that provider has no default amount and can not charge without one.
+	ErrAmountRequired = errs.New("amount is required by payment provider")
+)
+
+// MaxAmount defines the largest amount that can be charged in a single payment.
+var MaxAmount = currencies.MinorUnits(99_999_999)
+
+// SessionParams defines values needed to setup checkout session.
+type SessionParams struct {
+	// Reference identifies the donation in webhook events, e.g. donation id.
+	Reference string
+	// RedirectPath is where donor returns after checkout, must start with '/'.
+	RedirectPath string
+	// Amount chosen by the donor in the checkout currency, provider's default amount is charged if amount is zero.
+	Amount currencies.Money
+	// Description is displayed to the donor on the checkout page.
+	Description string
+}
+
+// Session describes created checkout session.
+type Session struct {
+	URL string
+	// TransactionID is provider's checkout session identifier.
+	TransactionID string
+}
+
+// SessionStatus describes current state of checkout session.
+type SessionStatus struct {
+	Paid   bool
+	Amount currencies.Money
+	// Expired is true if unpaid session can not be paid anymore.
+	Expired bool
+	// Reference is provider's payment identifier, if payment was made.
+	Reference string
+}
+
+// EventKind describes the effect of webhook event on the payment.
+type EventKind string
+
+const (
+	// EventIgnored defines event that does not change payment.
+	EventIgnored EventKind = "IGNORED"
+	// EventCompleted defines finished checkout, payment is charged if event is Paid.
+	EventCompleted EventKind = "COMPLETED"
+	// EventExpired defines checkout abandoned by donor.
+	EventExpired EventKind = "EXPIRED"
+	// EventFailed defines declined payment.
+	EventFailed EventKind = "FAILED"
+	// EventRefunded defines fully or partially refunded payment.
+	EventRefunded EventKind = "REFUNDED"
+)
+
+// Event holds verified webhook event data needed to update donation payment.
+type Event struct {
+	ID string
+	// Type is provider's event type.
+	Type    string
+	Kind    EventKind
+	Payload []byte
+
+	// Reference is the donation reference provided on session setup, if available in the event.
+	Reference     string
+	TransactionID string
+	// SubscriptionID is provider's subscription identifier of recurring charges.
+	SubscriptionID string
+	// PaymentReference is provider's payment identifier.
+	PaymentReference string
+	Paid             bool
+	// Amount is the charged amount, currency is empty if the event does not provide it.
+	Amount currencies.Money
+	// Refunded is the total refunded amount of the payment, in the currency of the charged amount.
+	Refunded       currencies.Amount
+	FailureMessage string
+}
+
+// Provider is a payment gateway that charges donors.
+type Provider interface {
+	// Type returns payment type of the provider, as stored in payment_types.
+	Type() string
+	// CreateSession setups checkout session and provides payment redirect url.
+	CreateSession(ctx context.Context, params SessionParams) (Session, error)
+	// Status queries provider for the current state of checkout session.
+	// Returns ErrSessionNotFound if the provider does not know the session.
+	Status(ctx context.Context, transactionID string) (SessionStatus, error)
+	// Refund returns amount of the payment to the donor.
+	Refund(ctx context.Context, payment Payment, amount currencies.Amount) error
+	// ParseWebhook verifies signature of webhook request and parses the event.
+	ParseWebhook(payload []byte, header http.Header) (Event, error)
+}
+
+// Providers holds payment providers registered by payment type.
+type Providers struct {
+	providers map[string]Provider
+}
+
+// NewProviders is a constructor for payment providers registry.
+func NewProviders(providers ...Provider) *Providers {
+	registry := &Providers{providers: make(map[string]Provider, len(providers))}
+	for _, provider := range providers {
+		registry.Register(provider)
+	}
+
+	return registry
+}
+
+// Register adds provider to the registry, replacing the provider of the same type.
+func (p *Providers) Register(provider Provider) {
+	p.providers[provider.Type()] = provider
+}
+
+// Get returns provider of the payment type.
+func (p *Providers) Get(paymentType string) (Provider, error) {
+	provider, ok := p.providers[paymentType]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+
+	return provider, nil
+}
+
+// Subscriptions returns provider of the payment type that supports recurring charges.
+func (p *Providers) Subscriptions(paymentType string) (SubscriptionProvider, error) {
+	provider, err := p.Get(paymentType)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions, ok := provider.(SubscriptionProvider)
+	if !ok {
+		return nil, ErrSubscriptionsUnsupported
+	}
+
+	return subscriptions, nil
+}
+
+// Fees returns processing fee schedule of the payment type, zero if provider has no fees configured.
+func (p *Providers) Fees(paymentType string) FeeSchedule {
+	provider, ok := p.providers[paymentType].(FeeProvider)
+	if !ok {
+		return FeeSchedule{}
+	}
+
+	return provider.Fees()
+}
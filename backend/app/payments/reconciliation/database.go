@@ -0,0 +1,23 @@
+package reconciliation
+
+import (
+	"context"
+	"time"
+)
+
+// DB exposes access to reconciliation reports db.
+//
+// architecture: DB
+type DB interface {
+	// Confirmed returns totals of confirmed donations created within the period, net of refunds,
+	// as reports without provider's amounts.
+	Confirmed(ctx context.Context, from, to time.Time) ([]Report, error)
+	// Save inserts report or replaces the report of the same day, payment type and currency.
+	Save(ctx context.Context, report Report) error
+	// List returns reports of the day ordered by payment type and currency.
+	List(ctx context.Context, date time.Time) ([]Report, error)
+	// MarkReported records that the day is reported, even if it has no reports.
+	MarkReported(ctx context.Context, date, reportedAt time.Time) error
+	// IsReported returns true if the day is reported.
+	IsReported(ctx context.Context, date time.Time) (bool, error)
+}
@@ -0,0 +1,30 @@
+package reconciliation
+
+import (
+	"time"
+
+	"one-help/app/currencies"
+)
+
+// Report compares amounts confirmed by us with amounts collected by the payment provider
+// for payments of one day in one currency.
+type Report struct {
+	Date        time.Time // INFO: start of the UTC day.
+	PaymentType string
+	Currency    string
+	// Confirmed is the total of confirmed donations net of refunds.
+	Confirmed currencies.Amount
+	// Collected is the total reported by the provider, net of refunds.
+	Collected currencies.Amount
+	CreatedAt time.Time
+}
+
+// Difference returns amount collected by provider but not confirmed by us, negative if we confirmed more.
+func (r *Report) Difference() currencies.Amount {
+	return r.Collected.Sub(r.Confirmed)
+}
+
+// IsBalanced returns true if confirmed and collected totals match.
+func (r *Report) IsBalanced() bool {
+	return r.Collected == r.Confirmed
+}
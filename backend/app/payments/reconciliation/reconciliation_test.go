@@ -0,0 +1,20 @@
+package reconciliation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"one-help/app/currencies"
+	"one-help/app/payments/reconciliation"
+)
+
+func TestReport(t *testing.T) {
+	report := reconciliation.Report{Confirmed: currencies.MinorUnits(10010), Collected: currencies.MinorUnits(10030)}
+	assert.False(t, report.IsBalanced())
+	assert.Equal(t, currencies.MinorUnits(20), report.Difference())
+
+	report.Collected = currencies.MinorUnits(10010)
+	assert.True(t, report.IsBalanced())
+	assert.Equal(t, currencies.Zero, report.Difference())
+}
@@ -0,0 +1,27 @@
+package refunds
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+)
+
+// ErrNoRefund indicates that refund does not exist.
+var ErrNoRefund = errs.New("refund does not exist")
+
+// DB exposes access to refunds db.
+//
+// architecture: DB
+type DB interface {
+	// Create inserts refund into the database.
+	Create(ctx context.Context, refund Refund) error
+	// Get returns refund by id.
+	Get(ctx context.Context, id uuid.UUID) (Refund, error)
+	// ListByDonation returns refunds of the donation, the oldest first.
+	ListByDonation(ctx context.Context, donationID uuid.UUID) ([]Refund, error)
+	// ListByStatus returns refunds with provided status, the oldest first.
+	ListByStatus(ctx context.Context, status string) ([]Refund, error)
+	// Update updates refund in the database by id.
+	Update(ctx context.Context, refund Refund) error
+}
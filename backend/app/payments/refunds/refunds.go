@@ -0,0 +1,59 @@
+package refunds
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"one-help/app/currencies"
+)
+
+const (
+	// StatusPendingApproval defines refund awaiting administrator's approval.
+	StatusPendingApproval string = "PENDING_APPROVAL"
+	// StatusProcessing defines refund sent to the payment provider.
+	StatusProcessing string = "PROCESSING"
+	// StatusSucceeded defines refund accepted by the payment provider.
+	StatusSucceeded string = "SUCCEEDED"
+	// StatusFailed defines refund declined by the payment provider.
+	StatusFailed string = "FAILED"
+	// StatusRejected defines refund rejected by administrator.
+	StatusRejected string = "REJECTED"
+)
+
+// Refund describes return of the whole or part of the donation payment to the donor.
+type Refund struct {
+	ID          uuid.UUID
+	DonationID  uuid.UUID
+	Amount      currencies.Amount // INFO: in the donation currency.
+	Currency    string
+	Reason      string // INFO: visible to the donor.
+	Status      string
+	RequestedBy uuid.UUID
+	ReviewedBy  uuid.UUID // INFO: nil uuid unless refund required approval.
+	// ReviewReason explains administrator's rejection.
+	ReviewReason   string
+	FailureMessage string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// IsOpen returns true if refund is not finished yet, its amount is reserved from the refundable balance.
+func (r *Refund) IsOpen() bool {
+	return r.Status == StatusPendingApproval || r.Status == StatusProcessing
+}
+
+// CreateParams defines values needed to refund the donation.
+type CreateParams struct {
+	DonationID uuid.UUID
+	CallerID   uuid.UUID
+	Amount     currencies.Amount // INFO: zero refunds whole remaining amount of the donation.
+	Reason     string
+}
+
+// ReviewParams defines values needed to approve or reject refund pending approval.
+type ReviewParams struct {
+	RefundID uuid.UUID
+	CallerID uuid.UUID
+	Reason   string // INFO: required for rejection.
+}
@@ -0,0 +1,361 @@
+package refunds
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+
+	"one-help/app/currencies"
+	"one-help/app/donations"
+	"one-help/app/fundraises"
+	"one-help/app/fundraises/payouts"
+	"one-help/app/fundraises/statuses"
+	"one-help/app/fundraises/transfers"
+	"one-help/app/payments"
+	"one-help/app/users"
+	"one-help/internal/logger"
+)
+
+var (
+	// Error wraps errors from refunds service that indicates about internal errors.
+	Error = errs.Class("refunds service")
+	// ParamsError wraps errors from refunds service that indicates about invalid or malformed parameters' data.
+	ParamsError = errs.Class("refunds service: params")
+	// ErrNotRefundable indicates that requested amount exceeds the not yet refunded amount of the donation.
+	ErrNotRefundable = errs.New("amount exceeds refundable amount of the donation")
+)
+
+// Service handles refunds related logic.
+//
+// architecture: Service
+type Service struct {
+	logger logger.Logger
+
+	refunds    DB
+	donations  donations.DB
+	fundraises fundraises.DB
+	payments   payments.DB
+	payouts    payouts.DB
+	transfers  transfers.DB
+	users      users.DB
+
+	providers *payments.Providers
+	// fundraisesService applies succeeded refunds to the fundraise balance and totals.
+	fundraisesService *fundraises.Service
+}
+
+// NewService is a constructor for refunds service.
+func NewService(
+	logger logger.Logger,
+	refunds DB,
+	donations donations.DB,
+	fundraisesDB fundraises.DB,
+	payments payments.DB,
+	payouts payouts.DB,
+	transfers transfers.DB,
+	users users.DB,
+	providers *payments.Providers,
+	fundraisesService *fundraises.Service,
+) *Service {
+	return &Service{
+		logger:            logger,
+		refunds:           refunds,
+		donations:         donations,
+		fundraises:        fundraisesDB,
+		payments:          payments,
+		payouts:           payouts,
+		transfers:         transfers,
+		users:             users,
+		providers:         providers,
+		fundraisesService: fundraisesService,
+	}
+}
+
+// Create returns whole or part of the confirmed donation to the donor through the payment provider,
+// allowed to the fundraise organizer and administrators.
+// Refund requested by organizer after funds of the fundraise were transferred, spent or paid out waits for administrator's approval.
+func (service *Service) Create(ctx context.Context, params CreateParams) (Refund, error) {
+	switch {
+	case params.Amount.Sign() < 0:
+		return Refund{}, ParamsError.New("amount must be positive")
+	case params.Reason == "":
+		return Refund{}, ParamsError.New("reason is required")
+	}
+
+	donation, err := service.donations.Get(ctx, params.DonationID)
+	if err != nil {
+		return Refund{}, Error.Wrap(err)
+	}
+
+	fundraise, err := service.fundraises.Get(ctx, donation.FundraiseId)
+	if err != nil {
+		return Refund{}, Error.Wrap(err)
+	}
+
+	caller, err := service.users.Get(ctx, params.CallerID)
+	if err != nil {
+		return Refund{}, Error.Wrap(err)
+	}
+
+	if fundraise.OrganizerId != caller.ID && !caller.IsAdmin() {
+		return Refund{}, ParamsError.Wrap(fundraises.ErrNotOrganizer)
+	}
+
+	payment, err := service.payments.Get(ctx, donation.ID)
+	if err != nil {
+		return Refund{}, Error.Wrap(err)
+	}
+
+	if !payment.Confirmed {
+		return Refund{}, ParamsError.New("only confirmed donation can be refunded")
+	}
+
+	if payments.IsSelfReported(payment.PaymentType) {
+		return Refund{}, ParamsError.New("self-reported donation can not be refunded")
+	}
+
+	refundable, err := service.refundable(ctx, donation, payment)
+	if err != nil {
+		return Refund{}, err
+	}
+
+	amount := params.Amount
+	if amount.IsZero() {
+		amount = refundable
+	}
+	if !amount.IsPositive() || amount.Cmp(refundable) > 0 {
+		return Refund{}, ParamsError.Wrap(ErrNotRefundable)
+	}
+
+	now := time.Now().UTC()
+	refund := Refund{
+		ID:          uuid.New(),
+		DonationID:  donation.ID,
+		Amount:      amount,
+		Currency:    donation.Currency,
+		Reason:      params.Reason,
+		Status:      StatusProcessing,
+		RequestedBy: caller.ID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	approval, err := service.requiresApproval(ctx, fundraise, donation, amount)
+	if err != nil {
+		return Refund{}, err
+	}
+
+	switch {
+	case approval && caller.IsAdmin(): // INFO: administrator's own refund is approved on creation.
+		refund.ReviewedBy = caller.ID
+	case approval:
+		refund.Status = StatusPendingApproval
+	}
+
+	if err = service.refunds.Create(ctx, refund); err != nil {
+		return Refund{}, Error.Wrap(err)
+	}
+
+	if refund.Status == StatusPendingApproval {
+		return refund, nil
+	}
+
+	return service.execute(ctx, refund)
+}
+
+// ListByDonation returns refunds of the donation, allowed to the donor, the fundraise organizer and administrators.
+func (service *Service) ListByDonation(ctx context.Context, donationID, callerID uuid.UUID) ([]Refund, error) {
+	donation, err := service.donations.Get(ctx, donationID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if donation.UserId != callerID {
+		fundraise, err := service.fundraises.Get(ctx, donation.FundraiseId)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+
+		if fundraise.OrganizerId != callerID {
+			if err = service.ensureAdmin(ctx, callerID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	list, err := service.refunds.ListByDonation(ctx, donationID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return list, nil
+}
+
+// Queue returns refunds awaiting approval, the oldest first, allowed only to administrators.
+func (service *Service) Queue(ctx context.Context, callerID uuid.UUID) ([]Refund, error) {
+	if err := service.ensureAdmin(ctx, callerID); err != nil {
+		return nil, err
+	}
+
+	list, err := service.refunds.ListByStatus(ctx, StatusPendingApproval)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return list, nil
+}
+
+// Approve sends refund pending approval to the payment provider.
+func (service *Service) Approve(ctx context.Context, params ReviewParams) (Refund, error) {
+	refund, err := service.pending(ctx, params.RefundID, params.CallerID)
+	if err != nil {
+		return refund, err
+	}
+
+	refund.Status = StatusProcessing
+	refund.ReviewedBy = params.CallerID
+	refund.ReviewReason = params.Reason
+	refund.UpdatedAt = time.Now().UTC()
+	if err = service.refunds.Update(ctx, refund); err != nil {
+		return refund, Error.Wrap(err)
+	}
+
+	return service.execute(ctx, refund)
+}
+
+// Reject declines refund pending approval, reason is required.
+func (service *Service) Reject(ctx context.Context, params ReviewParams) (Refund, error) {
+	if params.Reason == "" {
+		return Refund{}, ParamsError.New("reason is required")
+	}
+
+	refund, err := service.pending(ctx, params.RefundID, params.CallerID)
+	if err != nil {
+		return refund, err
+	}
+
+	refund.Status = StatusRejected
+	refund.ReviewedBy = params.CallerID
+	refund.ReviewReason = params.Reason
+	refund.UpdatedAt = time.Now().UTC()
+	if err = service.refunds.Update(ctx, refund); err != nil {
+		return refund, Error.Wrap(err)
+	}
+
+	return refund, nil
+}
+
+// pending returns refund awaiting administrator's decision.
+func (service *Service) pending(ctx context.Context, refundID, callerID uuid.UUID) (Refund, error) {
+	if err := service.ensureAdmin(ctx, callerID); err != nil {
+		return Refund{}, err
+	}
+
+	refund, err := service.refunds.Get(ctx, refundID)
+	if err != nil {
+		return refund, Error.Wrap(err)
+	}
+
+	if refund.Status != StatusPendingApproval {
+		return refund, ParamsError.New("refund is not pending approval")
+	}
+
+	return refund, nil
+}
+
+// execute sends refund to the payment provider and records it on the donation payment, so refunded amount stops counting
+// towards fundraise totals and its share of matches returns to the pledges. Provider's refund webhook later confirms the same total refunded amount.
+func (service *Service) execute(ctx context.Context, refund Refund) (Refund, error) {
+	payment, err := service.payments.Get(ctx, refund.DonationID)
+	if err != nil {
+		return refund, Error.Wrap(err)
+	}
+
+	provider, err := service.providers.Get(payment.PaymentType)
+	if err != nil {
+		return refund, Error.Wrap(err)
+	}
+
+	refund.UpdatedAt = time.Now().UTC()
+	if err = provider.Refund(ctx, payment, refund.Amount); err != nil {
+		refund.Status = StatusFailed
+		refund.FailureMessage = err.Error()
+		return refund, Error.Wrap(errs.Combine(err, service.refunds.Update(ctx, refund)))
+	}
+
+	refund.Status = StatusSucceeded
+	if err = service.refunds.Update(ctx, refund); err != nil {
+		return refund, Error.Wrap(err)
+	}
+
+	donation, err := service.donations.Get(ctx, refund.DonationID)
+	if err != nil {
+		return refund, Error.Wrap(err)
+	}
+
+	err = service.fundraisesService.RecordRefund(ctx, refund.ID.String(), donation, payment, refund.Amount)
+	return refund, Error.Wrap(err)
+}
+
+// refundable returns not yet refunded amount of the donation, excluding amounts of unfinished refunds.
+func (service *Service) refundable(ctx context.Context, donation donations.Donation, payment payments.Payment) (currencies.Amount, error) {
+	list, err := service.refunds.ListByDonation(ctx, donation.ID)
+	if err != nil {
+		return currencies.Zero, Error.Wrap(err)
+	}
+
+	refundable := donation.Amount.Sub(payment.RefundedAmount)
+	for _, refund := range list {
+		if refund.IsOpen() {
+			refundable = refundable.Sub(refund.Amount)
+		}
+	}
+
+	return refundable, nil
+}
+
+// requiresApproval returns true if donated funds may have already left the fundraise: fundraise is finished and its funds
+// are spent, funds were transferred out after the donation, or the refund exceeds balance left after payouts.
+func (service *Service) requiresApproval(ctx context.Context, fundraise fundraises.Fundraise, donation donations.Donation, amount currencies.Amount) (bool, error) {
+	if fundraise.Status == statuses.DoneStatus || fundraise.Status == statuses.TransferredStatus {
+		return true, nil
+	}
+
+	// INFO: paid out and reserved by open payouts funds are not available, refund must not overdraw the fundraise balance.
+	balance, err := service.payouts.Balance(ctx, fundraise.ID)
+	if err != nil {
+		return false, Error.Wrap(err)
+	}
+	if balance.Available.Cmp(amount.Mul(donation.ExchangeRate)) < 0 {
+		return true, nil
+	}
+
+	list, err := service.transfers.List(ctx, transfers.ListParams{FundraiseID: &fundraise.ID})
+	if err != nil {
+		return false, Error.Wrap(err)
+	}
+
+	for _, transfer := range list {
+		if transfer.FromFundraiseID == fundraise.ID && transfer.CreatedAt.After(donation.CreatedAt) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// ensureAdmin checks that caller is the platform administrator.
+func (service *Service) ensureAdmin(ctx context.Context, callerID uuid.UUID) error {
+	caller, err := service.users.Get(ctx, callerID)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if !caller.IsAdmin() {
+		return ParamsError.Wrap(fundraises.ErrNotAdmin)
+	}
+
+	return nil
+}
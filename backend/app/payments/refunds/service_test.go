@@ -0,0 +1,255 @@
+package refunds_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app"
+	"one-help/app/currencies"
+	"one-help/app/database"
+	"one-help/app/database/dbtesting"
+	"one-help/app/donations"
+	"one-help/app/fundraises"
+	"one-help/app/fundraises/fundraisestesting"
+	"one-help/app/fundraises/payouts"
+	payoutfake "one-help/app/fundraises/payouts/fake"
+	"one-help/app/ledger"
+	notificationfake "one-help/app/notifications/fake"
+	"one-help/app/payments"
+	"one-help/app/payments/fake"
+	"one-help/app/payments/refunds"
+	"one-help/app/users"
+	"one-help/app/users/roles"
+	"one-help/internal/logger/zaplog"
+)
+
+func TestRefunds(t *testing.T) {
+	organizer := users.User{
+		ID:        uuid.New(),
+		FirstName: "John",
+		LastName:  "Doe",
+	}
+	donor := users.User{
+		ID:        uuid.New(),
+		FirstName: "Jane",
+		LastName:  "Doe",
+	}
+	admin := users.User{
+		ID:        uuid.New(),
+		FirstName: "Admin",
+		LastName:  "Doe",
+		Role:      roles.AdminRole,
+	}
+
+	provider := fake.NewProvider(payments.TypeStripe)
+
+	dbtesting.Run(t, database.Config{MigrationsPath: "../../database/migrations"}, func(ctx context.Context, t *testing.T, db app.DB) {
+		require.NoError(t, db.Users().Create(ctx, organizer))
+		require.NoError(t, db.Users().SetTrusted(ctx, organizer.ID, true))
+		require.NoError(t, db.Users().Create(ctx, donor))
+		require.NoError(t, db.Users().Create(ctx, admin))
+
+		providers := payments.NewProviders(provider)
+		fundraisesService := fundraisestesting.NewService(db, providers, notificationfake.NewSender())
+		service := refunds.NewService(
+			zaplog.NewLog(),
+			db.Refunds(),
+			db.Donations(),
+			db.Fundraises(),
+			db.Payments(),
+			db.Payouts(),
+			db.FundraiseTransfers(),
+			db.Users(),
+			providers,
+			fundraisesService,
+		)
+
+		fundraise, err := fundraisesService.Create(ctx, fundraises.CreateParams{
+			OrganizerId:  organizer.ID,
+			Title:        "Test",
+			Description:  "Test Description",
+			TargetAmount: currencies.Major(1000),
+			Currency:     currencies.UAH,
+		})
+		require.NoError(t, err)
+
+		paid := fundraisestesting.Donate(ctx, t, fundraisesService, provider, fundraises.RegisterDonateParams{
+			FundraiseID: fundraise.ID,
+			UserID:      donor.ID,
+			Amount:      currencies.Major(100),
+		})
+		donationID := uuid.MustParse(paid.Reference)
+
+		t.Run("refund", func(t *testing.T) {
+			_, err := service.Create(ctx, refunds.CreateParams{
+				DonationID: donationID,
+				CallerID:   donor.ID,
+				Reason:     "changed mind",
+			})
+			require.ErrorIs(t, err, fundraises.ErrNotOrganizer)
+
+			refund, err := service.Create(ctx, refunds.CreateParams{
+				DonationID: donationID,
+				CallerID:   organizer.ID,
+				Amount:     currencies.Major(40),
+				Reason:     "charged twice",
+			})
+			require.NoError(t, err)
+			assert.Equal(t, refunds.StatusSucceeded, refund.Status)
+
+			filled, err := fundraisesService.Filled(ctx, fundraise.ID)
+			require.NoError(t, err)
+			assert.Equal(t, currencies.Major(60), filled)
+
+			_, err = service.Create(ctx, refunds.CreateParams{
+				DonationID: donationID,
+				CallerID:   organizer.ID,
+				Amount:     currencies.Major(61),
+				Reason:     "duplicate donation",
+			})
+			require.ErrorIs(t, err, refunds.ErrNotRefundable)
+
+			refund, err = service.Create(ctx, refunds.CreateParams{
+				DonationID: donationID,
+				CallerID:   organizer.ID,
+				Reason:     "duplicate donation",
+			})
+			require.NoError(t, err)
+			assert.Equal(t, refunds.StatusSucceeded, refund.Status)
+			assert.Equal(t, currencies.Major(60), refund.Amount)
+			assert.Equal(t, currencies.Major(100), provider.Refunded(paid.PaymentReference))
+
+			filled, err = fundraisesService.Filled(ctx, fundraise.ID)
+			require.NoError(t, err)
+			assert.Equal(t, currencies.Zero, filled)
+
+			list, err := service.ListByDonation(ctx, donationID, donor.ID)
+			require.NoError(t, err)
+			require.Len(t, list, 2)
+
+			// INFO: fully refunded donation shows its refunded amount in the donor's history.
+			history, err := db.Donations().ListHistory(ctx, donations.HistoryParams{UserID: donor.ID, FundraiseID: fundraise.ID})
+			require.NoError(t, err)
+			require.Len(t, history, 1)
+			assert.Equal(t, payments.StatusRefunded, history[0].PaymentStatus)
+			assert.Equal(t, currencies.Major(100), history[0].RefundedAmount)
+		})
+
+		t.Run("self-reported", func(t *testing.T) {
+			entry, err := fundraisesService.RecordOfflineDonation(ctx, fundraises.OfflineDonationParams{
+				FundraiseID: fundraise.ID,
+				CallerID:    organizer.ID,
+				PaymentType: payments.TypeCash,
+				Amount:      currencies.Major(50),
+				ReceivedAt:  time.Now().Add(-time.Hour),
+			})
+			require.NoError(t, err)
+
+			_, err = service.Create(ctx, refunds.CreateParams{
+				DonationID: entry.Donation.ID,
+				CallerID:   organizer.ID,
+				Reason:     "mistake",
+			})
+			require.True(t, refunds.ParamsError.Has(err))
+		})
+
+		t.Run("fees", func(t *testing.T) {
+			provider.SetFees(payments.FeeSchedule{Percent: 2.9})
+			defer provider.SetFees(payments.FeeSchedule{})
+
+			filled, err := fundraisesService.Filled(ctx, fundraise.ID)
+			require.NoError(t, err)
+
+			paid := fundraisestesting.Donate(ctx, t, fundraisesService, provider, fundraises.RegisterDonateParams{
+				FundraiseID: fundraise.ID,
+				UserID:      donor.ID,
+				Amount:      currencies.Major(100),
+			})
+
+			_, err = service.Create(ctx, refunds.CreateParams{
+				DonationID: uuid.MustParse(paid.Reference),
+				CallerID:   organizer.ID,
+				Amount:     currencies.Major(60),
+				Reason:     "charged twice",
+			})
+			require.NoError(t, err)
+
+			refund, err := service.Create(ctx, refunds.CreateParams{
+				DonationID: uuid.MustParse(paid.Reference),
+				CallerID:   organizer.ID,
+				Reason:     "duplicate donation",
+			})
+			require.NoError(t, err)
+			assert.Equal(t, refunds.StatusSucceeded, refund.Status)
+			assert.Equal(t, currencies.Major(40), refund.Amount)
+
+			// INFO: fundraise returns only the net amount it received, the fee kept by the provider is returned by the platform.
+			refunded, err := fundraisesService.Filled(ctx, fundraise.ID)
+			require.NoError(t, err)
+			assert.Equal(t, filled, refunded)
+
+			kept, err := db.Ledger().Balance(ctx, ledger.KeptFees(payments.TypeStripe), currencies.UAH)
+			require.NoError(t, err)
+			assert.Equal(t, currencies.MinorUnits(290), kept)
+
+			violations, err := db.Ledger().Check(ctx)
+			require.NoError(t, err)
+			assert.Empty(t, violations)
+		})
+
+		t.Run("paid out", func(t *testing.T) {
+			payoutsService := payouts.NewService(zaplog.NewLog(), db.Payouts(), db.Fundraises(), db.Users(), payoutfake.NewProvider())
+
+			donated := fundraisestesting.Donate(ctx, t, fundraisesService, provider, fundraises.RegisterDonateParams{
+				FundraiseID: fundraise.ID,
+				UserID:      donor.ID,
+				Amount:      currencies.Major(100),
+			})
+
+			_, err := payoutsService.SaveBankDetails(ctx, payouts.BankDetailsParams{
+				UserID:     organizer.ID,
+				HolderName: "John Doe",
+				IBAN:       "UA21 3223 1300 0002 6007 2335 6600 1",
+			})
+			require.NoError(t, err)
+
+			payout, err := payoutsService.Request(ctx, payouts.RequestParams{FundraiseID: fundraise.ID, CallerID: organizer.ID})
+			require.NoError(t, err)
+			_, err = payoutsService.Approve(ctx, payouts.ReviewParams{PayoutID: payout.ID, CallerID: admin.ID})
+			require.NoError(t, err)
+
+			// INFO: refund of paid out funds waits for administrator's approval.
+			refund, err := service.Create(ctx, refunds.CreateParams{
+				DonationID: uuid.MustParse(donated.Reference),
+				CallerID:   organizer.ID,
+				Reason:     "duplicate donation",
+			})
+			require.NoError(t, err)
+			assert.Equal(t, refunds.StatusPendingApproval, refund.Status)
+			assert.Equal(t, currencies.Zero, provider.Refunded(donated.PaymentReference))
+
+			queue, err := service.Queue(ctx, admin.ID)
+			require.NoError(t, err)
+			require.Len(t, queue, 1)
+
+			refund, err = service.Reject(ctx, refunds.ReviewParams{RefundID: refund.ID, CallerID: admin.ID, Reason: "funds were paid out"})
+			require.NoError(t, err)
+			assert.Equal(t, refunds.StatusRejected, refund.Status)
+		})
+
+		t.Run("ledger", func(t *testing.T) {
+			violations, err := db.Ledger().Check(ctx)
+			require.NoError(t, err)
+			assert.Empty(t, violations)
+
+			refunded, err := db.Ledger().Balance(ctx, ledger.Refunds(payments.TypeStripe), currencies.UAH)
+			require.NoError(t, err)
+			assert.Equal(t, currencies.Major(-200), refunded)
+		})
+	})
+}
@@ -0,0 +1,172 @@
+package statements
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"one-help/app/currencies"
+)
+
+// FormatCAMT053 defines ISO 20022 bank-to-customer statement format.
+const FormatCAMT053 = "camt.053"
+
+// CAMT053Parser parses ISO 20022 CAMT.053 XML statements of any version, elements are matched regardless of namespace.
+type CAMT053Parser struct{}
+
+// NewCAMT053Parser is a constructor for CAMT.053 statement parser.
+func NewCAMT053Parser() *CAMT053Parser {
+	return &CAMT053Parser{}
+}
+
+// Format returns name of the statement format.
+func (parser *CAMT053Parser) Format() string {
+	return FormatCAMT053
+}
+
+type camtDocument struct {
+	Statements []camtStatement `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtStatement struct {
+	Entries []camtEntry `xml:"Ntry"`
+}
+
+type camtEntry struct {
+	Reference         string            `xml:"NtryRef"`
+	Amount            camtAmount        `xml:"Amt"`
+	CreditDebit       string            `xml:"CdtDbtInd"`
+	BookingDate       camtDate          `xml:"BookgDt"`
+	ValueDate         camtDate          `xml:"ValDt"`
+	ServicerReference string            `xml:"AcctSvcrRef"`
+	AdditionalInfo    string            `xml:"AddtlNtryInf"`
+	Transactions      []camtTransaction `xml:"NtryDtls>TxDtls"`
+}
+
+type camtAmount struct {
+	Value    string `xml:",chardata"`
+	Currency string `xml:"Ccy,attr"`
+}
+
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+type camtTransaction struct {
+	Amount            camtAmount `xml:"Amt"`
+	EndToEndID        string     `xml:"Refs>EndToEndId"`
+	ServicerReference string     `xml:"Refs>AcctSvcrRef"`
+	Debtor            string     `xml:"RltdPties>Dbtr>Nm"`
+	DebtorParty       string     `xml:"RltdPties>Dbtr>Pty>Nm"` // INFO: since camt.053.001.08.
+	Unstructured      []string   `xml:"RmtInf>Ustrd"`
+	CreditorReference string     `xml:"RmtInf>Strd>CdtrRefInf>Ref"`
+}
+
+// Parse returns transactions of the CAMT.053 statement.
+// Batched entry with several transaction details is split into transactions of their amounts.
+func (parser *CAMT053Parser) Parse(r io.Reader) ([]Transaction, error) {
+	var document camtDocument
+	if err := xml.NewDecoder(r).Decode(&document); err != nil {
+		return nil, ErrMalformed.Wrap(err)
+	}
+
+	var transactions []Transaction
+	for _, statement := range document.Statements {
+		for i, entry := range statement.Entries {
+			bookedAt, err := entry.BookingDate.parse()
+			if err != nil {
+				if bookedAt, err = entry.ValueDate.parse(); err != nil {
+					return nil, ErrMalformed.New("entry %d: booking date is missing", i+1)
+				}
+			}
+
+			outgoing := false
+			switch entry.CreditDebit {
+			case "CRDT":
+			case "DBIT":
+				outgoing = true
+			default:
+				return nil, ErrMalformed.New("entry %d: unknown credit debit indicator %q", i+1, entry.CreditDebit)
+			}
+
+			entryID := entry.ServicerReference
+			if entryID == "" {
+				entryID = entry.Reference
+			}
+
+			details := entry.Transactions
+			if len(details) == 0 {
+				details = []camtTransaction{{}}
+			}
+			for j, detail := range details {
+				amount := entry.Amount
+				if len(details) > 1 && detail.Amount.Value != "" {
+					amount = detail.Amount
+				}
+
+				value, err := currencies.ParseAmount(amount.Value)
+				if err != nil || value.Sign() < 0 {
+					return nil, ErrMalformed.New("entry %d: invalid amount %q", i+1, amount.Value)
+				}
+
+				if outgoing {
+					value = value.Neg()
+				}
+
+				transaction := Transaction{
+					ExternalID:   detail.ServicerReference,
+					BookedAt:     bookedAt,
+					Amount:       value,
+					Currency:     strings.ToUpper(amount.Currency),
+					Reference:    detail.reference(entry.AdditionalInfo),
+					Counterparty: strings.TrimSpace(detail.Debtor + detail.DebtorParty),
+				}
+				if transaction.ExternalID == "" && entryID != "" {
+					transaction.ExternalID = entryID
+					if len(details) > 1 {
+						transaction.ExternalID += "/" + strconv.Itoa(j+1)
+					}
+				}
+
+				transactions = append(transactions, transaction)
+			}
+		}
+	}
+
+	identify(transactions)
+
+	return transactions, nil
+}
+
+// parse returns date or date time of the element in UTC.
+func (date camtDate) parse() (time.Time, error) {
+	if date.DateTime != "" {
+		parsed, err := time.Parse(time.RFC3339, strings.TrimSpace(date.DateTime))
+		if err != nil {
+			// INFO: ISODateTime may omit the time zone.
+			parsed, err = time.Parse("2006-01-02T15:04:05", strings.TrimSpace(date.DateTime))
+		}
+
+		return parsed.UTC(), err
+	}
+
+	parsed, err := time.Parse(time.DateOnly, strings.TrimSpace(date.Date))
+	return parsed.UTC(), err
+}
+
+// reference returns remittance information of the transaction, falling back to entry's additional information.
+func (detail camtTransaction) reference(additionalInfo string) string {
+	switch {
+	case len(detail.Unstructured) > 0:
+		return strings.TrimSpace(strings.Join(detail.Unstructured, " "))
+	case detail.CreditorReference != "":
+		return strings.TrimSpace(detail.CreditorReference)
+	case detail.EndToEndID != "" && detail.EndToEndID != "NOTPROVIDED":
+		return strings.TrimSpace(detail.EndToEndID)
+	}
+
+	return strings.TrimSpace(additionalInfo)
+}
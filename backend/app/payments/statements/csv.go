@@ -0,0 +1,161 @@
+package statements
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"one-help/app/currencies"
+)
+
+// CSVLayout describes columns of the bank's CSV export, column names are matched case-insensitively.
+type CSVLayout struct {
+	Format     string
+	Comma      rune
+	DateColumn string
+	DateLayout string
+	// Location is the time zone of the exported dates, UTC if nil.
+	Location     *time.Location
+	AmountColumn string
+	// DecimalComma is true if amounts use comma as the decimal separator.
+	DecimalComma       bool
+	CurrencyColumn     string // INFO: optional, Currency is used if empty.
+	Currency           string
+	ReferenceColumn    string
+	CounterpartyColumn string // INFO: optional.
+	IDColumn           string // INFO: optional, transactions are fingerprinted if empty.
+}
+
+// GenericCSV is the layout of the bank-neutral CSV statement.
+var GenericCSV = CSVLayout{
+	Format:             "csv",
+	Comma:              ',',
+	DateColumn:         "date",
+	DateLayout:         time.DateOnly,
+	AmountColumn:       "amount",
+	CurrencyColumn:     "currency",
+	Currency:           currencies.Default,
+	ReferenceColumn:    "reference",
+	CounterpartyColumn: "counterparty",
+	IDColumn:           "id",
+}
+
+// CSVParser parses CSV statements of the layout.
+type CSVParser struct {
+	layout CSVLayout
+}
+
+// NewCSVParser is a constructor for CSV statement parser.
+func NewCSVParser(layout CSVLayout) *CSVParser {
+	return &CSVParser{layout: layout}
+}
+
+// Format returns name of the statement format.
+func (parser *CSVParser) Format() string {
+	return parser.layout.Format
+}
+
+// Parse returns transactions of the CSV statement, the first row is the header.
+func (parser *CSVParser) Parse(r io.Reader) ([]Transaction, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = parser.layout.Comma
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, ErrMalformed.New("header is missing")
+		}
+
+		return nil, ErrMalformed.Wrap(err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimPrefix(name, "\ufeff") // INFO: Excel exports start with byte order mark.
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	column := func(name string) (int, bool) {
+		if name == "" {
+			return 0, false
+		}
+
+		index, ok := columns[strings.ToLower(name)]
+		return index, ok
+	}
+
+	for _, required := range []string{parser.layout.DateColumn, parser.layout.AmountColumn, parser.layout.ReferenceColumn} {
+		if _, ok := column(required); !ok {
+			return nil, ErrMalformed.New("column %q is missing", required)
+		}
+	}
+
+	location := parser.layout.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	var transactions []Transaction
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, ErrMalformed.Wrap(err)
+		}
+
+		value := func(name string) string {
+			index, ok := column(name)
+			if !ok || index >= len(record) {
+				return ""
+			}
+
+			return strings.TrimSpace(record[index])
+		}
+
+		bookedAt, err := time.ParseInLocation(parser.layout.DateLayout, value(parser.layout.DateColumn), location)
+		if err != nil {
+			return nil, ErrMalformed.New("row %d: invalid date %q", row, value(parser.layout.DateColumn))
+		}
+
+		amount, err := parseAmount(value(parser.layout.AmountColumn), parser.layout.DecimalComma)
+		if err != nil {
+			return nil, ErrMalformed.New("row %d: invalid amount %q", row, value(parser.layout.AmountColumn))
+		}
+
+		currency := strings.ToUpper(value(parser.layout.CurrencyColumn))
+		if currency == "" {
+			currency = parser.layout.Currency
+		}
+
+		transactions = append(transactions, Transaction{
+			ExternalID:   value(parser.layout.IDColumn),
+			BookedAt:     bookedAt.UTC(),
+			Amount:       amount,
+			Currency:     currency,
+			Reference:    value(parser.layout.ReferenceColumn),
+			Counterparty: value(parser.layout.CounterpartyColumn),
+		})
+	}
+
+	identify(transactions)
+
+	return transactions, nil
+}
+
+// parseAmount parses amount with optional thousands separators.
+func parseAmount(value string, decimalComma bool) (currencies.Amount, error) {
+	value = strings.NewReplacer(" ", "", "\u00a0", "", "'", "").Replace(value)
+	if decimalComma {
+		value = strings.ReplaceAll(value, ".", "")
+		value = strings.ReplaceAll(value, ",", ".")
+	} else {
+		value = strings.ReplaceAll(value, ",", "")
+	}
+
+	return currencies.ParseAmount(value)
+}
@@ -0,0 +1,30 @@
+package statements
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+)
+
+var (
+	// ErrNoLine indicates that statement line does not exist.
+	ErrNoLine = errs.New("statement line does not exist")
+	// ErrStatusChanged indicates that statement line status was changed concurrently, e.g. by another review.
+	ErrStatusChanged = errs.New("statement line status was changed")
+)
+
+// DB exposes access to bank statements db.
+//
+// architecture: DB
+type DB interface {
+	// Create inserts statement with its lines, lines of transactions imported before are skipped.
+	// Returns number of inserted lines.
+	Create(ctx context.Context, statement Statement, lines []Line) (int, error)
+	// GetLine returns statement line by id.
+	GetLine(ctx context.Context, id uuid.UUID) (Line, error)
+	// ListLines returns lines of the fundraise statements with provided status, all if empty, the latest booked first.
+	ListLines(ctx context.Context, fundraiseID uuid.UUID, status string) ([]Line, error)
+	// UpdateLine updates reconciliation state of the line only if it still has the previous status, returns ErrStatusChanged otherwise.
+	UpdateLine(ctx context.Context, line Line, previousStatus string) error
+}
@@ -0,0 +1,75 @@
+package statements
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"one-help/app/currencies"
+)
+
+// MatchWindow limits difference between the booking date and the date the offline donation was received.
+const MatchWindow = 3 * 24 * time.Hour
+
+// Candidate describes offline donation that may be confirmed by the bank statement.
+type Candidate struct {
+	DonationID uuid.UUID
+	Amount     currencies.Amount
+	Currency   string
+	ReceivedAt time.Time
+	Reference  string
+}
+
+// Match pairs incoming transactions with candidates of the same amount and currency received within the window.
+// Candidate whose reference is found in the transaction reference is preferred, then the closest by date.
+// Returns matched donation ids by transaction index, each candidate is matched at most once.
+func Match(transactions []Transaction, candidates []Candidate) map[int]uuid.UUID {
+	matches := make(map[int]uuid.UUID)
+	used := make([]bool, len(candidates))
+
+	// INFO: reference matches go first, so that they are not taken by the closest date of another transaction.
+	for _, byReference := range []bool{true, false} {
+		for i, transaction := range transactions {
+			if _, ok := matches[i]; ok || !transaction.IsIncoming() {
+				continue
+			}
+
+			best := -1
+			for j, candidate := range candidates {
+				if used[j] || !candidate.fits(transaction) || (byReference && !candidate.referencedBy(transaction)) {
+					continue
+				}
+
+				if best == -1 || distance(candidate, transaction) < distance(candidates[best], transaction) {
+					best = j
+				}
+			}
+
+			if best != -1 {
+				used[best] = true
+				matches[i] = candidates[best].DonationID
+			}
+		}
+	}
+
+	return matches
+}
+
+// fits returns true if transaction amount, currency and date correspond to the candidate.
+func (candidate Candidate) fits(transaction Transaction) bool {
+	return candidate.Currency == transaction.Currency &&
+		candidate.Amount == transaction.Amount &&
+		distance(candidate, transaction) <= MatchWindow
+}
+
+// referencedBy returns true if candidate reference is mentioned in the transaction reference.
+func (candidate Candidate) referencedBy(transaction Transaction) bool {
+	reference := strings.ToLower(strings.TrimSpace(candidate.Reference))
+	return reference != "" && strings.Contains(strings.ToLower(transaction.Reference), reference)
+}
+
+// distance returns absolute difference between the booking date and the date candidate was received.
+func distance(candidate Candidate, transaction Transaction) time.Duration {
+	return transaction.BookedAt.Sub(candidate.ReceivedAt).Abs()
+}
@@ -0,0 +1,307 @@
+package statements
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+
+	"one-help/app/donations/offline"
+	"one-help/app/fundraises"
+	"one-help/app/payments"
+	"one-help/internal/logger"
+)
+
+var (
+	// Error wraps errors from statements service that indicates about internal errors.
+	Error = errs.Class("statements service")
+	// ParamsError wraps errors from statements service that indicates about invalid or malformed parameters' data.
+	ParamsError = errs.Class("statements service: params")
+	// ErrLineReviewed indicates that statement line does not await review anymore.
+	ErrLineReviewed = errs.New("statement line is already reviewed")
+)
+
+// Service handles bank statements related logic.
+//
+// architecture: Service
+type Service struct {
+	logger logger.Logger
+
+	statements DB
+	fundraises fundraises.DB
+	offline    offline.DB
+
+	parsers *Parsers
+	// fundraisesService records confirmed incoming transfers as offline donations.
+	fundraisesService *fundraises.Service
+}
+
+// NewService is a constructor for statements service.
+func NewService(
+	logger logger.Logger,
+	statements DB,
+	fundraisesDB fundraises.DB,
+	offline offline.DB,
+	parsers *Parsers,
+	fundraisesService *fundraises.Service,
+) *Service {
+	return &Service{
+		logger:            logger,
+		statements:        statements,
+		fundraises:        fundraisesDB,
+		offline:           offline,
+		parsers:           parsers,
+		fundraisesService: fundraisesService,
+	}
+}
+
+// Import imports bank statement of the fundraise account, allowed only to the organizer.
+// Incoming transfers are matched to recorded offline bank transfers by amount, date and reference, which marks them
+// verified, unmatched ones await organizer's review. Transactions imported before are skipped.
+func (service *Service) Import(ctx context.Context, params ImportParams) (Summary, error) {
+	fundraise, err := service.fundraises.Get(ctx, params.FundraiseID)
+	if err != nil {
+		return Summary{}, Error.Wrap(err)
+	}
+
+	if fundraise.OrganizerId != params.CallerID {
+		return Summary{}, ParamsError.Wrap(fundraises.ErrNotOrganizer)
+	}
+
+	parser, err := service.parsers.Get(params.Format)
+	if err != nil {
+		return Summary{}, ParamsError.Wrap(err)
+	}
+
+	transactions, err := parser.Parse(params.Statement)
+	if err != nil {
+		return Summary{}, ParamsError.Wrap(err)
+	}
+
+	existing, err := service.statements.ListLines(ctx, fundraise.ID, "")
+	if err != nil {
+		return Summary{}, Error.Wrap(err)
+	}
+
+	imported := make(map[string]bool, len(existing))
+	reconciled := make(map[uuid.UUID]bool, len(existing))
+	for _, line := range existing {
+		imported[line.Transaction.ExternalID] = true
+		reconciled[line.DonationID] = true
+	}
+
+	now := time.Now().UTC()
+	summary := Summary{
+		Statement: Statement{
+			ID:          uuid.New(),
+			FundraiseID: fundraise.ID,
+			Format:      parser.Format(),
+			ImportedBy:  params.CallerID,
+			CreatedAt:   now,
+		},
+	}
+
+	var incoming []Transaction
+	for _, transaction := range transactions {
+		switch {
+		case !transaction.IsIncoming():
+			summary.Outgoing++
+		case imported[transaction.ExternalID]:
+			summary.Duplicates++
+		default:
+			imported[transaction.ExternalID] = true
+			incoming = append(incoming, transaction)
+		}
+	}
+
+	entries, err := service.offline.ListByFundraise(ctx, fundraise.ID)
+	if err != nil {
+		return Summary{}, Error.Wrap(err)
+	}
+
+	var candidates []Candidate
+	for _, entry := range entries {
+		if entry.PaymentType != payments.TypeBankTransfer || reconciled[entry.Donation.ID] {
+			continue
+		}
+
+		candidates = append(candidates, Candidate{
+			DonationID: entry.Donation.ID,
+			Amount:     entry.Donation.Amount,
+			Currency:   entry.Donation.Currency,
+			ReceivedAt: entry.Record.ReceivedAt,
+			Reference:  entry.Reference,
+		})
+	}
+
+	matches := Match(incoming, candidates)
+
+	var verified []uuid.UUID
+	lines := make([]Line, len(incoming))
+	for i, transaction := range incoming {
+		lines[i] = Line{
+			ID:          uuid.New(),
+			Transaction: transaction,
+			Status:      StatusUnmatched,
+			UpdatedAt:   now,
+		}
+		if donationID, ok := matches[i]; ok {
+			lines[i].Status = StatusMatched
+			lines[i].DonationID = donationID
+			verified = append(verified, donationID)
+			summary.Matched++
+		} else {
+			summary.Unmatched++
+		}
+	}
+
+	inserted, err := service.statements.Create(ctx, summary.Statement, lines)
+	if err != nil {
+		return Summary{}, Error.Wrap(err)
+	}
+
+	if skipped := len(lines) - inserted; skipped > 0 { // INFO: concurrent import of the same transactions.
+		service.logger.WarnF("%d lines of statement %s were imported concurrently", skipped, summary.Statement.ID)
+		summary.Duplicates += skipped
+	}
+
+	if len(verified) > 0 {
+		if err = service.offline.Verify(ctx, verified, now); err != nil {
+			return Summary{}, Error.Wrap(err)
+		}
+	}
+
+	return summary, nil
+}
+
+// Lines returns lines of the fundraise statements with provided status, allowed only to the organizer.
+func (service *Service) Lines(ctx context.Context, fundraiseID, callerID uuid.UUID, status string) ([]Line, error) {
+	fundraise, err := service.fundraises.Get(ctx, fundraiseID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if fundraise.OrganizerId != callerID {
+		return nil, ParamsError.Wrap(fundraises.ErrNotOrganizer)
+	}
+
+	lines, err := service.statements.ListLines(ctx, fundraise.ID, status)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return lines, nil
+}
+
+// ConfirmLine records unmatched incoming transfer as the verified offline bank transfer donation.
+func (service *Service) ConfirmLine(ctx context.Context, params LineParams) (Line, error) {
+	line, err := service.pendingLine(ctx, params.LineID, params.CallerID)
+	if err != nil {
+		return Line{}, err
+	}
+
+	// INFO: line is claimed before the donation is recorded, so concurrent reviews record it only once.
+	line.Status = StatusConfirmed
+	line.ReviewedBy = params.CallerID
+	line.UpdatedAt = time.Now().UTC()
+	if err = service.statements.UpdateLine(ctx, line, StatusUnmatched); err != nil {
+		return Line{}, wrapUpdate(err)
+	}
+
+	notes := "Imported from bank statement: " + line.Transaction.Reference
+	if line.Transaction.Counterparty != "" {
+		notes += ", from " + line.Transaction.Counterparty
+	}
+
+	entry, err := service.fundraisesService.RecordOfflineDonation(ctx, fundraises.OfflineDonationParams{
+		FundraiseID: line.FundraiseID,
+		CallerID:    params.CallerID,
+		DonorID:     params.DonorID,
+		PaymentType: payments.TypeBankTransfer,
+		Amount:      line.Transaction.Amount,
+		Currency:    line.Transaction.Currency,
+		ReceivedAt:  line.Transaction.BookedAt,
+		Reference:   line.Transaction.ExternalID,
+		Notes:       strings.TrimSpace(notes),
+		Message:     params.Message,
+	})
+	if err != nil {
+		// INFO: line is released for another review if the donation was not recorded.
+		line.Status = StatusUnmatched
+		line.ReviewedBy = uuid.Nil
+		line.UpdatedAt = time.Now().UTC()
+		if releaseErr := service.statements.UpdateLine(ctx, line, StatusConfirmed); releaseErr != nil {
+			return Line{}, Error.Wrap(errs.Combine(err, releaseErr))
+		}
+
+		if fundraises.ParamsError.Has(err) {
+			return Line{}, ParamsError.Wrap(errors.Unwrap(err))
+		}
+
+		return Line{}, Error.Wrap(err)
+	}
+
+	line.DonationID = entry.Donation.ID
+	line.UpdatedAt = time.Now().UTC()
+	if err = service.statements.UpdateLine(ctx, line, StatusConfirmed); err != nil {
+		return Line{}, Error.Wrap(err)
+	}
+
+	if err = service.offline.Verify(ctx, []uuid.UUID{entry.Donation.ID}, line.UpdatedAt); err != nil {
+		return Line{}, Error.Wrap(err)
+	}
+
+	return line, nil
+}
+
+// IgnoreLine marks unmatched incoming transfer as not a donation.
+func (service *Service) IgnoreLine(ctx context.Context, params LineParams) (Line, error) {
+	line, err := service.pendingLine(ctx, params.LineID, params.CallerID)
+	if err != nil {
+		return Line{}, err
+	}
+
+	line.Status = StatusIgnored
+	line.ReviewedBy = params.CallerID
+	line.UpdatedAt = time.Now().UTC()
+	if err = service.statements.UpdateLine(ctx, line, StatusUnmatched); err != nil {
+		return Line{}, wrapUpdate(err)
+	}
+
+	return line, nil
+}
+
+// pendingLine returns statement line awaiting review, allowed only to the organizer of its fundraise.
+func (service *Service) pendingLine(ctx context.Context, lineID, callerID uuid.UUID) (Line, error) {
+	line, err := service.statements.GetLine(ctx, lineID)
+	if err != nil {
+		return Line{}, Error.Wrap(err)
+	}
+
+	fundraise, err := service.fundraises.Get(ctx, line.FundraiseID)
+	if err != nil {
+		return Line{}, Error.Wrap(err)
+	}
+
+	if fundraise.OrganizerId != callerID {
+		return Line{}, ParamsError.Wrap(fundraises.ErrNotOrganizer)
+	}
+
+	if line.IsReviewed() {
+		return Line{}, ParamsError.Wrap(ErrLineReviewed)
+	}
+
+	return line, nil
+}
+
+// wrapUpdate wraps error of the line review, line reviewed concurrently is params error.
+func wrapUpdate(err error) error {
+	if errors.Is(err, ErrStatusChanged) {
+		return ParamsError.Wrap(ErrLineReviewed)
+	}
+
+	return Error.Wrap(err)
+}
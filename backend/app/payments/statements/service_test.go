@@ -0,0 +1,173 @@
+package statements_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app"
+	"one-help/app/currencies"
+	"one-help/app/database"
+	"one-help/app/database/dbtesting"
+	"one-help/app/fundraises"
+	"one-help/app/fundraises/fundraisestesting"
+	notificationfake "one-help/app/notifications/fake"
+	"one-help/app/payments"
+	"one-help/app/payments/fake"
+	"one-help/app/payments/statements"
+	"one-help/app/users"
+	"one-help/internal/logger/zaplog"
+)
+
+func TestStatements(t *testing.T) {
+	organizer := users.User{
+		ID:        uuid.New(),
+		FirstName: "John",
+		LastName:  "Doe",
+	}
+	donor := users.User{
+		ID:        uuid.New(),
+		FirstName: "Jane",
+		LastName:  "Doe",
+	}
+
+	dbtesting.Run(t, database.Config{MigrationsPath: "../../database/migrations"}, func(ctx context.Context, t *testing.T, db app.DB) {
+		require.NoError(t, db.Users().Create(ctx, organizer))
+		require.NoError(t, db.Users().SetTrusted(ctx, organizer.ID, true))
+		require.NoError(t, db.Users().Create(ctx, donor))
+
+		fundraisesService := fundraisestesting.NewService(db, payments.NewProviders(fake.NewProvider(payments.TypeStripe)), notificationfake.NewSender())
+		service := statements.NewService(
+			zaplog.NewLog(),
+			db.BankStatements(),
+			db.Fundraises(),
+			db.OfflineDonations(),
+			statements.NewParsers(statements.NewCSVParser(statements.GenericCSV), statements.NewCAMT053Parser()),
+			fundraisesService,
+		)
+
+		fundraise, err := fundraisesService.Create(ctx, fundraises.CreateParams{
+			OrganizerId:  organizer.ID,
+			Title:        "Test",
+			Description:  "Test Description",
+			TargetAmount: currencies.Major(1000),
+			Currency:     currencies.UAH,
+		})
+		require.NoError(t, err)
+
+		receivedAt := time.Now().UTC().Add(-24 * time.Hour)
+		recorded, err := fundraisesService.RecordOfflineDonation(ctx, fundraises.OfflineDonationParams{
+			FundraiseID: fundraise.ID,
+			CallerID:    organizer.ID,
+			PaymentType: payments.TypeBankTransfer,
+			Amount:      currencies.Major(75),
+			ReceivedAt:  receivedAt,
+			Reference:   "INV-42",
+		})
+		require.NoError(t, err)
+
+		statement := "date,amount,currency,reference,counterparty\n" +
+			receivedAt.Format(time.DateOnly) + ",75,UAH,Invoice INV-42,Jane Doe\n" +
+			receivedAt.Format(time.DateOnly) + ",20,UAH,For the fundraise,John Smith\n" +
+			receivedAt.Format(time.DateOnly) + ",-10,UAH,Bank fee,\n"
+
+		t.Run("import", func(t *testing.T) {
+			_, err := service.Import(ctx, statements.ImportParams{
+				FundraiseID: fundraise.ID,
+				CallerID:    organizer.ID,
+				Format:      "mt940",
+				Statement:   strings.NewReader(statement),
+			})
+			require.ErrorIs(t, err, statements.ErrUnknownFormat)
+
+			_, err = service.Import(ctx, statements.ImportParams{
+				FundraiseID: fundraise.ID,
+				CallerID:    donor.ID,
+				Format:      statements.GenericCSV.Format,
+				Statement:   strings.NewReader(statement),
+			})
+			require.ErrorIs(t, err, fundraises.ErrNotOrganizer)
+			require.True(t, statements.ParamsError.Has(err))
+
+			summary, err := service.Import(ctx, statements.ImportParams{
+				FundraiseID: fundraise.ID,
+				CallerID:    organizer.ID,
+				Format:      statements.GenericCSV.Format,
+				Statement:   strings.NewReader(statement),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, 1, summary.Matched)
+			assert.Equal(t, 1, summary.Unmatched)
+			assert.Equal(t, 1, summary.Outgoing)
+
+			// INFO: matched donation is marked verified.
+			record, err := db.OfflineDonations().Get(ctx, recorded.Donation.ID)
+			require.NoError(t, err)
+			assert.False(t, record.VerifiedAt.IsZero())
+
+			summary, err = service.Import(ctx, statements.ImportParams{
+				FundraiseID: fundraise.ID,
+				CallerID:    organizer.ID,
+				Format:      statements.GenericCSV.Format,
+				Statement:   strings.NewReader(statement),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, 2, summary.Duplicates)
+			assert.Zero(t, summary.Matched+summary.Unmatched)
+		})
+
+		t.Run("confirm", func(t *testing.T) {
+			unmatched, err := service.Lines(ctx, fundraise.ID, organizer.ID, statements.StatusUnmatched)
+			require.NoError(t, err)
+			require.Len(t, unmatched, 1)
+
+			filled, err := fundraisesService.Filled(ctx, fundraise.ID)
+			require.NoError(t, err)
+
+			_, err = service.ConfirmLine(ctx, statements.LineParams{LineID: unmatched[0].ID, CallerID: donor.ID})
+			require.ErrorIs(t, err, fundraises.ErrNotOrganizer)
+
+			// INFO: concurrent confirmations record the donation only once.
+			lines := make([]statements.Line, 2)
+			confirmations := make([]error, 2)
+			var wg sync.WaitGroup
+			for i := range confirmations {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					lines[i], confirmations[i] = service.ConfirmLine(ctx, statements.LineParams{LineID: unmatched[0].ID, CallerID: organizer.ID})
+				}()
+			}
+			wg.Wait()
+
+			var line statements.Line
+			for i, err := range confirmations {
+				if err == nil {
+					line = lines[i]
+					continue
+				}
+				assert.ErrorIs(t, err, statements.ErrLineReviewed)
+				assert.True(t, statements.ParamsError.Has(err))
+			}
+			assert.Equal(t, statements.StatusConfirmed, line.Status)
+			assert.NotEqual(t, uuid.Nil, line.DonationID)
+
+			confirmed, err := fundraisesService.Filled(ctx, fundraise.ID)
+			require.NoError(t, err)
+			assert.Equal(t, filled.Add(currencies.Major(20)), confirmed)
+
+			record, err := db.OfflineDonations().Get(ctx, line.DonationID)
+			require.NoError(t, err)
+			assert.False(t, record.VerifiedAt.IsZero())
+
+			_, err = service.IgnoreLine(ctx, statements.LineParams{LineID: line.ID, CallerID: organizer.ID})
+			require.ErrorIs(t, err, statements.ErrLineReviewed)
+		})
+	})
+}
@@ -0,0 +1,169 @@
+package statements
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zeebo/errs"
+
+	"one-help/app/currencies"
+)
+
+var (
+	// ErrUnknownFormat indicates that no parser is registered for the statement format.
+	ErrUnknownFormat = errs.New("bank statement format is not supported")
+	// ErrMalformed indicates that bank statement could not be parsed.
+	ErrMalformed = errs.Class("malformed bank statement")
+)
+
+const (
+	// StatusMatched defines incoming transfer matched to the offline donation recorded by the organizer.
+	StatusMatched string = "MATCHED"
+	// StatusUnmatched defines incoming transfer awaiting organizer's review.
+	StatusUnmatched string = "UNMATCHED"
+	// StatusConfirmed defines reviewed incoming transfer recorded as a new donation.
+	StatusConfirmed string = "CONFIRMED"
+	// StatusIgnored defines reviewed incoming transfer that is not a donation, e.g. own top-up.
+	StatusIgnored string = "IGNORED"
+)
+
+// Transaction describes a single entry of the bank statement.
+type Transaction struct {
+	// ExternalID identifies transaction within the account, repeated imports of the same transaction are skipped.
+	ExternalID string
+	BookedAt   time.Time
+	Amount     currencies.Amount // INFO: negative for outgoing transfers.
+	Currency   string
+	// Reference is the payment purpose entered by the payer.
+	Reference    string
+	Counterparty string
+}
+
+// IsIncoming returns true if transaction credits the account.
+func (t *Transaction) IsIncoming() bool {
+	return t.Amount.IsPositive()
+}
+
+// Statement describes imported bank statement.
+type Statement struct {
+	ID          uuid.UUID
+	FundraiseID uuid.UUID
+	Format      string
+	ImportedBy  uuid.UUID
+	CreatedAt   time.Time
+}
+
+// Line describes incoming transaction of the imported statement and its reconciliation state.
+type Line struct {
+	ID          uuid.UUID
+	StatementID uuid.UUID
+	FundraiseID uuid.UUID
+	Transaction Transaction
+	Status      string
+	DonationID  uuid.UUID // INFO: nil uuid until matched or confirmed.
+	ReviewedBy  uuid.UUID // INFO: nil uuid until reviewed.
+	UpdatedAt   time.Time
+}
+
+// IsReviewed returns true if line does not await organizer's review anymore.
+func (l *Line) IsReviewed() bool {
+	return l.Status != StatusUnmatched
+}
+
+// Parser parses bank statements of the specific bank export format.
+type Parser interface {
+	// Format returns name of the statement format.
+	Format() string
+	// Parse returns transactions of the statement.
+	Parse(r io.Reader) ([]Transaction, error)
+}
+
+// Parsers holds statement parsers registered by format.
+type Parsers struct {
+	parsers map[string]Parser
+}
+
+// NewParsers is a constructor for statement parsers registry.
+func NewParsers(parsers ...Parser) *Parsers {
+	registry := &Parsers{parsers: make(map[string]Parser, len(parsers))}
+	for _, parser := range parsers {
+		registry.Register(parser)
+	}
+
+	return registry
+}
+
+// Register adds parser to the registry, replacing the parser of the same format.
+func (p *Parsers) Register(parser Parser) {
+	p.parsers[parser.Format()] = parser
+}
+
+// Get returns parser of the statement format.
+func (p *Parsers) Get(format string) (Parser, error) {
+	parser, ok := p.parsers[format]
+	if !ok {
+		return nil, ErrUnknownFormat
+	}
+
+	return parser, nil
+}
+
+// fingerprint derives external id of the transaction the bank export does not identify.
+// Occurrence distinguishes identical transactions of the same statement.
+func fingerprint(transaction Transaction, occurrence int) string {
+	sum := sha256.Sum256([]byte(transaction.BookedAt.UTC().Format(time.RFC3339) + "|" +
+		transaction.Amount.String() + "|" + transaction.Currency + "|" +
+		transaction.Reference + "|" + transaction.Counterparty + "|" + strconv.Itoa(occurrence)))
+
+	return "sha256:" + hex.EncodeToString(sum[:16])
+}
+
+// identify assigns fingerprints to transactions without external id.
+func identify(transactions []Transaction) {
+	occurrences := make(map[string]int)
+	for i := range transactions {
+		if transactions[i].ExternalID != "" {
+			continue
+		}
+
+		key := fingerprint(transactions[i], 0)
+		transactions[i].ExternalID = fingerprint(transactions[i], occurrences[key])
+		occurrences[key]++
+	}
+}
+
+// MaxSize limits size of the imported statement in bytes.
+const MaxSize = 5 << 20
+
+// Summary describes result of the statement import.
+type Summary struct {
+	Statement Statement
+	// Matched is the number of incoming transfers matched to offline donations.
+	Matched int
+	// Unmatched is the number of incoming transfers awaiting organizer's review.
+	Unmatched int
+	// Duplicates is the number of transactions imported before.
+	Duplicates int
+	// Outgoing is the number of skipped outgoing transfers.
+	Outgoing int
+}
+
+// ImportParams defines values needed to import bank statement of the fundraise account.
+type ImportParams struct {
+	FundraiseID uuid.UUID
+	CallerID    uuid.UUID
+	Format      string
+	Statement   io.Reader
+}
+
+// LineParams defines values needed to review incoming transfer of the imported statement.
+type LineParams struct {
+	LineID   uuid.UUID
+	CallerID uuid.UUID
+	DonorID  uuid.UUID // INFO: optional donor of the confirmed transfer.
+	Message  string
+}
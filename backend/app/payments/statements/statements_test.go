@@ -0,0 +1,125 @@
+package statements_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app/currencies"
+	"one-help/app/payments/statements"
+)
+
+func TestCSVParser(t *testing.T) {
+	parser := statements.NewCSVParser(statements.GenericCSV)
+
+	transactions, err := parser.Parse(strings.NewReader("\ufeffDate,Amount,Currency,Reference,Counterparty\n" +
+		"2026-10-01,\"1,500.00\",uah,Donation INV-1,Jane Doe\n" +
+		"2026-10-01,-200,UAH,Bank fee,\n" +
+		"2026-10-01,\"1,500.00\",uah,Donation INV-1,Jane Doe\n"))
+	require.NoError(t, err)
+	require.Len(t, transactions, 3)
+
+	assert.Equal(t, time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC), transactions[0].BookedAt)
+	assert.Equal(t, currencies.Major(1500), transactions[0].Amount)
+	assert.Equal(t, "UAH", transactions[0].Currency)
+	assert.Equal(t, "Donation INV-1", transactions[0].Reference)
+	assert.True(t, transactions[0].IsIncoming())
+	assert.False(t, transactions[1].IsIncoming())
+
+	// INFO: identical transactions get distinct stable fingerprints.
+	assert.NotEqual(t, transactions[0].ExternalID, transactions[2].ExternalID)
+	again, err := parser.Parse(strings.NewReader("date,amount,currency,reference,counterparty\n2026-10-01,1500,UAH,Donation INV-1,Jane Doe\n"))
+	require.NoError(t, err)
+	assert.Equal(t, transactions[0].ExternalID, again[0].ExternalID)
+
+	_, err = parser.Parse(strings.NewReader("date,amount\n2026-10-01,100\n"))
+	require.True(t, statements.ErrMalformed.Has(err))
+
+	_, err = parser.Parse(strings.NewReader("date,amount,reference\n01.10.2026,100,test\n"))
+	require.True(t, statements.ErrMalformed.Has(err))
+}
+
+func TestCAMT053Parser(t *testing.T) {
+	document := `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">
+  <BkToCstmrStmt>
+    <Stmt>
+      <Ntry>
+        <Amt Ccy="EUR">250.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <BookgDt><Dt>2026-10-02</Dt></BookgDt>
+        <AcctSvcrRef>REF-1</AcctSvcrRef>
+        <NtryDtls><TxDtls>
+          <RltdPties><Dbtr><Nm>Jane Doe</Nm></Dbtr></RltdPties>
+          <RmtInf><Ustrd>Donation INV-2</Ustrd></RmtInf>
+        </TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">30.00</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <BookgDt><DtTm>2026-10-02T10:00:00+02:00</DtTm></BookgDt>
+        <NtryRef>REF-2</NtryRef>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">30.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <BookgDt><Dt>2026-10-03</Dt></BookgDt>
+        <AcctSvcrRef>REF-3</AcctSvcrRef>
+        <NtryDtls>
+          <TxDtls><Amt Ccy="EUR">10.00</Amt><RmtInf><Ustrd>first</Ustrd></RmtInf></TxDtls>
+          <TxDtls><Amt Ccy="EUR">20.00</Amt><RmtInf><Ustrd>second</Ustrd></RmtInf></TxDtls>
+        </NtryDtls>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>`
+
+	transactions, err := statements.NewCAMT053Parser().Parse(strings.NewReader(document))
+	require.NoError(t, err)
+	require.Len(t, transactions, 4)
+
+	assert.Equal(t, statements.Transaction{
+		ExternalID:   "REF-1",
+		BookedAt:     time.Date(2026, time.October, 2, 0, 0, 0, 0, time.UTC),
+		Amount:       currencies.Major(250),
+		Currency:     "EUR",
+		Reference:    "Donation INV-2",
+		Counterparty: "Jane Doe",
+	}, transactions[0])
+
+	assert.Equal(t, currencies.Major(-30), transactions[1].Amount)
+	assert.Equal(t, "REF-2", transactions[1].ExternalID)
+	assert.Equal(t, time.Date(2026, time.October, 2, 8, 0, 0, 0, time.UTC), transactions[1].BookedAt)
+
+	// INFO: batched entry is split by transaction details.
+	assert.Equal(t, currencies.Major(10), transactions[2].Amount)
+	assert.Equal(t, "REF-3/1", transactions[2].ExternalID)
+	assert.Equal(t, currencies.Major(20), transactions[3].Amount)
+	assert.Equal(t, "second", transactions[3].Reference)
+
+	_, err = statements.NewCAMT053Parser().Parse(strings.NewReader("<Document>"))
+	require.True(t, statements.ErrMalformed.Has(err))
+}
+
+func TestMatch(t *testing.T) {
+	day := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+	byDate := statements.Candidate{DonationID: uuid.New(), Amount: currencies.Major(100), Currency: "UAH", ReceivedAt: day}
+	byReference := statements.Candidate{DonationID: uuid.New(), Amount: currencies.Major(100), Currency: "UAH", ReceivedAt: day.Add(-48 * time.Hour), Reference: "inv-7"}
+	late := statements.Candidate{DonationID: uuid.New(), Amount: currencies.Major(50), Currency: "UAH", ReceivedAt: day.Add(-5 * 24 * time.Hour)}
+
+	transactions := []statements.Transaction{
+		{Amount: currencies.Major(100), Currency: "UAH", BookedAt: day},
+		{Amount: currencies.Major(100), Currency: "UAH", BookedAt: day, Reference: "Payment INV-7"},
+		{Amount: currencies.Major(50), Currency: "UAH", BookedAt: day},
+		{Amount: currencies.Major(-100), Currency: "UAH", BookedAt: day},
+		{Amount: currencies.Major(100), Currency: "EUR", BookedAt: day},
+	}
+
+	matches := statements.Match(transactions, []statements.Candidate{byDate, byReference, late})
+	assert.Equal(t, map[int]uuid.UUID{0: byDate.DonationID, 1: byReference.DonationID}, matches)
+}
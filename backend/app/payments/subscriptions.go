@@ -0,0 +1,52 @@
+package payments
+
+import (
+	"context"
+
+	"github.com/zeebo/errs"
+
+	"one-help/app/currencies"
+)
+
+// ErrSubscriptionsUnsupported indicates that provider does not support recurring charges.
+var ErrSubscriptionsUnsupported = errs.New("payment provider does not support subscriptions")
+
+const (
+	// EventSubscriptionStarted defines subscription created after donor's checkout.
+	EventSubscriptionStarted EventKind = "SUBSCRIPTION_STARTED"
+	// EventSubscriptionCharged defines successful recurring charge of the subscription.
+	EventSubscriptionCharged EventKind = "SUBSCRIPTION_CHARGED"
+	// EventSubscriptionFailed defines declined recurring charge of the subscription.
+	EventSubscriptionFailed EventKind = "SUBSCRIPTION_FAILED"
+	// EventSubscriptionCanceled defines subscription canceled on provider's side.
+	EventSubscriptionCanceled EventKind = "SUBSCRIPTION_CANCELED"
+)
+
+// SubscriptionParams defines values needed to setup subscription checkout session.
+type SubscriptionParams struct {
+	// Reference identifies the plan in webhook events, e.g. plan id.
+	Reference string
+	// RedirectPath is where donor returns after checkout, must start with '/'.
+	RedirectPath string
+	// Amount charged every billing period, in the subscription currency.
+	Amount currencies.Money
+	// Interval is a billing period, month or year.
+	Interval    string
+	Description string
+}
+
+// SubscriptionProvider is a payment provider that charges donors on schedule.
+// Provider's own retries of failed charges are expected to be disabled, as they are scheduled by the platform.
+type SubscriptionProvider interface {
+	Provider
+	// CreateSubscription setups subscription checkout session, subscription id is delivered with started event.
+	CreateSubscription(ctx context.Context, params SubscriptionParams) (Session, error)
+	// UpdateSubscription changes amount of the next charges.
+	UpdateSubscription(ctx context.Context, subscriptionID string, amount currencies.Amount) error
+	// PauseSubscription stops or resumes charges.
+	PauseSubscription(ctx context.Context, subscriptionID string, paused bool) error
+	// CancelSubscription stops charges permanently.
+	CancelSubscription(ctx context.Context, subscriptionID string) error
+	// RetrySubscription retries the latest failed charge, result is delivered with charged or failed event.
+	RetrySubscription(ctx context.Context, subscriptionID string) error
+}
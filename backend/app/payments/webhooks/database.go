@@ -0,0 +1,16 @@
+package webhooks
+
+import (
+	"context"
+	"time"
+)
+
+// DB exposes access to webhook events db.
+//
+// architecture: DB
+type DB interface {
+	// Store inserts event if it was not received before and returns the stored one.
+	Store(ctx context.Context, event Event) (Event, error)
+	// MarkProcessed sets event processing time.
+	MarkProcessed(ctx context.Context, provider, id string, processedAt time.Time) error
+}
@@ -0,0 +1,94 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"one-help/app/payments"
+	"one-help/internal/logger"
+)
+
+var (
+	// Error wraps errors from webhooks service that indicates about internal errors.
+	Error = errs.Class("webhooks service")
+	// ParamsError wraps errors from webhooks service that indicates about invalid or malformed parameters' data.
+	ParamsError = errs.Class("webhooks service: params")
+)
+
+// Handler applies payment provider's events, events of kinds it does not handle are ignored.
+type Handler interface {
+	// HandleEvent applies the event, it is repeated on redelivery if it fails.
+	HandleEvent(ctx context.Context, paymentType string, event payments.Event) error
+}
+
+// Service handles payment providers' webhooks.
+//
+// architecture: Service
+type Service struct {
+	logger logger.Logger
+
+	webhooks DB
+
+	providers *payments.Providers
+	handlers  []Handler
+}
+
+// NewService is a constructor for webhooks service.
+func NewService(logger logger.Logger, webhooks DB, providers *payments.Providers, handlers ...Handler) *Service {
+	return &Service{
+		logger:    logger,
+		webhooks:  webhooks,
+		providers: providers,
+		handlers:  handlers,
+	}
+}
+
+// Process verifies payment provider's webhook event and passes it to the handlers.
+// Events are stored on receipt and applied only once, so redelivered events are acknowledged without changes.
+func (service *Service) Process(ctx context.Context, paymentType string, payload []byte, header http.Header) error {
+	provider, err := service.providers.Get(paymentType)
+	if err != nil {
+		return ParamsError.Wrap(err)
+	}
+
+	event, err := provider.ParseWebhook(payload, header)
+	if err != nil {
+		if errors.Is(err, payments.ErrInvalidSignature) {
+			return ParamsError.Wrap(err)
+		}
+
+		return Error.Wrap(err)
+	}
+
+	stored, err := service.webhooks.Store(ctx, Event{
+		Provider:   paymentType,
+		ID:         event.ID,
+		Type:       event.Type,
+		Payload:    event.Payload,
+		ReceivedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if stored.IsProcessed() {
+		service.logger.DebugF("%s event %s is already processed", paymentType, event.ID)
+		return nil
+	}
+
+	if event.Kind == payments.EventIgnored {
+		service.logger.DebugF("ignoring %s event %s of type %s", paymentType, event.ID, event.Type)
+	}
+
+	for _, handler := range service.handlers {
+		if err = handler.HandleEvent(ctx, paymentType, event); err != nil {
+			return Error.Wrap(err)
+		}
+	}
+
+	return Error.Wrap(service.webhooks.MarkProcessed(ctx, paymentType, event.ID, time.Now().UTC()))
+}
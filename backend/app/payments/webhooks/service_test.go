@@ -0,0 +1,93 @@
+package webhooks_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"one-help/app"
+	"one-help/app/database"
+	"one-help/app/database/dbtesting"
+	"one-help/app/payments"
+	"one-help/app/payments/fake"
+	"one-help/app/payments/webhooks"
+	"one-help/internal/logger/zaplog"
+)
+
+// handler records applied events, failing them while err is set.
+type handler struct {
+	mu     sync.Mutex
+	events []payments.Event
+	err    error
+}
+
+// HandleEvent records the event unless handler fails.
+func (h *handler) HandleEvent(ctx context.Context, paymentType string, event payments.Event) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.err != nil {
+		return h.err
+	}
+
+	h.events = append(h.events, event)
+	return nil
+}
+
+// fail sets error returned for the following events.
+func (h *handler) fail(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.err = err
+}
+
+// applied returns number of recorded events.
+func (h *handler) applied() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.events)
+}
+
+func TestWebhooks(t *testing.T) {
+	provider := fake.NewProvider(payments.TypeStripe)
+
+	dbtesting.Run(t, database.Config{MigrationsPath: "../../database/migrations"}, func(ctx context.Context, t *testing.T, db app.DB) {
+		recorder := new(handler)
+		service := webhooks.NewService(zaplog.NewLog(), db.WebhookEvents(), payments.NewProviders(provider), recorder)
+
+		payload, header, err := provider.Webhook(payments.Event{ID: "evt_1", Kind: payments.EventCompleted, Paid: true})
+		require.NoError(t, err)
+
+		t.Run("unknown provider", func(t *testing.T) {
+			err := service.Process(ctx, payments.TypeLiqPay, payload, header)
+			require.True(t, webhooks.ParamsError.Has(err))
+		})
+
+		t.Run("invalid signature", func(t *testing.T) {
+			err := service.Process(ctx, payments.TypeStripe, payload, nil)
+			require.True(t, webhooks.ParamsError.Has(err))
+			assert.Zero(t, recorder.applied())
+		})
+
+		t.Run("failed", func(t *testing.T) {
+			recorder.fail(errors.New("database is unavailable"))
+			require.Error(t, service.Process(ctx, payments.TypeStripe, payload, header))
+			assert.Zero(t, recorder.applied())
+			recorder.fail(nil)
+		})
+
+		t.Run("processed once", func(t *testing.T) {
+			// INFO: failed event is applied on redelivery, after that redelivered event is acknowledged without changes.
+			for range 2 {
+				require.NoError(t, service.Process(ctx, payments.TypeStripe, payload, header))
+				assert.Equal(t, 1, recorder.applied())
+			}
+		})
+	})
+}
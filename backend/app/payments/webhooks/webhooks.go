@@ -0,0 +1,20 @@
+package webhooks
+
+import (
+	"time"
+)
+
+// Event describes payment provider's webhook event, stored to process every event only once.
+type Event struct {
+	Provider    string // INFO: payment type of the provider.
+	ID          string
+	Type        string
+	Payload     []byte
+	ReceivedAt  time.Time
+	ProcessedAt time.Time // INFO: zero until event is processed successfully.
+}
+
+// IsProcessed returns true if event has been already processed.
+func (e *Event) IsProcessed() bool {
+	return !e.ProcessedAt.IsZero()
+}
@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"one-help/app/currencies"
 )
 
 // Raffle describes raffle entity.
@@ -11,7 +13,7 @@ type Raffle struct {
 	ID              uuid.UUID
 	Title           string
 	Description     string
-	MinimumDonation float64
+	MinimumDonation currencies.Amount
 	StartDate       time.Time
 	EndDate         time.Time
 	FundraiseID     uuid.UUID
@@ -21,7 +23,7 @@ type Raffle struct {
 type CreateParams struct {
 	Title           string
 	Description     string
-	MinimumDonation float64
+	MinimumDonation currencies.Amount
 	StartDate       time.Time
 	EndDate         time.Time
 	FundraiseID     uuid.UUID
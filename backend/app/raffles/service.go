@@ -40,7 +40,7 @@ func (service *Service) Create(ctx context.Context, params CreateParams) (*Raffl
 		return nil, nil, ParamsError.New("title is required")
 	case params.Description == "":
 		return nil, nil, ParamsError.New("description is required")
-	case params.MinimumDonation < 0.:
+	case params.MinimumDonation.Sign() < 0:
 		return nil, nil, ParamsError.New("minimum amount must be positive on zero")
 	}
 
@@ -0,0 +1,48 @@
+package stripe
+
+import (
+	"context"
+	"time"
+
+	"github.com/stripe/stripe-go/v82"
+	"github.com/stripe/stripe-go/v82/charge"
+
+	"one-help/app/currencies"
+	"one-help/app/payments"
+)
+
+// ensures that Charger implements payments.BalanceProvider.
+var _ payments.BalanceProvider = (*Charger)(nil)
+
+// Collected returns captured amounts of succeeded charges created within the period, net of their refunds.
+func (c *Charger) Collected(ctx context.Context, from, to time.Time) (map[string]currencies.Amount, error) {
+	params := &stripe.ChargeListParams{
+		CreatedRange: &stripe.RangeQueryParams{
+			GreaterThanOrEqual: from.Unix(),
+			LesserThan:         to.Unix(),
+		},
+	}
+	params.Context = ctx
+
+	collected := make(map[string]int64)
+	iter := charge.List(params)
+	for iter.Next() {
+		charge_ := iter.Charge()
+		if charge_.Status != stripe.ChargeStatusSucceeded {
+			continue
+		}
+
+		collected[toCurrencyCode(charge_.Currency)] += charge_.AmountCaptured - charge_.AmountRefunded
+	}
+	if err := iter.Err(); err != nil {
+		c.log.Error("error listing charges", Error.Wrap(err))
+		return nil, Error.Wrap(err)
+	}
+
+	totals := make(map[string]currencies.Amount, len(collected))
+	for currency, amount := range collected {
+		totals[currency] = currencies.MinorUnits(amount)
+	}
+
+	return totals, nil
+}
@@ -1,21 +1,38 @@
 package stripe
 
 import (
+	"context"
+	"errors"
+	"strings"
+
 	"github.com/stripe/stripe-go/v82"
 	"github.com/stripe/stripe-go/v82/checkout/session"
+	"github.com/stripe/stripe-go/v82/refund"
 	"github.com/zeebo/errs"
 
+	"one-help/app/currencies"
+	"one-help/app/payments"
 	"one-help/internal/logger"
 )
 
 // Error is an error wrapper that notifies that error was produced by stripe charger.
 var Error = errs.Class("stripe charger")
 
+// ensures that Charger implements payments.Provider and payments.FeeProvider.
+var (
+	_ payments.Provider    = (*Charger)(nil)
+	_ payments.FeeProvider = (*Charger)(nil)
+)
+
 // Config holds configurable values for Stripe charger.
 type Config struct {
 	SecretAPIKey   string `env:"SECRET_API_KEY"`
 	RedirectDomain string `env:"REDIRECT_DOMAIN"` // INFO: Ex.: localhost:port/api/v0
 	PriceID        string `env:"PRICE_ID"`
+	// WebhookSecret verifies signatures of webhook events, webhooks are refused if it is not set.
+	WebhookSecret string `env:"WEBHOOK_SECRET" envDefault:""`
+
+	Fees payments.FeeSchedule `envPrefix:"FEE_"`
 }
 
 // Charger defines Stripe charger functionality.
@@ -27,49 +44,116 @@ type Charger struct {
 // NewCharger is a constructor for Charger.
 func NewCharger(log logger.Logger, config Config) *Charger {
 	stripe.Key = config.SecretAPIKey
+	if config.WebhookSecret == "" {
+		log.Warn("stripe webhook secret is not configured, stripe webhooks are refused")
+	}
+
 	return &Charger{
 		log:    log,
 		config: config,
 	}
 }
 
-// SetupChargeSessionURL setups charge session and provides payment redirect url.
-// NOTE: redirectPath must start with '/'.
-func (c *Charger) SetupChargeSessionURL(redirectPath string) (url string, id string, err error) {
-	redirectPath = c.config.RedirectDomain + redirectPath
+// Type returns payment type of the provider.
+func (c *Charger) Type() string {
+	return payments.TypeStripe
+}
+
+// Fees returns configured processing fee schedule of Stripe.
+func (c *Charger) Fees() payments.FeeSchedule {
+	return c.config.Fees
+}
+
+// CreateSession setups charge session in provided currency and provides payment redirect url.
+// Configured PriceID is charged if amount is zero.
+func (c *Charger) CreateSession(ctx context.Context, params payments.SessionParams) (payments.Session, error) {
+	redirectPath := c.config.RedirectDomain + params.RedirectPath
+	currency := strings.ToLower(params.Amount.Currency)
+
+	lineItem := &stripe.CheckoutSessionLineItemParams{
+		Price:    stripe.String(c.config.PriceID),
+		Quantity: stripe.Int64(1),
+	}
+	if params.Amount.Amount.IsPositive() {
+		lineItem.Price = nil
+		lineItem.PriceData = &stripe.CheckoutSessionLineItemPriceDataParams{
+			Currency:   stripe.String(currency),
+			UnitAmount: stripe.Int64(params.Amount.Amount.MinorUnits()),
+			ProductData: &stripe.CheckoutSessionLineItemPriceDataProductDataParams{
+				Name: stripe.String(params.Description),
+			},
+		}
+	}
+
 	checkoutParams := &stripe.CheckoutSessionParams{
-		LineItems: []*stripe.CheckoutSessionLineItemParams{{
-			Price:    stripe.String(c.config.PriceID),
-			Quantity: stripe.Int64(1),
-		}},
-		Mode:       stripe.String(string(stripe.CheckoutSessionModePayment)),
-		SuccessURL: stripe.String(redirectPath + "?success=true"),
-		CancelURL:  stripe.String(redirectPath + "?canceled=true"),
-		Currency:   stripe.String(string(stripe.CurrencyUAH)),
+		LineItems:         []*stripe.CheckoutSessionLineItemParams{lineItem},
+		Mode:              stripe.String(string(stripe.CheckoutSessionModePayment)),
+		SuccessURL:        stripe.String(redirectPath + "?success=true"),
+		CancelURL:         stripe.String(redirectPath + "?canceled=true"),
+		Currency:          stripe.String(currency),
+		ClientReferenceID: stripe.String(params.Reference),
+		// INFO: payment intent and its charges do not know about the session, so reference is copied to them.
+		PaymentIntentData: &stripe.CheckoutSessionPaymentIntentDataParams{
+			Metadata: map[string]string{referenceMetadataKey: params.Reference},
+		},
 	}
+	checkoutParams.Context = ctx
 
 	session_, err := session.New(checkoutParams)
 	if err != nil {
 		c.log.Error("error creating session", Error.Wrap(err))
-		return url, id, Error.Wrap(err)
+		return payments.Session{}, Error.Wrap(err)
 	}
 
-	url = session_.URL
-	id = session_.ID
-
-	return url, id, nil
+	return payments.Session{URL: session_.URL, TransactionID: session_.ID}, nil
 }
 
-// GetSessionPaymentData provides payment amount info.
-func (c *Charger) GetSessionPaymentData(id string) (paid bool, funded float64, err error) {
-	session_, err := session.Get(id, new(stripe.CheckoutSessionParams))
+// Status provides payment amount info of the session, currency is returned as upper-case ISO code.
+func (c *Charger) Status(ctx context.Context, transactionID string) (payments.SessionStatus, error) {
+	params := new(stripe.CheckoutSessionParams)
+	params.Context = ctx
+
+	session_, err := session.Get(transactionID, params)
 	if err != nil {
+		var stripeErr *stripe.Error
+		if errors.As(err, &stripeErr) && stripeErr.Code == stripe.ErrorCodeResourceMissing {
+			return payments.SessionStatus{}, Error.Wrap(payments.ErrSessionNotFound)
+		}
+
 		c.log.Error("error getting session", Error.Wrap(err))
-		return paid, funded, Error.Wrap(err)
+		return payments.SessionStatus{}, Error.Wrap(err)
+	}
+
+	status := payments.SessionStatus{
+		Paid:    session_.PaymentStatus == stripe.CheckoutSessionPaymentStatusPaid,
+		Amount:  currencies.NewMoney(currencies.MinorUnits(session_.AmountTotal), toCurrencyCode(session_.Currency)),
+		Expired: session_.Status == stripe.CheckoutSessionStatusExpired,
+	}
+	if session_.PaymentIntent != nil {
+		status.Reference = session_.PaymentIntent.ID
+	}
+
+	return status, nil
+}
+
+// Refund returns amount of the payment intent to the donor.
+func (c *Charger) Refund(ctx context.Context, payment payments.Payment, amount currencies.Amount) error {
+	params := &stripe.RefundParams{
+		PaymentIntent: stripe.String(payment.Reference),
+		Amount:        stripe.Int64(amount.MinorUnits()),
 	}
+	params.Context = ctx
 
-	paid = session_.PaymentStatus == stripe.CheckoutSessionPaymentStatusPaid
-	funded = float64(session_.AmountTotal) / 100.
+	_, err := refund.New(params)
+	if err != nil {
+		c.log.Error("error creating refund", Error.Wrap(err))
+		return Error.Wrap(err)
+	}
+
+	return nil
+}
 
-	return paid, funded, nil
+// toCurrencyCode converts Stripe lower-case currency to upper-case ISO code.
+func toCurrencyCode(currency stripe.Currency) string {
+	return strings.ToUpper(string(currency))
 }
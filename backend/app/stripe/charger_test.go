@@ -1,11 +1,14 @@
 package stripe_test
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"one-help/app/currencies"
+	"one-help/app/payments"
 	"one-help/app/stripe"
 	"one-help/internal/logger/zaplog"
 )
@@ -13,23 +16,28 @@ import (
 func TestCharger(t *testing.T) {
 	t.Skip("for manual use")
 
+	ctx := context.Background()
 	charger := stripe.NewCharger(zaplog.NewLog(), stripe.Config{
 		SecretAPIKey:   "",
 		RedirectDomain: "",
 		PriceID:        "",
 	})
 
-	t.Run("SetupChargeSessionURL", func(t *testing.T) {
-		url, id, err := charger.SetupChargeSessionURL("/fundraises/donation/id")
+	t.Run("CreateSession", func(t *testing.T) {
+		session, err := charger.CreateSession(ctx, payments.SessionParams{
+			RedirectPath: "/fundraises/donation/id",
+			Amount:       currencies.NewMoney(currencies.MinorUnits(15050), currencies.UAH),
+			Description:  "Donation",
+		})
 		require.NoError(t, err)
-		fmt.Println(id)
-		fmt.Println(url)
+		fmt.Println(session.TransactionID)
+		fmt.Println(session.URL)
 	})
 
-	t.Run("GetSessionPaymentData", func(t *testing.T) {
-		paid, funded, err := charger.GetSessionPaymentData("cs_test_a1Qt7394rR4TdfzCyBpx1La116znKYoWlCVIBMV5tfRlUnXsWymknP4fTn")
+	t.Run("Status", func(t *testing.T) {
+		status, err := charger.Status(ctx, "cs_test_a1Qt7394rR4TdfzCyBpx1La116znKYoWlCVIBMV5tfRlUnXsWymknP4fTn")
 		require.NoError(t, err)
-		fmt.Println(paid)
-		fmt.Println(funded)
+		fmt.Println(status.Paid)
+		fmt.Println(status.Amount)
 	})
 }
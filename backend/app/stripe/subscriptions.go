@@ -0,0 +1,166 @@
+package stripe
+
+import (
+	"context"
+	"strings"
+
+	"github.com/stripe/stripe-go/v82"
+	"github.com/stripe/stripe-go/v82/checkout/session"
+	"github.com/stripe/stripe-go/v82/invoice"
+	"github.com/stripe/stripe-go/v82/subscription"
+
+	"one-help/app/currencies"
+	"one-help/app/payments"
+)
+
+// ensures that Charger implements payments.SubscriptionProvider.
+var _ payments.SubscriptionProvider = (*Charger)(nil)
+
+// CreateSubscription setups subscription mode checkout session with the price of plan amount and interval.
+func (c *Charger) CreateSubscription(ctx context.Context, params payments.SubscriptionParams) (payments.Session, error) {
+	redirectPath := c.config.RedirectDomain + params.RedirectPath
+	currency := strings.ToLower(params.Amount.Currency)
+
+	checkoutParams := &stripe.CheckoutSessionParams{
+		LineItems: []*stripe.CheckoutSessionLineItemParams{{
+			PriceData: &stripe.CheckoutSessionLineItemPriceDataParams{
+				Currency:   stripe.String(currency),
+				UnitAmount: stripe.Int64(params.Amount.Amount.MinorUnits()),
+				Recurring: &stripe.CheckoutSessionLineItemPriceDataRecurringParams{
+					Interval: stripe.String(strings.ToLower(params.Interval)),
+				},
+				ProductData: &stripe.CheckoutSessionLineItemPriceDataProductDataParams{
+					Name: stripe.String(params.Description),
+				},
+			},
+			Quantity: stripe.Int64(1),
+		}},
+		Mode:              stripe.String(string(stripe.CheckoutSessionModeSubscription)),
+		SuccessURL:        stripe.String(redirectPath + "?success=true"),
+		CancelURL:         stripe.String(redirectPath + "?canceled=true"),
+		Currency:          stripe.String(currency),
+		ClientReferenceID: stripe.String(params.Reference),
+		// INFO: invoices of the subscription receive snapshot of its metadata.
+		SubscriptionData: &stripe.CheckoutSessionSubscriptionDataParams{
+			Metadata: map[string]string{referenceMetadataKey: params.Reference},
+		},
+	}
+	checkoutParams.Context = ctx
+
+	session_, err := session.New(checkoutParams)
+	if err != nil {
+		c.log.Error("error creating subscription session", Error.Wrap(err))
+		return payments.Session{}, Error.Wrap(err)
+	}
+
+	return payments.Session{URL: session_.URL, TransactionID: session_.ID}, nil
+}
+
+// UpdateSubscription replaces subscription price with the new amount, keeping product and interval.
+// Change applies to the next charge without proration.
+func (c *Charger) UpdateSubscription(ctx context.Context, subscriptionID string, amount currencies.Amount) error {
+	subscription_, err := c.getSubscription(ctx, subscriptionID)
+	if err != nil {
+		return err
+	}
+
+	if subscription_.Items == nil || len(subscription_.Items.Data) == 0 {
+		return Error.New("subscription %s has no items", subscriptionID)
+	}
+
+	item := subscription_.Items.Data[0]
+	if item.Price == nil || item.Price.Product == nil || item.Price.Recurring == nil {
+		return Error.New("subscription %s item has no recurring price", subscriptionID)
+	}
+
+	params := &stripe.SubscriptionParams{
+		Items: []*stripe.SubscriptionItemsParams{{
+			ID: stripe.String(item.ID),
+			PriceData: &stripe.SubscriptionItemPriceDataParams{
+				Currency:   stripe.String(string(item.Price.Currency)),
+				Product:    stripe.String(item.Price.Product.ID),
+				UnitAmount: stripe.Int64(amount.MinorUnits()),
+				Recurring: &stripe.SubscriptionItemPriceDataRecurringParams{
+					Interval: stripe.String(string(item.Price.Recurring.Interval)),
+				},
+			},
+		}},
+		ProrationBehavior: stripe.String("none"),
+	}
+	params.Context = ctx
+
+	if _, err = subscription.Update(subscriptionID, params); err != nil {
+		c.log.Error("error updating subscription", Error.Wrap(err))
+		return Error.Wrap(err)
+	}
+
+	return nil
+}
+
+// PauseSubscription voids invoices of the paused subscription, so donor is not charged until resumed.
+func (c *Charger) PauseSubscription(ctx context.Context, subscriptionID string, paused bool) error {
+	params := new(stripe.SubscriptionParams)
+	if paused {
+		params.PauseCollection = &stripe.SubscriptionPauseCollectionParams{Behavior: stripe.String("void")}
+	} else {
+		params.AddExtra("pause_collection", "")
+	}
+	params.Context = ctx
+
+	if _, err := subscription.Update(subscriptionID, params); err != nil {
+		c.log.Error("error pausing subscription", Error.Wrap(err))
+		return Error.Wrap(err)
+	}
+
+	return nil
+}
+
+// CancelSubscription cancels subscription immediately.
+func (c *Charger) CancelSubscription(ctx context.Context, subscriptionID string) error {
+	params := new(stripe.SubscriptionCancelParams)
+	params.Context = ctx
+
+	if _, err := subscription.Cancel(subscriptionID, params); err != nil {
+		c.log.Error("error canceling subscription", Error.Wrap(err))
+		return Error.Wrap(err)
+	}
+
+	return nil
+}
+
+// RetrySubscription attempts to pay the latest open invoice of the subscription.
+func (c *Charger) RetrySubscription(ctx context.Context, subscriptionID string) error {
+	subscription_, err := c.getSubscription(ctx, subscriptionID)
+	if err != nil {
+		return err
+	}
+
+	if subscription_.LatestInvoice == nil {
+		return Error.New("subscription %s has no invoices", subscriptionID)
+	}
+
+	params := new(stripe.InvoicePayParams)
+	params.Context = ctx
+
+	// INFO: declined payment is reported with invoice.payment_failed event as well.
+	if _, err = invoice.Pay(subscription_.LatestInvoice.ID, params); err != nil {
+		c.log.Error("error paying invoice", Error.Wrap(err))
+		return Error.Wrap(err)
+	}
+
+	return nil
+}
+
+// getSubscription returns subscription with its items.
+func (c *Charger) getSubscription(ctx context.Context, subscriptionID string) (*stripe.Subscription, error) {
+	params := new(stripe.SubscriptionParams)
+	params.Context = ctx
+
+	subscription_, err := subscription.Get(subscriptionID, params)
+	if err != nil {
+		c.log.Error("error getting subscription", Error.Wrap(err))
+		return nil, Error.Wrap(err)
+	}
+
+	return subscription_, nil
+}
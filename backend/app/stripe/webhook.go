@@ -0,0 +1,161 @@
+package stripe
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/stripe/stripe-go/v82"
+	"github.com/stripe/stripe-go/v82/webhook"
+	"github.com/zeebo/errs"
+
+	"one-help/app/currencies"
+	"one-help/app/payments"
+)
+
+// referenceMetadataKey is a metadata key of the donation reference on payment intents.
+const referenceMetadataKey = "reference"
+
+const (
+	// EventCheckoutCompleted is sent when donor finishes checkout, payment may still be processing.
+	EventCheckoutCompleted = "checkout.session.completed"
+	// EventCheckoutExpired is sent when checkout session expires without payment.
+	EventCheckoutExpired = "checkout.session.expired"
+	// EventPaymentFailed is sent when payment attempt fails.
+	EventPaymentFailed = "payment_intent.payment_failed"
+	// EventChargeRefunded is sent when charge is fully or partially refunded.
+	EventChargeRefunded = "charge.refunded"
+	// EventInvoicePaid is sent when recurring charge of the subscription succeeds.
+	EventInvoicePaid = "invoice.paid"
+	// EventInvoicePaymentFailed is sent when recurring charge of the subscription is declined.
+	EventInvoicePaymentFailed = "invoice.payment_failed"
+	// EventSubscriptionDeleted is sent when subscription is canceled.
+	EventSubscriptionDeleted = "customer.subscription.deleted"
+)
+
+// SignatureHeader is a header of Stripe webhook payload signature.
+const SignatureHeader = "Stripe-Signature"
+
+// ErrNoWebhookSecret indicates that webhook can not be verified, as webhook secret is not configured.
+var ErrNoWebhookSecret = errs.New("stripe webhook secret is not configured")
+
+// ParseWebhook verifies Stripe-Signature header of the payload and parses the event.
+// Events of unknown types are returned ignored, with ID and Type only.
+func (c *Charger) ParseWebhook(payload []byte, header http.Header) (payments.Event, error) {
+	if c.config.WebhookSecret == "" {
+		return payments.Event{}, Error.Wrap(errs.Combine(payments.ErrInvalidSignature, ErrNoWebhookSecret))
+	}
+
+	stripeEvent, err := webhook.ConstructEventWithOptions(payload, header.Get(SignatureHeader), c.config.WebhookSecret, webhook.ConstructEventOptions{
+		IgnoreAPIVersionMismatch: true,
+	})
+	if err != nil {
+		return payments.Event{}, Error.Wrap(errs.Combine(payments.ErrInvalidSignature, err))
+	}
+
+	event := payments.Event{
+		ID:      stripeEvent.ID,
+		Type:    string(stripeEvent.Type),
+		Kind:    payments.EventIgnored,
+		Payload: payload,
+	}
+
+	switch event.Type {
+	case EventCheckoutCompleted, EventCheckoutExpired:
+		event.Kind = payments.EventCompleted
+		if event.Type == EventCheckoutExpired {
+			event.Kind = payments.EventExpired
+		}
+
+		var checkoutSession stripe.CheckoutSession
+		if err = json.Unmarshal(stripeEvent.Data.Raw, &checkoutSession); err != nil {
+			return event, Error.Wrap(err)
+		}
+
+		if checkoutSession.Mode == stripe.CheckoutSessionModeSubscription && event.Kind == payments.EventCompleted {
+			event.Kind = payments.EventSubscriptionStarted
+		}
+		if checkoutSession.Subscription != nil {
+			event.SubscriptionID = checkoutSession.Subscription.ID
+		}
+
+		event.Reference = checkoutSession.ClientReferenceID
+		event.TransactionID = checkoutSession.ID
+		if checkoutSession.PaymentIntent != nil {
+			event.PaymentReference = checkoutSession.PaymentIntent.ID
+		}
+		event.Paid = checkoutSession.PaymentStatus == stripe.CheckoutSessionPaymentStatusPaid
+		event.Amount = currencies.NewMoney(currencies.MinorUnits(checkoutSession.AmountTotal), toCurrencyCode(checkoutSession.Currency))
+	case EventPaymentFailed:
+		event.Kind = payments.EventFailed
+
+		var paymentIntent stripe.PaymentIntent
+		if err = json.Unmarshal(stripeEvent.Data.Raw, &paymentIntent); err != nil {
+			return event, Error.Wrap(err)
+		}
+
+		event.Reference = paymentIntent.Metadata[referenceMetadataKey]
+		event.PaymentReference = paymentIntent.ID
+		event.Amount = currencies.NewMoney(currencies.MinorUnits(paymentIntent.Amount), toCurrencyCode(paymentIntent.Currency))
+		if paymentIntent.LastPaymentError != nil {
+			event.FailureMessage = paymentIntent.LastPaymentError.Msg
+		}
+	case EventChargeRefunded:
+		event.Kind = payments.EventRefunded
+
+		var charge stripe.Charge
+		if err = json.Unmarshal(stripeEvent.Data.Raw, &charge); err != nil {
+			return event, Error.Wrap(err)
+		}
+
+		event.Reference = charge.Metadata[referenceMetadataKey]
+		if charge.PaymentIntent != nil {
+			event.PaymentReference = charge.PaymentIntent.ID
+		}
+		event.Paid = charge.Paid
+		event.Amount = currencies.NewMoney(currencies.MinorUnits(charge.Amount), toCurrencyCode(charge.Currency))
+		event.Refunded = currencies.MinorUnits(charge.AmountRefunded)
+	case EventInvoicePaid, EventInvoicePaymentFailed:
+		event.Kind = payments.EventSubscriptionCharged
+		if event.Type == EventInvoicePaymentFailed {
+			event.Kind = payments.EventSubscriptionFailed
+		}
+
+		var invoice stripe.Invoice
+		if err = json.Unmarshal(stripeEvent.Data.Raw, &invoice); err != nil {
+			return event, Error.Wrap(err)
+		}
+
+		if invoice.Parent == nil || invoice.Parent.SubscriptionDetails == nil || invoice.Parent.SubscriptionDetails.Subscription == nil {
+			event.Kind = payments.EventIgnored
+			break
+		}
+
+		details := invoice.Parent.SubscriptionDetails
+		event.Reference = details.Metadata[referenceMetadataKey]
+		event.SubscriptionID = details.Subscription.ID
+		event.TransactionID = invoice.ID
+		event.Paid = event.Kind == payments.EventSubscriptionCharged
+		event.Amount = currencies.NewMoney(currencies.MinorUnits(invoice.AmountDue), toCurrencyCode(invoice.Currency))
+		if event.Paid {
+			event.Amount.Amount = currencies.MinorUnits(invoice.AmountPaid)
+		}
+		if invoice.Payments != nil && len(invoice.Payments.Data) > 0 {
+			payment := invoice.Payments.Data[0].Payment
+			if payment != nil && payment.PaymentIntent != nil {
+				event.PaymentReference = payment.PaymentIntent.ID
+			}
+		}
+	case EventSubscriptionDeleted:
+		event.Kind = payments.EventSubscriptionCanceled
+
+		var subscription stripe.Subscription
+		if err = json.Unmarshal(stripeEvent.Data.Raw, &subscription); err != nil {
+			return event, Error.Wrap(err)
+		}
+
+		event.Reference = subscription.Metadata[referenceMetadataKey]
+		event.SubscriptionID = subscription.ID
+	}
+
+	return event, nil
+}
@@ -0,0 +1,104 @@
+package stripe_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/stripe-go/v82/webhook"
+
+	"one-help/app/currencies"
+	"one-help/app/payments"
+	"one-help/app/stripe"
+	"one-help/internal/logger/zaplog"
+)
+
+func TestParseWebhookEvent(t *testing.T) {
+	const secret = "whsec_test"
+
+	charger := stripe.NewCharger(zaplog.NewLog(), stripe.Config{WebhookSecret: secret})
+
+	payload := []byte(`{
+		"id": "evt_test",
+		"object": "event",
+		"type": "checkout.session.completed",
+		"data": {"object": {
+			"id": "cs_test",
+			"object": "checkout.session",
+			"client_reference_id": "donation",
+			"payment_intent": "pi_test",
+			"payment_status": "paid",
+			"amount_total": 15050,
+			"currency": "uah"
+		}}
+	}`)
+
+	t.Run("valid signature", func(t *testing.T) {
+		signed := webhook.GenerateTestSignedPayload(&webhook.UnsignedPayload{Payload: payload, Secret: secret})
+
+		event, err := charger.ParseWebhook(signed.Payload, http.Header{stripe.SignatureHeader: {signed.Header}})
+		require.NoError(t, err)
+		assert.Equal(t, "evt_test", event.ID)
+		assert.Equal(t, stripe.EventCheckoutCompleted, event.Type)
+		assert.Equal(t, payments.EventCompleted, event.Kind)
+		assert.Equal(t, "donation", event.Reference)
+		assert.Equal(t, "cs_test", event.TransactionID)
+		assert.Equal(t, "pi_test", event.PaymentReference)
+		assert.True(t, event.Paid)
+		assert.Equal(t, currencies.NewMoney(currencies.MinorUnits(15050), currencies.UAH), event.Amount)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		signed := webhook.GenerateTestSignedPayload(&webhook.UnsignedPayload{Payload: payload, Secret: "whsec_other"})
+
+		_, err := charger.ParseWebhook(signed.Payload, http.Header{stripe.SignatureHeader: {signed.Header}})
+		require.Error(t, err)
+		require.ErrorIs(t, err, payments.ErrInvalidSignature)
+	})
+
+	t.Run("no webhook secret", func(t *testing.T) {
+		unconfigured := stripe.NewCharger(zaplog.NewLog(), stripe.Config{})
+
+		// INFO: payload signed with empty secret is refused as well.
+		signed := webhook.GenerateTestSignedPayload(&webhook.UnsignedPayload{Payload: payload, Secret: ""})
+
+		_, err := unconfigured.ParseWebhook(signed.Payload, http.Header{stripe.SignatureHeader: {signed.Header}})
+		require.ErrorIs(t, err, payments.ErrInvalidSignature)
+		require.ErrorIs(t, err, stripe.ErrNoWebhookSecret)
+	})
+}
+
+func TestParseSubscriptionWebhookEvent(t *testing.T) {
+	const secret = "whsec_test"
+
+	charger := stripe.NewCharger(zaplog.NewLog(), stripe.Config{WebhookSecret: secret})
+
+	payload := []byte(`{
+		"id": "evt_invoice",
+		"object": "event",
+		"type": "invoice.paid",
+		"data": {"object": {
+			"id": "in_test",
+			"object": "invoice",
+			"amount_due": 20000,
+			"amount_paid": 20000,
+			"currency": "uah",
+			"parent": {"subscription_details": {
+				"metadata": {"reference": "plan"},
+				"subscription": "sub_test"
+			}}
+		}}
+	}`)
+
+	signed := webhook.GenerateTestSignedPayload(&webhook.UnsignedPayload{Payload: payload, Secret: secret})
+
+	event, err := charger.ParseWebhook(signed.Payload, http.Header{stripe.SignatureHeader: {signed.Header}})
+	require.NoError(t, err)
+	assert.Equal(t, payments.EventSubscriptionCharged, event.Kind)
+	assert.Equal(t, "plan", event.Reference)
+	assert.Equal(t, "sub_test", event.SubscriptionID)
+	assert.Equal(t, "in_test", event.TransactionID)
+	assert.True(t, event.Paid)
+	assert.Equal(t, currencies.NewMoney(currencies.Major(200), currencies.UAH), event.Amount)
+}
@@ -24,6 +24,8 @@ type DB interface {
 	Get(ctx context.Context, id uuid.UUID) (User, error)
 	// Update updates user in database by id.
 	Update(ctx context.Context, user User) error
+	// SetTrusted updates user's trusted organizer flag.
+	SetTrusted(ctx context.Context, id uuid.UUID, trusted bool) error
 	// Delete user from the database.
 	Delete(ctx context.Context, id uuid.UUID) error
 	// ListParticipants returns all raffle participants.
@@ -0,0 +1,10 @@
+package roles
+
+const (
+	// UserRole defines default role of the registered user.
+	UserRole string = "USER"
+	// ModeratorRole defines role of the user that moderates community content.
+	ModeratorRole string = "MODERATOR"
+	// AdminRole defines role of the platform administrator.
+	AdminRole string = "ADMIN"
+)
@@ -11,6 +11,7 @@ import (
 	"github.com/zeebo/errs"
 
 	"one-help/app/users/credentials"
+	"one-help/app/users/roles"
 	"one-help/internal/jwt"
 	"one-help/internal/logger"
 )
@@ -20,6 +21,8 @@ var (
 	Error = errs.Class("users service")
 	// ParamsError wraps errors from users service that indicates about invalid or malformed parameters' data.
 	ParamsError = errs.Class("users service: params")
+	// ErrNotModerator indicates that caller is not allowed to moderate users.
+	ErrNotModerator = errs.New("caller is not a moderator")
 )
 
 // Service handles users related logic.
@@ -59,6 +62,8 @@ func (service *Service) Register(ctx context.Context, params RegisterParams) (*U
 		LastName:  params.LastName,
 		Website:   params.Website,
 		ImageUrl:  params.ImageUrl,
+		Role:      roles.UserRole,
+		Private:   params.Private,
 		DeliveryAddress: DeliveryAddress{
 			City:           params.City,
 			Post:           params.Post,
@@ -130,13 +135,18 @@ func (service *Service) Authorize(ctx context.Context, params AuthorizeParams) (
 	return &user, nil
 }
 
-// Update updates all User data.
-func (service *Service) Update(ctx context.Context, user User) error {
-	_, err := service.users.Get(ctx, user.ID)
+// Update updates all User data, stored private flag is kept when provided one is nil.
+func (service *Service) Update(ctx context.Context, user User, private *bool) error {
+	stored, err := service.users.Get(ctx, user.ID)
 	if err != nil {
 		return Error.Wrap(err)
 	}
 
+	user.Private = stored.Private
+	if private != nil {
+		user.Private = *private
+	}
+
 	if err = service.verifyUserData(&user); err != nil {
 		return err
 	}
@@ -162,6 +172,29 @@ func (service *Service) Get(ctx context.Context, id uuid.UUID) (*User, error) {
 	return &user, nil
 }
 
+// SetTrusted marks user as trusted organizer, whose fundraises skip moderation review, or removes the mark.
+// Allowed only to moderators.
+func (service *Service) SetTrusted(ctx context.Context, callerID, userID uuid.UUID, trusted bool) error {
+	caller, err := service.users.Get(ctx, callerID)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if !caller.IsModerator() {
+		return ParamsError.Wrap(ErrNotModerator)
+	}
+
+	if err = service.users.SetTrusted(ctx, userID, trusted); err != nil {
+		if errors.Is(err, ErrNoUser) {
+			return ParamsError.Wrap(ErrNoUser)
+		}
+
+		return Error.Wrap(err)
+	}
+
+	return nil
+}
+
 // GetCreds returns User creds by ID.
 func (service *Service) GetCreds(ctx context.Context, id uuid.UUID) (*credentials.Credentials, error) {
 	creds, err := service.credentials.Get(ctx, credentials.NewGetByID(id))
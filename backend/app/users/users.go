@@ -2,6 +2,8 @@ package users
 
 import (
 	"github.com/google/uuid"
+
+	"one-help/app/users/roles"
 )
 
 // Config defines configuration for users.
@@ -18,6 +20,11 @@ type User struct {
 	LastName  string
 	Website   string
 	ImageUrl  string
+	Role      string
+	// Trusted organizers' fundraises skip moderation review.
+	Trusted bool
+	// Private users are not shown by name on public leaderboards.
+	Private bool
 
 	DeliveryAddress
 }
@@ -32,6 +39,21 @@ func (u *User) FullName() string {
 	return u.FirstName + " " + u.LastName
 }
 
+// IsModerator returns true if user is allowed to moderate community content.
+func (u *User) IsModerator() bool {
+	return u.Role == roles.ModeratorRole || u.Role == roles.AdminRole
+}
+
+// IsAdmin returns true if user is the platform administrator.
+func (u *User) IsAdmin() bool {
+	return u.Role == roles.AdminRole
+}
+
+// SkipsReview returns true if user's fundraises are activated without moderation review.
+func (u *User) SkipsReview() bool {
+	return u.Trusted || u.IsModerator()
+}
+
 // IsDeliveryAddressFull returns true if delivery address is full-filled.
 func (u *User) IsDeliveryAddressFull() bool {
 	return u.City != "" && u.Post != "" && u.PostDepartment != ""
@@ -53,6 +75,7 @@ type RegisterParams struct {
 	LastName  string
 	Website   string
 	ImageUrl  string
+	Private   bool
 
 	City           string
 	Post           string
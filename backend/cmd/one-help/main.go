@@ -38,10 +38,16 @@ var (
 		RunE:        cmdRun,
 		Annotations: map[string]string{"type": "run"},
 	}
+	checkLedgerCmd = &cobra.Command{
+		Use:   "check-ledger",
+		Short: "verifies that every ledger transaction and account balances",
+		RunE:  cmdCheckLedger,
+	}
 )
 
 func init() {
 	rootCmd.AddCommand(runCmd)
+	rootCmd.AddCommand(checkLedgerCmd)
 }
 
 func main() {
@@ -95,3 +101,46 @@ func cmdRun(cmd *cobra.Command, args []string) (err error) {
 
 	return errs.Combine(peer.Run(ctx), peer.Close())
 }
+
+func cmdCheckLedger(cmd *cobra.Command, args []string) (err error) {
+	ctx := context.Background()
+	log := zaplog.NewLog()
+
+	if err = godotenv.Overload("./configs/.one-help.env"); err != nil {
+		log.Error("could not load launchpad config: %v", Error.Wrap(err))
+		return Error.Wrap(err)
+	}
+
+	// INFO: only database config is required to check the ledger.
+	config := new(struct{ Database database.Config })
+	envOpt := env.Options{RequiredIfNoDef: true}
+	if err = env.Parse(config, envOpt); err != nil {
+		log.Error("could not parse config: %v", Error.Wrap(err))
+		return Error.Wrap(err)
+	}
+
+	db, err := database.New(config.Database.Database)
+	if err != nil {
+		log.Error("error connecting to launchpad database", Error.Wrap(err))
+		return Error.Wrap(err)
+	}
+	defer func() {
+		err = errs.Combine(err, db.Close())
+	}()
+
+	violations, err := db.Ledger().Check(ctx)
+	if err != nil {
+		log.Error("could not check ledger", Error.Wrap(err))
+		return Error.Wrap(err)
+	}
+
+	for _, violation := range violations {
+		log.WarnF("%s %s: %s", violation.Subject, violation.Currency, violation.Message)
+	}
+	if len(violations) > 0 {
+		return Error.New("ledger has %d violations", len(violations))
+	}
+
+	log.Info("ledger is balanced")
+	return nil
+}
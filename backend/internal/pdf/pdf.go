@@ -0,0 +1,301 @@
+// Package pdf provides minimal writer of single-page PDF documents with text and lines in embedded TrueType fonts.
+// Output depends only on the drawn content, so the same document is always rendered to the same bytes.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/zeebo/errs"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// Error is an error wrapper that notifies that error was produced by pdf writer.
+var Error = errs.Class("pdf")
+
+const (
+	// PageWidth is the width of A4 page in points.
+	PageWidth = 595.0
+	// PageHeight is the height of A4 page in points.
+	PageHeight = 842.0
+)
+
+// glyphSpace is the number of glyph space units per text space unit, as defined by PDF.
+const glyphSpace = 1000
+
+// Font is a TrueType font embedded into the document.
+type Font struct {
+	name string
+	data []byte
+	font *sfnt.Font
+	buf  sfnt.Buffer
+
+	// used maps glyphs drawn in the document to their runes and widths in glyph space.
+	used map[sfnt.GlyphIndex]glyph
+}
+
+// glyph describes glyph drawn in the document.
+type glyph struct {
+	r     rune
+	width int
+}
+
+// Document is a single A4 page document.
+type Document struct {
+	fonts   []*Font
+	content bytes.Buffer
+}
+
+// New is a constructor for empty document.
+func New() *Document {
+	return &Document{}
+}
+
+// AddFont parses TrueType font data and registers it in the document.
+func (d *Document) AddFont(name string, data []byte) (*Font, error) {
+	parsed, err := sfnt.Parse(data)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	f := &Font{
+		name: name,
+		data: data,
+		font: parsed,
+		used: make(map[sfnt.GlyphIndex]glyph),
+	}
+	d.fonts = append(d.fonts, f)
+
+	return f, nil
+}
+
+// Width returns width of the text drawn with the font of provided size in points.
+func (f *Font) Width(size float64, text string) (float64, error) {
+	var width int
+	for _, r := range text {
+		index, err := f.glyph(r)
+		if err != nil {
+			return 0, err
+		}
+
+		width += f.used[index].width
+	}
+
+	return float64(width) * size / glyphSpace, nil
+}
+
+// Wrap splits text by words into lines that fit into the width when drawn with the font of provided size.
+// Words longer than the width are kept on their own lines.
+func (f *Font) Wrap(size, width float64, text string) ([]string, error) {
+	var (
+		lines []string
+		line  string
+	)
+	for _, word := range strings.Fields(text) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+
+		candidateWidth, err := f.Width(size, candidate)
+		if err != nil {
+			return nil, err
+		}
+		if candidateWidth > width && line != "" {
+			lines = append(lines, line)
+			candidate = word
+		}
+
+		line = candidate
+	}
+
+	return append(lines, line), nil
+}
+
+// glyph returns index of the rune glyph, registering it as used. Missing glyphs are replaced with '?'.
+func (f *Font) glyph(r rune) (sfnt.GlyphIndex, error) {
+	index, err := f.font.GlyphIndex(&f.buf, r)
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+	if index == 0 && r != '?' {
+		return f.glyph('?')
+	}
+
+	if _, ok := f.used[index]; ok {
+		return index, nil
+	}
+
+	advance, err := f.font.GlyphAdvance(&f.buf, index, fixed.I(glyphSpace), font.HintingNone)
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+
+	f.used[index] = glyph{r: r, width: f.scale(advance)}
+
+	return index, nil
+}
+
+// scale converts value measured with ppem of glyph space into glyph space units.
+func (f *Font) scale(value fixed.Int26_6) int {
+	return value.Round()
+}
+
+// Text draws text with its baseline starting at x, y. Coordinates start at the bottom-left corner of the page.
+func (d *Document) Text(f *Font, size, x, y float64, text string) error {
+	var hex strings.Builder
+	for _, r := range text {
+		index, err := f.glyph(r)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(&hex, "%04X", uint16(index))
+	}
+
+	fmt.Fprintf(&d.content, "BT /%s %s Tf %s %s Td <%s> Tj ET\n", f.name, number(size), number(x), number(y), hex.String())
+
+	return nil
+}
+
+// Line draws straight line of provided width between two points.
+func (d *Document) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&d.content, "%s w %s %s m %s %s l S\n", number(width), number(x1), number(y1), number(x2), number(y2))
+}
+
+// Bytes renders the document.
+func (d *Document) Bytes() ([]byte, error) {
+	w := &writer{}
+	w.buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// INFO: objects 1-4 are catalog, pages, page and its content, fonts take 5 objects each after them.
+	fontRefs := make([]string, len(d.fonts))
+	for i, f := range d.fonts {
+		fontRefs[i] = fmt.Sprintf("/%s %d 0 R", f.name, 5+i*5)
+	}
+
+	w.object("<< /Type /Catalog /Pages 2 0 R >>")
+	w.object("<< /Type /Pages /Kids [3 0 R] /Count 1 >>")
+	w.object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << %s >> >> /Contents 4 0 R >>",
+		number(PageWidth), number(PageHeight), strings.Join(fontRefs, " ")))
+	if err := w.stream("", d.content.Bytes()); err != nil {
+		return nil, err
+	}
+
+	for i, f := range d.fonts {
+		if err := w.font(f, 5+i*5); err != nil {
+			return nil, err
+		}
+	}
+
+	return w.finish(), nil
+}
+
+// writer writes numbered objects and tracks their offsets for the cross-reference table.
+type writer struct {
+	buf     bytes.Buffer
+	offsets []int
+}
+
+// object writes next object.
+func (w *writer) object(body string) {
+	w.offsets = append(w.offsets, w.buf.Len())
+	fmt.Fprintf(&w.buf, "%d 0 obj\n%s\nendobj\n", len(w.offsets), body)
+}
+
+// stream writes next object as compressed stream with extra dictionary entries.
+func (w *writer) stream(entries string, data []byte) error {
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	if _, err := zw.Write(data); err != nil {
+		return Error.Wrap(err)
+	}
+	if err := zw.Close(); err != nil {
+		return Error.Wrap(err)
+	}
+
+	w.offsets = append(w.offsets, w.buf.Len())
+	fmt.Fprintf(&w.buf, "%d 0 obj\n<< /Length %d /Filter /FlateDecode%s >>\nstream\n", len(w.offsets), compressed.Len(), entries)
+	w.buf.Write(compressed.Bytes())
+	w.buf.WriteString("\nendstream\nendobj\n")
+
+	return nil
+}
+
+// font writes Type0 font with Identity-H encoding, its descendant font, descriptor, unicode map and font file.
+func (w *writer) font(f *Font, ref int) error {
+	indexes := make([]sfnt.GlyphIndex, 0, len(f.used))
+	for index := range f.used {
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+
+	var widths, unicodes strings.Builder
+	for _, index := range indexes {
+		fmt.Fprintf(&widths, "%d [%d] ", index, f.used[index].width)
+		fmt.Fprintf(&unicodes, "<%04X> <%s>\n", uint16(index), utf16Hex(f.used[index].r))
+	}
+
+	metrics, err := f.font.Metrics(&f.buf, fixed.I(glyphSpace), font.HintingNone)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	bounds, err := f.font.Bounds(&f.buf, fixed.I(glyphSpace), font.HintingNone)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	w.object(fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H /DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>",
+		f.name, ref+1, ref+3))
+	w.object(fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s "+
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> /FontDescriptor %d 0 R /W [%s] /CIDToGIDMap /Identity >>",
+		f.name, ref+2, strings.TrimSpace(widths.String())))
+	w.object(fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags 32 /FontBBox [%d %d %d %d] /ItalicAngle 0 "+
+		"/Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 %d 0 R >>",
+		f.name, f.scale(bounds.Min.X), -f.scale(bounds.Max.Y), f.scale(bounds.Max.X), -f.scale(bounds.Min.Y),
+		f.scale(metrics.Ascent), -f.scale(metrics.Descent), f.scale(metrics.CapHeight), ref+4))
+
+	cmap := "/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n" +
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n" +
+		"/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n" +
+		"1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n" +
+		fmt.Sprintf("%d beginbfchar\n%sendbfchar\n", len(indexes), unicodes.String()) +
+		"endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n"
+	if err = w.stream("", []byte(cmap)); err != nil {
+		return err
+	}
+
+	return w.stream(fmt.Sprintf(" /Length1 %d", len(f.data)), f.data)
+}
+
+// finish writes cross-reference table and trailer.
+func (w *writer) finish() []byte {
+	xref := w.buf.Len()
+	fmt.Fprintf(&w.buf, "xref\n0 %d\n0000000000 65535 f \n", len(w.offsets)+1)
+	for _, offset := range w.offsets {
+		fmt.Fprintf(&w.buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&w.buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(w.offsets)+1, xref)
+
+	return w.buf.Bytes()
+}
+
+// number formats number with at most two decimals.
+func number(value float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", value), "0"), ".")
+}
+
+// utf16Hex returns hex encoded UTF-16BE representation of the rune.
+func utf16Hex(r rune) string {
+	if r < 0x10000 {
+		return fmt.Sprintf("%04X", r)
+	}
+
+	r -= 0x10000
+	return fmt.Sprintf("%04X%04X", 0xD800+(r>>10), 0xDC00+(r&0x3FF))
+}
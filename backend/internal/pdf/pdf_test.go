@@ -0,0 +1,44 @@
+package pdf_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/font/gofont/goregular"
+
+	"one-help/internal/pdf"
+)
+
+func TestDocument(t *testing.T) {
+	render := func() []byte {
+		doc := pdf.New()
+		regular, err := doc.AddFont("F1", goregular.TTF)
+		require.NoError(t, err)
+
+		require.NoError(t, doc.Text(regular, 12, 50, 800, "Receipt Квитанція №1"))
+		doc.Line(50, 790, 545, 790, 0.5)
+
+		width, err := regular.Width(12, "Receipt")
+		require.NoError(t, err)
+		assert.Greater(t, width, 0.0)
+
+		lines, err := regular.Wrap(12, width*1.5, "Receipt Receipt  Receipt")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Receipt", "Receipt", "Receipt"}, lines)
+
+		data, err := doc.Bytes()
+		require.NoError(t, err)
+
+		return data
+	}
+
+	data := render()
+	assert.True(t, bytes.HasPrefix(data, []byte("%PDF-1.4")))
+	assert.True(t, bytes.HasSuffix(data, []byte("%%EOF\n")))
+	assert.Contains(t, string(data), "/Encoding /Identity-H")
+
+	// INFO: same content is rendered to the same bytes.
+	assert.Equal(t, data, render())
+}
@@ -0,0 +1,54 @@
+// Package profanity provides basic check of user-provided texts for obscene words.
+package profanity
+
+import (
+	"strings"
+	"unicode"
+)
+
+// roots are lower-case stems of obscene words, matched at the start of the word.
+var roots = []string{
+	// INFO: english.
+	"fuck", "shit", "bitch", "cunt", "asshole", "bastard", "dick", "motherfuck", "whore", "slut",
+	// INFO: ukrainian and russian.
+	"хуй", "хуя", "хує", "хуе", "пизд", "їбан", "йоб", "ебан", "ебат", "еба", "ёб", "бля", "сука", "суки", "мудак", "мудил",
+	"підар", "пидор", "пидар", "гандон", "курв", "шлюх", "залуп",
+}
+
+// exceptions are common words starting with one of the roots.
+var exceptions = []string{"dickens", "бляха", "бляшан"}
+
+// leet maps commonly substituted symbols to letters.
+var leet = strings.NewReplacer("0", "o", "1", "i", "3", "e", "4", "a", "5", "s", "7", "t", "@", "a", "$", "s")
+
+// Contains returns true if text contains obscene word.
+func Contains(text string) bool {
+	words := strings.FieldsFunc(strings.ToLower(leet.Replace(text)), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '*'
+	})
+
+	for _, word := range words {
+		if isObscene(strings.ReplaceAll(word, "*", "")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isObscene returns true if word starts with obscene root and is not an exception.
+func isObscene(word string) bool {
+	for _, exception := range exceptions {
+		if strings.HasPrefix(word, exception) {
+			return false
+		}
+	}
+
+	for _, root := range roots {
+		if strings.HasPrefix(word, root) {
+			return true
+		}
+	}
+
+	return false
+}
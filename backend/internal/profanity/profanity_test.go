@@ -0,0 +1,29 @@
+package profanity_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"one-help/internal/profanity"
+)
+
+func TestContains(t *testing.T) {
+	tests := []struct {
+		text    string
+		obscene bool
+	}{
+		{text: "Stay strong, we believe in you!", obscene: false},
+		{text: "Тримайтесь! Слава Україні", obscene: false},
+		{text: "Charles Dickens would be proud", obscene: false},
+		{text: "Бляшанка для збору", obscene: false},
+		{text: "What the FUCK", obscene: true},
+		{text: "sh1t happens", obscene: true},
+		{text: "fu*ck this", obscene: true},
+		{text: "ну ти й мудак", obscene: true},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.obscene, profanity.Contains(test.text), test.text)
+	}
+}
@@ -8,11 +8,30 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"one-help/app"
+	"one-help/app/comments"
 	"one-help/app/console"
+	"one-help/app/currencies"
 	"one-help/app/donations"
+	"one-help/app/donations/offline"
+	"one-help/app/donations/plans"
+	"one-help/app/donations/receipts"
 	"one-help/app/events"
 	"one-help/app/fundraises"
+	"one-help/app/fundraises/analytics"
+	"one-help/app/fundraises/leaderboards"
+	"one-help/app/fundraises/matching"
+	"one-help/app/fundraises/payouts"
+	"one-help/app/fundraises/reviews"
+	"one-help/app/fundraises/transfers"
+	"one-help/app/idempotency"
+	"one-help/app/ledger"
+	"one-help/app/liqpay"
+	"one-help/app/notifications"
 	"one-help/app/payments"
+	"one-help/app/payments/reconciliation"
+	"one-help/app/payments/refunds"
+	"one-help/app/payments/statements"
+	"one-help/app/payments/webhooks"
 	"one-help/app/raffles"
 	"one-help/app/stripe"
 	"one-help/app/users"
@@ -20,6 +39,8 @@ import (
 	"one-help/internal/logger"
 
 	eventparticipants "one-help/app/events/participants"
+	payoutfake "one-help/app/fundraises/payouts/fake"
+	notificationfake "one-help/app/notifications/fake"
 )
 
 // Config is the global configuration for one-help app.
@@ -30,7 +51,10 @@ type Config struct {
 	Users struct {
 		Config users.Config `envPrefix:"USERS_"`
 	}
-	Stripe stripe.Config `envPrefix:"STRIPE_"`
+	Stripe      stripe.Config      `envPrefix:"STRIPE_"`
+	LiqPay      liqpay.Config      `envPrefix:"LIQPAY_"`
+	Currencies  currencies.Config  `envPrefix:"CURRENCIES_"`
+	Idempotency idempotency.Config `envPrefix:"IDEMPOTENCY_"`
 }
 
 // Peer is the representation of a server.
@@ -52,10 +76,44 @@ type Peer struct {
 	}
 
 	Fundraises struct {
-		DB          fundraises.DB
-		DonationsDB donations.DB
-		PaymentDB   payments.DB
-		Service     *fundraises.Service
+		DB               fundraises.DB
+		DonationsDB      donations.DB
+		ReceiptsDB       receipts.DB
+		PaymentDB        payments.DB
+		ReconciliationDB reconciliation.DB
+		TransfersDB      transfers.DB
+		AnalyticsDB      analytics.DB
+		ReviewsDB        reviews.DB
+		MatchingDB       matching.DB
+		OfflineDB        offline.DB
+		LeaderboardsDB   leaderboards.DB
+		NotificationsDB  notifications.DB
+		Service          *fundraises.Service
+	}
+
+	Plans struct {
+		DB      plans.DB
+		Service *plans.Service
+	}
+
+	Refunds struct {
+		DB      refunds.DB
+		Service *refunds.Service
+	}
+
+	Payouts struct {
+		DB      payouts.DB
+		Service *payouts.Service
+	}
+
+	Statements struct {
+		DB      statements.DB
+		Service *statements.Service
+	}
+
+	Webhooks struct {
+		DB      webhooks.DB
+		Service *webhooks.Service
 	}
 
 	Events struct {
@@ -77,8 +135,36 @@ type Peer struct {
 		Service *raffles.Service
 	}
 
-	Stripe struct {
-		Charger *stripe.Charger
+	Payments struct {
+		Stripe *stripe.Charger
+		// LiqPay is nil if liqpay keys are not configured.
+		LiqPay    *liqpay.Client
+		Providers *payments.Providers
+		Payouts   payouts.Provider
+		// Statements parses bank statements imported by organizers.
+		Statements *statements.Parsers
+	}
+
+	Currencies struct {
+		Rates currencies.RateProvider
+	}
+
+	Ledger struct {
+		DB ledger.DB
+	}
+
+	Comments struct {
+		DB      comments.DB
+		Service *comments.Service
+	}
+
+	Idempotency struct {
+		DB      idempotency.DB
+		Service *idempotency.Service
+	}
+
+	Notifications struct {
+		Sender notifications.Sender
 	}
 }
 
@@ -97,21 +183,139 @@ func New(ctx context.Context, logger logger.Logger, config Config, db app.DB) (p
 		peer.Users.Service = users.NewService(peer.Log, peer.Config.Users.Config, peer.Users.DB, peer.Users.CredsDB)
 	}
 
-	{ // stripe setup
-		peer.Stripe.Charger = stripe.NewCharger(peer.Log, peer.Config.Stripe)
+	{ // payment providers setup
+		peer.Payments.Stripe = stripe.NewCharger(peer.Log, peer.Config.Stripe)
+		peer.Payments.Providers = payments.NewProviders(peer.Payments.Stripe)
+		if peer.Config.LiqPay.IsConfigured() {
+			peer.Payments.LiqPay = liqpay.NewClient(peer.Log, peer.Config.LiqPay)
+			peer.Payments.Providers.Register(peer.Payments.LiqPay)
+		} else {
+			peer.Log.Info("liqpay keys are not configured, liqpay payments are disabled")
+		}
+		// NOTE: payouts are recorded by local provider until bank integration is available.
+		peer.Payments.Payouts = payoutfake.NewProvider()
+		peer.Payments.Statements = statements.NewParsers(
+			statements.NewCSVParser(statements.GenericCSV),
+			statements.NewCAMT053Parser(),
+		)
+	}
+
+	{ // notifications setup
+		// NOTE: notifications are recorded by local sender until email provider is available.
+		peer.Notifications.Sender = notificationfake.NewSender()
+	}
+
+	{ // currencies setup
+		peer.Currencies.Rates = currencies.IdentityProvider{}
+		if peer.Config.Currencies.RatesFile != "" {
+			peer.Currencies.Rates, err = currencies.NewFileProvider(peer.Config.Currencies.RatesFile)
+			if err != nil {
+				return &Peer{}, err
+			}
+		} else {
+			peer.Log.Warn("rates file is not configured, donations are accepted only in fundraise currency")
+		}
+	}
+
+	{ // ledger setup
+		peer.Ledger.DB = db.Ledger()
 	}
 
 	// users setup
 	{
 		peer.Fundraises.DB = db.Fundraises()
 		peer.Fundraises.DonationsDB = db.Donations()
+		peer.Fundraises.ReceiptsDB = db.Receipts()
 		peer.Fundraises.PaymentDB = db.Payments()
+		peer.Fundraises.ReconciliationDB = db.ReconciliationReports()
+		peer.Fundraises.TransfersDB = db.FundraiseTransfers()
+		peer.Fundraises.AnalyticsDB = db.FundraiseAnalytics()
+		peer.Fundraises.ReviewsDB = db.FundraiseReviews()
+		peer.Fundraises.MatchingDB = db.Matching()
+		peer.Fundraises.OfflineDB = db.OfflineDonations()
+		peer.Fundraises.LeaderboardsDB = db.Leaderboards()
+		peer.Fundraises.NotificationsDB = db.Notifications()
 		peer.Fundraises.Service = fundraises.NewService(
 			peer.Log,
 			peer.Fundraises.DB,
 			peer.Fundraises.DonationsDB,
+			peer.Fundraises.ReceiptsDB,
 			peer.Fundraises.PaymentDB,
-			peer.Stripe.Charger,
+			peer.Fundraises.ReconciliationDB,
+			peer.Fundraises.TransfersDB,
+			peer.Fundraises.AnalyticsDB,
+			peer.Fundraises.ReviewsDB,
+			peer.Fundraises.MatchingDB,
+			peer.Ledger.DB,
+			peer.Fundraises.OfflineDB,
+			peer.Fundraises.LeaderboardsDB,
+			peer.Fundraises.NotificationsDB,
+			peer.Users.DB,
+			peer.Payments.Providers,
+			peer.Notifications.Sender,
+			peer.Currencies.Rates,
+		)
+	}
+
+	// plans setup
+	{
+		peer.Plans.DB = db.DonationPlans()
+		peer.Plans.Service = plans.NewService(
+			peer.Log,
+			peer.Plans.DB,
+			peer.Fundraises.DB,
+			peer.Fundraises.PaymentDB,
+			peer.Users.DB,
+			peer.Payments.Providers,
+			peer.Fundraises.Service,
+		)
+	}
+
+	// payouts setup
+	{
+		peer.Payouts.DB = db.Payouts()
+		peer.Payouts.Service = payouts.NewService(peer.Log, peer.Payouts.DB, peer.Fundraises.DB, peer.Users.DB, peer.Payments.Payouts)
+	}
+
+	// refunds setup
+	{
+		peer.Refunds.DB = db.Refunds()
+		peer.Refunds.Service = refunds.NewService(
+			peer.Log,
+			peer.Refunds.DB,
+			peer.Fundraises.DonationsDB,
+			peer.Fundraises.DB,
+			peer.Fundraises.PaymentDB,
+			peer.Payouts.DB,
+			peer.Fundraises.TransfersDB,
+			peer.Users.DB,
+			peer.Payments.Providers,
+			peer.Fundraises.Service,
+		)
+	}
+
+	// statements setup
+	{
+		peer.Statements.DB = db.BankStatements()
+		peer.Statements.Service = statements.NewService(
+			peer.Log,
+			peer.Statements.DB,
+			peer.Fundraises.DB,
+			peer.Fundraises.OfflineDB,
+			peer.Payments.Statements,
+			peer.Fundraises.Service,
+		)
+	}
+
+	// webhooks setup
+	{
+		peer.Webhooks.DB = db.WebhookEvents()
+		peer.Webhooks.Service = webhooks.NewService(
+			peer.Log,
+			peer.Webhooks.DB,
+			peer.Payments.Providers,
+			peer.Fundraises.Service,
+			peer.Plans.Service,
 		)
 	}
 
@@ -130,6 +334,24 @@ func New(ctx context.Context, logger logger.Logger, config Config, db app.DB) (p
 		peer.Raffles.Service = raffles.NewService(peer.Log, peer.Raffles.DB)
 	}
 
+	// comments setup
+	{
+		peer.Comments.DB = db.Comments()
+		peer.Comments.Service = comments.NewService(
+			peer.Log,
+			peer.Comments.DB,
+			peer.Fundraises.DB,
+			peer.Events.DB,
+			peer.Users.DB,
+		)
+	}
+
+	// idempotency setup
+	{
+		peer.Idempotency.DB = db.IdempotencyKeys()
+		peer.Idempotency.Service = idempotency.NewService(peer.Log, peer.Config.Idempotency, peer.Idempotency.DB)
+	}
+
 	// console setup
 	{
 		peer.Console.Listener, err = net.Listen("tcp", config.Console.Config.Address)
@@ -143,8 +365,15 @@ func New(ctx context.Context, logger logger.Logger, config Config, db app.DB) (p
 			peer.Console.Listener,
 			peer.Users.Service,
 			peer.Fundraises.Service,
+			peer.Plans.Service,
+			peer.Refunds.Service,
+			peer.Payouts.Service,
+			peer.Statements.Service,
+			peer.Webhooks.Service,
 			peer.Events.Service,
 			peer.Raffles.Service,
+			peer.Comments.Service,
+			peer.Idempotency.Service,
 		)
 	}
 
@@ -161,6 +390,22 @@ func (peer *Peer) Run(ctx context.Context) error {
 		return peer.Console.Endpoint.Run(ctx)
 	})
 
+	group.Go(func() error {
+		return peer.Plans.Service.RunDunning(ctx)
+	})
+
+	group.Go(func() error {
+		return peer.Fundraises.Service.RunReconciliation(ctx)
+	})
+
+	group.Go(func() error {
+		return peer.Fundraises.Service.RunNotifications(ctx)
+	})
+
+	group.Go(func() error {
+		return peer.Idempotency.Service.RunCleanup(ctx)
+	})
+
 	return group.Wait()
 }
 